@@ -0,0 +1,139 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/console"
+	"github.com/minio/mc/pkg/probe"
+)
+
+var (
+	aclGetFlags = []cli.Flag{
+		cli.BoolFlag{
+			Name:  "recursive, r",
+			Usage: "get the ACL of all objects under the prefix",
+		},
+	}
+)
+
+var aclGetCmd = cli.Command{
+	Name:   "get",
+	Usage:  "get the access control list of objects",
+	Action: mainACLGet,
+	Before: setGlobalsFromContext,
+	Flags:  append(aclGetFlags, globalFlags...),
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [FLAGS] TARGET
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+DESCRIPTION:
+  Object ACLs are only supported by S3 backends which still use them, MinIO
+  and buckets with ACLs disabled manage access with bucket policies instead.
+
+EXAMPLES:
+  1. Get the ACL of an object on Amazon S3 cloud storage.
+     $ {{.HelpName}} s3/mybucket/photos/2019/kitten.png
+
+  2. Get the ACL of all objects under a prefix on Amazon S3 cloud storage.
+     $ {{.HelpName}} --recursive s3/mybucket/photos/
+`,
+}
+
+// aclGetMessage container for object access control lists.
+type aclGetMessage struct {
+	Status string     `json:"status"`
+	URL    string     `json:"url"`
+	Canned string     `json:"canned"`
+	Owner  string     `json:"owner"`
+	Grants []aclGrant `json:"grants"`
+}
+
+// String colorized acl get message.
+func (a aclGetMessage) String() string {
+	msg := fmt.Sprintf("%s %s", console.Colorize("ACL", fmt.Sprintf("%-18s", a.Canned)), a.URL)
+	if a.Canned != aclCustom {
+		return msg
+	}
+	for _, grant := range a.Grants {
+		grantee := grant.Grantee.URI
+		if grantee == "" {
+			grantee = grant.Grantee.ID
+		}
+		msg += fmt.Sprintf("\n  %-12s %s", grant.Permission, grantee)
+	}
+	return msg
+}
+
+// JSON jsonified acl get message.
+func (a aclGetMessage) JSON() string {
+	a.Status = "success"
+	aclGetMessageBytes, e := json.MarshalIndent(a, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(aclGetMessageBytes)
+}
+
+// checkACLGetSyntax - validate all the passed arguments
+func checkACLGetSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) != 1 {
+		cli.ShowCommandHelpAndExit(ctx, "get", 1) // last argument is exit code
+	}
+}
+
+// mainACLGet is the entry point for acl get command.
+func mainACLGet(ctx *cli.Context) error {
+	checkACLGetSyntax(ctx)
+
+	console.SetColor("ACL", color.New(color.FgGreen, color.Bold))
+	console.SetColor("ACLWarning", color.New(color.FgYellow, color.Bold))
+
+	targetURL := ctx.Args().First()
+	var cErr error
+	for object := range aclObjectClients(targetURL, ctx.Bool("recursive")) {
+		if object.err != nil {
+			checkACLSupported(object.err, targetURL)
+			errorIf(object.err, "Unable to get the ACL of `"+targetURL+"`.")
+			cErr = exitStatus(globalErrorExitStatus)
+			continue
+		}
+		objectURL := object.clnt.GetURL().String()
+		acp, err := object.clnt.GetObjectACL()
+		if err != nil {
+			checkACLSupported(err, targetURL)
+			errorIf(err.Trace(objectURL), "Unable to get the ACL of `"+objectURL+"`.")
+			cErr = exitStatus(globalErrorExitStatus)
+			continue
+		}
+		printMsg(aclGetMessage{
+			URL:    objectURL,
+			Canned: cannedACLName(acp),
+			Owner:  acp.Owner.ID,
+			Grants: acp.Grants,
+		})
+	}
+	return cErr
+}
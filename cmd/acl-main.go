@@ -0,0 +1,200 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/console"
+	"github.com/minio/mc/pkg/probe"
+	minio "github.com/minio/minio-go/v6"
+)
+
+var (
+	aclFlags = []cli.Flag{}
+)
+
+var aclCmd = cli.Command{
+	Name:            "acl",
+	Usage:           "manage object access control lists",
+	HideHelpCommand: true,
+	Action:          mainACL,
+	Before:          setGlobalsFromContext,
+	Flags:           append(aclFlags, globalFlags...),
+	Subcommands: []cli.Command{
+		aclGetCmd,
+		aclSetCmd,
+	},
+}
+
+// mainACL is the handle for "mc acl" command.
+func mainACL(ctx *cli.Context) error {
+	cli.ShowCommandHelp(ctx, ctx.Args().First())
+	return nil
+	// Sub-commands like "get", "set" have their own main.
+}
+
+// Canned access control lists supported by acl commands.
+const (
+	aclPrivate           = "private"
+	aclPublicRead        = "public-read"
+	aclPublicReadWrite   = "public-read-write"
+	aclAuthenticatedRead = "authenticated-read"
+	aclCustom            = "custom"
+)
+
+// Group grantees used by canned access control lists.
+const (
+	aclAllUsersURI           = "http://acs.amazonaws.com/groups/global/AllUsers"
+	aclAuthenticatedUsersURI = "http://acs.amazonaws.com/groups/global/AuthenticatedUsers"
+)
+
+// isCannedACL returns true if name is a supported canned access control list.
+func isCannedACL(name string) bool {
+	switch name {
+	case aclPrivate, aclPublicRead, aclPublicReadWrite, aclAuthenticatedRead:
+		return true
+	}
+	return false
+}
+
+// cannedACLGrants returns the grants of a canned access control list for
+// the given owner.
+func cannedACLGrants(ownerID, ownerName, canned string) []aclGrant {
+	grants := []aclGrant{{
+		Grantee:    aclGrantee{ID: ownerID, DisplayName: ownerName},
+		Permission: "FULL_CONTROL",
+	}}
+	switch canned {
+	case aclPublicRead:
+		grants = append(grants, aclGrant{Grantee: aclGrantee{URI: aclAllUsersURI}, Permission: "READ"})
+	case aclPublicReadWrite:
+		grants = append(grants,
+			aclGrant{Grantee: aclGrantee{URI: aclAllUsersURI}, Permission: "READ"},
+			aclGrant{Grantee: aclGrantee{URI: aclAllUsersURI}, Permission: "WRITE"})
+	case aclAuthenticatedRead:
+		grants = append(grants, aclGrant{Grantee: aclGrantee{URI: aclAuthenticatedUsersURI}, Permission: "READ"})
+	}
+	return grants
+}
+
+// cannedACLName returns the canned access control list matching the
+// given grants, or "custom" if they do not match any.
+func cannedACLName(acp *accessControlPolicy) string {
+	groups := make(map[string]bool)
+	for _, grant := range acp.Grants {
+		switch {
+		case grant.Grantee.URI != "":
+			groups[grant.Grantee.URI+" "+grant.Permission] = true
+		case grant.Grantee.ID == acp.Owner.ID && grant.Permission == "FULL_CONTROL":
+		default:
+			return aclCustom
+		}
+	}
+	switch {
+	case len(groups) == 0:
+		return aclPrivate
+	case len(groups) == 1 && groups[aclAllUsersURI+" READ"]:
+		return aclPublicRead
+	case len(groups) == 2 && groups[aclAllUsersURI+" READ"] && groups[aclAllUsersURI+" WRITE"]:
+		return aclPublicReadWrite
+	case len(groups) == 1 && groups[aclAuthenticatedUsersURI+" READ"]:
+		return aclAuthenticatedRead
+	}
+	return aclCustom
+}
+
+// isACLNotSupported returns true if the backend does not support object
+// access control lists, such as MinIO or AWS S3 buckets with ACLs disabled.
+func isACLNotSupported(err *probe.Error) bool {
+	switch err.ToGoError().(type) {
+	case APINotImplemented:
+		return true
+	}
+	switch minio.ToErrorResponse(err.ToGoError()).Code {
+	case "NotImplemented", "XNotImplemented", "AccessControlListNotSupported":
+		return true
+	}
+	return false
+}
+
+// aclObject is an object whose access control list is read or changed.
+type aclObject struct {
+	clnt *s3Client
+	err  *probe.Error
+}
+
+// aclObjectClients returns a client for every object under targetURL, a
+// single object is returned unless recursive is set.
+func aclObjectClients(targetURL string, recursive bool) <-chan aclObject {
+	objectCh := make(chan aclObject)
+
+	go func() {
+		defer close(objectCh)
+
+		clnt, err := newClient(targetURL)
+		if err != nil {
+			objectCh <- aclObject{err: err.Trace(targetURL)}
+			return
+		}
+		s3Clnt, ok := clnt.(*s3Client)
+		if !ok {
+			objectCh <- aclObject{err: probe.NewError(APINotImplemented{API: "ObjectACL", APIType: "filesystem"}).Trace(targetURL)}
+			return
+		}
+		if !recursive {
+			objectCh <- aclObject{clnt: s3Clnt}
+			return
+		}
+
+		alias, _ := url2Alias(targetURL)
+		for content := range clnt.List(true, false, DirNone) {
+			if content.Err != nil {
+				objectCh <- aclObject{err: content.Err.Trace(targetURL)}
+				continue
+			}
+			if content.Type.IsDir() {
+				continue
+			}
+			objectClnt, err := newClientFromAlias(alias, content.URL.String())
+			if err != nil {
+				objectCh <- aclObject{err: err.Trace(content.URL.String())}
+				continue
+			}
+			objectCh <- aclObject{clnt: objectClnt.(*s3Client)}
+		}
+	}()
+
+	return objectCh
+}
+
+// checkACLSupported exits with a warning when the backend does not
+// support object access control lists.
+func checkACLSupported(err *probe.Error, targetURL string) {
+	if err == nil || !isACLNotSupported(err) {
+		return
+	}
+	console.Fatalln(console.Colorize("ACLWarning", "Warning: `"+targetURL+"` does not support object ACLs, use `mc policy` to manage access instead."))
+}
+
+// errInvalidCannedACL is returned for unknown canned access control lists.
+func errInvalidCannedACL(canned string) *probe.Error {
+	return probe.NewError(errors.New("unknown canned ACL `" + canned + "`, supported ACLs are [" +
+		strings.Join([]string{aclPrivate, aclPublicRead, aclPublicReadWrite, aclAuthenticatedRead}, ", ") + "]"))
+}
@@ -0,0 +1,46 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import "testing"
+
+func TestCannedACLName(t *testing.T) {
+	for i, canned := range []string{aclPrivate, aclPublicRead, aclPublicReadWrite, aclAuthenticatedRead} {
+		acp := &accessControlPolicy{Grants: cannedACLGrants("owner", "", canned)}
+		acp.Owner.ID = "owner"
+		if got := cannedACLName(acp); got != canned {
+			t.Errorf("Test %d: expected %s, got %s", i+1, canned, got)
+		}
+	}
+
+	testCases := []struct {
+		grants   []aclGrant
+		expected string
+	}{
+		{nil, aclPrivate},
+		{[]aclGrant{{Grantee: aclGrantee{ID: "other"}, Permission: "READ"}}, aclCustom},
+		{[]aclGrant{{Grantee: aclGrantee{URI: aclAllUsersURI}, Permission: "WRITE"}}, aclCustom},
+		{[]aclGrant{{Grantee: aclGrantee{URI: aclAuthenticatedUsersURI}, Permission: "FULL_CONTROL"}}, aclCustom},
+	}
+	for i, testCase := range testCases {
+		acp := &accessControlPolicy{Grants: testCase.grants}
+		acp.Owner.ID = "owner"
+		if got := cannedACLName(acp); got != testCase.expected {
+			t.Errorf("Test %d: expected %s, got %s", i+1, testCase.expected, got)
+		}
+	}
+}
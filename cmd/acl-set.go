@@ -0,0 +1,138 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/console"
+	"github.com/minio/mc/pkg/probe"
+)
+
+var (
+	aclSetFlags = []cli.Flag{
+		cli.StringFlag{
+			Name:  "canned",
+			Usage: "canned ACL to set, one of [private, public-read, public-read-write, authenticated-read]",
+		},
+		cli.BoolFlag{
+			Name:  "recursive, r",
+			Usage: "set the ACL of all objects under the prefix",
+		},
+	}
+)
+
+var aclSetCmd = cli.Command{
+	Name:   "set",
+	Usage:  "set a canned access control list on objects",
+	Action: mainACLSet,
+	Before: setGlobalsFromContext,
+	Flags:  append(aclSetFlags, globalFlags...),
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} --canned ACL [FLAGS] TARGET
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+DESCRIPTION:
+  The owner of each object keeps full control, the canned ACL replaces all other
+  grants. Object ACLs are only supported by S3 backends which still use them, MinIO
+  and buckets with ACLs disabled manage access with bucket policies instead.
+
+EXAMPLES:
+  1. Make an object on Amazon S3 cloud storage publicly readable.
+     $ {{.HelpName}} --canned public-read s3/mybucket/photos/2019/kitten.png
+
+  2. Make all objects under a prefix on Amazon S3 cloud storage private.
+     $ {{.HelpName}} --canned private --recursive s3/mybucket/photos/
+`,
+}
+
+// aclSetMessage container for changed object access control lists.
+type aclSetMessage struct {
+	Status string `json:"status"`
+	URL    string `json:"url"`
+	Canned string `json:"canned"`
+}
+
+// String colorized acl set message.
+func (a aclSetMessage) String() string {
+	return console.Colorize("ACL", "ACL `"+a.Canned+"` is set on `"+a.URL+"`.")
+}
+
+// JSON jsonified acl set message.
+func (a aclSetMessage) JSON() string {
+	a.Status = "success"
+	aclSetMessageBytes, e := json.MarshalIndent(a, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(aclSetMessageBytes)
+}
+
+// checkACLSetSyntax - validate all the passed arguments
+func checkACLSetSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) != 1 || ctx.String("canned") == "" {
+		cli.ShowCommandHelpAndExit(ctx, "set", 1) // last argument is exit code
+	}
+	if canned := ctx.String("canned"); !isCannedACL(canned) {
+		fatalIf(errInvalidCannedACL(canned), "Invalid --canned value.")
+	}
+}
+
+// setObjectACL replaces the access control list of an object with a
+// canned one, keeping the current owner.
+func setObjectACL(clnt *s3Client, canned string) *probe.Error {
+	acp, err := clnt.GetObjectACL()
+	if err != nil {
+		return err
+	}
+	acp.Grants = cannedACLGrants(acp.Owner.ID, acp.Owner.DisplayName, canned)
+	return clnt.SetObjectACL(acp)
+}
+
+// mainACLSet is the entry point for acl set command.
+func mainACLSet(ctx *cli.Context) error {
+	checkACLSetSyntax(ctx)
+
+	console.SetColor("ACL", color.New(color.FgGreen, color.Bold))
+	console.SetColor("ACLWarning", color.New(color.FgYellow, color.Bold))
+
+	targetURL := ctx.Args().First()
+	canned := ctx.String("canned")
+	var cErr error
+	for object := range aclObjectClients(targetURL, ctx.Bool("recursive")) {
+		if object.err != nil {
+			checkACLSupported(object.err, targetURL)
+			errorIf(object.err, "Unable to set the ACL of `"+targetURL+"`.")
+			cErr = exitStatus(globalErrorExitStatus)
+			continue
+		}
+		objectURL := object.clnt.GetURL().String()
+		if err := setObjectACL(object.clnt, canned); err != nil {
+			checkACLSupported(err, targetURL)
+			errorIf(err.Trace(objectURL), "Unable to set the ACL of `"+objectURL+"`.")
+			cErr = exitStatus(globalErrorExitStatus)
+			continue
+		}
+		printMsg(aclSetMessage{URL: objectURL, Canned: canned})
+	}
+	return cErr
+}
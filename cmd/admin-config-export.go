@@ -0,0 +1,146 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/console"
+	"github.com/minio/mc/pkg/probe"
+)
+
+var adminConfigExportCmd = cli.Command{
+	Name:   "export",
+	Usage:  "export the server config as a keyfile",
+	Before: setGlobalsFromContext,
+	Action: mainAdminConfigExport,
+	Flags:  globalFlags,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} TARGET
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+DESCRIPTION:
+  Every value of the config is written on its own line as KEY=VALUE, KEY
+  being the dotted path of the value, e.g. 'notify.webhook.1.endpoint'.
+
+EXAMPLES:
+  1. Export the config of a MinIO server/cluster to a keyfile.
+     $ {{.HelpName}} myminio/ > myminio.conf
+`,
+}
+
+var adminConfigImportCmd = cli.Command{
+	Name:   "import",
+	Usage:  "set the server config keys listed in a keyfile",
+	Before: setGlobalsFromContext,
+	Action: mainAdminConfigImport,
+	Flags:  globalFlags,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} TARGET [KEYFILE]
+
+KEYFILE:
+  KEY=VALUE lines as written by 'mc admin config export', read from the
+  standard input when omitted. Keys missing from the file are left unchanged.
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+  1. Copy the config of a MinIO server/cluster to another one.
+     $ mc admin config export myminio/ > myminio.conf
+     $ {{.HelpName}} newminio/ myminio.conf
+`,
+}
+
+// mainAdminConfigExport is the handle for "mc admin config export" command.
+func mainAdminConfigExport(ctx *cli.Context) error {
+	if len(ctx.Args()) != 1 {
+		cli.ShowCommandHelpAndExit(ctx, "export", 1) // last argument is exit code
+	}
+	aliasedURL := ctx.Args().Get(0)
+
+	// Create a new MinIO Admin Client
+	client, err := newAdminClient(aliasedURL)
+	fatalIf(err, "Unable to initialize admin connection.")
+
+	c, e := client.GetConfig()
+	fatalIf(probe.NewError(e), "Cannot get server configuration file.")
+	config, err := parseServerConfig(c)
+	fatalIf(err, "Cannot unmarshal server configuration file.")
+
+	keys := map[string]string{}
+	flattenConfig(config, "", keys)
+	printMsg(configKeysMessage{Keys: keys})
+	return nil
+}
+
+// mainAdminConfigImport is the handle for "mc admin config import" command.
+func mainAdminConfigImport(ctx *cli.Context) error {
+	if len(ctx.Args()) != 1 && len(ctx.Args()) != 2 {
+		cli.ShowCommandHelpAndExit(ctx, "import", 1) // last argument is exit code
+	}
+	console.SetColor("SetConfigSuccess", color.New(color.FgGreen, color.Bold))
+
+	args := ctx.Args()
+	aliasedURL := args.Get(0)
+
+	var keyfile io.Reader = os.Stdin
+	if args.Get(1) != "" {
+		file, e := os.Open(args.Get(1))
+		fatalIf(probe.NewError(e).Trace(args.Get(1)), "Unable to open the keyfile.")
+		defer file.Close()
+		keyfile = file
+	}
+	keys, err := readConfigKeyfile(keyfile)
+	fatalIf(err.Trace(args.Get(1)), "Unable to read the keyfile.")
+
+	// Create a new MinIO Admin Client
+	client, err := newAdminClient(aliasedURL)
+	fatalIf(err, "Unable to initialize admin connection.")
+
+	oldConfig, e := client.GetConfig()
+	fatalIf(probe.NewError(e), "Cannot get server configuration file.")
+	config, err := parseServerConfig(oldConfig)
+	fatalIf(err, "Cannot unmarshal server configuration file.")
+
+	for key, value := range keys {
+		fatalIf(setConfigValue(config, strings.Split(key, "."), value), "Unable to set `"+key+"`.")
+	}
+	newConfig, e := json.Marshal(config)
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	applyServerConfig(client, aliasedURL, "config import", nil, oldConfig, newConfig)
+
+	printMsg(configSetMessage{
+		setConfigStatus: true,
+		targetAlias:     aliasedURL,
+	})
+	return nil
+}
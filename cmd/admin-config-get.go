@@ -17,6 +17,8 @@
 package cmd
 
 import (
+	"strings"
+
 	"github.com/minio/cli"
 	json "github.com/minio/mc/pkg/colorjson"
 	"github.com/minio/mc/pkg/probe"
@@ -32,7 +34,11 @@ var adminConfigGetCmd = cli.Command{
   {{.HelpName}} - {{.Usage}}
 
 USAGE:
-  {{.HelpName}} TARGET
+  {{.HelpName}} TARGET [SUBSYS[:TARGET] [KEY...]]
+
+SUBSYS:
+  Config section, '_' separating nested sections, e.g. 'notify_webhook:1'
+  for the webhook notification target '1'. Dotted paths are accepted too.
 
 FLAGS:
   {{range .VisibleFlags}}{{.}}
@@ -40,6 +46,12 @@ FLAGS:
 EXAMPLES:
   1. Get server configuration of a MinIO server/cluster.
      $ {{.HelpName}} play/
+
+  2. Get the endpoint of the webhook notification target '1'.
+     $ {{.HelpName}} myminio/ notify_webhook:1 endpoint
+
+  3. Get all keys of the storage class section.
+     $ {{.HelpName}} myminio/ storageclass
 `,
 }
 
@@ -68,11 +80,33 @@ func (u configGetMessage) JSON() string {
 
 // checkAdminConfigGetSyntax - validate all the passed arguments
 func checkAdminConfigGetSyntax(ctx *cli.Context) {
-	if len(ctx.Args()) == 0 || len(ctx.Args()) > 2 {
+	if len(ctx.Args()) == 0 {
 		cli.ShowCommandHelpAndExit(ctx, "get", 1) // last argument is exit code
 	}
 }
 
+// getConfigKeys - returns the keys of the subsys section, all of them
+// unless names are given.
+func getConfigKeys(config map[string]interface{}, subsys string, names []string) map[string]string {
+	path := getConfigPath(subsys)
+	if len(names) == 0 {
+		names = []string{""}
+	}
+	keys := map[string]string{}
+	for _, name := range names {
+		keyPath := path
+		if name != "" {
+			keyPath = append(path[:len(path):len(path)], name)
+		}
+		value, ok := lookupConfigValue(config, keyPath)
+		if !ok {
+			fatalIf(errInvalidArgument().Trace(subsys, name), "Unknown config key `"+strings.Join(keyPath, ".")+"`.")
+		}
+		flattenConfig(value, strings.Join(keyPath, "."), keys)
+	}
+	return keys
+}
+
 func mainAdminConfigGet(ctx *cli.Context) error {
 
 	checkAdminConfigGetSyntax(ctx)
@@ -89,6 +123,13 @@ func mainAdminConfigGet(ctx *cli.Context) error {
 	c, e := client.GetConfig()
 	fatalIf(probe.NewError(e), "Cannot get server configuration file.")
 
+	if len(args) > 1 {
+		config, err := parseServerConfig(c)
+		fatalIf(err, "Cannot unmarshal server configuration file.")
+		printMsg(configKeysMessage{Keys: getConfigKeys(config, args.Get(1), args[2:])})
+		return nil
+	}
+
 	config := map[string]interface{}{}
 	e = json.Unmarshal(c, &config)
 	fatalIf(probe.NewError(e), "Cannot unmarshal server configuration file.")
@@ -0,0 +1,333 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/console"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio/pkg/madmin"
+)
+
+var adminConfigHistoryCmd = cli.Command{
+	Name:   "history",
+	Usage:  "list the saved versions of the server config",
+	Before: setGlobalsFromContext,
+	Action: mainAdminConfigHistory,
+	Flags:  globalFlags,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} TARGET
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+DESCRIPTION:
+  The server config is saved locally before every change made through mc,
+  the last 20 versions of each alias are kept.
+
+EXAMPLES:
+  1. List the saved config versions of a MinIO server/cluster.
+     $ {{.HelpName}} myminio/
+`,
+}
+
+var adminConfigRestoreCmd = cli.Command{
+	Name:   "restore",
+	Usage:  "restore a saved version of the server config",
+	Before: setGlobalsFromContext,
+	Action: mainAdminConfigRestore,
+	Flags:  globalFlags,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} TARGET VERSION
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+  1. Restore the config of a MinIO server/cluster as it was before a change.
+     $ {{.HelpName}} myminio/ 20191020T101530.123Z
+`,
+}
+
+var adminConfigDiffCmd = cli.Command{
+	Name:   "diff",
+	Usage:  "show the changes of the server config since a saved version",
+	Before: setGlobalsFromContext,
+	Action: mainAdminConfigDiff,
+	Flags:  globalFlags,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} TARGET [VERSION]
+
+VERSION:
+  A version listed by 'mc admin config history', the latest by default.
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+  1. Show the last change made to the config of a MinIO server/cluster.
+     $ {{.HelpName}} myminio/
+`,
+}
+
+const (
+	// Folder of the saved server configs inside the mc config folder.
+	configBackupDir = "admin-config-history"
+	// Number of saved server configs kept for each alias.
+	configMaxBackups = 20
+	// Versions are named after the time the config was replaced.
+	configBackupTimeFormat = "20060102T150405.000Z"
+)
+
+// getConfigBackupDir - returns the folder of the saved configs of alias.
+func getConfigBackupDir(alias string) (string, *probe.Error) {
+	configDir, err := getMcConfigDir()
+	if err != nil {
+		return "", err.Trace()
+	}
+	return filepath.Join(configDir, configBackupDir, alias), nil
+}
+
+// listConfigBackups - returns the saved config versions of alias, the
+// oldest first.
+func listConfigBackups(alias string) ([]string, *probe.Error) {
+	dir, err := getConfigBackupDir(alias)
+	if err != nil {
+		return nil, err.Trace(alias)
+	}
+	files, e := ioutil.ReadDir(dir)
+	if os.IsNotExist(e) {
+		return nil, nil
+	}
+	if e != nil {
+		return nil, probe.NewError(e).Trace(dir)
+	}
+	var versions []string
+	for _, file := range files {
+		if version := strings.TrimSuffix(file.Name(), ".json"); version != file.Name() {
+			versions = append(versions, version)
+		}
+	}
+	sort.Strings(versions)
+	return versions, nil
+}
+
+// loadConfigBackup - reads a saved config version of alias.
+func loadConfigBackup(alias, version string) ([]byte, *probe.Error) {
+	dir, err := getConfigBackupDir(alias)
+	if err != nil {
+		return nil, err.Trace(alias)
+	}
+	config, e := ioutil.ReadFile(filepath.Join(dir, filepath.Base(version)+".json"))
+	if e != nil {
+		return nil, probe.NewError(e).Trace(alias, version)
+	}
+	return config, nil
+}
+
+// saveConfigBackup - saves config as a new version of alias, removing
+// the oldest versions beyond configMaxBackups.
+func saveConfigBackup(alias string, config []byte) *probe.Error {
+	dir, err := getConfigBackupDir(alias)
+	if err != nil {
+		return err.Trace(alias)
+	}
+	if e := os.MkdirAll(dir, 0700); e != nil {
+		return probe.NewError(e).Trace(dir)
+	}
+	version := UTCNow().Format(configBackupTimeFormat)
+	if e := ioutil.WriteFile(filepath.Join(dir, version+".json"), config, 0600); e != nil {
+		return probe.NewError(e).Trace(dir, version)
+	}
+	versions, err := listConfigBackups(alias)
+	if err != nil {
+		return err.Trace(alias)
+	}
+	for len(versions) > configMaxBackups {
+		os.Remove(filepath.Join(dir, versions[0]+".json"))
+		versions = versions[1:]
+	}
+	return nil
+}
+
+// applyServerConfig - replaces the server config, saving the old one
+// and recording the change in admin history.
+func applyServerConfig(client *madmin.AdminClient, aliasedURL, action string, args []string, oldConfig, newConfig []byte) {
+	alias, _ := url2Alias(aliasedURL)
+	errorIf(saveConfigBackup(alias, oldConfig), "Unable to save the current server configuration.")
+
+	fatalIf(probe.NewError(client.SetConfig(bytes.NewReader(newConfig))), "Cannot set server configuration file.")
+	recordAdminHistory(aliasedURL, action, args, diffLines(indentJSON(oldConfig), indentJSON(newConfig)))
+}
+
+// configBackupMessage container for a saved config version.
+type configBackupMessage struct {
+	Status  string    `json:"status"`
+	Version string    `json:"version"`
+	Time    time.Time `json:"time"`
+}
+
+// String colorized saved config version.
+func (c configBackupMessage) String() string {
+	return console.Colorize("ConfigTime", "["+c.Time.Local().Format(printDate)+"] ") + c.Version
+}
+
+// JSON jsonified saved config version.
+func (c configBackupMessage) JSON() string {
+	c.Status = "success"
+	backupBytes, e := json.MarshalIndent(c, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(backupBytes)
+}
+
+// mainAdminConfigHistory is the handle for "mc admin config history" command.
+func mainAdminConfigHistory(ctx *cli.Context) error {
+	if len(ctx.Args()) != 1 {
+		cli.ShowCommandHelpAndExit(ctx, "history", 1) // last argument is exit code
+	}
+	console.SetColor("ConfigTime", color.New(color.FgGreen))
+
+	alias, _ := url2Alias(ctx.Args().Get(0))
+	versions, err := listConfigBackups(alias)
+	fatalIf(err, "Unable to list the saved server configurations.")
+
+	for _, version := range versions {
+		t, _ := time.Parse(configBackupTimeFormat, version)
+		printMsg(configBackupMessage{Version: version, Time: t})
+	}
+	return nil
+}
+
+// mainAdminConfigRestore is the handle for "mc admin config restore" command.
+func mainAdminConfigRestore(ctx *cli.Context) error {
+	if len(ctx.Args()) != 2 {
+		cli.ShowCommandHelpAndExit(ctx, "restore", 1) // last argument is exit code
+	}
+	console.SetColor("SetConfigSuccess", color.New(color.FgGreen, color.Bold))
+
+	args := ctx.Args()
+	aliasedURL, version := args.Get(0), args.Get(1)
+	alias, _ := url2Alias(aliasedURL)
+
+	newConfig, err := loadConfigBackup(alias, version)
+	fatalIf(err, "Unable to read the saved server configuration.")
+
+	// Create a new MinIO Admin Client
+	client, err := newAdminClient(aliasedURL)
+	fatalIf(err, "Unable to initialize admin connection.")
+
+	oldConfig, e := client.GetConfig()
+	fatalIf(probe.NewError(e), "Cannot get server configuration file.")
+
+	applyServerConfig(client, aliasedURL, "config restore", []string{version}, oldConfig, newConfig)
+
+	printMsg(configSetMessage{
+		setConfigStatus: true,
+		targetAlias:     aliasedURL,
+	})
+	return nil
+}
+
+// configDiffMessage container for the changes of the server config.
+type configDiffMessage struct {
+	Status  string `json:"status"`
+	Version string `json:"version"`
+	Diff    string `json:"diff"`
+}
+
+// String colorized config changes.
+func (c configDiffMessage) String() string {
+	if c.Diff == "" {
+		return "No changes since " + c.Version + "."
+	}
+	var lines []string
+	for _, line := range strings.Split(strings.TrimSuffix(c.Diff, "\n"), "\n") {
+		switch {
+		case strings.HasPrefix(line, "+"):
+			line = console.Colorize("ConfigAdded", line)
+		case strings.HasPrefix(line, "-"):
+			line = console.Colorize("ConfigRemoved", line)
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// JSON jsonified config changes.
+func (c configDiffMessage) JSON() string {
+	c.Status = "success"
+	diffBytes, e := json.MarshalIndent(c, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(diffBytes)
+}
+
+// mainAdminConfigDiff is the handle for "mc admin config diff" command.
+func mainAdminConfigDiff(ctx *cli.Context) error {
+	if len(ctx.Args()) != 1 && len(ctx.Args()) != 2 {
+		cli.ShowCommandHelpAndExit(ctx, "diff", 1) // last argument is exit code
+	}
+	console.SetColor("ConfigAdded", color.New(color.FgGreen))
+	console.SetColor("ConfigRemoved", color.New(color.FgRed))
+
+	args := ctx.Args()
+	aliasedURL, version := args.Get(0), args.Get(1)
+	alias, _ := url2Alias(aliasedURL)
+	if version == "" {
+		versions, err := listConfigBackups(alias)
+		fatalIf(err, "Unable to list the saved server configurations.")
+		if len(versions) == 0 {
+			fatalIf(errDummy().Trace(alias), "No saved server configuration for `"+alias+"`.")
+		}
+		version = versions[len(versions)-1]
+	}
+	oldConfig, err := loadConfigBackup(alias, version)
+	fatalIf(err, "Unable to read the saved server configuration.")
+
+	// Create a new MinIO Admin Client
+	client, err := newAdminClient(aliasedURL)
+	fatalIf(err, "Unable to initialize admin connection.")
+
+	newConfig, e := client.GetConfig()
+	fatalIf(probe.NewError(e), "Cannot get server configuration file.")
+
+	printMsg(configDiffMessage{
+		Version: version,
+		Diff:    diffLines(indentJSON(oldConfig), indentJSON(newConfig)),
+	})
+	return nil
+}
@@ -0,0 +1,212 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/minio/mc/pkg/probe"
+)
+
+// parseServerConfig - decodes a server config, keeping numbers as they
+// are written.
+func parseServerConfig(data []byte) (map[string]interface{}, *probe.Error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	config := map[string]interface{}{}
+	if e := decoder.Decode(&config); e != nil {
+		return nil, probe.NewError(e)
+	}
+	return config, nil
+}
+
+// getConfigPath - converts a config key to the path of its value, keys
+// are either dotted paths or of the SUBSYS[:TARGET] form, so that
+// "notify_webhook:primary" is the same as "notify.webhook.primary".
+func getConfigPath(key string) []string {
+	if strings.Contains(key, ".") {
+		return strings.Split(key, ".")
+	}
+	var target string
+	if i := strings.Index(key, ":"); i >= 0 {
+		key, target = key[:i], key[i+1:]
+	}
+	path := strings.Split(key, "_")
+	if target != "" {
+		path = append(path, target)
+	}
+	return path
+}
+
+// lookupConfigValue - returns the value at path.
+func lookupConfigValue(config map[string]interface{}, path []string) (interface{}, bool) {
+	var value interface{} = config
+	for _, name := range path {
+		m, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		if value, ok = m[name]; !ok {
+			return nil, false
+		}
+	}
+	return value, true
+}
+
+// parseConfigValue - parses value with the type of the current value,
+// new values are strings unless they are valid JSON.
+func parseConfigValue(current interface{}, value string) (interface{}, error) {
+	switch current.(type) {
+	case string:
+		return value, nil
+	case bool:
+		return strconv.ParseBool(value)
+	case json.Number:
+		if _, e := strconv.ParseFloat(value, 64); e != nil {
+			return nil, e
+		}
+		return json.Number(value), nil
+	case map[string]interface{}:
+		return nil, errors.New("cannot set a whole config section")
+	case []interface{}:
+		var list []interface{}
+		if e := json.Unmarshal([]byte(value), &list); e == nil {
+			return list, nil
+		}
+		// Comma separated lists of strings are accepted too.
+		for _, item := range strings.Split(value, ",") {
+			list = append(list, item)
+		}
+		return list, nil
+	case nil:
+		var parsed interface{}
+		decoder := json.NewDecoder(strings.NewReader(value))
+		decoder.UseNumber()
+		if e := decoder.Decode(&parsed); e == nil && !decoder.More() {
+			return parsed, nil
+		}
+	}
+	return value, nil
+}
+
+// setConfigValue - sets the value at path, adding missing sections.
+func setConfigValue(config map[string]interface{}, path []string, value string) *probe.Error {
+	if len(path) == 0 || path[0] == "" {
+		return errInvalidArgument().Trace(value)
+	}
+	m := config
+	for i, name := range path[:len(path)-1] {
+		next, ok := m[name]
+		if !ok || next == nil {
+			next = map[string]interface{}{}
+			m[name] = next
+		}
+		if m, ok = next.(map[string]interface{}); !ok {
+			return probe.NewError(errors.New("`" + strings.Join(path[:i+1], ".") + "` is not a config section"))
+		}
+	}
+	key := path[len(path)-1]
+	parsed, e := parseConfigValue(m[key], value)
+	if e != nil {
+		return probe.NewError(e).Trace(strings.Join(path, "."), value)
+	}
+	m[key] = parsed
+	return nil
+}
+
+// formatConfigValue - formats a config value for a keyfile.
+func formatConfigValue(value interface{}) string {
+	if s, ok := value.(string); ok {
+		return s
+	}
+	valueBytes, _ := json.Marshal(value)
+	return string(valueBytes)
+}
+
+// flattenConfig - adds all values below value to keys, by their dotted
+// path.
+func flattenConfig(value interface{}, prefix string, keys map[string]string) {
+	m, ok := value.(map[string]interface{})
+	if !ok {
+		keys[prefix] = formatConfigValue(value)
+		return
+	}
+	for name, v := range m {
+		if prefix != "" {
+			name = prefix + "." + name
+		}
+		flattenConfig(v, name, keys)
+	}
+}
+
+// readConfigKeyfile - reads KEY=VALUE lines, skipping blank lines and
+// comments.
+func readConfigKeyfile(r io.Reader) (map[string]string, *probe.Error) {
+	keys := map[string]string{}
+	scanner := bufio.NewScanner(r)
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		i := strings.Index(line, "=")
+		if i <= 0 {
+			return nil, probe.NewError(errors.New("expected KEY=VALUE")).Trace(strconv.Itoa(lineNum), line)
+		}
+		keys[line[:i]] = line[i+1:]
+	}
+	if e := scanner.Err(); e != nil {
+		return nil, probe.NewError(e)
+	}
+	return keys, nil
+}
+
+// configKeysMessage container for config keys.
+type configKeysMessage struct {
+	Status string            `json:"status"`
+	Keys   map[string]string `json:"keys"`
+}
+
+// String config keys as a keyfile.
+func (c configKeysMessage) String() string {
+	var names []string
+	for name := range c.Keys {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var lines []string
+	for _, name := range names {
+		lines = append(lines, name+"="+c.Keys[name])
+	}
+	return strings.Join(lines, "\n")
+}
+
+// JSON jsonified config keys message.
+func (c configKeysMessage) JSON() string {
+	c.Status = "success"
+	keysBytes, e := json.MarshalIndent(c, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(keysBytes)
+}
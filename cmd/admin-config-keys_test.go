@@ -0,0 +1,103 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestGetConfigPath(t *testing.T) {
+	testCases := []struct {
+		key  string
+		path []string
+	}{
+		{"region", []string{"region"}},
+		{"notify_webhook:primary", []string{"notify", "webhook", "primary"}},
+		{"notify.webhook.1", []string{"notify", "webhook", "1"}},
+		{"policy_opa", []string{"policy", "opa"}},
+	}
+	for i, testCase := range testCases {
+		if path := getConfigPath(testCase.key); !reflect.DeepEqual(path, testCase.path) {
+			t.Errorf("Test %d: expected %v, got %v", i+1, testCase.path, path)
+		}
+	}
+}
+
+func TestSetConfigValue(t *testing.T) {
+	config, err := parseServerConfig([]byte(`{"version":"33","region":"us-east-1",
+		"compress":{"enabled":false,"extensions":[".txt"]},
+		"cache":{"expiry":90},
+		"notify":{"webhook":{"1":{"enable":false,"endpoint":""}}}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	testCases := []struct {
+		key     string
+		value   string
+		success bool
+	}{
+		{"region", "eu-west-1", true},
+		{"compress.enabled", "true", true},
+		{"compress.enabled", "yes", false},
+		{"compress.extensions", ".txt,.log", true},
+		{"cache.expiry", "30", true},
+		{"cache.expiry", "1d", false},
+		{"notify.webhook.1.endpoint", "http://localhost:8080", true},
+		{"notify.webhook.2.enable", "true", true},
+		{"notify.webhook", "x", false},
+		{"region.name", "x", false},
+	}
+	for i, testCase := range testCases {
+		err = setConfigValue(config, strings.Split(testCase.key, "."), testCase.value)
+		if (err == nil) != testCase.success {
+			t.Errorf("Test %d: expected success %v, got %v", i+1, testCase.success, err)
+		}
+	}
+
+	configBytes, e := json.Marshal(config)
+	if e != nil {
+		t.Fatal(e)
+	}
+	expected := `{"cache":{"expiry":30},"compress":{"enabled":true,"extensions":[".txt",".log"]},` +
+		`"notify":{"webhook":{"1":{"enable":false,"endpoint":"http://localhost:8080"},"2":{"enable":true}}},` +
+		`"region":"eu-west-1","version":"33"}`
+	if string(configBytes) != expected {
+		t.Errorf("Expected %s, got %s", expected, configBytes)
+	}
+
+	// Exported keys are imported back unchanged.
+	keys := map[string]string{}
+	flattenConfig(config, "", keys)
+	imported, err := readConfigKeyfile(strings.NewReader("# exported\n\n" + configKeysMessage{Keys: keys}.String()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(imported, keys) {
+		t.Errorf("Expected %v, got %v", keys, imported)
+	}
+	for key, value := range imported {
+		if err = setConfigValue(config, strings.Split(key, "."), value); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if reimported, _ := json.Marshal(config); string(reimported) != expected {
+		t.Errorf("Expected %s, got %s", expected, reimported)
+	}
+}
@@ -17,8 +17,11 @@
 package cmd
 
 import (
+	stdjson "encoding/json"
 	"fmt"
+	"io/ioutil"
 	"os"
+	"strings"
 
 	"github.com/fatih/color"
 	"github.com/minio/cli"
@@ -37,7 +40,12 @@ var adminConfigSetCmd = cli.Command{
   {{.HelpName}} - {{.Usage}}
 
 USAGE:
-  {{.HelpName}} TARGET
+  {{.HelpName}} TARGET [SUBSYS[:TARGET] KEY=VALUE...]
+
+SUBSYS:
+  Config section, '_' separating nested sections, e.g. 'notify_webhook:1'
+  for the webhook notification target '1'. Without keys the whole config
+  is read from the standard input.
 
 FLAGS:
   {{range .VisibleFlags}}{{.}}
@@ -45,6 +53,9 @@ FLAGS:
 EXAMPLES:
   1. Set server configuration of a MinIO server/cluster.
      $ cat myconfig | {{.HelpName}} myminio/
+
+  2. Enable the webhook notification target '1' and set its endpoint.
+     $ {{.HelpName}} myminio/ notify_webhook:1 enable=true endpoint=http://localhost:8080/minio/events
 `,
 }
 
@@ -86,9 +97,14 @@ func (u configSetMessage) JSON() string {
 
 // checkAdminConfigSetSyntax - validate all the passed arguments
 func checkAdminConfigSetSyntax(ctx *cli.Context) {
-	if len(ctx.Args()) == 0 || len(ctx.Args()) > 2 {
+	if len(ctx.Args()) == 0 || len(ctx.Args()) == 2 {
 		cli.ShowCommandHelpAndExit(ctx, "set", 1) // last argument is exit code
 	}
+	for i, keyValue := range ctx.Args() {
+		if i > 1 && strings.Index(keyValue, "=") <= 0 {
+			fatalIf(errInvalidArgument().Trace(keyValue), "Expected KEY=VALUE, got `"+keyValue+"`.")
+		}
+	}
 }
 
 // main config set function
@@ -109,8 +125,32 @@ func mainAdminConfigSet(ctx *cli.Context) error {
 	client, err := newAdminClient(aliasedURL)
 	fatalIf(err, "Unable to initialize admin connection.")
 
-	// Call get config API
-	fatalIf(probe.NewError(client.SetConfig(os.Stdin)), "Cannot set server configuration file.")
+	// Keep the current config to record the changes in admin history.
+	oldConfig, e := client.GetConfig()
+	fatalIf(probe.NewError(e), "Cannot get server configuration file.")
+
+	var newConfig []byte
+	// Values are left out of admin history, they may be secrets.
+	var keys []string
+	if len(args) > 1 {
+		config, err := parseServerConfig(oldConfig)
+		fatalIf(err, "Cannot unmarshal server configuration file.")
+		path := getConfigPath(args.Get(1))
+		for _, keyValue := range args[2:] {
+			i := strings.Index(keyValue, "=")
+			keyPath := append(path[:len(path):len(path)], keyValue[:i])
+			fatalIf(setConfigValue(config, keyPath, keyValue[i+1:]), "Unable to set `"+keyValue[:i]+"`.")
+			keys = append(keys, strings.Join(keyPath, "."))
+		}
+		newConfig, e = stdjson.Marshal(config)
+		fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	} else {
+		newConfig, e = ioutil.ReadAll(os.Stdin)
+		fatalIf(probe.NewError(e), "Unable to read new server configuration file.")
+	}
+
+	// Call set config API
+	applyServerConfig(client, aliasedURL, "config set", keys, oldConfig, newConfig)
 
 	// Print set config result
 	printMsg(configSetMessage{
@@ -27,6 +27,11 @@ var adminConfigCmd = cli.Command{
 	Subcommands: []cli.Command{
 		adminConfigGetCmd,
 		adminConfigSetCmd,
+		adminConfigExportCmd,
+		adminConfigImportCmd,
+		adminConfigHistoryCmd,
+		adminConfigRestoreCmd,
+		adminConfigDiffCmd,
 	},
 	HideHelpCommand: true,
 }
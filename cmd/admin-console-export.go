@@ -0,0 +1,197 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	humanize "github.com/dustin/go-humanize"
+	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio/pkg/madmin"
+)
+
+// Default syslog port when the forward target has none.
+const defaultSyslogPort = "514"
+
+// Matches the ANSI color sequences of the console output.
+var ansiColorRegexp = regexp.MustCompile("\x1b\\[[0-9;]*m")
+
+// stripColors - removes the ANSI color sequences from s.
+func stripColors(s string) string {
+	return ansiColorRegexp.ReplaceAllString(s, "")
+}
+
+// consoleLogForwarder - sends log entries to a remote endpoint.
+type consoleLogForwarder interface {
+	forward(l madmin.LogInfo) error
+}
+
+// syslogForwarder - sends log entries as RFC 5424 syslog messages.
+type syslogForwarder struct {
+	conn     net.Conn
+	network  string
+	hostname string
+}
+
+func (s syslogForwarder) forward(l madmin.LogInfo) error {
+	// Facility user, severity error or informational.
+	priority := 1*8 + 3
+	if getConsoleLogType(l) == consoleLogInfo {
+		priority = 1*8 + 6
+	}
+	text := strings.Join(strings.Fields(getConsoleLogText(l)), " ")
+	msg := fmt.Sprintf("<%d>1 %s %s mc - - - %s", priority, UTCNow().Format(time.RFC3339), s.hostname, text)
+	// Messages over TCP are delimited by a new line.
+	if s.network == "tcp" {
+		msg += "\n"
+	}
+	_, e := s.conn.Write([]byte(msg))
+	return e
+}
+
+// httpForwarder - posts log entries as JSON documents.
+type httpForwarder struct {
+	url    string
+	client *http.Client
+}
+
+func (h httpForwarder) forward(l madmin.LogInfo) error {
+	body, e := json.Marshal(l)
+	if e != nil {
+		return e
+	}
+	resp, e := h.client.Post(h.url, "application/json", bytes.NewReader(body))
+	if e != nil {
+		return e
+	}
+	resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("%s replied %s", h.url, resp.Status)
+	}
+	return nil
+}
+
+// newConsoleLogForwarder - returns a forwarder to target, which is
+// syslog://HOST[:PORT] (UDP), syslog+tcp://HOST[:PORT] or an HTTP URL.
+func newConsoleLogForwarder(target string) (consoleLogForwarder, *probe.Error) {
+	u, e := url.Parse(target)
+	if e != nil {
+		return nil, probe.NewError(e)
+	}
+	switch u.Scheme {
+	case "http", "https":
+		return httpForwarder{
+			url: target,
+			client: &http.Client{
+				Transport: &http.Transport{Proxy: http.ProxyFromEnvironment},
+				Timeout:   10 * time.Second,
+			},
+		}, nil
+	case "syslog", "syslog+udp", "syslog+tcp":
+		network := "udp"
+		if u.Scheme == "syslog+tcp" {
+			network = "tcp"
+		}
+		address := u.Host
+		if u.Port() == "" {
+			address = net.JoinHostPort(u.Hostname(), defaultSyslogPort)
+		}
+		conn, e := net.DialTimeout(network, address, 10*time.Second)
+		if e != nil {
+			return nil, probe.NewError(e)
+		}
+		hostname, _ := os.Hostname()
+		if hostname == "" {
+			hostname = "-"
+		}
+		return syslogForwarder{conn: conn, network: network, hostname: hostname}, nil
+	}
+	return nil, errInvalidArgument().Trace(target)
+}
+
+// consoleLogExporter - persists console log entries to a file and
+// forwards them, next to the console output.
+type consoleLogExporter struct {
+	out       *rotatingFile
+	jsonLines bool
+	forwarder consoleLogForwarder
+}
+
+// newConsoleLogExporter - sets up --out and --forward, it returns nil
+// when neither is given.
+func newConsoleLogExporter(ctx *cli.Context) (*consoleLogExporter, *probe.Error) {
+	outPath, forward := ctx.String("out"), ctx.String("forward")
+	if outPath == "" && forward == "" {
+		return nil, nil
+	}
+	x := &consoleLogExporter{}
+	if outPath != "" {
+		maxSize, e := humanize.ParseBytes(ctx.String("rotate-size"))
+		if e != nil {
+			return nil, probe.NewError(e).Trace(ctx.String("rotate-size"))
+		}
+		out, err := openRotatingFile(outPath, int64(maxSize))
+		if err != nil {
+			return nil, err.Trace(outPath)
+		}
+		x.out = out
+		// Structured output unless a text log is asked for.
+		x.jsonLines = filepath.Ext(outPath) != ".log" && filepath.Ext(outPath) != ".txt"
+	}
+	if forward != "" {
+		forwarder, err := newConsoleLogForwarder(forward)
+		if err != nil {
+			return nil, err.Trace(forward)
+		}
+		x.forwarder = forwarder
+	}
+	return x, nil
+}
+
+// export - writes and forwards a log entry, a failing file or endpoint
+// is reported once and then skipped.
+func (x *consoleLogExporter) export(l madmin.LogInfo) {
+	if x.out != nil {
+		var line []byte
+		if x.jsonLines {
+			line, _ = json.Marshal(l)
+		} else {
+			line = []byte(strings.TrimSuffix(stripColors(logMessage{l}.String()), "\n"))
+		}
+		if _, e := x.out.Write(append(line, '\n')); e != nil {
+			errorIf(probe.NewError(e), "Unable to write console logs to `"+x.out.path+"`.")
+			x.out = nil
+		}
+	}
+	if x.forwarder != nil {
+		if e := x.forwarder.forward(l); e != nil {
+			errorIf(probe.NewError(e), "Unable to forward console logs.")
+			x.forwarder = nil
+		}
+	}
+}
@@ -0,0 +1,86 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/minio/minio/pkg/madmin"
+)
+
+func TestConsoleLogForwarders(t *testing.T) {
+	logInfo := madmin.LogInfo{ConsoleMsg: "Endpoint:\n  http://127.0.0.1:9000\n", NodeName: "node1"}
+
+	// Syslog over UDP.
+	conn, e := net.ListenPacket("udp", "127.0.0.1:0")
+	if e != nil {
+		t.Fatal(e)
+	}
+	defer conn.Close()
+	forwarder, err := newConsoleLogForwarder("syslog://" + conn.LocalAddr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e = forwarder.forward(logInfo); e != nil {
+		t.Fatal(e)
+	}
+	buf := make([]byte, 1024)
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	n, _, e := conn.ReadFrom(buf)
+	if e != nil {
+		t.Fatal(e)
+	}
+	syslogLine := regexp.MustCompile(`^<14>1 \S+ \S+ mc - - - Endpoint: http://127.0.0.1:9000$`)
+	if !syslogLine.Match(buf[:n]) {
+		t.Errorf("Unexpected syslog message %q", buf[:n])
+	}
+
+	// HTTP.
+	var received madmin.LogInfo
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		json.Unmarshal(body, &received)
+	}))
+	defer server.Close()
+	forwarder, err = newConsoleLogForwarder(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e = forwarder.forward(logInfo); e != nil {
+		t.Fatal(e)
+	}
+	if received.ConsoleMsg != logInfo.ConsoleMsg || received.NodeName != logInfo.NodeName {
+		t.Errorf("Unexpected HTTP entry %+v", received)
+	}
+
+	if _, err = newConsoleLogForwarder("ftp://logs.example.com"); err == nil {
+		t.Error("Expected an error for an unsupported scheme")
+	}
+}
+
+func TestStripColors(t *testing.T) {
+	if s := stripColors("\x1b[1;31mAPI:\x1b[0m PutObject"); s != "API: PutObject" {
+		t.Errorf("Unexpected %q", s)
+	}
+}
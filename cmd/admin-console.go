@@ -18,7 +18,9 @@ package cmd
 
 import (
 	"fmt"
+	"regexp"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/fatih/color"
@@ -31,12 +33,51 @@ import (
 
 const logTimeFormat string = "15:04:05 MST 01/02/2006"
 
+// Without --follow, the logs end once the server sent nothing for this long.
+const consoleIdleTimeout = 2 * time.Second
+
+// Types of console log entries.
+const (
+	consoleLogError       = "error"
+	consoleLogInfo        = "info"
+	consoleLogApplication = "application"
+)
+
 var adminConsoleFlags = []cli.Flag{
 	cli.IntFlag{
-		Name:  "limit, l",
-		Usage: "show last n log entries",
+		Name:  "limit, last, l",
+		Usage: "show last n log entries, of each node when nodes are given",
 		Value: 10,
 	},
+	cli.BoolFlag{
+		Name:  "follow, f",
+		Usage: "keep streaming new log entries",
+	},
+	cli.StringSliceFlag{
+		Name:  "type",
+		Usage: "show entries of this type only: 'error' for server errors, 'application' for errors of API calls, 'info' for console messages",
+	},
+	cli.StringFlag{
+		Name:  "since",
+		Usage: "show entries newer than this duration only, e.g. 1h",
+	},
+	cli.StringFlag{
+		Name:  "match",
+		Usage: "show entries whose message matches this regular expression only",
+	},
+	cli.StringFlag{
+		Name:  "out",
+		Usage: "also save the entries to a file, as JSON lines unless the file name ends with .log",
+	},
+	cli.StringFlag{
+		Name:  "rotate-size",
+		Usage: "rotate the --out file once it grows past this size",
+		Value: "100MiB",
+	},
+	cli.StringFlag{
+		Name:  "forward",
+		Usage: "also send the entries to a syslog://HOST[:PORT], syslog+tcp://HOST[:PORT] or http(s):// endpoint",
+	},
 }
 
 var adminConsoleCmd = cli.Command{
@@ -50,7 +91,7 @@ var adminConsoleCmd = cli.Command{
   {{.HelpName}} - {{.Usage}}
 
 USAGE:
-  {{.HelpName}} [FLAGS] TARGET [NODENAME]
+  {{.HelpName}} [FLAGS] TARGET [NODENAME...]
 
 FLAGS:
   {{range .VisibleFlags}}{{.}}
@@ -61,13 +102,106 @@ EXAMPLES:
 
   2. Show last 5 log entries for node 'node1' on MinIO server with alias 'cluster1'
      $ {{.HelpName}} --limit 5 cluster1 node1
+
+  3. Follow the errors of API calls logged during the last hour on two nodes, 5 initial entries each
+     $ {{.HelpName}} --follow --type application --since 1h --last 5 cluster1 node1 node2
+
+  4. Show the log entries mentioning a bucket
+     $ {{.HelpName}} --match "bucket=(photos|docs)" cluster1
+
+  5. Keep the console logs of a cluster in JSON lines files of at most 10MiB
+     $ {{.HelpName}} --follow --out /var/log/minio/console.jsonl --rotate-size 10MiB cluster1
+
+  6. Forward the errors of a cluster to a syslog server
+     $ {{.HelpName}} --follow --type error --forward syslog://logs.example.com:514 cluster1
 `,
 }
 
 func checkAdminLogSyntax(ctx *cli.Context) {
-	if len(ctx.Args()) == 0 || len(ctx.Args()) > 2 {
+	if len(ctx.Args()) == 0 {
 		cli.ShowCommandHelpAndExit(ctx, "console", 1) // last argument is exit code
 	}
+	for _, logType := range ctx.StringSlice("type") {
+		switch logType {
+		case consoleLogError, consoleLogInfo, consoleLogApplication:
+		default:
+			fatalIf(errInvalidArgument().Trace(logType), "Invalid type `"+logType+"`, expected one of error, info or application.")
+		}
+	}
+}
+
+// consoleLogFilter - selects the log entries to print.
+type consoleLogFilter struct {
+	types map[string]bool
+	since time.Time
+	match *regexp.Regexp
+}
+
+// newConsoleLogFilter - builds the log filter from the command line.
+func newConsoleLogFilter(ctx *cli.Context) consoleLogFilter {
+	filter := consoleLogFilter{types: make(map[string]bool)}
+	for _, logType := range ctx.StringSlice("type") {
+		filter.types[logType] = true
+	}
+	if since := ctx.String("since"); since != "" {
+		d, e := time.ParseDuration(since)
+		fatalIf(probe.NewError(e), "Unable to parse since=`"+since+"`.")
+		filter.since = time.Now().Add(-d)
+	}
+	if match := ctx.String("match"); match != "" {
+		var e error
+		filter.match, e = regexp.Compile(match)
+		fatalIf(probe.NewError(e), "Unable to parse match=`"+match+"`.")
+	}
+	return filter
+}
+
+// getConsoleLogType - classifies a log entry, console messages are
+// info, errors raised while serving an API call are application errors
+// and any other error is a server error.
+func getConsoleLogType(l madmin.LogInfo) string {
+	if l.ConsoleMsg != "" {
+		return consoleLogInfo
+	}
+	if l.API != nil && l.API.Name != "" && l.API.Name != "SYSTEM" {
+		return consoleLogApplication
+	}
+	return consoleLogError
+}
+
+// getConsoleLogText - returns the text searched by --match.
+func getConsoleLogText(l madmin.LogInfo) string {
+	if l.ConsoleMsg != "" {
+		return l.ConsoleMsg
+	}
+	text := l.Message
+	if l.API != nil {
+		text += " API: " + l.API.Name
+		if l.API.Args != nil {
+			text += " bucket=" + l.API.Args.Bucket + " object=" + l.API.Args.Object
+		}
+	}
+	if l.Trace != nil {
+		text += " " + l.Trace.Message
+	}
+	return text
+}
+
+// matches - tells if the log entry passes all the filters.
+func (f consoleLogFilter) matches(l madmin.LogInfo) bool {
+	if len(f.types) > 0 && !f.types[getConsoleLogType(l)] {
+		return false
+	}
+	// Entries without a valid time are kept.
+	if !f.since.IsZero() {
+		if t, e := time.Parse(time.RFC3339Nano, l.Time); e == nil && t.Before(f.since) {
+			return false
+		}
+	}
+	if f.match != nil && !f.match.MatchString(getConsoleLogText(l)) {
+		return false
+	}
+	return true
 }
 
 // Extend madmin.LogInfo to add String() and JSON() methods
@@ -158,10 +292,9 @@ func mainAdminConsole(ctx *cli.Context) error {
 		console.SetColor(fmt.Sprintf("Node%d", c), color.New(c))
 	}
 	aliasedURL := ctx.Args().Get(0)
-	var node string
-	if len(ctx.Args()) > 1 {
-		node = ctx.Args().Get(1)
-	}
+	nodes := ctx.Args().Tail()
+	filter := newConsoleLogFilter(ctx)
+	follow := ctx.Bool("follow")
 	var limit int
 	if ctx.IsSet("limit") {
 		limit = ctx.Int("limit")
@@ -175,20 +308,70 @@ func mainAdminConsole(ctx *cli.Context) error {
 		fatalIf(err.Trace(aliasedURL), "Cannot initialize admin client.")
 		return nil
 	}
+	exporter, err := newConsoleLogExporter(ctx)
+	fatalIf(err, "Unable to set up the export of console logs.")
+	if exporter != nil && exporter.out != nil {
+		defer exporter.out.Close()
+	}
+
 	doneCh := make(chan struct{})
 	defer close(doneCh)
 
-	// Start listening on all console log activity.
-	logCh := client.GetLogs(node, limit, doneCh)
-	for logInfo := range logCh {
+	// Start listening on all console log activity, with a stream per
+	// node so that the limit applies to each node.
+	if len(nodes) == 0 {
+		nodes = []string{""}
+	}
+	logCh := make(chan madmin.LogInfo)
+	var wg sync.WaitGroup
+	for _, node := range nodes {
+		wg.Add(1)
+		go func(nodeCh <-chan madmin.LogInfo) {
+			defer wg.Done()
+			for logInfo := range nodeCh {
+				select {
+				case logCh <- logInfo:
+				case <-doneCh:
+					return
+				}
+			}
+		}(client.GetLogs(node, limit, doneCh))
+	}
+	go func() {
+		wg.Wait()
+		close(logCh)
+	}()
+
+	for {
+		// Without --follow, stop once the last entries were sent.
+		var idleCh <-chan time.Time
+		if !follow {
+			idleCh = time.After(consoleIdleTimeout)
+		}
+		var logInfo madmin.LogInfo
+		var ok bool
+		select {
+		case logInfo, ok = <-logCh:
+			if !ok {
+				return nil
+			}
+		case <-idleCh:
+			return nil
+		}
+
 		if logInfo.Err != nil {
 			fatalIf(probe.NewError(logInfo.Err), "Cannot listen to console logs")
 		}
-		// drop nodeName from output if specified as cli arg
-		if node != "" {
+		if !filter.matches(logInfo) {
+			continue
+		}
+		if exporter != nil {
+			exporter.export(logInfo)
+		}
+		// drop nodeName from output if a single node is specified as cli arg
+		if len(nodes) == 1 && nodes[0] != "" {
 			logInfo.NodeName = ""
 		}
 		printMsg(logMessage{logInfo})
 	}
-	return nil
 }
@@ -0,0 +1,69 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/minio/minio/cmd/logger/message/log"
+	"github.com/minio/minio/pkg/madmin"
+)
+
+func TestConsoleLogFilter(t *testing.T) {
+	now := time.Now()
+	consoleMsg := madmin.LogInfo{ConsoleMsg: "Endpoint: http://127.0.0.1:9000"}
+	serverErr := madmin.LogInfo{Entry: log.Entry{
+		Time:  now.Add(-2 * time.Hour).Format(time.RFC3339Nano),
+		API:   &log.API{Name: "SYSTEM"},
+		Trace: &log.Trace{Message: "disk not found"},
+	}}
+	apiErr := madmin.LogInfo{Entry: log.Entry{
+		Time:  now.Add(-time.Minute).Format(time.RFC3339Nano),
+		API:   &log.API{Name: "PutObject", Args: &log.Args{Bucket: "photos", Object: "a.jpg"}},
+		Trace: &log.Trace{Message: "access denied"},
+	}}
+
+	for l, logType := range map[*madmin.LogInfo]string{
+		&consoleMsg: consoleLogInfo,
+		&serverErr:  consoleLogError,
+		&apiErr:     consoleLogApplication,
+	} {
+		if got := getConsoleLogType(*l); got != logType {
+			t.Errorf("expected type %s, got %s", logType, got)
+		}
+	}
+
+	testCases := []struct {
+		filter  consoleLogFilter
+		matches []bool // consoleMsg, serverErr, apiErr
+	}{
+		{consoleLogFilter{}, []bool{true, true, true}},
+		{consoleLogFilter{types: map[string]bool{consoleLogError: true, consoleLogApplication: true}}, []bool{false, true, true}},
+		{consoleLogFilter{since: now.Add(-time.Hour)}, []bool{true, false, true}},
+		{consoleLogFilter{match: regexp.MustCompile("bucket=photos")}, []bool{false, false, true}},
+		{consoleLogFilter{match: regexp.MustCompile("(?i)DISK|endpoint")}, []bool{true, true, false}},
+	}
+	for i, testCase := range testCases {
+		for j, l := range []madmin.LogInfo{consoleMsg, serverErr, apiErr} {
+			if got := testCase.filter.matches(l); got != testCase.matches[j] {
+				t.Errorf("Test %d: entry %d expected %v, got %v", i+1, j+1, testCase.matches[j], got)
+			}
+		}
+	}
+}
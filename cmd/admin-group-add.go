@@ -77,6 +77,17 @@ func (u groupMessage) String() string {
 			s = append(s, console.Colorize("GroupMessage", g))
 		}
 		return strings.Join(s, "\n")
+	case "long":
+		policy := u.GroupPolicy
+		if policy == "" {
+			policy = "-"
+		}
+		return newPrettyTable("  ",
+			Field{"GroupStatus", 9},
+			Field{"GroupName", 20},
+			Field{"GroupPolicy", 20},
+			Field{"GroupMessage", -1},
+		).buildRow(u.GroupStatus, u.GroupName, policy, strings.Join(u.Members, ","))
 	case "disable":
 		return console.Colorize("GroupMessage", "Disabled group `"+u.GroupName+"` successfully.")
 	case "enable":
@@ -134,6 +145,7 @@ func mainAdminGroupAdd(ctx *cli.Context) error {
 		IsRemove: false,
 	}
 	fatalIf(probe.NewError(client.UpdateGroupMembers(gAddRemove)).Trace(args...), "Cannot add new group")
+	recordAdminHistory(aliasedURL, "group add", args[1:], "")
 
 	printMsg(groupMessage{
 		op:        "add",
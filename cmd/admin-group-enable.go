@@ -81,6 +81,7 @@ func mainAdminGroupEnableDisable(ctx *cli.Context) error {
 	}
 	err1 = client.SetGroupStatus(group, status)
 	fatalIf(probe.NewError(err1).Trace(args...), "Could not get group enable")
+	recordAdminHistory(aliasedURL, "group "+ctx.Command.Name, []string{group}, "")
 
 	printMsg(groupMessage{
 		op:          ctx.Command.Name,
@@ -17,23 +17,32 @@
 package cmd
 
 import (
+	"sort"
+
 	"github.com/fatih/color"
 	"github.com/minio/cli"
 	"github.com/minio/mc/pkg/console"
 	"github.com/minio/mc/pkg/probe"
 )
 
+var adminGroupListFlags = []cli.Flag{
+	cli.BoolFlag{
+		Name:  "long, l",
+		Usage: "show status, policy and members of each group",
+	},
+}
+
 var adminGroupListCmd = cli.Command{
 	Name:   "list",
 	Usage:  "display list of groups",
 	Action: mainAdminGroupList,
 	Before: setGlobalsFromContext,
-	Flags:  globalFlags,
+	Flags:  append(adminGroupListFlags, globalFlags...),
 	CustomHelpTemplate: `NAME:
   {{.HelpName}} - {{.Usage}}
 
 USAGE:
-  {{.HelpName}} TARGET
+  {{.HelpName}} [FLAGS] TARGET
 
 FLAGS:
   {{range .VisibleFlags}}{{.}}
@@ -41,6 +50,9 @@ FLAGS:
 EXAMPLES:
   1. List all groups.
      $ {{.HelpName}} myminio
+
+  2. List all groups with their status, policy and members.
+     $ {{.HelpName}} --long myminio
 `,
 }
 
@@ -56,6 +68,9 @@ func mainAdminGroupList(ctx *cli.Context) error {
 	checkAdminGroupListSyntax(ctx)
 
 	console.SetColor("GroupMessage", color.New(color.FgGreen))
+	console.SetColor("GroupStatus", color.New(color.FgCyan))
+	console.SetColor("GroupName", color.New(color.FgBlue))
+	console.SetColor("GroupPolicy", color.New(color.FgYellow))
 
 	// Get the alias parameter from cli
 	args := ctx.Args()
@@ -68,10 +83,26 @@ func mainAdminGroupList(ctx *cli.Context) error {
 	gs, err1 := client.ListGroups()
 	fatalIf(probe.NewError(err1).Trace(args...), "Could not get group list")
 
-	printMsg(groupMessage{
-		op:     "list",
-		Groups: gs,
-	})
+	if !ctx.Bool("long") {
+		printMsg(groupMessage{
+			op:     "list",
+			Groups: gs,
+		})
+		return nil
+	}
+
+	sort.Strings(gs)
+	for _, group := range gs {
+		gd, e := client.GetGroupDescription(group)
+		fatalIf(probe.NewError(e).Trace(aliasedURL, group), "Could not get group info")
+		printMsg(groupMessage{
+			op:          "long",
+			GroupName:   group,
+			GroupStatus: gd.Status,
+			GroupPolicy: gd.Policy,
+			Members:     gd.Members,
+		})
+	}
 
 	return nil
 }
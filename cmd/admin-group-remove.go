@@ -81,6 +81,7 @@ func mainAdminGroupRemove(ctx *cli.Context) error {
 
 	e := client.UpdateGroupMembers(gAddRemove)
 	fatalIf(probe.NewError(e).Trace(args...), "Could not perform remove operation")
+	recordAdminHistory(aliasedURL, "group remove", args[1:], "")
 
 	printMsg(groupMessage{
 		op:        "remove",
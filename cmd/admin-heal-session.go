@@ -0,0 +1,98 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio/pkg/madmin"
+)
+
+// Name of the file inside the mc config folder keeping the heal
+// sequences which can be resumed.
+const healSessionsFile = "heal-sessions.json"
+
+// healSession - a heal sequence started by mc, to be watched again
+// with 'mc admin heal --resume'.
+type healSession struct {
+	ClientToken string          `json:"clientToken"`
+	StartTime   time.Time       `json:"startTime"`
+	Opts        madmin.HealOpts `json:"opts"`
+}
+
+// getHealSessionsPath - returns the path of the heal sessions file.
+func getHealSessionsPath() (string, *probe.Error) {
+	configDir, err := getMcConfigDir()
+	if err != nil {
+		return "", err.Trace()
+	}
+	return filepath.Join(configDir, healSessionsFile), nil
+}
+
+// loadHealSessions - reads the heal sessions, by healed URL.
+func loadHealSessions() (map[string]healSession, *probe.Error) {
+	sessionsPath, err := getHealSessionsPath()
+	if err != nil {
+		return nil, err.Trace()
+	}
+	sessions := map[string]healSession{}
+	sessionsBytes, e := ioutil.ReadFile(sessionsPath)
+	if os.IsNotExist(e) {
+		return sessions, nil
+	}
+	if e != nil {
+		return nil, probe.NewError(e).Trace(sessionsPath)
+	}
+	if e = json.Unmarshal(sessionsBytes, &sessions); e != nil {
+		return nil, probe.NewError(e).Trace(sessionsPath)
+	}
+	return sessions, nil
+}
+
+// saveHealSession - saves or, when session is nil, removes the heal
+// session of aliasedURL.
+func saveHealSession(aliasedURL string, session *healSession) *probe.Error {
+	sessions, err := loadHealSessions()
+	if err != nil {
+		return err.Trace(aliasedURL)
+	}
+	if session == nil {
+		if _, ok := sessions[aliasedURL]; !ok {
+			return nil
+		}
+		delete(sessions, aliasedURL)
+	} else {
+		sessions[aliasedURL] = *session
+	}
+	sessionsPath, err := getHealSessionsPath()
+	if err != nil {
+		return err.Trace(aliasedURL)
+	}
+	sessionsBytes, e := json.MarshalIndent(sessions, "", " ")
+	if e != nil {
+		return probe.NewError(e)
+	}
+	if e = ioutil.WriteFile(sessionsPath, sessionsBytes, 0600); e != nil {
+		return probe.NewError(e).Trace(sessionsPath)
+	}
+	return nil
+}
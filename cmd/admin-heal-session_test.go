@@ -0,0 +1,57 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/minio/minio/pkg/madmin"
+)
+
+func TestHealSessions(t *testing.T) {
+	configDir, e := ioutil.TempDir("", "mc-heal-")
+	if e != nil {
+		t.Fatal(e)
+	}
+	defer os.RemoveAll(configDir)
+	defer setMcConfigDir(mcCustomConfigDir)
+	setMcConfigDir(configDir)
+
+	session := healSession{ClientToken: "token1", Opts: madmin.HealOpts{Recursive: true, DryRun: true}}
+	if err := saveHealSession("myminio/bucket", &session); err != nil {
+		t.Fatal(err)
+	}
+	if err := saveHealSession("myminio/other", &healSession{ClientToken: "token2"}); err != nil {
+		t.Fatal(err)
+	}
+	sessions, err := loadHealSessions()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sessions) != 2 || sessions["myminio/bucket"] != session {
+		t.Fatalf("Unexpected heal sessions %+v", sessions)
+	}
+
+	if err = saveHealSession("myminio/bucket", nil); err != nil {
+		t.Fatal(err)
+	}
+	if sessions, _ = loadHealSessions(); len(sessions) != 1 || sessions["myminio/other"].ClientToken != "token2" {
+		t.Errorf("Unexpected heal sessions after removal %+v", sessions)
+	}
+}
@@ -393,14 +393,7 @@ func (ui *uiData) UpdateDisplay(s *madmin.HealTaskStatus) (err error) {
 }
 
 func (ui *uiData) healResumeMsg(aliasedURL string) string {
-	var flags string
-	if ui.HealOpts.Recursive {
-		flags += "--recursive "
-	}
-	if ui.HealOpts.DryRun {
-		flags += "--dry-run "
-	}
-	return fmt.Sprintf("Healing is backgrounded, to resume watching use `mc admin heal %s %s`", flags, aliasedURL)
+	return fmt.Sprintf("Healing is backgrounded, to resume watching use `mc admin heal --resume %s`", aliasedURL)
 }
 
 func (ui *uiData) DisplayAndFollowHealStatus(aliasedURL string) (res madmin.HealTaskStatus, err error) {
@@ -61,6 +61,10 @@ var adminHealFlags = []cli.Flag{
 		Name:  "remove",
 		Usage: "remove dangling objects in heal sequence",
 	},
+	cli.BoolFlag{
+		Name:  "resume",
+		Usage: "resume watching a heal sequence left running in the background",
+	},
 }
 
 var adminHealCmd = cli.Command{
@@ -107,6 +111,9 @@ EXAMPLES:
 		
   8. Issue a dry-run heal operation to inspect objects health under 'dir' prefix
      $ {{.HelpName}} --dry-run myminio/testbucket/dir/
+
+  9. Resume watching the heal sequence of 'testbucket' after it was left running with Ctrl-C
+     $ {{.HelpName}} --resume myminio/testbucket/
 `,
 }
 
@@ -204,7 +211,7 @@ func mainAdminHeal(ctx *cli.Context) error {
 
 	// Return the background heal status when the user
 	// doesn't pass a bucket or --recursive flag.
-	if bucket == "" && !ctx.Bool("recursive") {
+	if bucket == "" && !ctx.Bool("recursive") && !ctx.Bool("resume") {
 		bgHealStatus, berr := client.BackgroundHealStatus()
 		fatalIf(probe.NewError(berr), "Failed to get the status of the background heal.")
 		printMsg(backgroundHealStatusMessage{Status: "success", HealInfo: bgHealStatus})
@@ -218,23 +225,44 @@ func mainAdminHeal(ctx *cli.Context) error {
 		DryRun:    ctx.Bool("dry-run"),
 	}
 
+	// Heal sequences are resumed by the URL they were started with.
+	sessionURL := strings.TrimSuffix(aliasedURL, "/")
+
 	forceStart := ctx.Bool("force-start")
 	forceStop := ctx.Bool("force-stop")
 	if forceStop {
 		_, _, herr := client.Heal(bucket, prefix, opts, "", forceStart, forceStop)
 		fatalIf(probe.NewError(herr), "Failed to stop heal sequence.")
+		errorIf(saveHealSession(sessionURL, nil), "Unable to remove the stopped heal sequence.")
 		printMsg(stopHealMessage{Status: "success", Alias: aliasedURL})
 		return nil
 	}
 
-	healStart, _, herr := client.Heal(bucket, prefix, opts, "", forceStart, false)
-	fatalIf(probe.NewError(herr), "Failed to start heal sequence.")
+	var clientToken string
+	if ctx.Bool("resume") {
+		sessions, err := loadHealSessions()
+		fatalIf(err, "Unable to read the heal sequences to resume.")
+		session, ok := sessions[sessionURL]
+		if !ok {
+			fatalIf(errDummy().Trace(aliasedURL), "No heal sequence to resume at `"+aliasedURL+"`.")
+		}
+		clientToken, opts = session.ClientToken, session.Opts
+	} else {
+		healStart, _, herr := client.Heal(bucket, prefix, opts, "", forceStart, false)
+		fatalIf(probe.NewError(herr), "Failed to start heal sequence.")
+		clientToken = healStart.ClientToken
+		errorIf(saveHealSession(sessionURL, &healSession{
+			ClientToken: clientToken,
+			StartTime:   healStart.StartTime,
+			Opts:        opts,
+		}), "Unable to save the heal sequence, it cannot be resumed.")
+	}
 
 	ui := uiData{
 		Bucket:                bucket,
 		Prefix:                prefix,
 		Client:                client,
-		ClientToken:           healStart.ClientToken,
+		ClientToken:           clientToken,
 		ForceStart:            forceStart,
 		HealOpts:              &opts,
 		ObjectsByOnlineDrives: make(map[int]int64),
@@ -243,6 +271,10 @@ func mainAdminHeal(ctx *cli.Context) error {
 	}
 
 	res, e := ui.DisplayAndFollowHealStatus(aliasedURL)
+	// Sequences left running can still be resumed.
+	if res.Summary == "finished" || res.Summary == "stopped" {
+		errorIf(saveHealSession(sessionURL, nil), "Unable to remove the finished heal sequence.")
+	}
 	if e != nil {
 		if res.FailureDetail != "" {
 			data, _ := json.MarshalIndent(res, "", " ")
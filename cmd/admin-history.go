@@ -0,0 +1,329 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/console"
+	"github.com/minio/mc/pkg/probe"
+)
+
+var adminHistoryFlags = []cli.Flag{
+	cli.BoolFlag{
+		Name:  "diff",
+		Usage: "show the changes recorded for each action",
+	},
+}
+
+var adminHistoryCmd = cli.Command{
+	Name:   "history",
+	Usage:  "show admin actions performed through mc",
+	Action: mainAdminHistory,
+	Before: setGlobalsFromContext,
+	Flags:  append(adminHistoryFlags, globalFlags...),
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [FLAGS] TARGET
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+DESCRIPTION:
+  Every admin change made through mc (config, users, groups and policies) is
+  appended to a local ledger. Each entry carries the hash of the previous one,
+  the whole ledger is verified before anything is printed. Removing the latest
+  entries can only be detected by comparing with a previously recorded hash.
+
+EXAMPLES:
+  1. Show all admin actions performed on a MinIO server/cluster.
+     $ {{.HelpName}} myminio
+
+  2. Show all admin actions along with the configuration and policy changes.
+     $ {{.HelpName}} --diff myminio
+`,
+}
+
+// Name of the admin history ledger inside the mc config folder.
+const adminHistoryFile = "admin-history.json"
+
+// adminHistoryEntry is a single admin action in the ledger.
+type adminHistoryEntry struct {
+	Time     time.Time `json:"time"`
+	Alias    string    `json:"alias"`
+	Action   string    `json:"action"`
+	Args     []string  `json:"args,omitempty"`
+	Diff     string    `json:"diff,omitempty"`
+	PrevHash string    `json:"prevHash"`
+	Hash     string    `json:"hash"`
+}
+
+// hash returns the hash of an entry, which covers all fields but the
+// hash itself.
+func (h adminHistoryEntry) hash() string {
+	h.Hash = ""
+	entryBytes, _ := json.Marshal(h)
+	sum := sha256.Sum256(entryBytes)
+	return hex.EncodeToString(sum[:])
+}
+
+// getAdminHistoryPath returns the path of the admin history ledger.
+func getAdminHistoryPath() (string, *probe.Error) {
+	configDir, err := getMcConfigDir()
+	if err != nil {
+		return "", err.Trace()
+	}
+	return filepath.Join(configDir, adminHistoryFile), nil
+}
+
+// loadAdminHistory reads all entries of the admin history ledger.
+func loadAdminHistory() ([]adminHistoryEntry, *probe.Error) {
+	historyPath, err := getAdminHistoryPath()
+	if err != nil {
+		return nil, err.Trace()
+	}
+	historyFile, e := os.Open(historyPath)
+	if os.IsNotExist(e) {
+		return nil, nil
+	}
+	if e != nil {
+		return nil, probe.NewError(e).Trace(historyPath)
+	}
+	defer historyFile.Close()
+
+	var entries []adminHistoryEntry
+	scanner := bufio.NewScanner(historyFile)
+	scanner.Buffer(make([]byte, 64*1024), 64*1024*1024)
+	for scanner.Scan() {
+		var entry adminHistoryEntry
+		if e = json.Unmarshal(scanner.Bytes(), &entry); e != nil {
+			return nil, probe.NewError(e).Trace(historyPath, strconv.Itoa(len(entries)+1))
+		}
+		entries = append(entries, entry)
+	}
+	if e = scanner.Err(); e != nil {
+		return nil, probe.NewError(e).Trace(historyPath)
+	}
+	return entries, nil
+}
+
+// verifyAdminHistory checks the hash chain of the ledger, returning the
+// index of the first entry which was modified, or -1.
+func verifyAdminHistory(entries []adminHistoryEntry) int {
+	var prevHash string
+	for i, entry := range entries {
+		if entry.PrevHash != prevHash || entry.Hash != entry.hash() {
+			return i
+		}
+		prevHash = entry.Hash
+	}
+	return -1
+}
+
+// appendAdminHistory appends an admin action to the ledger.
+func appendAdminHistory(aliasedURL, action string, args []string, diff string) *probe.Error {
+	entries, err := loadAdminHistory()
+	if err != nil {
+		return err.Trace(aliasedURL)
+	}
+	if i := verifyAdminHistory(entries); i >= 0 {
+		return probe.NewError(fmt.Errorf("admin history entry %d was modified", i+1))
+	}
+
+	alias, _ := url2Alias(aliasedURL)
+	entry := adminHistoryEntry{
+		Time:   UTCNow(),
+		Alias:  alias,
+		Action: action,
+		Args:   args,
+		Diff:   diff,
+	}
+	if len(entries) > 0 {
+		entry.PrevHash = entries[len(entries)-1].Hash
+	}
+	entry.Hash = entry.hash()
+	entryBytes, e := json.Marshal(entry)
+	if e != nil {
+		return probe.NewError(e)
+	}
+
+	historyPath, err := getAdminHistoryPath()
+	if err != nil {
+		return err.Trace()
+	}
+	historyFile, e := os.OpenFile(historyPath, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+	if e != nil {
+		return probe.NewError(e).Trace(historyPath)
+	}
+	if _, e = historyFile.Write(append(entryBytes, '\n')); e != nil {
+		historyFile.Close()
+		return probe.NewError(e).Trace(historyPath)
+	}
+	return probe.NewError(historyFile.Close())
+}
+
+// recordAdminHistory records an admin action which already succeeded,
+// failing to record it only prints an error.
+func recordAdminHistory(aliasedURL, action string, args []string, diff string) {
+	errorIf(appendAdminHistory(aliasedURL, action, args, diff), "Unable to record `"+action+"` in admin history.")
+}
+
+// indentJSON returns indented JSON, or data unchanged if it is not JSON.
+func indentJSON(data []byte) string {
+	var out bytes.Buffer
+	if e := json.Indent(&out, data, "", " "); e != nil {
+		return string(data)
+	}
+	return out.String()
+}
+
+// diffLines returns the lines removed from before, prefixed by '-', and
+// the lines added in after, prefixed by '+'.
+func diffLines(before, after string) string {
+	a := strings.Split(strings.TrimSuffix(before, "\n"), "\n")
+	b := strings.Split(strings.TrimSuffix(after, "\n"), "\n")
+	if before == "" {
+		a = nil
+	}
+	if after == "" {
+		b = nil
+	}
+
+	// Longest common subsequence of lines.
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			switch {
+			case a[i] == b[j]:
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			case lcs[i+1][j] >= lcs[i][j+1]:
+				lcs[i][j] = lcs[i+1][j]
+			default:
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	var diff strings.Builder
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			i++
+			j++
+		case i < len(a) && (j == len(b) || lcs[i+1][j] >= lcs[i][j+1]):
+			diff.WriteString("-" + a[i] + "\n")
+			i++
+		default:
+			diff.WriteString("+" + b[j] + "\n")
+			j++
+		}
+	}
+	return diff.String()
+}
+
+// adminHistoryMessage container for admin history entries.
+type adminHistoryMessage struct {
+	Status string `json:"status"`
+	adminHistoryEntry
+	showDiff bool
+}
+
+// String colorized admin history message.
+func (h adminHistoryMessage) String() string {
+	msg := console.Colorize("HistoryTime", "["+h.Time.Local().Format(printDate)+"] ") +
+		console.Colorize("HistoryAction", h.Action) + " " + strings.Join(h.Args, " ") +
+		console.Colorize("HistoryHash", " "+h.Hash[:12])
+	if !h.showDiff || h.Diff == "" {
+		return msg
+	}
+	for _, line := range strings.Split(strings.TrimSuffix(h.Diff, "\n"), "\n") {
+		switch {
+		case strings.HasPrefix(line, "+"):
+			line = console.Colorize("HistoryAdded", line)
+		case strings.HasPrefix(line, "-"):
+			line = console.Colorize("HistoryRemoved", line)
+		}
+		msg += "\n  " + line
+	}
+	return msg
+}
+
+// JSON jsonified admin history message.
+func (h adminHistoryMessage) JSON() string {
+	h.Status = "success"
+	if !h.showDiff {
+		h.Diff = ""
+	}
+	historyBytes, e := json.MarshalIndent(h, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(historyBytes)
+}
+
+// checkAdminHistorySyntax - validate all the passed arguments
+func checkAdminHistorySyntax(ctx *cli.Context) {
+	if len(ctx.Args()) != 1 {
+		cli.ShowCommandHelpAndExit(ctx, "history", 1) // last argument is exit code
+	}
+}
+
+// mainAdminHistory is the handle for "mc admin history" command.
+func mainAdminHistory(ctx *cli.Context) error {
+	checkAdminHistorySyntax(ctx)
+
+	console.SetColor("HistoryTime", color.New(color.FgGreen))
+	console.SetColor("HistoryAction", color.New(color.Bold))
+	console.SetColor("HistoryHash", color.New(color.FgYellow))
+	console.SetColor("HistoryAdded", color.New(color.FgGreen))
+	console.SetColor("HistoryRemoved", color.New(color.FgRed))
+
+	alias, _ := url2Alias(ctx.Args().Get(0))
+
+	entries, err := loadAdminHistory()
+	fatalIf(err, "Unable to read admin history.")
+	if i := verifyAdminHistory(entries); i >= 0 {
+		fatalIf(probe.NewError(errors.New("hash mismatch")).Trace(strconv.Itoa(i+1)),
+			fmt.Sprintf("Admin history was tampered with at entry %d.", i+1))
+	}
+
+	for _, entry := range entries {
+		if entry.Alias != alias {
+			continue
+		}
+		printMsg(adminHistoryMessage{adminHistoryEntry: entry, showDiff: ctx.Bool("diff")})
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import "testing"
+
+func TestDiffLines(t *testing.T) {
+	testCases := []struct {
+		before, after string
+		expected      string
+	}{
+		{"", "", ""},
+		{"a\nb\n", "a\nb\n", ""},
+		{"", "a\n", "+a\n"},
+		{"a\n", "", "-a\n"},
+		{"a\nb\nc", "a\nx\nc", "-b\n+x\n"},
+		{"a\nb", "a\nb\nc", "+c\n"},
+	}
+	for i, testCase := range testCases {
+		if got := diffLines(testCase.before, testCase.after); got != testCase.expected {
+			t.Errorf("Test %d: expected %q, got %q", i+1, testCase.expected, got)
+		}
+	}
+}
+
+func TestVerifyAdminHistory(t *testing.T) {
+	var entries []adminHistoryEntry
+	for _, action := range []string{"user add", "policy set", "config set"} {
+		entry := adminHistoryEntry{Alias: "myminio", Action: action}
+		if len(entries) > 0 {
+			entry.PrevHash = entries[len(entries)-1].Hash
+		}
+		entry.Hash = entry.hash()
+		entries = append(entries, entry)
+	}
+	if i := verifyAdminHistory(entries); i != -1 {
+		t.Fatalf("expected a valid ledger, entry %d failed", i+1)
+	}
+
+	entries[1].Action = "policy remove"
+	if i := verifyAdminHistory(entries); i != 1 {
+		t.Fatalf("expected modified entry 2 to be detected, got %d", i+1)
+	}
+
+	entries[1].Hash = entries[1].hash()
+	if i := verifyAdminHistory(entries); i != 2 {
+		t.Fatalf("expected broken chain at entry 3 to be detected, got %d", i+1)
+	}
+
+	if i := verifyAdminHistory(append(entries[:1:1], entries[2])); i != 1 {
+		t.Fatalf("expected removed entry to be detected, got %d", i+1)
+	}
+}
@@ -0,0 +1,254 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	humanize "github.com/dustin/go-humanize"
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/console"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio/pkg/madmin"
+)
+
+var adminInfoCluster = cli.Command{
+	Name:   "cluster",
+	Usage:  "display health and capacity of a MinIO cluster",
+	Action: mainAdminInfoCluster,
+	Before: setGlobalsFromContext,
+	Flags:  globalFlags,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} TARGET
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+  1. Show the health and capacity of the 'play' MinIO server.
+     $ {{.HelpName}} play/
+
+  2. Show the drives of each node of a cluster in JSON.
+     $ {{.HelpName}} --json cluster1
+`,
+}
+
+// Drive state reported by healthy drives.
+const driveStateOk = "ok"
+
+// clusterNodeInfo - version, uptime, network and drives of a node.
+type clusterNodeInfo struct {
+	Addr    string                 `json:"address"`
+	Error   string                 `json:"error,omitempty"`
+	Version string                 `json:"version,omitempty"`
+	Uptime  time.Duration          `json:"uptime,omitempty"`
+	Network madmin.ServerConnStats `json:"network"`
+	Drives  []madmin.DriveInfo     `json:"drives,omitempty"`
+}
+
+// onlineDrives - counts the healthy drives of the node.
+func (n clusterNodeInfo) onlineDrives() (online int) {
+	for _, drive := range n.Drives {
+		if drive.State == driveStateOk {
+			online++
+		}
+	}
+	return online
+}
+
+// clusterInfoMessage - health and capacity of a cluster.
+type clusterInfoMessage struct {
+	Status        string              `json:"status"`
+	Backend       string              `json:"backend"`
+	Used          uint64              `json:"used"`
+	Available     uint64              `json:"available"`
+	Total         uint64              `json:"total"`
+	OnlineDrives  int                 `json:"onlineDrives"`
+	OfflineDrives int                 `json:"offlineDrives"`
+	Healing       *madmin.BgHealState `json:"healing,omitempty"`
+	Nodes         []clusterNodeInfo   `json:"nodes"`
+}
+
+// String colorized cluster info message.
+func (c clusterInfoMessage) String() (msg string) {
+	dot := "●"
+	for _, node := range c.Nodes {
+		if node.Error != "" {
+			msg += fmt.Sprintf("%s  %s\n", console.Colorize("InfoFail", dot), console.Colorize("PrintB", node.Addr))
+			msg += fmt.Sprintf("    Error: %s\n\n", console.Colorize("InfoFail", node.Error))
+			continue
+		}
+		online := node.onlineDrives()
+		state := "Info"
+		if online < len(node.Drives) {
+			state = "InfoDegraded"
+		}
+		msg += fmt.Sprintf("%s  %s\n", console.Colorize(state, dot), console.Colorize("PrintB", node.Addr))
+		msg += fmt.Sprintf("   Uptime: %s\n", console.Colorize("Info",
+			humanize.RelTime(time.Now(), time.Now().Add(-node.Uptime), "", "")))
+		version := node.Version
+		if version == "DEVELOPMENT.GOGET" {
+			version = "<development>"
+		}
+		msg += fmt.Sprintf("  Version: %s\n", version)
+		msg += fmt.Sprintf("  Network: In %s, Out %s\n",
+			humanize.IBytes(node.Network.TotalInputBytes), humanize.IBytes(node.Network.TotalOutputBytes))
+		if len(node.Drives) > 0 {
+			msg += fmt.Sprintf("   Drives: %s\n", console.Colorize(state, fmt.Sprintf("%d/%d OK", online, len(node.Drives))))
+			for _, drive := range node.Drives {
+				if drive.State != driveStateOk {
+					msg += fmt.Sprintf("           %s %s\n", drive.Endpoint, console.Colorize("InfoFail", drive.State))
+				}
+			}
+		}
+		msg += "\n"
+	}
+
+	// Cluster wide summary
+	var usage string
+	if c.Total > 0 {
+		usage = fmt.Sprintf(" (%.1f%% used)", float64(c.Used)*100/float64(c.Total))
+	}
+	msg += fmt.Sprintf("  Backend: %s\n", c.Backend)
+	msg += fmt.Sprintf(" Capacity: Used %s, Free %s, Total %s%s\n", humanize.IBytes(c.Used),
+		humanize.IBytes(c.Available), humanize.IBytes(c.Total), usage)
+	if c.OnlineDrives+c.OfflineDrives > 0 {
+		drives := fmt.Sprintf("%d online, %d offline", c.OnlineDrives, c.OfflineDrives)
+		if c.OfflineDrives > 0 {
+			drives = console.Colorize("InfoDegraded", drives)
+		}
+		msg += fmt.Sprintf("   Drives: %s\n", drives)
+	}
+	if c.Healing != nil {
+		lastActivity := "never"
+		if !c.Healing.LastHealActivity.IsZero() {
+			lastActivity = humanize.Time(c.Healing.LastHealActivity)
+		}
+		msg += fmt.Sprintf("  Healing: %d objects scanned, last activity %s\n", c.Healing.ScannedItemsCount, lastActivity)
+	}
+	return strings.TrimSuffix(msg, "\n")
+}
+
+// JSON jsonified cluster info message.
+func (c clusterInfoMessage) JSON() string {
+	c.Status = "success"
+	jsonBytes, e := json.MarshalIndent(c, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(jsonBytes)
+}
+
+// isDriveOnNode - tells if the drive endpoint belongs to the node at
+// addr, local paths are only reported by single servers.
+func isDriveOnNode(endpoint, addr string, servers int) bool {
+	u, e := url.Parse(endpoint)
+	if e != nil || u.Host == "" {
+		return servers == 1
+	}
+	return u.Host == addr
+}
+
+// getClusterInfo - builds the cluster info from the info of each server.
+func getClusterInfo(serversInfo []madmin.ServerInfo, healing *madmin.BgHealState) clusterInfoMessage {
+	c := clusterInfoMessage{Healing: healing}
+	var storageInfo *madmin.StorageInfo
+	for _, serverInfo := range serversInfo {
+		node := clusterNodeInfo{Addr: serverInfo.Addr, Error: serverInfo.Error}
+		if serverInfo.Error == "" && serverInfo.Data != nil {
+			node.Version = serverInfo.Data.Properties.Version
+			node.Uptime = serverInfo.Data.Properties.Uptime
+			node.Network = serverInfo.Data.ConnStats
+			// Each server reports the storage of the whole cluster.
+			if storageInfo == nil {
+				storageInfo = &serverInfo.Data.StorageInfo
+			}
+		}
+		c.Nodes = append(c.Nodes, node)
+	}
+	sort.Slice(c.Nodes, func(i, j int) bool {
+		return c.Nodes[i].Addr < c.Nodes[j].Addr
+	})
+	if storageInfo == nil {
+		c.Backend = "unknown"
+		return c
+	}
+
+	c.Used, c.Available, c.Total = storageInfo.Used, storageInfo.Available, storageInfo.Total
+	if storageInfo.Backend.Type != madmin.Erasure {
+		c.Backend = string(fsType)
+		return c
+	}
+	c.Backend = string(erasureType)
+	for _, set := range storageInfo.Backend.Sets {
+		for _, drive := range set {
+			if drive.State == driveStateOk {
+				c.OnlineDrives++
+			} else {
+				c.OfflineDrives++
+			}
+			for i := range c.Nodes {
+				if isDriveOnNode(drive.Endpoint, c.Nodes[i].Addr, len(c.Nodes)) {
+					c.Nodes[i].Drives = append(c.Nodes[i].Drives, drive)
+					break
+				}
+			}
+		}
+	}
+	return c
+}
+
+// checkAdminInfoClusterSyntax - validate all the passed arguments
+func checkAdminInfoClusterSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) != 1 {
+		cli.ShowCommandHelpAndExit(ctx, "cluster", 1) // last argument is exit code
+	}
+}
+
+func mainAdminInfoCluster(ctx *cli.Context) error {
+	checkAdminInfoClusterSyntax(ctx)
+
+	console.SetColor("Info", color.New(color.FgGreen, color.Bold))
+	console.SetColor("InfoDegraded", color.New(color.FgYellow, color.Bold))
+	console.SetColor("InfoFail", color.New(color.FgRed, color.Bold))
+
+	aliasedURL := ctx.Args().Get(0)
+
+	// Create a new MinIO Admin Client
+	client, err := newAdminClient(aliasedURL)
+	fatalIf(err, "Unable to initialize admin connection.")
+
+	serversInfo, e := client.ServerInfo()
+	fatalIf(probe.NewError(e).Trace(aliasedURL), "Unable to get server information.")
+
+	// Background healing is only available in erasure mode.
+	var healing *madmin.BgHealState
+	if healState, e := client.BackgroundHealStatus(); e == nil {
+		healing = &healState
+	}
+
+	printMsg(getClusterInfo(serversInfo, healing))
+	return nil
+}
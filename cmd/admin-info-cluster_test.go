@@ -0,0 +1,81 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"testing"
+
+	"github.com/minio/minio/pkg/madmin"
+)
+
+func TestGetClusterInfo(t *testing.T) {
+	newInfo := func(addr string, sets [][]madmin.DriveInfo) madmin.ServerInfo {
+		info := madmin.ServerInfo{Addr: addr, Data: &madmin.ServerInfoData{}}
+		info.Data.Properties.Version = "2019-10-12T01:39:57Z"
+		info.Data.StorageInfo.Used = 25
+		info.Data.StorageInfo.Available = 75
+		info.Data.StorageInfo.Total = 100
+		info.Data.StorageInfo.Backend.Type = madmin.Erasure
+		info.Data.StorageInfo.Backend.Sets = sets
+		return info
+	}
+	sets := [][]madmin.DriveInfo{{
+		{Endpoint: "http://node1:9000/data1", State: "ok"},
+		{Endpoint: "http://node1:9000/data2", State: "ok"},
+		{Endpoint: "http://node2:9000/data1", State: "offline"},
+		{Endpoint: "http://node2:9000/data2", State: "ok"},
+	}}
+	serversInfo := []madmin.ServerInfo{
+		newInfo("node2:9000", sets),
+		newInfo("node1:9000", sets),
+		{Addr: "node3:9000", Error: "rpc: retry error"},
+	}
+
+	c := getClusterInfo(serversInfo, nil)
+	if c.Backend != string(erasureType) || c.Used != 25 || c.Total != 100 {
+		t.Fatalf("Unexpected cluster info %+v", c)
+	}
+	if c.OnlineDrives != 3 || c.OfflineDrives != 1 {
+		t.Errorf("Expected 3 online and 1 offline drives, got %d and %d", c.OnlineDrives, c.OfflineDrives)
+	}
+	testCases := []struct {
+		addr   string
+		err    string
+		drives int
+		online int
+	}{
+		{"node1:9000", "", 2, 2},
+		{"node2:9000", "", 2, 1},
+		{"node3:9000", "rpc: retry error", 0, 0},
+	}
+	for i, testCase := range testCases {
+		node := c.Nodes[i]
+		if node.Addr != testCase.addr || node.Error != testCase.err {
+			t.Errorf("Test %d: unexpected node %+v", i+1, node)
+		}
+		if len(node.Drives) != testCase.drives || node.onlineDrives() != testCase.online {
+			t.Errorf("Test %d: expected %d/%d drives, got %d/%d", i+1,
+				testCase.online, testCase.drives, node.onlineDrives(), len(node.Drives))
+		}
+	}
+
+	// Local drives of a single server.
+	single := newInfo("localhost:9000", [][]madmin.DriveInfo{{{Endpoint: "/data1", State: "ok"}}})
+	if c = getClusterInfo([]madmin.ServerInfo{single}, nil); len(c.Nodes[0].Drives) != 1 {
+		t.Errorf("Expected the local drive on the single server, got %+v", c.Nodes[0])
+	}
+}
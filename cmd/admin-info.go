@@ -28,6 +28,7 @@ var adminInfoCmd = cli.Command{
 	Flags:  globalFlags,
 	Subcommands: []cli.Command{
 		adminInfoServer,
+		adminInfoCluster,
 		adminInfoCPU,
 		adminInfoMem,
 	},
@@ -0,0 +1,142 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/console"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio/pkg/madmin"
+)
+
+var adminKMSKeyStatusCmd = cli.Command{
+	Name:   "status",
+	Usage:  "check the status of a KMS master key",
+	Action: mainAdminKMSKeyStatus,
+	Before: setGlobalsFromContext,
+	Flags:  globalFlags,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} TARGET [KEY_ID]
+
+KEY_ID:
+  The master key to check, the default master key of the server when omitted.
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+DESCRIPTION:
+  The server generates a data key with the master key, decrypts it again
+  and re-wraps it, reporting the result of each step.
+
+EXAMPLES:
+  1. Check the default master key of the server.
+     $ {{.HelpName}} myminio
+
+  2. Check the master key 'my-minio-key'.
+     $ {{.HelpName}} myminio my-minio-key
+`,
+}
+
+// kmsKeyStatusMessage container for the status of a KMS master key.
+type kmsKeyStatusMessage struct {
+	Status        string `json:"status"`
+	KeyID         string `json:"keyId"`
+	EncryptionErr string `json:"encryptionError,omitempty"`
+	DecryptionErr string `json:"decryptionError,omitempty"`
+	UpdateErr     string `json:"updateError,omitempty"`
+}
+
+// ok tells whether all operations with the key succeeded.
+func (k kmsKeyStatusMessage) ok() bool {
+	return k.EncryptionErr == "" && k.DecryptionErr == "" && k.UpdateErr == ""
+}
+
+// String colorized KMS key status message.
+func (k kmsKeyStatusMessage) String() string {
+	result := func(err string) string {
+		if err == "" {
+			return console.Colorize("KMSKeyOK", "✔")
+		}
+		return console.Colorize("KMSKeyFail", "✗ "+err)
+	}
+	msg := fmt.Sprintf("Key: %s\n", console.Colorize("PrintB", k.KeyID))
+	msg += fmt.Sprintf("   - Encryption %s\n", result(k.EncryptionErr))
+	msg += fmt.Sprintf("   - Decryption %s\n", result(k.DecryptionErr))
+	msg += fmt.Sprintf("   - Re-wrap    %s", result(k.UpdateErr))
+	return msg
+}
+
+// JSON jsonified KMS key status message.
+func (k kmsKeyStatusMessage) JSON() string {
+	k.Status = "success"
+	if !k.ok() {
+		k.Status = "error"
+	}
+	statusJSONBytes, e := json.MarshalIndent(k, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(statusJSONBytes)
+}
+
+// newKMSKeyStatusMessage - converts the key status reported by the server.
+func newKMSKeyStatusMessage(status *madmin.KMSKeyStatus) kmsKeyStatusMessage {
+	return kmsKeyStatusMessage{
+		KeyID:         status.KeyID,
+		EncryptionErr: status.EncryptionErr,
+		DecryptionErr: status.DecryptionErr,
+		UpdateErr:     status.UpdateErr,
+	}
+}
+
+// checkAdminKMSKeyStatusSyntax - validate all the passed arguments
+func checkAdminKMSKeyStatusSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) == 0 || len(ctx.Args()) > 2 {
+		cli.ShowCommandHelpAndExit(ctx, "status", 1) // last argument is exit code
+	}
+}
+
+// mainAdminKMSKeyStatus is the handle for "mc admin kms key status" command.
+func mainAdminKMSKeyStatus(ctx *cli.Context) error {
+	checkAdminKMSKeyStatusSyntax(ctx)
+
+	console.SetColor("KMSKeyOK", color.New(color.FgGreen, color.Bold))
+	console.SetColor("KMSKeyFail", color.New(color.FgRed, color.Bold))
+
+	args := ctx.Args()
+	aliasedURL := args.Get(0)
+
+	// Create a new MinIO Admin Client
+	client, err := newAdminClient(aliasedURL)
+	fatalIf(err, "Unable to initialize admin connection.")
+
+	status, e := client.GetKeyStatus(args.Get(1))
+	fatalIf(probe.NewError(e).Trace(args...), "Unable to get the status of the key.")
+
+	msg := newKMSKeyStatusMessage(status)
+	printMsg(msg)
+	if !msg.ok() {
+		return exitStatus(globalErrorExitStatus)
+	}
+	return nil
+}
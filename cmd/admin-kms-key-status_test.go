@@ -0,0 +1,41 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"testing"
+
+	"github.com/minio/minio/pkg/madmin"
+)
+
+func TestKMSKeyStatusMessage(t *testing.T) {
+	testCases := []struct {
+		status madmin.KMSKeyStatus
+		ok     bool
+	}{
+		{madmin.KMSKeyStatus{KeyID: "my-minio-key"}, true},
+		{madmin.KMSKeyStatus{KeyID: "my-minio-key", EncryptionErr: "key does not exist"}, false},
+		{madmin.KMSKeyStatus{KeyID: "my-minio-key", DecryptionErr: "invalid ciphertext"}, false},
+		{madmin.KMSKeyStatus{KeyID: "my-minio-key", UpdateErr: "not allowed"}, false},
+	}
+	for i, testCase := range testCases {
+		msg := newKMSKeyStatusMessage(&testCase.status)
+		if msg.ok() != testCase.ok {
+			t.Errorf("Test %d: expected ok %v, got %v", i+1, testCase.ok, msg.ok())
+		}
+	}
+}
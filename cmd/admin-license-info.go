@@ -0,0 +1,136 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/console"
+	"github.com/minio/mc/pkg/probe"
+)
+
+// License MinIO server releases are distributed under.
+const minioServerLicense = "Apache License 2.0"
+
+var adminLicenseInfoCmd = cli.Command{
+	Name:   "info",
+	Usage:  "display the license and release of all servers of a deployment",
+	Action: mainAdminLicenseInfo,
+	Before: setGlobalsFromContext,
+	Flags:  globalFlags,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} TARGET
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+DESCRIPTION:
+  MinIO servers do not expose a subscription API, the license shown is the
+  license the installed MinIO releases are distributed under.
+
+EXAMPLES:
+  1. Display the license of all servers of the deployment 'play'.
+     $ {{.HelpName}} play
+`,
+}
+
+// licenseServer is the release information of a single server.
+type licenseServer struct {
+	Addr    string `json:"address"`
+	Version string `json:"version,omitempty"`
+	Err     string `json:"error,omitempty"`
+}
+
+// licenseInfoMessage container for license information.
+type licenseInfoMessage struct {
+	Status  string          `json:"status"`
+	Alias   string          `json:"alias"`
+	License string          `json:"license"`
+	Servers []licenseServer `json:"servers"`
+}
+
+// String colorized license information.
+func (l licenseInfoMessage) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s %s\n", console.Colorize("Key", "License:"), l.License)
+	for _, server := range l.Servers {
+		if server.Err != "" {
+			fmt.Fprintf(&b, "  %s %s\n", server.Addr, console.Colorize("Error", server.Err))
+			continue
+		}
+		fmt.Fprintf(&b, "  %s %s\n", server.Addr, server.Version)
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// JSON jsonified license information.
+func (l licenseInfoMessage) JSON() string {
+	l.Status = "success"
+	licenseBytes, e := json.MarshalIndent(l, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(licenseBytes)
+}
+
+// checkAdminLicenseInfoSyntax - validate all the passed arguments
+func checkAdminLicenseInfoSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) != 1 {
+		cli.ShowCommandHelpAndExit(ctx, "info", 1) // last argument is exit code
+	}
+}
+
+// mainAdminLicenseInfo is the handle for "mc admin license info" command.
+func mainAdminLicenseInfo(ctx *cli.Context) error {
+	checkAdminLicenseInfoSyntax(ctx)
+
+	console.SetColor("Key", color.New(color.FgCyan, color.Bold))
+	console.SetColor("Error", color.New(color.FgRed, color.Bold))
+
+	aliasedURL := ctx.Args().Get(0)
+	client, err := newAdminClient(aliasedURL)
+	fatalIf(err, "Unable to initialize admin connection.")
+
+	serversInfo, e := client.ServerInfo()
+	fatalIf(probe.NewError(e), "Unable to get server information.")
+	if len(serversInfo) == 0 {
+		fatalIf(probe.NewError(errors.New("no servers found")).Trace(aliasedURL), "Unable to get server information.")
+	}
+
+	msg := licenseInfoMessage{
+		Alias:   aliasedURL,
+		License: minioServerLicense,
+	}
+	for _, serverInfo := range serversInfo {
+		server := licenseServer{Addr: serverInfo.Addr}
+		if serverInfo.Error != "" {
+			server.Err = serverInfo.Error
+		} else {
+			server.Version = serverInfo.Data.Properties.Version
+		}
+		msg.Servers = append(msg.Servers, server)
+	}
+	printMsg(msg)
+	return nil
+}
@@ -43,6 +43,10 @@ var adminCmd = cli.Command{
 		adminTraceCmd,
 		adminConsoleCmd,
 		adminPrometheusCmd,
+		adminLicenseCmd,
+		adminHistoryCmd,
+		adminKMSCmd,
+		adminSpeedtestCmd,
 	},
 }
 
@@ -27,23 +27,35 @@ import (
 	"github.com/minio/mc/pkg/probe"
 )
 
+var adminPolicyAddFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:  "builtin",
+		Usage: "add a builtin policy instead of POLICYFILE: readonly, readwrite or writeonly",
+	},
+	cli.StringFlag{
+		Name:  "bucket",
+		Usage: "restrict the builtin policy to this bucket",
+	},
+}
+
 var adminPolicyAddCmd = cli.Command{
 	Name:   "add",
 	Usage:  "add new policy",
 	Action: mainAdminPolicyAdd,
 	Before: setGlobalsFromContext,
-	Flags:  globalFlags,
+	Flags:  append(adminPolicyAddFlags, globalFlags...),
 	CustomHelpTemplate: `NAME:
   {{.HelpName}} - {{.Usage}}
 
 USAGE:
-  {{.HelpName}} TARGET POLICYNAME POLICYFILE
+  {{.HelpName}} [FLAGS] TARGET POLICYNAME [POLICYFILE]
 
 POLICYNAME:
   Name of the canned policy on MinIO server.
 
 POLICYFILE:
-  Name of the policy file associated with the policy name.
+  Name of the policy file associated with the policy name, it is
+  checked for syntax, action names and resource ARNs before it is added.
 
 FLAGS:
   {{range .VisibleFlags}}{{.}}
@@ -51,14 +63,25 @@ FLAGS:
 EXAMPLES:
   1. Add a new canned policy 'writeonly'.
      $ {{.HelpName}} myminio writeonly /tmp/writeonly.json
+
+  2. Add a policy 'photos-readonly' giving read access to the bucket 'photos' only.
+     $ {{.HelpName}} --builtin readonly --bucket photos myminio photos-readonly
  `,
 }
 
 // checkAdminPolicyAddSyntax - validate all the passed arguments
 func checkAdminPolicyAddSyntax(ctx *cli.Context) {
-	if len(ctx.Args()) != 3 {
+	builtin := ctx.String("builtin")
+	if (builtin == "" && len(ctx.Args()) != 3) || (builtin != "" && len(ctx.Args()) != 2) {
 		cli.ShowCommandHelpAndExit(ctx, "add", 1) // last argument is exit code
 	}
+	if builtin != "" {
+		if _, ok := builtinPolicyActions[builtin]; !ok {
+			fatalIf(errInvalidArgument().Trace(builtin), "Unknown builtin policy `"+builtin+"`, expected readonly, readwrite or writeonly.")
+		}
+	} else if ctx.IsSet("bucket") {
+		fatalIf(errInvalidArgument().Trace(ctx.String("bucket")), "--bucket applies to builtin policies only.")
+	}
 }
 
 // userPolicyMessage container for content message structure
@@ -114,14 +137,29 @@ func mainAdminPolicyAdd(ctx *cli.Context) error {
 	args := ctx.Args()
 	aliasedURL := args.Get(0)
 
-	policy, e := ioutil.ReadFile(args.Get(2))
-	fatalIf(probe.NewError(e).Trace(args...), "Unable to get policy")
+	var policy []byte
+	if builtin := ctx.String("builtin"); builtin != "" {
+		var err *probe.Error
+		policy, err = getBuiltinPolicy(builtin, ctx.String("bucket"))
+		fatalIf(err.Trace(builtin), "Unable to get builtin policy")
+	} else {
+		var e error
+		policy, e = ioutil.ReadFile(args.Get(2))
+		fatalIf(probe.NewError(e).Trace(args...), "Unable to get policy")
+	}
+	fatalIf(validatePolicy(policy).Trace(args...), "Invalid policy document")
 
 	// Create a new MinIO Admin Client
 	client, err := newAdminClient(aliasedURL)
 	fatalIf(err, "Unable to initialize admin connection.")
 
+	// Keep the current policy to record the changes in admin history.
+	policies, e := client.ListCannedPolicies()
+	fatalIf(probe.NewError(e).Trace(args...), "Cannot list policy")
+
 	fatalIf(probe.NewError(client.AddCannedPolicy(args.Get(1), string(policy))).Trace(args...), "Cannot add new policy")
+	recordAdminHistory(aliasedURL, "policy add", []string{args.Get(1)},
+		diffLines(indentJSON(policies[args.Get(1)]), indentJSON(policy)))
 
 	printMsg(userPolicyMessage{
 		op:     "add",
@@ -0,0 +1,71 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+
+	"github.com/minio/mc/pkg/probe"
+	iampolicy "github.com/minio/minio/pkg/iam/policy"
+)
+
+// Actions granted by the builtin policies.
+var builtinPolicyActions = map[string][]string{
+	"readonly":  {"s3:GetBucketLocation", "s3:ListBucket", "s3:GetObject"},
+	"writeonly": {"s3:GetBucketLocation", "s3:PutObject", "s3:ListMultipartUploadParts", "s3:AbortMultipartUpload"},
+	"readwrite": {"s3:*"},
+}
+
+// getBuiltinPolicy - returns the policy document of a builtin policy,
+// restricted to bucket unless it is empty.
+func getBuiltinPolicy(name, bucket string) ([]byte, *probe.Error) {
+	actions, ok := builtinPolicyActions[name]
+	if !ok {
+		return nil, errInvalidArgument().Trace(name)
+	}
+	resources := []string{"arn:aws:s3:::*"}
+	if bucket != "" {
+		resources = []string{"arn:aws:s3:::" + bucket, "arn:aws:s3:::" + bucket + "/*"}
+	}
+	document := map[string]interface{}{
+		"Version": iampolicy.DefaultVersion,
+		"Statement": []map[string]interface{}{{
+			"Effect":   "Allow",
+			"Action":   actions,
+			"Resource": resources,
+		}},
+	}
+	policy, e := json.MarshalIndent(document, "", "  ")
+	if e != nil {
+		return nil, probe.NewError(e)
+	}
+	return policy, nil
+}
+
+// validatePolicy - checks the syntax, actions and resources of a policy
+// document before it is sent to the server.
+func validatePolicy(policy []byte) *probe.Error {
+	if len(bytes.TrimSpace(policy)) == 0 {
+		return probe.NewError(errors.New("policy document is empty"))
+	}
+	if _, e := iampolicy.ParseConfig(bytes.NewReader(policy)); e != nil {
+		return probe.NewError(e)
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"testing"
+)
+
+func TestValidatePolicy(t *testing.T) {
+	for _, name := range []string{"readonly", "readwrite", "writeonly"} {
+		for _, bucket := range []string{"", "photos"} {
+			policy, err := getBuiltinPolicy(name, bucket)
+			if err != nil {
+				t.Fatalf("Builtin %s on %q: %v", name, bucket, err)
+			}
+			if err = validatePolicy(policy); err != nil {
+				t.Errorf("Builtin %s on %q is invalid: %v", name, bucket, err)
+			}
+		}
+	}
+	if _, err := getBuiltinPolicy("superuser", ""); err == nil {
+		t.Error("Expected an error for an unknown builtin policy")
+	}
+
+	testCases := []struct {
+		policy string
+		valid  bool
+	}{
+		{`{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":["s3:GetObject"],"Resource":["arn:aws:s3:::photos/*"]}]}`, true},
+		{``, false},
+		{`{"Version":"2012-10-17","Statement":[`, false},
+		// Unknown action.
+		{`{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":["s3:GetObjectz"],"Resource":["arn:aws:s3:::photos/*"]}]}`, false},
+		// Invalid resource ARN.
+		{`{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":["s3:GetObject"],"Resource":["photos/*"]}]}`, false},
+		// Invalid effect.
+		{`{"Version":"2012-10-17","Statement":[{"Effect":"Permit","Action":["s3:GetObject"],"Resource":["arn:aws:s3:::photos/*"]}]}`, false},
+		// Missing resource.
+		{`{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":["s3:GetObject"]}]}`, false},
+	}
+	for i, testCase := range testCases {
+		err := validatePolicy([]byte(testCase.policy))
+		if (err == nil) != testCase.valid {
+			t.Errorf("Test %d: expected valid %v, got %v", i+1, testCase.valid, err)
+		}
+	}
+}
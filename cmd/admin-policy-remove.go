@@ -69,6 +69,7 @@ func mainAdminPolicyRemove(ctx *cli.Context) error {
 	fatalIf(err, "Unable to initialize admin connection.")
 
 	fatalIf(probe.NewError(client.RemoveCannedPolicy(args.Get(1))).Trace(args...), "Cannot remove policy")
+	recordAdminHistory(aliasedURL, "policy remove", []string{args.Get(1)}, "")
 
 	printMsg(userPolicyMessage{
 		op:     "remove",
@@ -106,6 +106,7 @@ func mainAdminPolicySet(ctx *cli.Context) error {
 	e := client.SetPolicy(policyName, userOrGroup, isGroup)
 
 	if e == nil {
+		recordAdminHistory(aliasedURL, "policy set", []string{policyName, entityArg}, "")
 		printMsg(userPolicyMessage{
 			op:          "set",
 			Policy:      policyName,
@@ -0,0 +1,175 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"archive/zip"
+	"io"
+	"io/ioutil"
+	"os"
+	"time"
+
+	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/console"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio/pkg/madmin"
+)
+
+// defaultProfilePath is where profile data is saved by default.
+const defaultProfilePath = "profile.zip"
+
+var adminProfileDownloadFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:  "output, o",
+		Usage: "path of the zip file to save profile data to",
+		Value: defaultProfilePath,
+	},
+}
+
+var adminProfileDownloadCmd = cli.Command{
+	Name:            "download",
+	Usage:           "download profile data of all nodes as a zip file",
+	Action:          mainAdminProfileDownload,
+	Before:          setGlobalsFromContext,
+	Flags:           append(adminProfileDownloadFlags, globalFlags...),
+	HideHelpCommand: true,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [FLAGS] TARGET
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+    1. Download profile data of all nodes in the current directory
+       $ {{.HelpName}} myminio/
+
+    2. Download profile data of all nodes to /tmp/myminio-profile.zip
+       $ {{.HelpName}} --output /tmp/myminio-profile.zip myminio/
+`,
+}
+
+func checkAdminProfileDownloadSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) != 1 || ctx.String("output") == "" {
+		cli.ShowCommandHelpAndExit(ctx, "download", 1) // last argument is exit code
+	}
+}
+
+// downloadProfileData downloads the zipped profile data of all
+// nodes into a temporary file and returns its path.
+func downloadProfileData(client *madmin.AdminClient) (string, *probe.Error) {
+	tmpFile, e := ioutil.TempFile("", "mc-profile-")
+	if e != nil {
+		return "", probe.NewError(e)
+	}
+	defer tmpFile.Close()
+
+	// Ask for profile data, which will come compressed with zip format
+	zippedData, e := client.DownloadProfilingData()
+	if e != nil {
+		os.Remove(tmpFile.Name())
+		return "", probe.NewError(e)
+	}
+	defer zippedData.Close()
+
+	if _, e = io.Copy(tmpFile, zippedData); e != nil {
+		os.Remove(tmpFile.Name())
+		return "", probe.NewError(e)
+	}
+	return tmpFile.Name(), nil
+}
+
+// mergeProfileData writes all entries of the zip files in srcPaths
+// into a single zip file at dstPath.
+func mergeProfileData(dstPath string, srcPaths []string) error {
+	dstFile, e := os.Create(dstPath)
+	if e != nil {
+		return e
+	}
+	defer dstFile.Close()
+
+	zipWriter := zip.NewWriter(dstFile)
+	for _, srcPath := range srcPaths {
+		zipReader, e := zip.OpenReader(srcPath)
+		if e != nil {
+			return e
+		}
+		for _, file := range zipReader.File {
+			if e = copyZipEntry(zipWriter, file); e != nil {
+				zipReader.Close()
+				return e
+			}
+		}
+		zipReader.Close()
+	}
+	return zipWriter.Close()
+}
+
+// copyZipEntry copies a single zip entry as is into zipWriter.
+func copyZipEntry(zipWriter *zip.Writer, file *zip.File) error {
+	header := file.FileHeader
+	w, e := zipWriter.CreateHeader(&header)
+	if e != nil {
+		return e
+	}
+	r, e := file.Open()
+	if e != nil {
+		return e
+	}
+	defer r.Close()
+	_, e = io.Copy(w, r)
+	return e
+}
+
+// saveProfileData moves the downloaded profile data at tmpPath to
+// downloadPath, keeping any previous file as a timestamped backup.
+func saveProfileData(tmpPath, downloadPath string) *probe.Error {
+	fi, e := os.Stat(downloadPath)
+	if e == nil && !fi.IsDir() {
+		e = moveFile(downloadPath, downloadPath+"."+time.Now().Format("2006-01-02T15:04:05.999999-07:00"))
+		if e != nil {
+			return probe.NewError(e)
+		}
+	} else if e != nil && !os.IsNotExist(e) {
+		return probe.NewError(e)
+	}
+	return probe.NewError(moveFile(tmpPath, downloadPath))
+}
+
+// mainAdminProfileDownload - the entry function of profile download command
+func mainAdminProfileDownload(ctx *cli.Context) error {
+	// Check for command syntax
+	checkAdminProfileDownloadSyntax(ctx)
+
+	// Get the alias parameter from cli
+	aliasedURL := ctx.Args().Get(0)
+	downloadPath := ctx.String("output")
+
+	// Create a new MinIO Admin Client
+	client, err := newAdminClient(aliasedURL)
+	fatalIf(err, "Unable to initialize admin connection.")
+
+	tmpPath, err := downloadProfileData(client)
+	fatalIf(err, "Unable to download profile data.")
+
+	fatalIf(saveProfileData(tmpPath, downloadPath), "Unable to save profile data to `"+downloadPath+"`.")
+
+	console.Infof("Profile data successfully downloaded as %s\n", downloadPath)
+	return nil
+}
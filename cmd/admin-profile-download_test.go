@@ -0,0 +1,95 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"archive/zip"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestMergeProfileData(t *testing.T) {
+	dir, e := ioutil.TempDir("", "mc-profile-test-")
+	if e != nil {
+		t.Fatal(e)
+	}
+	defer os.RemoveAll(dir)
+
+	writeZip := func(name string, entries ...string) string {
+		path := filepath.Join(dir, name)
+		f, e := os.Create(path)
+		if e != nil {
+			t.Fatal(e)
+		}
+		defer f.Close()
+		w := zip.NewWriter(f)
+		for _, entry := range entries {
+			fw, e := w.Create(entry)
+			if e != nil {
+				t.Fatal(e)
+			}
+			if _, e = fw.Write([]byte(entry)); e != nil {
+				t.Fatal(e)
+			}
+		}
+		if e = w.Close(); e != nil {
+			t.Fatal(e)
+		}
+		return path
+	}
+
+	srcs := []string{
+		writeZip("cpu.zip", "profiling-node1:9000-cpu.pprof", "profiling-node2:9000-cpu.pprof"),
+		writeZip("mem.zip", "profiling-node1:9000-mem.pprof"),
+	}
+	dst := filepath.Join(dir, "profile.zip")
+	if e = mergeProfileData(dst, srcs); e != nil {
+		t.Fatal(e)
+	}
+
+	r, e := zip.OpenReader(dst)
+	if e != nil {
+		t.Fatal(e)
+	}
+	defer r.Close()
+
+	var names []string
+	for _, file := range r.File {
+		rc, e := file.Open()
+		if e != nil {
+			t.Fatal(e)
+		}
+		data, e := ioutil.ReadAll(rc)
+		rc.Close()
+		if e != nil {
+			t.Fatal(e)
+		}
+		if string(data) != file.Name {
+			t.Errorf("%s: unexpected content %q", file.Name, data)
+		}
+		names = append(names, file.Name)
+	}
+	sort.Strings(names)
+	expected := []string{"profiling-node1:9000-cpu.pprof", "profiling-node1:9000-mem.pprof", "profiling-node2:9000-cpu.pprof"}
+	if !reflect.DeepEqual(names, expected) {
+		t.Errorf("expected %v, got %v", expected, names)
+	}
+}
@@ -17,9 +17,15 @@
 package cmd
 
 import (
+	"fmt"
+	"io/ioutil"
+	"os"
 	"strings"
+	"time"
 
+	"github.com/fatih/color"
 	"github.com/minio/cli"
+	json "github.com/minio/mc/pkg/colorjson"
 	"github.com/minio/mc/pkg/console"
 	"github.com/minio/mc/pkg/probe"
 	"github.com/minio/minio/pkg/madmin"
@@ -28,9 +34,13 @@ import (
 var adminProfileStartFlags = []cli.Flag{
 	cli.StringFlag{
 		Name:  "type",
-		Usage: "start profiler type, possible values are 'cpu', 'mem', 'block', 'mutex' and 'trace'",
+		Usage: "start profiler type, possible values are 'cpu', 'mem', 'block', 'mutex' and 'trace', separated by commas",
 		Value: "mem",
 	},
+	cli.StringFlag{
+		Name:  "duration",
+		Usage: "record each profiler type for the given duration, then download profile data as " + defaultProfilePath,
+	},
 }
 
 var adminProfileStartCmd = cli.Command{
@@ -49,38 +59,115 @@ USAGE:
 FLAGS:
   {{range .VisibleFlags}}{{.}}
   {{end}}
+NOTE:
+  MinIO servers record one profiler type at a time, several types are
+  only accepted with --duration and are then recorded one after another.
+
 EXAMPLES:
     1. Start CPU profile
        $ {{.HelpName}} --type cpu myminio/
 
+    2. Record CPU, memory and block profiles for 30 seconds each and download them as profile.zip
+       $ {{.HelpName}} --type cpu,mem,block --duration 30s myminio/
+
 `,
 }
 
+// profilerTypes lists all profiler types supported by MinIO servers.
+var profilerTypes = []madmin.ProfilerType{
+	madmin.ProfilerCPU,
+	madmin.ProfilerMEM,
+	madmin.ProfilerBlock,
+	madmin.ProfilerMutex,
+	madmin.ProfilerTrace,
+}
+
+// parseProfilerTypes parses a comma separated list of profiler types,
+// ignoring duplicates.
+func parseProfilerTypes(types string) ([]madmin.ProfilerType, *probe.Error) {
+	var parsed []madmin.ProfilerType
+	seen := make(map[madmin.ProfilerType]bool)
+	for _, profilerType := range strings.Split(types, ",") {
+		profiler := madmin.ProfilerType(strings.ToLower(strings.TrimSpace(profilerType)))
+		supported := false
+		for _, p := range profilerTypes {
+			if profiler == p {
+				supported = true
+				break
+			}
+		}
+		if !supported {
+			return nil, probe.NewError(fmt.Errorf("profiler type `%s` unrecognized, possible values are: %v", profilerType, profilerTypes))
+		}
+		if !seen[profiler] {
+			seen[profiler] = true
+			parsed = append(parsed, profiler)
+		}
+	}
+	return parsed, nil
+}
+
+// profileStartMessage is container for the result of starting a profiler on a node.
+type profileStartMessage struct {
+	Status   string `json:"status"`
+	Type     string `json:"type"`
+	NodeName string `json:"nodeName"`
+	Err      string `json:"error,omitempty"`
+}
+
+// String colorized profile start message.
+func (p profileStartMessage) String() string {
+	if p.Err != "" {
+		return console.Colorize("ProfileFailure", fmt.Sprintf("Unable to start %s profiling on %s: %s", p.Type, p.NodeName, p.Err))
+	}
+	return console.Colorize("ProfileSuccess", fmt.Sprintf("Started %s profiling on %s.", p.Type, p.NodeName))
+}
+
+// JSON jsonified profile start message.
+func (p profileStartMessage) JSON() string {
+	p.Status = "success"
+	if p.Err != "" {
+		p.Status = "error"
+	}
+	profileStartJSONBytes, e := json.MarshalIndent(p, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(profileStartJSONBytes)
+}
+
 func checkAdminProfileStartSyntax(ctx *cli.Context) {
 	// Check flags combinations
 	if len(ctx.Args()) != 1 {
 		cli.ShowCommandHelpAndExit(ctx, "start", 1) // last argument is exit code
 	}
 
-	profilerTypes := []madmin.ProfilerType{
-		madmin.ProfilerCPU,
-		madmin.ProfilerMEM,
-		madmin.ProfilerBlock,
-		madmin.ProfilerMutex,
-		madmin.ProfilerTrace,
-	}
+	types, err := parseProfilerTypes(ctx.String("type"))
+	fatalIf(err, "Invalid profiler type.")
 
-	// Check if the provided profiler type is known and supported
-	supportedProfiler := false
-	profilerType := strings.ToLower(ctx.String("type"))
-	for _, profiler := range profilerTypes {
-		if profilerType == string(profiler) {
-			supportedProfiler = true
-			break
+	if ctx.String("duration") == "" {
+		if len(types) > 1 {
+			fatalIf(errInvalidArgument(), "Only one profiler type can be started at a time, use --duration to record several types one after another.")
 		}
+		return
 	}
-	if !supportedProfiler {
-		fatalIf(errDummy(), "Profiler type unrecognized. Possible values are: %v.", profilerTypes)
+	duration, e := time.ParseDuration(ctx.String("duration"))
+	if e != nil || duration <= 0 {
+		fatalIf(errInvalidArgument().Trace(ctx.String("duration")), "Invalid duration `"+ctx.String("duration")+"`.")
+	}
+}
+
+// startProfiling starts the given profiler on all nodes and prints
+// the result of each node.
+func startProfiling(client *madmin.AdminClient, profiler madmin.ProfilerType) {
+	results, e := client.StartProfiling(profiler)
+	fatalIf(probe.NewError(e), "Unable to start "+string(profiler)+" profiling.")
+
+	for _, result := range results {
+		printMsg(profileStartMessage{
+			Type:     string(profiler),
+			NodeName: result.NodeName,
+			Err:      result.Error,
+		})
 	}
 }
 
@@ -89,11 +176,14 @@ func mainAdminProfileStart(ctx *cli.Context) error {
 	// Check for command syntax
 	checkAdminProfileStartSyntax(ctx)
 
+	console.SetColor("ProfileSuccess", color.New(color.FgGreen, color.Bold))
+	console.SetColor("ProfileFailure", color.New(color.FgRed, color.Bold))
+
 	// Get the alias parameter from cli
 	args := ctx.Args()
 	aliasedURL := args.Get(0)
 
-	profilerType := ctx.String("type")
+	types, _ := parseProfilerTypes(ctx.String("type"))
 
 	// Create a new MinIO Admin Client
 	client, err := newAdminClient(aliasedURL)
@@ -102,10 +192,42 @@ func mainAdminProfileStart(ctx *cli.Context) error {
 		return nil
 	}
 
-	// Start profile
-	_, cmdErr := client.StartProfiling(madmin.ProfilerType(profilerType))
-	fatalIf(probe.NewError(cmdErr), "Unable to start profile.")
+	if ctx.String("duration") == "" {
+		startProfiling(client, types[0])
+		return nil
+	}
+
+	// Record each profiler type in turn, since starting a profiler
+	// stops the one currently running on the server.
+	duration, _ := time.ParseDuration(ctx.String("duration"))
+	var tmpPaths []string
+	defer func() {
+		for _, tmpPath := range tmpPaths {
+			os.Remove(tmpPath)
+		}
+	}()
+	for _, profiler := range types {
+		startProfiling(client, profiler)
+		if !globalQuiet && !globalJSON {
+			console.Infof("Recording %s profile for %s...\n", profiler, duration)
+		}
+		time.Sleep(duration)
 
-	console.Infoln("Profile data successfully started.")
+		tmpPath, err := downloadProfileData(client)
+		fatalIf(err, "Unable to download "+string(profiler)+" profile data.")
+		tmpPaths = append(tmpPaths, tmpPath)
+	}
+
+	mergedFile, e := ioutil.TempFile("", "mc-profile-")
+	fatalIf(probe.NewError(e), "Unable to save profile data.")
+	mergedFile.Close()
+	tmpPaths = append(tmpPaths, mergedFile.Name())
+
+	fatalIf(probe.NewError(mergeProfileData(mergedFile.Name(), tmpPaths[:len(tmpPaths)-1])), "Unable to save profile data.")
+	fatalIf(saveProfileData(mergedFile.Name(), defaultProfilePath), "Unable to save profile data.")
+
+	if !globalJSON {
+		console.Infof("Profile data successfully downloaded as %s\n", defaultProfilePath)
+	}
 	return nil
 }
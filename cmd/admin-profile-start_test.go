@@ -0,0 +1,50 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/minio/minio/pkg/madmin"
+)
+
+func TestParseProfilerTypes(t *testing.T) {
+	testCases := []struct {
+		types    string
+		expected []madmin.ProfilerType
+		success  bool
+	}{
+		{"mem", []madmin.ProfilerType{madmin.ProfilerMEM}, true},
+		{"CPU", []madmin.ProfilerType{madmin.ProfilerCPU}, true},
+		{"cpu,mem,block", []madmin.ProfilerType{madmin.ProfilerCPU, madmin.ProfilerMEM, madmin.ProfilerBlock}, true},
+		{"cpu, mutex ,cpu", []madmin.ProfilerType{madmin.ProfilerCPU, madmin.ProfilerMutex}, true},
+		{"trace", []madmin.ProfilerType{madmin.ProfilerTrace}, true},
+		{"goroutine", nil, false},
+		{"cpu,", nil, false},
+		{"", nil, false},
+	}
+	for i, testCase := range testCases {
+		types, err := parseProfilerTypes(testCase.types)
+		if testCase.success != (err == nil) {
+			t.Fatalf("Test %d: expected success %v, got error %v", i+1, testCase.success, err)
+		}
+		if !reflect.DeepEqual(types, testCase.expected) {
+			t.Errorf("Test %d: expected %v, got %v", i+1, testCase.expected, types)
+		}
+	}
+}
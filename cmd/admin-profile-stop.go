@@ -18,13 +18,10 @@ package cmd
 
 import (
 	"io"
-	"io/ioutil"
 	"os"
-	"time"
 
 	"github.com/minio/cli"
 	"github.com/minio/mc/pkg/console"
-	"github.com/minio/mc/pkg/probe"
 )
 
 var adminProfileStopCmd = cli.Command{
@@ -44,7 +41,7 @@ FLAGS:
   {{range .VisibleFlags}}{{.}}
   {{end}}
 EXAMPLES:
-    2. Download latest profile data in the current directory
+    1. Download latest profile data in the current directory
        $ {{.HelpName}} myminio/
 `,
 }
@@ -98,35 +95,11 @@ func mainAdminProfileStop(ctx *cli.Context) error {
 		return nil
 	}
 
-	// Create profile zip file
-	tmpFile, e := ioutil.TempFile("", "mc-profile-")
-	fatalIf(probe.NewError(e), "Unable to download profile data.")
+	tmpPath, err := downloadProfileData(client)
+	fatalIf(err, "Unable to download profile data.")
 
-	// Ask for profile data, which will come compressed with zip format
-	zippedData, adminErr := client.DownloadProfilingData()
-	fatalIf(probe.NewError(adminErr), "Unable to download profile data.")
-
-	// Copy zip content to target download file
-	_, e = io.Copy(tmpFile, zippedData)
-	fatalIf(probe.NewError(e), "Unable to download profile data.")
-
-	// Close everything
-	zippedData.Close()
-	tmpFile.Close()
-
-	downloadPath := "profile.zip"
-
-	fi, e := os.Stat(downloadPath)
-	if e == nil && !fi.IsDir() {
-		e = moveFile(downloadPath, downloadPath+"."+time.Now().Format("2006-01-02T15:04:05.999999-07:00"))
-		fatalIf(probe.NewError(e), "Unable to create a backup of profile.zip")
-	} else {
-		if !os.IsNotExist(e) {
-			fatal(probe.NewError(e), "Unable to download profile data.")
-		}
-	}
-
-	fatalIf(probe.NewError(moveFile(tmpFile.Name(), downloadPath)), "Unable to download profile data.")
+	downloadPath := defaultProfilePath
+	fatalIf(saveProfileData(tmpPath, downloadPath), "Unable to download profile data.")
 
 	console.Infof("Profile data successfully downloaded as %s\n", downloadPath)
 	return nil
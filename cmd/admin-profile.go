@@ -29,6 +29,7 @@ var adminProfileCmd = cli.Command{
 	Subcommands: []cli.Command{
 		adminProfileStartCmd,
 		adminProfileStopCmd,
+		adminProfileDownloadCmd,
 	},
 	HideHelpCommand: true,
 }
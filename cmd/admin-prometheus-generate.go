@@ -36,18 +36,30 @@ const (
 	defaultMetricsPath = "/minio/prometheus/metrics"
 )
 
+var adminPrometheusGenerateFlags = []cli.Flag{
+	cli.BoolFlag{
+		Name:  "public",
+		Usage: "generate a config without bearer token, for servers with MINIO_PROMETHEUS_AUTH_TYPE=public",
+	},
+	cli.StringFlag{
+		Name:  "job-name",
+		Usage: "name of the prometheus scrape job",
+		Value: defaultJobName,
+	},
+}
+
 var adminPrometheusGenerateCmd = cli.Command{
 	Name:            "generate",
 	Usage:           "generates prometheus config",
 	Action:          mainAdminPrometheusGenerate,
 	Before:          setGlobalsFromContext,
-	Flags:           globalFlags,
+	Flags:           append(adminPrometheusGenerateFlags, globalFlags...),
 	HideHelpCommand: true,
 	CustomHelpTemplate: `NAME:
   {{.HelpName}} - {{.Usage}}
 
 USAGE:
-  {{.HelpName}} TARGET
+  {{.HelpName}} [FLAGS] TARGET
 
 FLAGS:
   {{range .VisibleFlags}}{{.}}
@@ -56,6 +68,11 @@ EXAMPLES:
   1. Generate a default prometheus config.
      $ {{.HelpName}} myminio
 
+  2. Generate a prometheus config for a server exposing its metrics publicly.
+     $ {{.HelpName}} --public myminio
+
+  3. Generate a prometheus config with the scrape job named 'minio-dc1'.
+     $ {{.HelpName}} --job-name minio-dc1 dc1
 `,
 }
 
@@ -104,7 +121,7 @@ func (t StatConfig) JSON() string {
 // ScrapeConfig configures a scraping unit for Prometheus.
 type ScrapeConfig struct {
 	JobName       string       `yaml:"job_name" json:"jobName"`
-	BearerToken   string       `yaml:"bearer_token" json:"bearerToken"`
+	BearerToken   string       `yaml:"bearer_token,omitempty" json:"bearerToken,omitempty"`
 	MetricsPath   string       `yaml:"metrics_path,omitempty" json:"metricsPath"`
 	Scheme        string       `yaml:"scheme,omitempty" json:"scheme"`
 	StaticConfigs []StatConfig `yaml:"static_configs,omitempty" json:"staticConfigs"`
@@ -154,19 +171,23 @@ func generatePrometheusConfig(ctx *cli.Context) error {
 		return err
 	}
 
-	jwt := jwtgo.NewWithClaims(jwtgo.SigningMethodHS512, jwtgo.StandardClaims{
-		ExpiresAt: UTCNow().Add(defaultPrometheusJWTExpiry).Unix(),
-		Subject:   hostConfig.AccessKey,
-		Issuer:    "prometheus",
-	})
-
-	token, err := jwt.SignedString([]byte(hostConfig.SecretKey))
-	if err != nil {
-		return err
+	// Public metrics need no token.
+	if !ctx.Bool("public") {
+		jwt := jwtgo.NewWithClaims(jwtgo.SigningMethodHS512, jwtgo.StandardClaims{
+			ExpiresAt: UTCNow().Add(defaultPrometheusJWTExpiry).Unix(),
+			Subject:   hostConfig.AccessKey,
+			Issuer:    "prometheus",
+		})
+
+		token, err := jwt.SignedString([]byte(hostConfig.SecretKey))
+		if err != nil {
+			return err
+		}
+		defaultConfig.ScrapeConfigs[0].BearerToken = token
 	}
 
 	// Setting the values
-	defaultConfig.ScrapeConfigs[0].BearerToken = token
+	defaultConfig.ScrapeConfigs[0].JobName = ctx.String("job-name")
 	defaultConfig.ScrapeConfigs[0].Scheme = u.Scheme
 	defaultConfig.ScrapeConfigs[0].StaticConfigs[0].Targets[0] = u.Host
 
@@ -182,9 +203,8 @@ func mainAdminPrometheusGenerate(ctx *cli.Context) error {
 
 	checkAdminPrometheusSyntax(ctx)
 
-	if err := generatePrometheusConfig(ctx); err != nil {
-		return nil
-	}
+	e := generatePrometheusConfig(ctx)
+	fatalIf(probe.NewError(e), "Unable to generate the prometheus config.")
 
 	return nil
 }
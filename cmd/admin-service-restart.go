@@ -70,15 +70,15 @@ func (s serviceRestartCommand) JSON() string {
 type serviceRestartMessage struct {
 	Status    string `json:"status"`
 	ServerURL string `json:"serverURL"`
-	Err       error  `json:"error,omitempty"`
+	Err       string `json:"error,omitempty"`
 }
 
 // String colorized service restart message.
 func (s serviceRestartMessage) String() string {
-	if s.Err == nil {
+	if s.Err == "" {
 		return console.Colorize("ServiceRestart", "Restarted `"+s.ServerURL+"` successfully.")
 	}
-	return console.Colorize("FailedServiceRestart", "Failed to restart `"+s.ServerURL+"`. error: "+s.Err.Error())
+	return console.Colorize("FailedServiceRestart", "Failed to restart `"+s.ServerURL+"`. error: "+s.Err)
 }
 
 // JSON jsonified service restart message.
@@ -124,13 +124,18 @@ func mainAdminServiceRestart(ctx *cli.Context) error {
 	// Sleep for 6 seconds and then check if the server is online.
 	time.Sleep(6 * time.Second)
 
-	// Fetch the service status of the specified MinIO server
-	_, e := client.ServerInfo()
-
+	// Fetch the service status of each node of the specified MinIO server
+	serversInfo, e := client.ServerInfo()
 	if e != nil {
-		printMsg(serviceRestartMessage{Status: "failure", Err: e, ServerURL: aliasedURL})
-	} else {
-		printMsg(serviceRestartMessage{Status: "success", ServerURL: aliasedURL})
+		printMsg(serviceRestartMessage{Status: "failure", Err: e.Error(), ServerURL: aliasedURL})
+		return nil
+	}
+	for _, status := range getServiceStatus(serversInfo) {
+		if status.Online {
+			printMsg(serviceRestartMessage{Status: "success", ServerURL: status.Node})
+		} else {
+			printMsg(serviceRestartMessage{Status: "failure", Err: status.Err, ServerURL: status.Node})
+		}
 	}
 
 	return nil
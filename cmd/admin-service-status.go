@@ -0,0 +1,139 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	humanize "github.com/dustin/go-humanize"
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/console"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio/pkg/madmin"
+)
+
+var adminServiceStatusCmd = cli.Command{
+	Name:   "status",
+	Usage:  "show whether each MinIO server is online",
+	Action: mainAdminServiceStatus,
+	Before: setGlobalsFromContext,
+	Flags:  globalFlags,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} TARGET
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+  1. Show the status of all servers of a MinIO cluster represented by its alias 'mydist'.
+     $ {{.HelpName}} mydist/
+`,
+}
+
+// serviceStatusMessage is container for the service status of a node.
+type serviceStatusMessage struct {
+	Status  string        `json:"status"`
+	Node    string        `json:"node"`
+	Online  bool          `json:"online"`
+	Uptime  time.Duration `json:"uptime,omitempty"`
+	Version string        `json:"version,omitempty"`
+	Err     string        `json:"error,omitempty"`
+}
+
+// String colorized service status message.
+func (s serviceStatusMessage) String() string {
+	dot := "●"
+	if !s.Online {
+		return fmt.Sprintf("%s  %s  %s", console.Colorize("ServiceOffline", dot),
+			console.Colorize("PrintB", s.Node), console.Colorize("ServiceOffline", "offline: "+s.Err))
+	}
+	return fmt.Sprintf("%s  %s  online since %s, version %s", console.Colorize("ServiceOnline", dot),
+		console.Colorize("PrintB", s.Node),
+		humanize.RelTime(time.Now(), time.Now().Add(-s.Uptime), "", "ago"), s.Version)
+}
+
+// JSON jsonified service status message.
+func (s serviceStatusMessage) JSON() string {
+	s.Status = "success"
+	if !s.Online {
+		s.Status = "error"
+	}
+	serviceStatusJSONBytes, e := json.MarshalIndent(s, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(serviceStatusJSONBytes)
+}
+
+// getServiceStatus returns the status of each node, sorted by address.
+func getServiceStatus(serversInfo []madmin.ServerInfo) []serviceStatusMessage {
+	var statuses []serviceStatusMessage
+	for _, serverInfo := range serversInfo {
+		status := serviceStatusMessage{Node: serverInfo.Addr, Err: serverInfo.Error}
+		if serverInfo.Error == "" && serverInfo.Data != nil {
+			status.Online = true
+			status.Uptime = serverInfo.Data.Properties.Uptime
+			status.Version = serverInfo.Data.Properties.Version
+		}
+		statuses = append(statuses, status)
+	}
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Node < statuses[j].Node
+	})
+	return statuses
+}
+
+// printServiceStatus prints the status of each node, or a single
+// offline status for aliasedURL when it cannot be reached.
+func printServiceStatus(client *madmin.AdminClient, aliasedURL string) {
+	serversInfo, e := client.ServerInfo()
+	if e != nil {
+		printMsg(serviceStatusMessage{Node: aliasedURL, Err: e.Error()})
+		return
+	}
+	for _, status := range getServiceStatus(serversInfo) {
+		printMsg(status)
+	}
+}
+
+// checkAdminServiceStatusSyntax - validate all the passed arguments
+func checkAdminServiceStatusSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) != 1 {
+		cli.ShowCommandHelpAndExit(ctx, "status", 1) // last argument is exit code
+	}
+}
+
+func mainAdminServiceStatus(ctx *cli.Context) error {
+	checkAdminServiceStatusSyntax(ctx)
+
+	console.SetColor("ServiceOnline", color.New(color.FgGreen, color.Bold))
+	console.SetColor("ServiceOffline", color.New(color.FgRed, color.Bold))
+
+	aliasedURL := ctx.Args().Get(0)
+
+	client, err := newAdminClient(aliasedURL)
+	fatalIf(err, "Unable to initialize admin connection.")
+
+	printServiceStatus(client, aliasedURL)
+	return nil
+}
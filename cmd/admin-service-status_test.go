@@ -0,0 +1,45 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/minio/minio/pkg/madmin"
+)
+
+func TestGetServiceStatus(t *testing.T) {
+	online := madmin.ServerInfo{Addr: "node2:9000", Data: &madmin.ServerInfoData{}}
+	online.Data.Properties.Uptime = time.Hour
+	online.Data.Properties.Version = "2019-10-12T01:39:57Z"
+	offline := madmin.ServerInfo{Addr: "node1:9000", Error: "rpc: retry error"}
+
+	statuses := getServiceStatus([]madmin.ServerInfo{online, offline})
+	expected := []serviceStatusMessage{
+		{Node: "node1:9000", Err: "rpc: retry error"},
+		{Node: "node2:9000", Online: true, Uptime: time.Hour, Version: "2019-10-12T01:39:57Z"},
+	}
+	if len(statuses) != len(expected) {
+		t.Fatalf("Expected %d statuses, got %d", len(expected), len(statuses))
+	}
+	for i := range expected {
+		if statuses[i] != expected[i] {
+			t.Errorf("Test %d: expected %+v, got %+v", i+1, expected[i], statuses[i])
+		}
+	}
+}
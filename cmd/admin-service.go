@@ -20,7 +20,7 @@ import "github.com/minio/cli"
 
 var adminServiceCmd = cli.Command{
 	Name:            "service",
-	Usage:           "restart and stop all MinIO servers",
+	Usage:           "restart, stop and show the status of all MinIO servers",
 	Action:          mainAdminService,
 	Before:          setGlobalsFromContext,
 	Flags:           globalFlags,
@@ -28,6 +28,7 @@ var adminServiceCmd = cli.Command{
 	Subcommands: []cli.Command{
 		adminServiceRestartCmd,
 		adminServiceStopCmd,
+		adminServiceStatusCmd,
 	},
 }
 
@@ -0,0 +1,179 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/minio/mc/pkg/probe"
+)
+
+// Concurrency bounds used when autotuning the object speed test.
+const (
+	minSpeedtestConcurrent = 4
+	maxSpeedtestConcurrent = 128
+)
+
+// objectSpeedtestResult is the outcome of an object speed test round.
+type objectSpeedtestResult struct {
+	Size          int64         `json:"size"`
+	Concurrent    int           `json:"concurrent"`
+	Duration      time.Duration `json:"duration"`
+	PutThroughput float64       `json:"putThroughput"`
+	PutIOPS       float64       `json:"putIOPS"`
+	GetThroughput float64       `json:"getThroughput"`
+	GetIOPS       float64       `json:"getIOPS"`
+}
+
+// throughput returns the combined PUT and GET throughput.
+func (r objectSpeedtestResult) throughput() float64 {
+	return r.PutThroughput + r.GetThroughput
+}
+
+// speedtestObjectURL returns the URL of the object written by worker i.
+func speedtestObjectURL(bucketURL, prefix string, i int) string {
+	return urlJoinPath(bucketURL, prefix+"obj-"+strconv.Itoa(i))
+}
+
+// runSpeedtestPhase runs fn from concurrent workers until duration
+// elapses and returns the number of operations and the elapsed time.
+func runSpeedtestPhase(concurrent int, duration time.Duration, fn func(worker int) *probe.Error) (int64, time.Duration, *probe.Error) {
+	var ops int64
+	var firstErr *probe.Error
+	var errOnce sync.Once
+	var failed int32
+
+	start := time.Now()
+	deadline := start.Add(duration)
+	var wg sync.WaitGroup
+	for i := 0; i < concurrent; i++ {
+		wg.Add(1)
+		go func(worker int) {
+			defer wg.Done()
+			for time.Now().Before(deadline) && atomic.LoadInt32(&failed) == 0 {
+				if err := fn(worker); err != nil {
+					errOnce.Do(func() {
+						firstErr = err
+						atomic.StoreInt32(&failed, 1)
+					})
+					return
+				}
+				atomic.AddInt64(&ops, 1)
+			}
+		}(i)
+	}
+	wg.Wait()
+	return ops, time.Since(start), firstErr
+}
+
+// runObjectSpeedtest uploads and then downloads objects of the given
+// size below prefix in bucketURL from concurrent workers, for duration
+// each, and reports the measured throughput.
+func runObjectSpeedtest(bucketURL, prefix string, size int64, concurrent int, duration time.Duration) (objectSpeedtestResult, *probe.Error) {
+	result := objectSpeedtestResult{Size: size, Concurrent: concurrent, Duration: duration}
+
+	data := make([]byte, size)
+	rand.New(rand.NewSource(time.Now().UnixNano())).Read(data)
+
+	clnts := make([]Client, concurrent)
+	for i := range clnts {
+		clnt, err := newClient(speedtestObjectURL(bucketURL, prefix, i))
+		if err != nil {
+			return result, err.Trace(bucketURL)
+		}
+		clnts[i] = clnt
+	}
+
+	ops, elapsed, err := runSpeedtestPhase(concurrent, duration, func(worker int) *probe.Error {
+		_, err := clnts[worker].Put(context.Background(), bytes.NewReader(data), size, nil, nil, nil)
+		return err
+	})
+	if err != nil {
+		return result, err.Trace(bucketURL)
+	}
+	result.PutIOPS = float64(ops) / elapsed.Seconds()
+	result.PutThroughput = result.PutIOPS * float64(size)
+
+	ops, elapsed, err = runSpeedtestPhase(concurrent, duration, func(worker int) *probe.Error {
+		reader, err := clnts[worker].Get(nil)
+		if err != nil {
+			return err
+		}
+		defer reader.Close()
+		_, e := io.Copy(ioutil.Discard, reader)
+		return probe.NewError(e)
+	})
+	if err != nil {
+		return result, err.Trace(bucketURL)
+	}
+	result.GetIOPS = float64(ops) / elapsed.Seconds()
+	result.GetThroughput = result.GetIOPS * float64(size)
+
+	return result, nil
+}
+
+// autotuneObjectSpeedtest doubles the concurrency of the object speed
+// test until the throughput stops improving by at least 10%, and returns
+// the best round. Every round is passed to progress.
+func autotuneObjectSpeedtest(bucketURL, prefix string, size int64, duration time.Duration, progress func(objectSpeedtestResult)) (objectSpeedtestResult, *probe.Error) {
+	var best objectSpeedtestResult
+	for concurrent := minSpeedtestConcurrent; concurrent <= maxSpeedtestConcurrent; concurrent *= 2 {
+		result, err := runObjectSpeedtest(bucketURL, prefix, size, concurrent, duration)
+		if err != nil {
+			return best, err
+		}
+		progress(result)
+		if best.Concurrent > 0 && result.throughput() < best.throughput()*1.1 {
+			if result.throughput() > best.throughput() {
+				best = result
+			}
+			break
+		}
+		best = result
+	}
+	return best, nil
+}
+
+// removeSpeedtestObjects removes the objects written by up to
+// concurrent workers of the object speed test.
+func removeSpeedtestObjects(bucketURL, prefix string, concurrent int) *probe.Error {
+	clnt, err := newClient(bucketURL)
+	if err != nil {
+		return err.Trace(bucketURL)
+	}
+	contentCh := make(chan *clientContent, concurrent)
+	for i := 0; i < concurrent; i++ {
+		objectURL := urlJoinPath(clnt.GetURL().String(), prefix+"obj-"+strconv.Itoa(i))
+		contentCh <- &clientContent{URL: *newClientURL(objectURL)}
+	}
+	close(contentCh)
+
+	isIncomplete, isRemoveBucket := false, false
+	errorCh := clnt.Remove(isIncomplete, isRemoveBucket, contentCh)
+	if err, ok := <-errorCh; ok {
+		return err.Trace(bucketURL)
+	}
+	return nil
+}
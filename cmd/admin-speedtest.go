@@ -0,0 +1,378 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+	"time"
+
+	humanize "github.com/dustin/go-humanize"
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/console"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio/pkg/madmin"
+)
+
+var adminSpeedtestFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:  "size",
+		Usage: "size of the objects and of the drive and network test data",
+		Value: "64MiB",
+	},
+	cli.StringFlag{
+		Name:  "duration",
+		Usage: "duration of each object speed test round",
+		Value: "10s",
+	},
+	cli.IntFlag{
+		Name:  "concurrent",
+		Usage: "number of concurrent object requests, autotuned when not set",
+	},
+}
+
+var adminSpeedtestCmd = cli.Command{
+	Name:   "speedtest",
+	Usage:  "run object, drive and network performance tests",
+	Action: mainAdminSpeedtest,
+	Before: setGlobalsFromContext,
+	Flags:  append(adminSpeedtestFlags, globalFlags...),
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [FLAGS] TARGET
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+DESCRIPTION:
+  Every server measures the read and write speed of its drives and the
+  network throughput to its peers. When TARGET names a bucket, objects are
+  also uploaded to and downloaded from it from this machine, doubling the
+  number of concurrent requests while the throughput keeps improving.
+  Test objects are removed afterwards.
+
+EXAMPLES:
+  1. Run drive and network performance tests on the MinIO cluster 'myminio'.
+     $ {{.HelpName}} myminio/
+
+  2. Also run object PUT and GET speed tests with 16MiB objects in the bucket 'testbucket'.
+     $ {{.HelpName}} --size 16MiB myminio/testbucket
+
+  3. Run object speed tests with 32 concurrent requests for 30 seconds.
+     $ {{.HelpName}} --concurrent 32 --duration 30s myminio/testbucket
+`,
+}
+
+// driveSpeedtestResult is the measured speed of a drive.
+type driveSpeedtestResult struct {
+	Node       string  `json:"node"`
+	Path       string  `json:"path,omitempty"`
+	WriteSpeed float64 `json:"writeSpeed"`
+	ReadSpeed  float64 `json:"readSpeed"`
+	Err        string  `json:"error,omitempty"`
+}
+
+// netSpeedtestResult is the measured throughput from a node to a peer.
+type netSpeedtestResult struct {
+	Node       string `json:"node"`
+	Peer       string `json:"peer,omitempty"`
+	Throughput uint64 `json:"throughput"`
+	Err        string `json:"error,omitempty"`
+}
+
+// Speed test verdicts.
+const (
+	speedtestPass = "PASS"
+	speedtestWarn = "WARN"
+	speedtestFail = "FAIL"
+)
+
+// speedtestMessage is container for the results of all speed tests.
+type speedtestMessage struct {
+	Status   string                 `json:"status"`
+	Drives   []driveSpeedtestResult `json:"drives,omitempty"`
+	Network  []netSpeedtestResult   `json:"network,omitempty"`
+	Object   *objectSpeedtestResult `json:"object,omitempty"`
+	Verdict  string                 `json:"verdict"`
+	Problems []string               `json:"problems,omitempty"`
+}
+
+// humanizeSpeed formats a speed in bytes per second.
+func humanizeSpeed(bytesPerSec float64) string {
+	return humanize.IBytes(uint64(bytesPerSec)) + "/s"
+}
+
+// String colorized speed test message.
+func (s speedtestMessage) String() string {
+	var lines []string
+	if len(s.Drives) > 0 {
+		lines = append(lines, console.Colorize("SpeedtestHeader", "Drives:"))
+		for _, d := range s.Drives {
+			if d.Err != "" {
+				lines = append(lines, fmt.Sprintf("  %s  %s  %s", d.Node, d.Path, console.Colorize("SpeedtestFail", d.Err)))
+				continue
+			}
+			lines = append(lines, fmt.Sprintf("  %s  %s  write %s  read %s", d.Node, d.Path, humanizeSpeed(d.WriteSpeed), humanizeSpeed(d.ReadSpeed)))
+		}
+	}
+	if len(s.Network) > 0 {
+		lines = append(lines, console.Colorize("SpeedtestHeader", "Network:"))
+		for _, n := range s.Network {
+			if n.Err != "" {
+				lines = append(lines, fmt.Sprintf("  %s -> %s  %s", n.Node, n.Peer, console.Colorize("SpeedtestFail", n.Err)))
+				continue
+			}
+			lines = append(lines, fmt.Sprintf("  %s -> %s  %s", n.Node, n.Peer, humanizeSpeed(float64(n.Throughput))))
+		}
+	}
+	if s.Object != nil {
+		lines = append(lines, console.Colorize("SpeedtestHeader", fmt.Sprintf("Objects (%s, %d concurrent):",
+			humanize.IBytes(uint64(s.Object.Size)), s.Object.Concurrent)))
+		lines = append(lines, fmt.Sprintf("  PUT  %s  %.1f objects/s", humanizeSpeed(s.Object.PutThroughput), s.Object.PutIOPS))
+		lines = append(lines, fmt.Sprintf("  GET  %s  %.1f objects/s", humanizeSpeed(s.Object.GetThroughput), s.Object.GetIOPS))
+	}
+	lines = append(lines, "Verdict: "+console.Colorize("Speedtest"+strings.Title(strings.ToLower(s.Verdict)), s.Verdict))
+	for _, problem := range s.Problems {
+		lines = append(lines, "  "+problem)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// JSON jsonified speed test message.
+func (s speedtestMessage) JSON() string {
+	s.Status = "success"
+	speedtestJSONBytes, e := json.MarshalIndent(s, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(speedtestJSONBytes)
+}
+
+// median returns the median of values.
+func median(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[mid-1] + sorted[mid]) / 2
+	}
+	return sorted[mid]
+}
+
+// setSpeedtestVerdict fails the speed test when any test reported an
+// error, and warns about drives and network links slower than half of
+// the median of their peers.
+func setSpeedtestVerdict(s *speedtestMessage) {
+	var failures, warnings []string
+
+	var writeSpeeds, readSpeeds []float64
+	for _, d := range s.Drives {
+		if d.Err == "" {
+			writeSpeeds = append(writeSpeeds, d.WriteSpeed)
+			readSpeeds = append(readSpeeds, d.ReadSpeed)
+		}
+	}
+	writeMedian, readMedian := median(writeSpeeds), median(readSpeeds)
+	for _, d := range s.Drives {
+		switch {
+		case d.Err != "":
+			failures = append(failures, fmt.Sprintf("Drive %s on %s failed: %s", d.Path, d.Node, d.Err))
+		case d.WriteSpeed < writeMedian/2:
+			warnings = append(warnings, fmt.Sprintf("Drive %s on %s writes at %s, less than half of the median %s",
+				d.Path, d.Node, humanizeSpeed(d.WriteSpeed), humanizeSpeed(writeMedian)))
+		case d.ReadSpeed < readMedian/2:
+			warnings = append(warnings, fmt.Sprintf("Drive %s on %s reads at %s, less than half of the median %s",
+				d.Path, d.Node, humanizeSpeed(d.ReadSpeed), humanizeSpeed(readMedian)))
+		}
+	}
+
+	var throughputs []float64
+	for _, n := range s.Network {
+		if n.Err == "" {
+			throughputs = append(throughputs, float64(n.Throughput))
+		}
+	}
+	throughputMedian := median(throughputs)
+	for _, n := range s.Network {
+		switch {
+		case n.Err != "":
+			failures = append(failures, fmt.Sprintf("Network from %s to %s failed: %s", n.Node, n.Peer, n.Err))
+		case float64(n.Throughput) < throughputMedian/2:
+			warnings = append(warnings, fmt.Sprintf("Network from %s to %s runs at %s, less than half of the median %s",
+				n.Node, n.Peer, humanizeSpeed(float64(n.Throughput)), humanizeSpeed(throughputMedian)))
+		}
+	}
+
+	s.Problems = append(failures, warnings...)
+	switch {
+	case len(failures) > 0:
+		s.Verdict = speedtestFail
+	case len(warnings) > 0:
+		s.Verdict = speedtestWarn
+	default:
+		s.Verdict = speedtestPass
+	}
+}
+
+// getDriveSpeedtestResults flattens the drive performance of all nodes,
+// sorted by node and path.
+func getDriveSpeedtestResults(serversPerf []madmin.ServerDrivesPerfInfo) []driveSpeedtestResult {
+	var results []driveSpeedtestResult
+	for _, serverPerf := range serversPerf {
+		if serverPerf.Error != "" {
+			results = append(results, driveSpeedtestResult{Node: serverPerf.Addr, Err: serverPerf.Error})
+			continue
+		}
+		for _, perf := range serverPerf.Perf {
+			results = append(results, driveSpeedtestResult{
+				Node:       serverPerf.Addr,
+				Path:       perf.Path,
+				WriteSpeed: perf.WriteSpeed,
+				ReadSpeed:  perf.ReadSpeed,
+				Err:        perf.Error,
+			})
+		}
+	}
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Node != results[j].Node {
+			return results[i].Node < results[j].Node
+		}
+		return results[i].Path < results[j].Path
+	})
+	return results
+}
+
+// getNetSpeedtestResults flattens the network performance of all nodes,
+// sorted by node and peer.
+func getNetSpeedtestResults(netPerf map[string][]madmin.NetPerfInfo) []netSpeedtestResult {
+	var results []netSpeedtestResult
+	for node, peersPerf := range netPerf {
+		for _, perf := range peersPerf {
+			results = append(results, netSpeedtestResult{
+				Node:       node,
+				Peer:       perf.Addr,
+				Throughput: perf.ReadThroughput,
+				Err:        perf.Error,
+			})
+		}
+	}
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Node != results[j].Node {
+			return results[i].Node < results[j].Node
+		}
+		return results[i].Peer < results[j].Peer
+	})
+	return results
+}
+
+// checkAdminSpeedtestSyntax - validate all the passed arguments
+func checkAdminSpeedtestSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) != 1 {
+		cli.ShowCommandHelpAndExit(ctx, "speedtest", 1) // last argument is exit code
+	}
+	if _, e := humanize.ParseBytes(ctx.String("size")); e != nil {
+		fatalIf(probe.NewError(e).Trace(ctx.String("size")), "Invalid size `"+ctx.String("size")+"`.")
+	}
+	if d, e := time.ParseDuration(ctx.String("duration")); e != nil || d <= 0 {
+		fatalIf(errInvalidArgument().Trace(ctx.String("duration")), "Invalid duration `"+ctx.String("duration")+"`.")
+	}
+	if ctx.Int("concurrent") < 0 {
+		fatalIf(errInvalidArgument().Trace(ctx.Args()...), "Number of concurrent requests cannot be negative.")
+	}
+}
+
+// printProgress prints a progress line unless the output is
+// quiet or JSON.
+func printProgress(format string, data ...interface{}) {
+	if !globalQuiet && !globalJSON {
+		console.Infof(format, data...)
+	}
+}
+
+// mainAdminSpeedtest is the handle for "mc admin speedtest" command.
+func mainAdminSpeedtest(ctx *cli.Context) error {
+	checkAdminSpeedtestSyntax(ctx)
+
+	console.SetColor("SpeedtestHeader", color.New(color.Bold))
+	console.SetColor("SpeedtestPass", color.New(color.FgGreen, color.Bold))
+	console.SetColor("SpeedtestWarn", color.New(color.FgYellow, color.Bold))
+	console.SetColor("SpeedtestFail", color.New(color.FgRed, color.Bold))
+
+	aliasedURL := ctx.Args().Get(0)
+	size, _ := humanize.ParseBytes(ctx.String("size"))
+	duration, _ := time.ParseDuration(ctx.String("duration"))
+
+	splits := splitStr(strings.TrimSuffix(aliasedURL, "/"), "/", 2)
+	alias, bucket := splits[0], splits[1]
+
+	client, err := newAdminClient(alias)
+	fatalIf(err, "Unable to initialize admin connection.")
+
+	var msg speedtestMessage
+
+	printProgress("Measuring drive performance...\n")
+	drivesPerf, e := client.ServerDrivesPerfInfo(int64(size))
+	fatalIf(probe.NewError(e), "Unable to measure drive performance.")
+	msg.Drives = getDriveSpeedtestResults(drivesPerf)
+
+	printProgress("Measuring network performance...\n")
+	netPerf, e := client.NetPerfInfo(int(size))
+	if e != nil {
+		// Network tests are only supported by distributed setups.
+		printProgress("Skipping network performance: %s\n", e)
+	} else {
+		msg.Network = getNetSpeedtestResults(netPerf)
+	}
+
+	if bucket != "" {
+		bucketURL := alias + "/" + bucket
+		prefix := randString(20, rand.NewSource(time.Now().UnixNano()), ".mc-speedtest-") + "/"
+		progress := func(result objectSpeedtestResult) {
+			printProgress("%d concurrent: PUT %s, GET %s\n", result.Concurrent,
+				humanizeSpeed(result.PutThroughput), humanizeSpeed(result.GetThroughput))
+		}
+
+		var result objectSpeedtestResult
+		concurrent := ctx.Int("concurrent")
+		if concurrent > 0 {
+			printProgress("Measuring object performance...\n")
+			result, err = runObjectSpeedtest(bucketURL, prefix, int64(size), concurrent, duration)
+		} else {
+			printProgress("Measuring object performance, autotuning concurrency...\n")
+			concurrent = maxSpeedtestConcurrent
+			result, err = autotuneObjectSpeedtest(bucketURL, prefix, int64(size), duration, progress)
+		}
+		errorIf(removeSpeedtestObjects(bucketURL, prefix, concurrent), "Unable to remove speed test objects below `"+bucketURL+"/"+prefix+"`.")
+		fatalIf(err, "Unable to measure object performance.")
+		msg.Object = &result
+	}
+
+	setSpeedtestVerdict(&msg)
+	printMsg(msg)
+	if msg.Verdict == speedtestFail {
+		return exitStatus(globalErrorExitStatus)
+	}
+	return nil
+}
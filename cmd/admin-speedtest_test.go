@@ -0,0 +1,78 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"testing"
+
+	"github.com/minio/minio/pkg/disk"
+	"github.com/minio/minio/pkg/madmin"
+)
+
+func TestMedian(t *testing.T) {
+	testCases := []struct {
+		values   []float64
+		expected float64
+	}{
+		{nil, 0},
+		{[]float64{3}, 3},
+		{[]float64{5, 1, 3}, 3},
+		{[]float64{4, 1, 3, 2}, 2.5},
+	}
+	for i, testCase := range testCases {
+		if got := median(testCase.values); got != testCase.expected {
+			t.Errorf("Test %d: expected %v, got %v", i+1, testCase.expected, got)
+		}
+	}
+}
+
+func TestSpeedtestVerdict(t *testing.T) {
+	drivesPerf := func(speeds ...float64) []madmin.ServerDrivesPerfInfo {
+		var perf []disk.Performance
+		for _, speed := range speeds {
+			perf = append(perf, disk.Performance{Path: "/data", WriteSpeed: speed, ReadSpeed: speed})
+		}
+		return []madmin.ServerDrivesPerfInfo{{Addr: "node1:9000", Perf: perf}}
+	}
+	testCases := []struct {
+		drives   []madmin.ServerDrivesPerfInfo
+		network  map[string][]madmin.NetPerfInfo
+		verdict  string
+		problems int
+	}{
+		{drivesPerf(100, 110, 90), nil, speedtestPass, 0},
+		{drivesPerf(100, 110, 40), nil, speedtestWarn, 1},
+		{[]madmin.ServerDrivesPerfInfo{{Addr: "node2:9000", Error: "connection refused"}}, nil, speedtestFail, 1},
+		{drivesPerf(100, 100), map[string][]madmin.NetPerfInfo{
+			"node1:9000": {{Addr: "node2:9000", ReadThroughput: 1000}, {Addr: "node3:9000", ReadThroughput: 1100}},
+			"node2:9000": {{Addr: "node1:9000", ReadThroughput: 300}, {Addr: "node3:9000", Error: "i/o timeout"}},
+		}, speedtestFail, 2},
+	}
+	for i, testCase := range testCases {
+		msg := speedtestMessage{
+			Drives:  getDriveSpeedtestResults(testCase.drives),
+			Network: getNetSpeedtestResults(testCase.network),
+		}
+		setSpeedtestVerdict(&msg)
+		if msg.Verdict != testCase.verdict {
+			t.Errorf("Test %d: expected verdict %s, got %s", i+1, testCase.verdict, msg.Verdict)
+		}
+		if len(msg.Problems) != testCase.problems {
+			t.Errorf("Test %d: expected %d problems, got %v", i+1, testCase.problems, msg.Problems)
+		}
+	}
+}
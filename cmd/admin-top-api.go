@@ -0,0 +1,224 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	humanize "github.com/dustin/go-humanize"
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/console"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio/pkg/madmin"
+)
+
+var adminTopAPIFlags = []cli.Flag{
+	cli.BoolFlag{
+		Name:  "all, a",
+		Usage: "include internode calls between MinIO servers",
+	},
+}
+
+var adminTopAPICmd = cli.Command{
+	Name:   "api",
+	Usage:  "show live API call rates and latencies on a MinIO cluster",
+	Before: setGlobalsFromContext,
+	Action: mainAdminTopAPI,
+	Flags:  append(append(adminTopAPIFlags, adminTopViewFlags...), globalFlags...),
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [FLAGS] TARGET
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+  1. Show the API calls served by a MinIO cluster, refreshed every 2 seconds.
+     $ {{.HelpName}} myminio/
+
+  2. Show the API calls including internode calls, refreshed every 10 seconds.
+     $ {{.HelpName}} --all --interval 10s myminio/
+
+  3. Print 5 JSON snapshots of the API calls, one every minute.
+     $ {{.HelpName}} --json --count 5 --interval 1m myminio/
+`,
+}
+
+// topAPIStat - calls of one API during a refresh interval.
+type topAPIStat struct {
+	API        string        `json:"api"`
+	Calls      int           `json:"calls"`
+	Errors     int           `json:"errors"`
+	Rx         int64         `json:"rx"`
+	Tx         int64         `json:"tx"`
+	AvgLatency time.Duration `json:"avgLatency"`
+	MaxLatency time.Duration `json:"maxLatency"`
+
+	totalLatency time.Duration
+}
+
+// topAPICollector - aggregates traced calls per API.
+type topAPICollector struct {
+	mutex sync.Mutex
+	stats map[string]*topAPIStat
+}
+
+func newTopAPICollector() *topAPICollector {
+	return &topAPICollector{stats: make(map[string]*topAPIStat)}
+}
+
+// add - accounts a traced call.
+func (c *topAPICollector) add(ti madmin.ServiceTraceInfo) {
+	t := ti.Trace
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	stat, ok := c.stats[t.FuncName]
+	if !ok {
+		stat = &topAPIStat{API: t.FuncName}
+		c.stats[t.FuncName] = stat
+	}
+	stat.Calls++
+	if t.RespInfo.StatusCode >= 400 {
+		stat.Errors++
+	}
+	stat.Rx += int64(t.CallStats.InputBytes)
+	stat.Tx += int64(t.CallStats.OutputBytes)
+	stat.totalLatency += t.CallStats.Latency
+	if t.CallStats.Latency > stat.MaxLatency {
+		stat.MaxLatency = t.CallStats.Latency
+	}
+}
+
+// snapshot - returns the calls since the last snapshot, busiest first.
+func (c *topAPICollector) snapshot() []topAPIStat {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	stats := make([]topAPIStat, 0, len(c.stats))
+	for _, stat := range c.stats {
+		stat.AvgLatency = stat.totalLatency / time.Duration(stat.Calls)
+		stats = append(stats, *stat)
+	}
+	c.stats = make(map[string]*topAPIStat)
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].Calls != stats[j].Calls {
+			return stats[i].Calls > stats[j].Calls
+		}
+		return stats[i].API < stats[j].API
+	})
+	return stats
+}
+
+// topAPIMessage - a refresh of the API view.
+type topAPIMessage struct {
+	Status   string        `json:"status"`
+	Time     time.Time     `json:"time"`
+	Interval time.Duration `json:"interval"`
+	APIs     []topAPIStat  `json:"apis"`
+}
+
+// String colorized API view.
+func (m topAPIMessage) String() string {
+	table := newPrettyTable("  ",
+		Field{"API", 28},
+		Field{"Calls", 8},
+		Field{"Rate", 10},
+		Field{"Errors", 8},
+		Field{"Avg", 10},
+		Field{"Max", 10},
+		Field{"Traffic", -1},
+	)
+	lines := []string{
+		console.Colorize("TopTime", fmt.Sprintf("%s, every %s", m.Time.Local().Format(printDate), m.Interval)),
+		console.Colorize("TopHeaders", table.buildRow("API", "CALLS", "CALLS/S", "ERRORS", "AVG", "MAX", "RX/TX")),
+	}
+	for _, stat := range m.APIs {
+		row := table.buildRow(stat.API,
+			fmt.Sprint(stat.Calls),
+			fmt.Sprintf("%.1f", float64(stat.Calls)/m.Interval.Seconds()),
+			fmt.Sprint(stat.Errors),
+			stat.AvgLatency.Round(time.Millisecond).String(),
+			stat.MaxLatency.Round(time.Millisecond).String(),
+			humanize.IBytes(uint64(stat.Rx))+"/"+humanize.IBytes(uint64(stat.Tx)))
+		if stat.Errors > 0 {
+			row = console.Colorize("TopError", row)
+		}
+		lines = append(lines, row)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// JSON jsonified API view.
+func (m topAPIMessage) JSON() string {
+	m.Status = "success"
+	statusJSONBytes, e := json.MarshalIndent(m, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(statusJSONBytes)
+}
+
+// checkAdminTopAPISyntax - validate all the passed arguments
+func checkAdminTopAPISyntax(ctx *cli.Context) {
+	if len(ctx.Args()) != 1 {
+		cli.ShowCommandHelpAndExit(ctx, "api", 1) // last argument is exit code
+	}
+}
+
+func mainAdminTopAPI(ctx *cli.Context) error {
+	checkAdminTopAPISyntax(ctx)
+
+	aliasedURL := ctx.Args().Get(0)
+	interval := getTopViewInterval(ctx)
+
+	console.SetColor("TopTime", color.New(color.FgYellow))
+	console.SetColor("TopHeaders", color.New(color.FgGreen, color.Bold))
+	console.SetColor("TopError", color.New(color.FgRed))
+
+	// Create a new MinIO Admin Client
+	client, err := newAdminClient(aliasedURL)
+	fatalIf(err, "Unable to initialize admin connection.")
+
+	doneCh := make(chan struct{})
+	defer close(doneCh)
+
+	// Aggregate the traced calls in the background.
+	collector := newTopAPICollector()
+	traceCh := client.ServiceTrace(ctx.Bool("all"), false, doneCh)
+	go func() {
+		for traceInfo := range traceCh {
+			if traceInfo.Err != nil {
+				fatalIf(probe.NewError(traceInfo.Err), "Unable to trace API calls.")
+			}
+			collector.add(traceInfo)
+		}
+	}()
+
+	runTopView(interval, ctx.Int("count"), func() message {
+		return topAPIMessage{
+			Time:     time.Now(),
+			Interval: interval,
+			APIs:     collector.snapshot(),
+		}
+	})
+	return nil
+}
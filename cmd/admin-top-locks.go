@@ -18,6 +18,7 @@ package cmd
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/fatih/color"
@@ -33,12 +34,12 @@ var adminTopLocksCmd = cli.Command{
 	Usage:  "Get a list of the 10 oldest locks on a MinIO cluster.",
 	Before: setGlobalsFromContext,
 	Action: mainAdminTopLocks,
-	Flags:  globalFlags,
+	Flags:  append(adminTopViewFlags, globalFlags...),
 	CustomHelpTemplate: `NAME:
   {{.HelpName}} - {{.Usage}}
 
 USAGE:
-  {{.HelpName}} TARGET
+  {{.HelpName}} [FLAGS] TARGET
 
 FLAGS:
   {{range .VisibleFlags}}{{.}}
@@ -46,6 +47,9 @@ FLAGS:
 EXAMPLES:
   1. Get a list of the 10 oldest locks on a MinIO cluster.
      $ {{.HelpName}} myminio/
+
+  2. Watch the 10 oldest locks on a MinIO cluster, refreshed every 5 seconds.
+     $ {{.HelpName}} --interval 5s myminio/
 `,
 }
 
@@ -113,19 +117,62 @@ func mainAdminTopLocks(ctx *cli.Context) error {
 	client, err := newAdminClient(aliasedURL)
 	fatalIf(err, "Unable to initialize admin connection.")
 
-	// Call top locks API
-	entries, e := client.TopLocks()
-	fatalIf(probe.NewError(e), "Cannot get server locks list.")
-
 	console.SetColor("StaleLock", color.New(color.FgRed, color.Bold))
 	console.SetColor("Lock", color.New(color.FgBlue, color.Bold))
 	console.SetColor("Headers", color.New(color.FgGreen, color.Bold))
 
+	// Refresh the locks until interrupted when asked to.
+	if ctx.IsSet("interval") || ctx.IsSet("count") {
+		console.SetColor("TopTime", color.New(color.FgYellow))
+		runTopView(getTopViewInterval(ctx), ctx.Int("count"), func() message {
+			entries, e := client.TopLocks()
+			fatalIf(probe.NewError(e), "Cannot get server locks list.")
+			return topLocksMessage{Time: time.Now(), Locks: entries}
+		})
+		return nil
+	}
+
+	// Call top locks API
+	entries, e := client.TopLocks()
+	fatalIf(probe.NewError(e), "Cannot get server locks list.")
+
 	// Print
 	printLocks(entries)
 	return nil
 }
 
+// topLocksMessage - a refresh of the locks view.
+type topLocksMessage struct {
+	Status string             `json:"status"`
+	Time   time.Time          `json:"time"`
+	Locks  madmin.LockEntries `json:"locks"`
+}
+
+// String colorized locks view.
+func (m topLocksMessage) String() string {
+	lines := []string{
+		console.Colorize("TopTime", m.Time.Local().Format(printDate)),
+		console.Colorize("Headers", newPrettyTable("  ",
+			Field{"Time", 20},
+			Field{"Type", 6},
+			Field{"Owner", 20},
+			Field{"Resource", -1},
+		).buildRow("Time", "Type", "Owner", "Resource")),
+	}
+	for _, entry := range m.Locks {
+		lines = append(lines, lockMessage{Lock: entry}.String())
+	}
+	return strings.Join(lines, "\n")
+}
+
+// JSON jsonified locks view.
+func (m topLocksMessage) JSON() string {
+	m.Status = "success"
+	statusJSONBytes, e := json.MarshalIndent(m, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(statusJSONBytes)
+}
+
 func printHeaders() {
 	timeFieldMaxLen := 20
 	resourceFieldMaxLen := -1
@@ -0,0 +1,180 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	humanize "github.com/dustin/go-humanize"
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/console"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio/pkg/madmin"
+)
+
+var adminTopNetCmd = cli.Command{
+	Name:   "net",
+	Usage:  "show live network throughput of each MinIO server",
+	Before: setGlobalsFromContext,
+	Action: mainAdminTopNet,
+	Flags:  append(adminTopViewFlags, globalFlags...),
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [FLAGS] TARGET
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+  1. Show the network throughput of each server of a MinIO cluster, refreshed every 2 seconds.
+     $ {{.HelpName}} myminio/
+
+  2. Print a single JSON snapshot of the network throughput measured over 10 seconds.
+     $ {{.HelpName}} --json --count 1 --interval 10s myminio/
+`,
+}
+
+// topNetNode - network throughput of one server during a refresh interval.
+type topNetNode struct {
+	Addr    string  `json:"addr"`
+	Error   string  `json:"error,omitempty"`
+	RxRate  float64 `json:"rxRate"`
+	TxRate  float64 `json:"txRate"`
+	RxTotal uint64  `json:"rxTotal"`
+	TxTotal uint64  `json:"txTotal"`
+}
+
+// topNetMessage - a refresh of the network view.
+type topNetMessage struct {
+	Status   string        `json:"status"`
+	Time     time.Time     `json:"time"`
+	Interval time.Duration `json:"interval"`
+	Nodes    []topNetNode  `json:"nodes"`
+}
+
+// String colorized network view.
+func (m topNetMessage) String() string {
+	table := newPrettyTable("  ",
+		Field{"Addr", 32},
+		Field{"RxRate", 12},
+		Field{"TxRate", 12},
+		Field{"Total", -1},
+	)
+	lines := []string{
+		console.Colorize("TopTime", fmt.Sprintf("%s, every %s", m.Time.Local().Format(printDate), m.Interval)),
+		console.Colorize("TopHeaders", table.buildRow("SERVER", "RX/S", "TX/S", "RX/TX TOTAL")),
+	}
+	for _, node := range m.Nodes {
+		if node.Error != "" {
+			lines = append(lines, console.Colorize("TopError", table.buildRow(node.Addr, "-", "-", node.Error)))
+			continue
+		}
+		lines = append(lines, table.buildRow(node.Addr,
+			humanize.IBytes(uint64(node.RxRate)),
+			humanize.IBytes(uint64(node.TxRate)),
+			humanize.IBytes(node.RxTotal)+"/"+humanize.IBytes(node.TxTotal)))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// JSON jsonified network view.
+func (m topNetMessage) JSON() string {
+	m.Status = "success"
+	statusJSONBytes, e := json.MarshalIndent(m, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(statusJSONBytes)
+}
+
+// getTopNetNodes - computes the throughput of each server from two
+// samples of the server info taken interval apart. Servers missing in
+// the previous sample only report their totals.
+func getTopNetNodes(prev, cur []madmin.ServerInfo, interval time.Duration) []topNetNode {
+	prevStats := make(map[string]madmin.ServerConnStats)
+	for _, info := range prev {
+		if info.Error == "" && info.Data != nil {
+			prevStats[info.Addr] = info.Data.ConnStats
+		}
+	}
+	nodes := make([]topNetNode, 0, len(cur))
+	for _, info := range cur {
+		node := topNetNode{Addr: info.Addr, Error: info.Error}
+		if info.Error == "" && info.Data == nil {
+			node.Error = "no data"
+		}
+		if node.Error != "" {
+			nodes = append(nodes, node)
+			continue
+		}
+		stats := info.Data.ConnStats
+		node.RxTotal, node.TxTotal = stats.TotalInputBytes, stats.TotalOutputBytes
+		// Counters restart with the server, skip rates then.
+		if p, ok := prevStats[info.Addr]; ok && stats.TotalInputBytes >= p.TotalInputBytes && stats.TotalOutputBytes >= p.TotalOutputBytes {
+			node.RxRate = float64(stats.TotalInputBytes-p.TotalInputBytes) / interval.Seconds()
+			node.TxRate = float64(stats.TotalOutputBytes-p.TotalOutputBytes) / interval.Seconds()
+		}
+		nodes = append(nodes, node)
+	}
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].Addr < nodes[j].Addr })
+	return nodes
+}
+
+// checkAdminTopNetSyntax - validate all the passed arguments
+func checkAdminTopNetSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) != 1 {
+		cli.ShowCommandHelpAndExit(ctx, "net", 1) // last argument is exit code
+	}
+}
+
+func mainAdminTopNet(ctx *cli.Context) error {
+	checkAdminTopNetSyntax(ctx)
+
+	aliasedURL := ctx.Args().Get(0)
+	interval := getTopViewInterval(ctx)
+
+	console.SetColor("TopTime", color.New(color.FgYellow))
+	console.SetColor("TopHeaders", color.New(color.FgGreen, color.Bold))
+	console.SetColor("TopError", color.New(color.FgRed))
+
+	// Create a new MinIO Admin Client
+	client, err := newAdminClient(aliasedURL)
+	fatalIf(err, "Unable to initialize admin connection.")
+
+	prev, e := client.ServerInfo()
+	fatalIf(probe.NewError(e), "Unable to get server info.")
+	prevTime := time.Now()
+
+	runTopView(interval, ctx.Int("count"), func() message {
+		cur, e := client.ServerInfo()
+		fatalIf(probe.NewError(e), "Unable to get server info.")
+		now := time.Now()
+		nodes := getTopNetNodes(prev, cur, now.Sub(prevTime))
+		prev, prevTime = cur, now
+		return topNetMessage{
+			Time:     now,
+			Interval: interval,
+			Nodes:    nodes,
+		}
+	})
+	return nil
+}
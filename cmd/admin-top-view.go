@@ -0,0 +1,67 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"time"
+
+	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/console"
+	"github.com/minio/mc/pkg/probe"
+)
+
+// Flags of the live top views.
+var adminTopViewFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:  "interval, i",
+		Value: "2s",
+		Usage: "refresh the view every interval",
+	},
+	cli.IntFlag{
+		Name:  "count, n",
+		Usage: "stop after count refreshes, 0 refreshes until interrupted",
+	},
+}
+
+// Moves the cursor home and clears the terminal.
+const clearScreen = "\033[H\033[2J"
+
+// getTopViewInterval - parses the refresh interval of a top view.
+func getTopViewInterval(ctx *cli.Context) time.Duration {
+	interval, e := time.ParseDuration(ctx.String("interval"))
+	fatalIf(probe.NewError(e), "Unable to parse interval=`"+ctx.String("interval")+"`.")
+	if interval < time.Second {
+		fatalIf(errInvalidArgument().Trace(ctx.String("interval")), "Interval should be at least one second.")
+	}
+	return interval
+}
+
+// runTopView - prints the message returned by refresh every interval,
+// count times or forever when count is 0. Terminals are cleared before
+// each refresh, JSON output prints one snapshot per refresh.
+func runTopView(interval time.Duration, count int, refresh func() message) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for i := 0; count == 0 || i < count; i++ {
+		<-ticker.C
+		msg := refresh()
+		if !globalJSON && isTerminal() {
+			console.Print(clearScreen)
+		}
+		printMsg(msg)
+	}
+}
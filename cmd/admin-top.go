@@ -26,6 +26,8 @@ var adminTopCmd = cli.Command{
 	Flags:  globalFlags,
 	Subcommands: []cli.Command{
 		adminTopLocksCmd,
+		adminTopAPICmd,
+		adminTopNetCmd,
 	},
 	HideHelpCommand: true,
 }
@@ -0,0 +1,83 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/minio/minio/pkg/madmin"
+	mtrace "github.com/minio/minio/pkg/trace"
+)
+
+func TestTopAPICollector(t *testing.T) {
+	newTrace := func(api string, status int, latency time.Duration) madmin.ServiceTraceInfo {
+		return madmin.ServiceTraceInfo{Trace: mtrace.Info{
+			FuncName:  api,
+			RespInfo:  mtrace.ResponseInfo{StatusCode: status},
+			CallStats: mtrace.CallStats{Latency: latency, InputBytes: 10, OutputBytes: 100},
+		}}
+	}
+	c := newTopAPICollector()
+	c.add(newTrace("s3.GetObject", 200, 10*time.Millisecond))
+	c.add(newTrace("s3.PutObject", 200, 40*time.Millisecond))
+	c.add(newTrace("s3.GetObject", 404, 30*time.Millisecond))
+
+	stats := c.snapshot()
+	expected := []topAPIStat{
+		{API: "s3.GetObject", Calls: 2, Errors: 1, Rx: 20, Tx: 200, AvgLatency: 20 * time.Millisecond, MaxLatency: 30 * time.Millisecond, totalLatency: 40 * time.Millisecond},
+		{API: "s3.PutObject", Calls: 1, Rx: 10, Tx: 100, AvgLatency: 40 * time.Millisecond, MaxLatency: 40 * time.Millisecond, totalLatency: 40 * time.Millisecond},
+	}
+	if !reflect.DeepEqual(stats, expected) {
+		t.Fatalf("expected %+v, got %+v", expected, stats)
+	}
+	// Snapshots only hold the calls since the previous one.
+	if stats = c.snapshot(); len(stats) != 0 {
+		t.Fatalf("expected an empty snapshot, got %+v", stats)
+	}
+}
+
+func TestGetTopNetNodes(t *testing.T) {
+	newInfo := func(addr string, rx, tx uint64) madmin.ServerInfo {
+		return madmin.ServerInfo{Addr: addr, Data: &madmin.ServerInfoData{
+			ConnStats: madmin.ServerConnStats{TotalInputBytes: rx, TotalOutputBytes: tx},
+		}}
+	}
+	prev := []madmin.ServerInfo{
+		newInfo("node2:9000", 1000, 5000),
+		newInfo("node1:9000", 100, 200),
+		newInfo("node3:9000", 5000, 5000),
+	}
+	cur := []madmin.ServerInfo{
+		newInfo("node1:9000", 2100, 4200),
+		newInfo("node2:9000", 1000, 5000),
+		// Restarted server, counters went back.
+		newInfo("node3:9000", 10, 10),
+		{Addr: "node4:9000", Error: "connection refused"},
+	}
+	nodes := getTopNetNodes(prev, cur, 2*time.Second)
+	expected := []topNetNode{
+		{Addr: "node1:9000", RxRate: 1000, TxRate: 2000, RxTotal: 2100, TxTotal: 4200},
+		{Addr: "node2:9000", RxTotal: 1000, TxTotal: 5000},
+		{Addr: "node3:9000", RxTotal: 10, TxTotal: 10},
+		{Addr: "node4:9000", Error: "connection refused"},
+	}
+	if !reflect.DeepEqual(nodes, expected) {
+		t.Fatalf("expected %+v, got %+v", expected, nodes)
+	}
+}
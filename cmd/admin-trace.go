@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"hash/fnv"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
@@ -31,6 +32,7 @@ import (
 	"github.com/minio/mc/pkg/console"
 	"github.com/minio/mc/pkg/probe"
 	"github.com/minio/minio/pkg/madmin"
+	"github.com/minio/minio/pkg/wildcard"
 )
 
 var adminTraceFlags = []cli.Flag{
@@ -43,9 +45,25 @@ var adminTraceFlags = []cli.Flag{
 		Usage: "trace all traffic (including internode traffic between MinIO servers)",
 	},
 	cli.BoolFlag{
-		Name:  "errors, e",
+		Name:  "errors-only, errors, e",
 		Usage: "trace failed requests only",
 	},
+	cli.BoolFlag{
+		Name:  "body",
+		Usage: "include request and response bodies in verbose traces",
+	},
+	cli.StringSliceFlag{
+		Name:  "node",
+		Usage: "trace calls served by these nodes only",
+	},
+	cli.StringSliceFlag{
+		Name:  "status",
+		Usage: "trace calls with these response statuses only, e.g. 404 or 5xx",
+	},
+	cli.StringFlag{
+		Name:  "path",
+		Usage: "trace calls whose path matches this wildcard pattern only",
+	},
 }
 
 var adminTraceCmd = cli.Command{
@@ -70,6 +88,12 @@ EXAMPLES:
 
   2. Show trace only for failed requests for a MinIO server with alias 'myminio'
     $ {{.HelpName}} -v -e myminio
+
+  3. Show trace of the server errors of a single node including the bodies
+     $ {{.HelpName}} -v --body --status 5xx --node minio1:9000 myminio
+
+  4. Show trace of the calls to objects under "mybucket/photos"
+     $ {{.HelpName}} --path "/mybucket/photos/*" myminio
 `,
 }
 
@@ -83,6 +107,73 @@ func checkAdminTraceSyntax(ctx *cli.Context) {
 	if len(ctx.Args()) != 1 {
 		cli.ShowCommandHelpAndExit(ctx, "trace", 1) // last argument is exit code
 	}
+	for _, status := range ctx.StringSlice("status") {
+		if !isValidTraceStatus(status) {
+			fatalIf(errInvalidArgument().Trace(status), "Invalid status `"+status+"`, expected a status code like 404 or a class like 4xx.")
+		}
+	}
+}
+
+// traceFilter - selects the traced calls to print.
+type traceFilter struct {
+	nodes    []string
+	statuses []string
+	path     string
+}
+
+// isValidTraceStatus - tells if status is a status code like 404 or a
+// status class like 4xx.
+func isValidTraceStatus(status string) bool {
+	if len(status) != 3 || status[0] < '1' || status[0] > '5' {
+		return false
+	}
+	if strings.ToLower(status[1:]) == "xx" {
+		return true
+	}
+	_, e := strconv.Atoi(status[1:])
+	return e == nil
+}
+
+// matchTraceStatus - tells if code matches a status code or class.
+func matchTraceStatus(status string, code int) bool {
+	if strings.ToLower(status[1:]) == "xx" {
+		return code/100 == int(status[0]-'0')
+	}
+	return status == strconv.Itoa(code)
+}
+
+// match - tells if the traced call passes all the filters.
+func (f traceFilter) match(ti madmin.ServiceTraceInfo) bool {
+	t := ti.Trace
+	if len(f.nodes) > 0 {
+		found := false
+		for _, node := range f.nodes {
+			// Nodes match with or without their port.
+			if node == t.NodeName || strings.HasPrefix(t.NodeName, node+":") {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if len(f.statuses) > 0 {
+		found := false
+		for _, status := range f.statuses {
+			if matchTraceStatus(status, t.RespInfo.StatusCode) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if f.path != "" && !wildcard.Match(f.path, t.ReqInfo.Path) {
+		return false
+	}
+	return true
 }
 
 // mainAdminTrace - the entry function of trace command
@@ -92,6 +183,12 @@ func mainAdminTrace(ctx *cli.Context) error {
 	verbose := ctx.Bool("verbose")
 	all := ctx.Bool("all")
 	errfltr := ctx.Bool("errors")
+	withBody := ctx.Bool("body")
+	filter := traceFilter{
+		nodes:    ctx.StringSlice("node"),
+		statuses: ctx.StringSlice("status"),
+		path:     ctx.String("path"),
+	}
 	aliasedURL := ctx.Args().Get(0)
 	console.SetColor("Stat", color.New(color.FgYellow))
 
@@ -126,6 +223,13 @@ func mainAdminTrace(ctx *cli.Context) error {
 		if traceInfo.Err != nil {
 			fatalIf(probe.NewError(traceInfo.Err), "Cannot listen to http trace")
 		}
+		if !filter.match(traceInfo) {
+			continue
+		}
+		if !withBody {
+			traceInfo.Trace.ReqInfo.Body = nil
+			traceInfo.Trace.RespInfo.Body = nil
+		}
 		if verbose {
 			printMsg(traceMessage{traceInfo})
 			continue
@@ -328,7 +432,9 @@ func (t traceMessage) String() string {
 			fmt.Sprintf("%s: ", k))+console.Colorize("HeaderValue", fmt.Sprintf("%s\n", strings.Join(v, ""))))
 	}
 
-	fmt.Fprintf(b, "%s%s", nodeNameStr, console.Colorize("Body", fmt.Sprintf("%s\n", string(ri.Body))))
+	if len(ri.Body) > 0 {
+		fmt.Fprintf(b, "%s%s", nodeNameStr, console.Colorize("Body", fmt.Sprintf("%s\n", string(ri.Body))))
+	}
 	fmt.Fprintf(b, "%s%s", nodeNameStr, console.Colorize("Response", fmt.Sprintf("[RESPONSE] ")))
 	fmt.Fprintf(b, "[%s] ", rs.Time.Format(timeFormat))
 	fmt.Fprint(b, console.Colorize("Stat", fmt.Sprintf("[ Duration %2s  🠉 %s  🠋 %s ]\n", trc.CallStats.Latency.Round(time.Microsecond), humanize.IBytes(uint64(trc.CallStats.InputBytes)), humanize.IBytes(uint64(trc.CallStats.OutputBytes)))))
@@ -343,7 +449,9 @@ func (t traceMessage) String() string {
 		fmt.Fprintf(b, "%s%s", nodeNameStr, console.Colorize("RespHeaderKey",
 			fmt.Sprintf("%s: ", k))+console.Colorize("HeaderValue", fmt.Sprintf("%s\n", strings.Join(v, ""))))
 	}
-	fmt.Fprintf(b, "%s%s\n", nodeNameStr, console.Colorize("Body", string(rs.Body)))
+	if len(rs.Body) > 0 {
+		fmt.Fprintf(b, "%s%s\n", nodeNameStr, console.Colorize("Body", string(rs.Body)))
+	}
 	fmt.Fprint(b, nodeNameStr)
 	return b.String()
 }
@@ -0,0 +1,66 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"testing"
+
+	"github.com/minio/minio/pkg/madmin"
+	mtrace "github.com/minio/minio/pkg/trace"
+)
+
+func TestTraceFilter(t *testing.T) {
+	newTrace := func(node, path string, status int) madmin.ServiceTraceInfo {
+		return madmin.ServiceTraceInfo{Trace: mtrace.Info{
+			NodeName: node,
+			ReqInfo:  mtrace.RequestInfo{Path: path},
+			RespInfo: mtrace.ResponseInfo{StatusCode: status},
+		}}
+	}
+	testCases := []struct {
+		filter traceFilter
+		trace  madmin.ServiceTraceInfo
+		match  bool
+	}{
+		{traceFilter{}, newTrace("minio1:9000", "/bucket/object", 200), true},
+		{traceFilter{nodes: []string{"minio1"}}, newTrace("minio1:9000", "/bucket", 200), true},
+		{traceFilter{nodes: []string{"minio1:9000"}}, newTrace("minio1:9000", "/bucket", 200), true},
+		{traceFilter{nodes: []string{"minio1"}}, newTrace("minio10:9000", "/bucket", 200), false},
+		{traceFilter{statuses: []string{"5xx"}}, newTrace("minio1:9000", "/bucket", 503), true},
+		{traceFilter{statuses: []string{"5xx"}}, newTrace("minio1:9000", "/bucket", 404), false},
+		{traceFilter{statuses: []string{"5XX", "404"}}, newTrace("minio1:9000", "/bucket", 404), true},
+		{traceFilter{path: "/bucket/photos/*"}, newTrace("minio1:9000", "/bucket/photos/2019/a.jpg", 200), true},
+		{traceFilter{path: "/bucket/photos/*"}, newTrace("minio1:9000", "/bucket/docs/a.pdf", 200), false},
+		{traceFilter{nodes: []string{"minio2"}, statuses: []string{"2xx"}}, newTrace("minio1:9000", "/bucket", 200), false},
+	}
+	for i, testCase := range testCases {
+		if match := testCase.filter.match(testCase.trace); match != testCase.match {
+			t.Errorf("Test %d: expected match %v, got %v", i+1, testCase.match, match)
+		}
+	}
+}
+
+func TestIsValidTraceStatus(t *testing.T) {
+	for status, valid := range map[string]bool{
+		"200": true, "404": true, "4xx": true, "5XX": true,
+		"": false, "4x": false, "600": false, "4ab": false, "40": false,
+	} {
+		if isValidTraceStatus(status) != valid {
+			t.Errorf("%q: expected valid %v", status, valid)
+		}
+	}
+}
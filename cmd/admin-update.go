@@ -18,6 +18,7 @@ package cmd
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/fatih/color"
 	"github.com/minio/cli"
@@ -36,7 +37,10 @@ var adminServerUpdateCmd = cli.Command{
   {{.HelpName}} - {{.Usage}}
 
 USAGE:
-  {{.HelpName}} TARGET
+  {{.HelpName}} TARGET [UPDATEURL]
+
+UPDATEURL:
+  URL of the MinIO server release to update to, the latest release by default.
 
 FLAGS:
   {{range .VisibleFlags}}{{.}}
@@ -47,6 +51,9 @@ EXAMPLES:
 
   2. Update all MinIO servers in a distributed setup, represented by its alias 'mydist'.
      $ {{.HelpName}} mydist/
+
+  3. Update all MinIO servers of 'mydist' to a given release.
+     $ {{.HelpName}} mydist/ https://dl.min.io/server/minio/release/linux-amd64/minio.RELEASE.2019-10-12T01-39-57Z.sha256sum
 `,
 }
 
@@ -113,5 +120,15 @@ func mainAdminServerUpdate(ctx *cli.Context) error {
 		CurrentVersion: us.CurrentVersion,
 		UpdatedVersion: us.UpdatedVersion,
 	})
+	if us.CurrentVersion == us.UpdatedVersion {
+		return nil
+	}
+
+	// Servers restart with the new binary, report the version each
+	// one came back with.
+	console.SetColor("ServiceOnline", color.New(color.FgGreen, color.Bold))
+	console.SetColor("ServiceOffline", color.New(color.FgRed, color.Bold))
+	time.Sleep(6 * time.Second)
+	printServiceStatus(client, aliasedURL)
 	return nil
 }
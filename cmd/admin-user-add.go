@@ -27,23 +27,30 @@ import (
 	"github.com/minio/mc/pkg/probe"
 )
 
+var adminUserAddFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:  "policy",
+		Usage: "attach this policy to the new user",
+	},
+}
+
 var adminUserAddCmd = cli.Command{
 	Name:   "add",
 	Usage:  "add a new user",
 	Action: mainAdminUserAdd,
 	Before: setGlobalsFromContext,
-	Flags:  globalFlags,
+	Flags:  append(adminUserAddFlags, globalFlags...),
 	CustomHelpTemplate: `NAME:
   {{.HelpName}} - {{.Usage}}
 
 USAGE:
-  {{.HelpName}} TARGET ACCESSKEY SECRETKEY
+  {{.HelpName}} [FLAGS] TARGET ACCESSKEY [SECRETKEY]
 
 ACCESSKEY:
   Also called as username.
 
 SECRETKEY:
-  Also called as password.
+  Also called as password, prompted for when omitted.
 
 FLAGS:
   {{range .VisibleFlags}}{{.}}
@@ -53,12 +60,15 @@ EXAMPLES:
      $ set +o history
      $ {{.HelpName}} myminio foobar foo12345
      $ set -o history
+
+  2. Add a new user 'james' with the 'readwrite' policy, prompting for the secret key.
+     $ {{.HelpName}} --policy readwrite myminio james
 `,
 }
 
 // checkAdminUserAddSyntax - validate all the passed arguments
 func checkAdminUserAddSyntax(ctx *cli.Context) {
-	if len(ctx.Args()) != 3 {
+	if len(ctx.Args()) != 2 && len(ctx.Args()) != 3 {
 		cli.ShowCommandHelpAndExit(ctx, "add", 1) // last argument is exit code
 	}
 }
@@ -129,12 +139,29 @@ func mainAdminUserAdd(ctx *cli.Context) error {
 	client, err := newAdminClient(aliasedURL)
 	fatalIf(err, "Unable to initialize admin connection.")
 
-	fatalIf(probe.NewError(client.AddUser(args.Get(1), args.Get(2))).Trace(args...), "Cannot add new user")
+	accessKey, secretKey := args.Get(1), args.Get(2)
+	if len(args) == 2 {
+		// Keep the secret key out of the shell history.
+		secretKey, err = readPassword("", "Enter secret key: ")
+		fatalIf(err, "Unable to read the secret key.")
+	}
+
+	fatalIf(probe.NewError(client.AddUser(accessKey, secretKey)).Trace(aliasedURL, accessKey), "Cannot add new user")
+	// Secret keys are never recorded.
+	recordAdminHistory(aliasedURL, "user add", []string{accessKey}, "")
+
+	policyName := ctx.String("policy")
+	if policyName != "" {
+		fatalIf(probe.NewError(client.SetPolicy(policyName, accessKey, false)).Trace(aliasedURL, accessKey, policyName),
+			"Cannot set policy `"+policyName+"` on the new user")
+		recordAdminHistory(aliasedURL, "policy set", []string{policyName, "user=" + accessKey}, "")
+	}
 
 	printMsg(userMessage{
 		op:         "add",
-		AccessKey:  args.Get(1),
-		SecretKey:  args.Get(2),
+		AccessKey:  accessKey,
+		SecretKey:  secretKey,
+		PolicyName: policyName,
 		UserStatus: "enabled",
 	})
 
@@ -68,6 +68,7 @@ func mainAdminUserDisable(ctx *cli.Context) error {
 
 	e := client.SetUserStatus(args.Get(1), madmin.AccountDisabled)
 	fatalIf(probe.NewError(e).Trace(args...), "Cannot disable user")
+	recordAdminHistory(aliasedURL, "user disable", []string{args.Get(1)}, "")
 
 	printMsg(userMessage{
 		op:        "disable",
@@ -68,6 +68,7 @@ func mainAdminUserEnable(ctx *cli.Context) error {
 
 	e := client.SetUserStatus(args.Get(1), madmin.AccountEnabled)
 	fatalIf(probe.NewError(e).Trace(args...), "Cannot enable user")
+	recordAdminHistory(aliasedURL, "user enable", []string{args.Get(1)}, "")
 
 	printMsg(userMessage{
 		op:        "enable",
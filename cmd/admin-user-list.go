@@ -17,6 +17,8 @@
 package cmd
 
 import (
+	"sort"
+
 	"github.com/fatih/color"
 	"github.com/minio/cli"
 	"github.com/minio/mc/pkg/console"
@@ -72,12 +74,17 @@ func mainAdminUserList(ctx *cli.Context) error {
 	users, e := client.ListUsers()
 	fatalIf(probe.NewError(e).Trace(args...), "Cannot list user")
 
-	for k, v := range users {
+	accessKeys := make([]string, 0, len(users))
+	for k := range users {
+		accessKeys = append(accessKeys, k)
+	}
+	sort.Strings(accessKeys)
+	for _, k := range accessKeys {
 		printMsg(userMessage{
 			op:         "list",
 			AccessKey:  k,
-			PolicyName: v.PolicyName,
-			UserStatus: string(v.Status),
+			PolicyName: users[k].PolicyName,
+			UserStatus: string(users[k].Status),
 		})
 	}
 	return nil
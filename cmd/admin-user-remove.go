@@ -67,6 +67,7 @@ func mainAdminUserRemove(ctx *cli.Context) error {
 
 	e := client.RemoveUser(args.Get(1))
 	fatalIf(probe.NewError(e).Trace(args...), "Cannot remove new user")
+	recordAdminHistory(aliasedURL, "user remove", []string{args.Get(1)}, "")
 
 	printMsg(userMessage{
 		op:        "remove",
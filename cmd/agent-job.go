@@ -0,0 +1,244 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/minio/mc/pkg/probe"
+)
+
+// Job types and states reported by the agent.
+const (
+	agentJobCopy   = "cp"
+	agentJobMirror = "mirror"
+
+	agentJobRunning   = "running"
+	agentJobCompleted = "completed"
+	agentJobFailed    = "failed"
+	agentJobCanceled  = "canceled"
+)
+
+// agentMaxJobErrors is the number of errors kept per job.
+const agentMaxJobErrors = 100
+
+// agentJobRequest is the body of a job submission.
+type agentJobRequest struct {
+	Type      string   `json:"type"`
+	Sources   []string `json:"sources"`
+	Target    string   `json:"target"`
+	Recursive bool     `json:"recursive,omitempty"`
+	Overwrite bool     `json:"overwrite,omitempty"`
+	Remove    bool     `json:"remove,omitempty"`
+}
+
+// validate checks a job submission.
+func (r agentJobRequest) validate() *probe.Error {
+	switch r.Type {
+	case agentJobCopy:
+		if r.Overwrite || r.Remove {
+			return probe.NewError(errors.New("overwrite and remove are only valid for mirror jobs"))
+		}
+	case agentJobMirror:
+		if len(r.Sources) != 1 {
+			return probe.NewError(errors.New("mirror jobs take exactly one source"))
+		}
+		if r.Recursive {
+			return probe.NewError(errors.New("recursive is only valid for cp jobs, mirror is always recursive"))
+		}
+	default:
+		return probe.NewError(errors.New("unknown job type `" + r.Type + "`, supported types are [cp, mirror]"))
+	}
+	if len(r.Sources) == 0 || r.Target == "" {
+		return probe.NewError(errors.New("a job needs sources and a target"))
+	}
+	for _, source := range append(r.Sources, r.Target) {
+		if source == "" || source == "-" {
+			return probe.NewError(errors.New("`" + source + "` is not a valid source or target"))
+		}
+	}
+	return nil
+}
+
+// agentJobStatus is the state of a job as reported by the agent.
+type agentJobStatus struct {
+	ID string `json:"id"`
+	agentJobRequest
+	State              string     `json:"state"`
+	StartTime          time.Time  `json:"startTime"`
+	EndTime            *time.Time `json:"endTime,omitempty"`
+	TotalObjects       int64      `json:"totalObjects"`
+	TotalBytes         int64      `json:"totalBytes"`
+	TransferredObjects int64      `json:"transferredObjects"`
+	TransferredBytes   int64      `json:"transferredBytes"`
+	Errors             []string   `json:"errors,omitempty"`
+}
+
+// agentJob is a copy or mirror running in the agent.
+type agentJob struct {
+	mutex    sync.Mutex
+	status   agentJobStatus
+	cancel   context.CancelFunc
+	parallel *ParallelManager
+}
+
+// newAgentJob starts a job for the request.
+func newAgentJob(request agentJobRequest, encKeyDB map[string][]prefixSSEPair) *agentJob {
+	ctx, cancel := context.WithCancel(context.Background())
+	j := &agentJob{
+		status: agentJobStatus{
+			ID:              newRandomID(8),
+			agentJobRequest: request,
+			State:           agentJobRunning,
+			StartTime:       UTCNow(),
+		},
+		cancel: cancel,
+	}
+	go j.run(ctx, encKeyDB)
+	return j
+}
+
+// Status returns a snapshot of the job state.
+func (j *agentJob) Status() agentJobStatus {
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+
+	status := j.status
+	status.Errors = append([]string(nil), j.status.Errors...)
+	if j.parallel != nil {
+		status.TransferredBytes = atomic.LoadInt64(&j.parallel.sentBytes)
+	}
+	return status
+}
+
+// Cancel stops a running job, finished jobs are not affected.
+func (j *agentJob) Cancel() {
+	j.cancel()
+}
+
+func (j *agentJob) isRunning() bool {
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+
+	return j.status.State == agentJobRunning
+}
+
+// run copies or mirrors all objects of the job with parallel workers.
+func (j *agentJob) run(ctx context.Context, encKeyDB map[string][]prefixSSEPair) {
+	request := j.status.agentJobRequest
+
+	var URLsCh <-chan URLs
+	if request.Type == agentJobMirror {
+		URLsCh = prepareMirrorURLs(request.Sources[0], request.Target, false, request.Overwrite, request.Remove, false, nil, encKeyDB)
+	} else {
+		URLsCh = prepareCopyURLs(request.Sources, request.Target, request.Recursive, encKeyDB)
+	}
+
+	statusCh := make(chan URLs)
+	parallel, queueCh := newParallelManager(statusCh)
+	j.mutex.Lock()
+	j.parallel = parallel
+	j.mutex.Unlock()
+
+	go func() {
+		defer func() {
+			close(queueCh)
+			parallel.wait()
+			close(statusCh)
+		}()
+
+		for sURLs := range URLsCh {
+			// Drain the remaining URLs of a canceled job.
+			if ctx.Err() != nil {
+				continue
+			}
+			sURLs := sURLs
+			switch {
+			case sURLs.Error != nil:
+				statusCh <- sURLs
+			case sURLs.SourceContent != nil:
+				j.addTotal(sURLs.SourceContent.Size)
+				sURLs.TargetContent.Metadata = make(map[string]string)
+				sURLs.TargetContent.UserMetadata = make(map[string]string)
+				queueCh <- func() URLs {
+					return uploadSourceToTargetURL(ctx, sURLs, parallel, encKeyDB)
+				}
+			case sURLs.TargetContent != nil && request.Remove:
+				j.addTotal(0)
+				queueCh <- func() URLs {
+					return removeMirrorTarget(sURLs)
+				}
+			}
+		}
+	}()
+
+	for sURLs := range statusCh {
+		if sURLs.Error != nil {
+			if ctx.Err() == nil && !isErrIgnored(sURLs.Error) {
+				j.addError(sURLs)
+			}
+			continue
+		}
+		j.mutex.Lock()
+		j.status.TransferredObjects++
+		j.mutex.Unlock()
+	}
+
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+
+	endTime := UTCNow()
+	j.status.EndTime = &endTime
+	switch {
+	case ctx.Err() != nil:
+		j.status.State = agentJobCanceled
+	case len(j.status.Errors) > 0:
+		j.status.State = agentJobFailed
+	default:
+		j.status.State = agentJobCompleted
+	}
+	j.cancel()
+}
+
+func (j *agentJob) addTotal(size int64) {
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+
+	j.status.TotalObjects++
+	j.status.TotalBytes += size
+}
+
+func (j *agentJob) addError(sURLs URLs) {
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+
+	if len(j.status.Errors) >= agentMaxJobErrors {
+		return
+	}
+	msg := sURLs.Error.ToGoError().Error()
+	switch {
+	case sURLs.SourceContent != nil:
+		msg = "Failed to copy `" + sURLs.SourceContent.URL.String() + "`: " + msg
+	case sURLs.TargetContent != nil:
+		msg = "Failed to remove `" + sURLs.TargetContent.URL.String() + "`: " + msg
+	}
+	j.status.Errors = append(j.status.Errors, msg)
+}
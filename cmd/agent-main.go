@@ -0,0 +1,376 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	jsoncolor "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/console"
+	"github.com/minio/mc/pkg/probe"
+)
+
+var (
+	agentFlags = []cli.Flag{
+		cli.StringFlag{
+			Name:  "address",
+			Value: "127.0.0.1:9393",
+			Usage: "address to listen on",
+		},
+		cli.StringFlag{
+			Name:   "token",
+			Usage:  "require clients to send this bearer token, mandatory when not listening on a loopback address",
+			EnvVar: "MC_AGENT_TOKEN",
+		},
+	}
+)
+
+// Run mc as a daemon driven over HTTP.
+var agentCmd = cli.Command{
+	Name:   "agent",
+	Usage:  "serve ls, cp and mirror over a local REST API",
+	Action: mainAgent,
+	Before: setGlobalsFromContext,
+	Flags:  append(append(agentFlags, ioFlags...), globalFlags...),
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [FLAGS]
+{{if .VisibleFlags}}
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}{{end}}
+API:
+  GET    /v1/ls?target=TARGET[&recursive=true]  list objects, as 'mc ls --json' entries
+  GET    /v1/jobs                               list all jobs
+  GET    /v1/scheduled                          list the jobs scheduled with 'mc job schedule'
+  POST   /v1/jobs                               submit a cp or mirror job
+  GET    /v1/jobs/ID                            show the state and progress of a job
+  DELETE /v1/jobs/ID                            cancel a running job, or forget a finished one
+
+  A job is submitted as a JSON object with the fields "type" ("cp" or "mirror"),
+  "sources", "target" and, for cp, "recursive" or, for mirror, "overwrite" and "remove".
+  Jobs are kept in memory until they are deleted or the agent exits.
+
+  The agent also runs the jobs scheduled with 'mc job schedule' when they are due.
+
+ENVIRONMENT VARIABLES:
+  MC_AGENT_TOKEN:  bearer token required from clients
+  MC_ENCRYPT_KEY:  list of comma delimited prefix=secret values
+
+EXAMPLES:
+  1. Start the agent on the default address 127.0.0.1:9393.
+     $ {{.HelpName}}
+
+  2. Submit a recursive copy and follow its progress.
+     $ curl -d '{"type":"cp","sources":["play/mybucket/photos"],"target":"/tmp/photos","recursive":true}' http://127.0.0.1:9393/v1/jobs
+     $ curl http://127.0.0.1:9393/v1/jobs/WRlhMf9g
+
+  3. Serve the API on all interfaces, requiring a token.
+     $ MC_AGENT_TOKEN=secret {{.HelpName}} --address :9393
+     $ curl -H "Authorization: Bearer secret" http://agent.example.com:9393/v1/jobs
+`,
+}
+
+// agentMessage is printed when the agent is ready.
+type agentMessage struct {
+	Status  string `json:"status"`
+	Address string `json:"address"`
+}
+
+func (a agentMessage) String() string {
+	return console.Colorize("Agent", "Agent listening on http://"+a.Address+"/v1/")
+}
+
+func (a agentMessage) JSON() string {
+	a.Status = "success"
+	jsonMessageBytes, e := jsoncolor.MarshalIndent(a, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(jsonMessageBytes)
+}
+
+// agentErrorResponse is the body of all failed API requests.
+type agentErrorResponse struct {
+	Error string `json:"error"`
+}
+
+// agentServer serves the agent REST API.
+type agentServer struct {
+	token    string
+	encKeyDB map[string][]prefixSSEPair
+
+	mutex sync.Mutex
+	jobs  map[string]*agentJob
+}
+
+func newAgentServer(token string, encKeyDB map[string][]prefixSSEPair) *agentServer {
+	return &agentServer{
+		token:    token,
+		encKeyDB: encKeyDB,
+		jobs:     make(map[string]*agentJob),
+	}
+}
+
+func (s *agentServer) writeJSON(w http.ResponseWriter, statusCode int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(v)
+}
+
+func (s *agentServer) writeError(w http.ResponseWriter, statusCode int, err *probe.Error) {
+	s.writeJSON(w, statusCode, agentErrorResponse{Error: err.ToGoError().Error()})
+}
+
+// ServeHTTP - implements http.Handler.
+func (s *agentServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if s.token != "" {
+		token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if subtle.ConstantTimeCompare([]byte(token), []byte(s.token)) != 1 {
+			s.writeError(w, http.StatusUnauthorized, probe.NewError(errors.New("missing or invalid bearer token")))
+			return
+		}
+	}
+
+	switch {
+	case r.URL.Path == "/v1/ls" && r.Method == http.MethodGet:
+		s.listHandler(w, r)
+	case r.URL.Path == "/v1/jobs" && r.Method == http.MethodGet:
+		s.listJobsHandler(w, r)
+	case r.URL.Path == "/v1/scheduled" && r.Method == http.MethodGet:
+		s.listScheduledHandler(w, r)
+	case r.URL.Path == "/v1/jobs" && r.Method == http.MethodPost:
+		s.submitJobHandler(w, r)
+	case strings.HasPrefix(r.URL.Path, "/v1/jobs/") && r.Method == http.MethodGet:
+		s.jobHandler(w, r)
+	case strings.HasPrefix(r.URL.Path, "/v1/jobs/") && r.Method == http.MethodDelete:
+		s.deleteJobHandler(w, r)
+	default:
+		s.writeError(w, http.StatusNotFound, probe.NewError(errors.New("unknown API `"+r.Method+" "+r.URL.Path+"`")))
+	}
+}
+
+// listHandler lists a target like 'mc ls --json'.
+func (s *agentServer) listHandler(w http.ResponseWriter, r *http.Request) {
+	target := r.URL.Query().Get("target")
+	if target == "" {
+		s.writeError(w, http.StatusBadRequest, probe.NewError(errors.New("missing target")))
+		return
+	}
+	isRecursive := r.URL.Query().Get("recursive") == "true"
+
+	clnt, err := newClient(target)
+	if err != nil {
+		s.writeError(w, http.StatusBadRequest, err.Trace(target))
+		return
+	}
+
+	contents := []contentMessage{}
+	for content := range clnt.List(isRecursive, false, DirNone) {
+		if content.Err != nil {
+			if err == nil {
+				err = content.Err.Trace(target)
+			}
+			continue
+		}
+		msg := parseContent(content)
+		msg.Status = "success"
+		contents = append(contents, msg)
+	}
+	if err != nil {
+		s.writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	s.writeJSON(w, http.StatusOK, contents)
+}
+
+func (s *agentServer) listJobsHandler(w http.ResponseWriter, r *http.Request) {
+	s.mutex.Lock()
+	jobs := make([]agentJobStatus, 0, len(s.jobs))
+	for _, job := range s.jobs {
+		jobs = append(jobs, job.Status())
+	}
+	s.mutex.Unlock()
+
+	sort.Slice(jobs, func(i, j int) bool {
+		return jobs[i].StartTime.Before(jobs[j].StartTime)
+	})
+	s.writeJSON(w, http.StatusOK, jobs)
+}
+
+// listScheduledHandler lists the scheduled jobs like 'mc job list --json'.
+func (s *agentServer) listScheduledHandler(w http.ResponseWriter, r *http.Request) {
+	jobs, err := loadScheduledJobs()
+	if err != nil {
+		s.writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	msgs := []jobMessage{}
+	for _, job := range sortedScheduledJobs(jobs) {
+		msg := newJobMessage("list", job)
+		msg.Status = "success"
+		msgs = append(msgs, msg)
+	}
+	s.writeJSON(w, http.StatusOK, msgs)
+}
+
+func (s *agentServer) submitJobHandler(w http.ResponseWriter, r *http.Request) {
+	var request agentJobRequest
+	if e := json.NewDecoder(r.Body).Decode(&request); e != nil {
+		s.writeError(w, http.StatusBadRequest, probe.NewError(e))
+		return
+	}
+	if err := request.validate(); err != nil {
+		s.writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	job := newAgentJob(request, s.encKeyDB)
+	status := job.Status()
+	s.mutex.Lock()
+	s.jobs[status.ID] = job
+	s.mutex.Unlock()
+	s.writeJSON(w, http.StatusCreated, status)
+}
+
+func (s *agentServer) getJob(w http.ResponseWriter, r *http.Request) *agentJob {
+	id := strings.TrimPrefix(r.URL.Path, "/v1/jobs/")
+	s.mutex.Lock()
+	job, ok := s.jobs[id]
+	s.mutex.Unlock()
+	if !ok {
+		s.writeError(w, http.StatusNotFound, probe.NewError(errors.New("no job `"+id+"`")))
+		return nil
+	}
+	return job
+}
+
+func (s *agentServer) jobHandler(w http.ResponseWriter, r *http.Request) {
+	if job := s.getJob(w, r); job != nil {
+		s.writeJSON(w, http.StatusOK, job.Status())
+	}
+}
+
+func (s *agentServer) deleteJobHandler(w http.ResponseWriter, r *http.Request) {
+	job := s.getJob(w, r)
+	if job == nil {
+		return
+	}
+	if job.isRunning() {
+		job.Cancel()
+	} else {
+		s.mutex.Lock()
+		delete(s.jobs, job.Status().ID)
+		s.mutex.Unlock()
+	}
+	s.writeJSON(w, http.StatusOK, job.Status())
+}
+
+// cancelJobs cancels all running jobs.
+func (s *agentServer) cancelJobs() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for _, job := range s.jobs {
+		job.Cancel()
+	}
+}
+
+// isLoopbackAddress returns true if the listen address only accepts local connections.
+func isLoopbackAddress(address string) bool {
+	host, _, e := net.SplitHostPort(address)
+	if e != nil {
+		return false
+	}
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// checkAgentSyntax - validate all the passed arguments
+func checkAgentSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) != 0 {
+		cli.ShowCommandHelpAndExit(ctx, "agent", 1) // last argument is exit code
+	}
+	address := ctx.String("address")
+	if _, _, e := net.SplitHostPort(address); e != nil {
+		fatalIf(probe.NewError(e).Trace(address), "Invalid address `"+address+"`.")
+	}
+	if ctx.String("token") == "" && !isLoopbackAddress(address) {
+		fatalIf(errInvalidArgument().Trace(address), "A token is required to listen on the non loopback address `"+address+"`.")
+	}
+}
+
+// mainAgent is the handle for "mc agent" command.
+func mainAgent(ctx *cli.Context) error {
+	console.SetColor("Agent", color.New(color.FgGreen, color.Bold))
+	console.SetColor("Time", color.New(color.FgGreen))
+	console.SetColor("Job", color.New(color.FgCyan, color.Bold))
+	console.SetColor("JobSuccess", color.New(color.FgGreen))
+	console.SetColor("JobFailure", color.New(color.FgRed))
+
+	checkAgentSyntax(ctx)
+
+	// Parse encryption keys per command.
+	encKeyDB, err := getEncKeys(ctx)
+	fatalIf(err, "Unable to parse encryption keys.")
+
+	address := ctx.String("address")
+	listener, e := net.Listen("tcp", address)
+	fatalIf(probe.NewError(e).Trace(address), "Unable to listen on `"+address+"`.")
+
+	agent := newAgentServer(ctx.String("token"), encKeyDB)
+	server := &http.Server{Handler: agent}
+	trapCh := signalTrap(os.Interrupt, syscall.SIGTERM)
+
+	serveCh := make(chan error, 1)
+	go func() {
+		serveCh <- server.Serve(listener)
+	}()
+	printMsg(agentMessage{Address: listener.Addr().String()})
+
+	doneCh := make(chan struct{})
+	defer close(doneCh)
+	go runScheduledJobsEvery(time.Minute, doneCh)
+
+	select {
+	case e = <-serveCh:
+		fatalIf(probe.NewError(e), "Unable to serve the agent API.")
+	case <-trapCh:
+		agent.cancelJobs()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		e = server.Shutdown(shutdownCtx)
+		fatalIf(probe.NewError(e), "Unable to shut down the agent.")
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestAgentJobRequestValidate(t *testing.T) {
+	testCases := []struct {
+		request agentJobRequest
+		success bool
+	}{
+		{agentJobRequest{Type: "cp", Sources: []string{"play/bucket/a"}, Target: "/tmp/a"}, true},
+		{agentJobRequest{Type: "cp", Sources: []string{"play/bucket/a", "play/bucket/b"}, Target: "/tmp", Recursive: true}, true},
+		{agentJobRequest{Type: "mirror", Sources: []string{"play/bucket"}, Target: "/tmp/bucket", Overwrite: true, Remove: true}, true},
+		{agentJobRequest{Type: "mv", Sources: []string{"play/bucket/a"}, Target: "/tmp/a"}, false},
+		{agentJobRequest{Type: "cp", Target: "/tmp/a"}, false},
+		{agentJobRequest{Type: "cp", Sources: []string{"play/bucket/a"}}, false},
+		{agentJobRequest{Type: "cp", Sources: []string{"-"}, Target: "/tmp/a"}, false},
+		{agentJobRequest{Type: "cp", Sources: []string{"play/bucket/a"}, Target: "/tmp/a", Remove: true}, false},
+		{agentJobRequest{Type: "mirror", Sources: []string{"play/a", "play/b"}, Target: "/tmp/a"}, false},
+		{agentJobRequest{Type: "mirror", Sources: []string{"play/a"}, Target: "/tmp/a", Recursive: true}, false},
+	}
+	for i, testCase := range testCases {
+		err := testCase.request.validate()
+		if testCase.success && err != nil {
+			t.Errorf("Test %d: unexpected error %v", i+1, err.ToGoError())
+		}
+		if !testCase.success && err == nil {
+			t.Errorf("Test %d: expected an error", i+1)
+		}
+	}
+}
+
+func TestIsLoopbackAddress(t *testing.T) {
+	testCases := []struct {
+		address  string
+		loopback bool
+	}{
+		{"127.0.0.1:9393", true},
+		{"localhost:9393", true},
+		{"[::1]:9393", true},
+		{":9393", false},
+		{"0.0.0.0:9393", false},
+		{"192.168.1.10:9393", false},
+		{"127.0.0.1", false},
+	}
+	for i, testCase := range testCases {
+		if loopback := isLoopbackAddress(testCase.address); loopback != testCase.loopback {
+			t.Errorf("Test %d: expected %v for %q, got %v", i+1, testCase.loopback, testCase.address, loopback)
+		}
+	}
+}
+
+func TestAgentServer(t *testing.T) {
+	agent := newAgentServer("secret", nil)
+	testCases := []struct {
+		method, path, token, body string
+		statusCode                int
+	}{
+		{"GET", "/v1/jobs", "", "", http.StatusUnauthorized},
+		{"GET", "/v1/jobs", "wrong", "", http.StatusUnauthorized},
+		{"GET", "/v1/jobs", "secret", "", http.StatusOK},
+		{"GET", "/v1/jobs/unknown", "secret", "", http.StatusNotFound},
+		{"DELETE", "/v1/jobs/unknown", "secret", "", http.StatusNotFound},
+		{"POST", "/v1/jobs", "secret", "{", http.StatusBadRequest},
+		{"POST", "/v1/jobs", "secret", `{"type":"mv","sources":["a"],"target":"b"}`, http.StatusBadRequest},
+		{"GET", "/v1/ls", "secret", "", http.StatusBadRequest},
+		{"PUT", "/v1/jobs", "secret", "", http.StatusNotFound},
+		{"GET", "/v2/jobs", "secret", "", http.StatusNotFound},
+	}
+	for i, testCase := range testCases {
+		req := httptest.NewRequest(testCase.method, testCase.path, strings.NewReader(testCase.body))
+		if testCase.token != "" {
+			req.Header.Set("Authorization", "Bearer "+testCase.token)
+		}
+		rec := httptest.NewRecorder()
+		agent.ServeHTTP(rec, req)
+		if rec.Code != testCase.statusCode {
+			t.Errorf("Test %d: expected status %d, got %d: %s", i+1, testCase.statusCode, rec.Code, rec.Body.String())
+		}
+		if ctype := rec.Header().Get("Content-Type"); ctype != "application/json" {
+			t.Errorf("Test %d: expected JSON response, got %q", i+1, ctype)
+		}
+	}
+}
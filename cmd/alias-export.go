@@ -0,0 +1,188 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sort"
+
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	jsoncolor "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/console"
+	"github.com/minio/mc/pkg/probe"
+)
+
+var aliasExportFlags = []cli.Flag{
+	cli.BoolFlag{
+		Name:  "encrypt",
+		Usage: "encrypt secret keys with a password",
+	},
+}
+
+var aliasExportCmd = cli.Command{
+	Name:            "export",
+	Usage:           "export aliases to a file",
+	Action:          mainAliasExport,
+	Before:          setGlobalsFromContext,
+	Flags:           append(aliasExportFlags, globalFlags...),
+	HideHelpCommand: true,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [FLAGS] FILE [ALIAS...]
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+DESCRIPTION:
+  Export all or the given aliases to FILE, or to stdout if FILE is '-', to set them up
+  on other hosts with 'mc alias import'. Credentials of profiles are not exported.
+
+  Secret keys are exported as is unless --encrypt is given. The password is read from
+  the MC_ALIAS_PASSWORD environment variable, or from the terminal.
+
+EXAMPLES:
+  1. Export all aliases with encrypted secret keys.
+     $ {{.HelpName}} --encrypt aliases.json
+
+  2. Export "myminio" and "mys3" aliases to stdout.
+     $ {{.HelpName}} - myminio mys3
+`,
+}
+
+// aliasExportMessage container for alias export messages.
+type aliasExportMessage struct {
+	Status    string   `json:"status"`
+	File      string   `json:"file"`
+	Aliases   []string `json:"aliases"`
+	Encrypted bool     `json:"encrypted"`
+}
+
+// String colorized alias export message.
+func (a aliasExportMessage) String() string {
+	return console.Colorize("AliasMessage", fmt.Sprintf("Exported %d alias(es) to `%s`.", len(a.Aliases), a.File))
+}
+
+// JSON jsonified alias export message.
+func (a aliasExportMessage) JSON() string {
+	a.Status = "success"
+	jsonMessageBytes, e := jsoncolor.MarshalIndent(a, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(jsonMessageBytes)
+}
+
+// checkAliasExportSyntax - verifies input arguments to 'alias export'.
+func checkAliasExportSyntax(ctx *cli.Context) {
+	args := ctx.Args()
+	if len(args) < 1 {
+		cli.ShowCommandHelpAndExit(ctx, "export", 1) // last argument is exit code
+	}
+	for _, alias := range args.Tail() {
+		if !isValidAlias(alias) {
+			fatalIf(errInvalidAlias(alias), "Invalid alias.")
+		}
+	}
+}
+
+// exportAliases - returns the export of the given aliases, or of all
+// aliases if none is given. Secret keys are encrypted with password
+// unless it is empty.
+func exportAliases(cfg *configV9, aliases []string, password string) (aliasExport, *probe.Error) {
+	export := aliasExport{
+		Version:   aliasExportVersion,
+		Encrypted: password != "",
+		Aliases:   make(map[string]hostConfigV9),
+	}
+	if len(aliases) == 0 {
+		for alias := range cfg.Hosts {
+			aliases = append(aliases, alias)
+		}
+	}
+	for _, alias := range aliases {
+		hostCfg, ok := cfg.Hosts[alias]
+		if !ok {
+			return export, errNoMatchingHost(alias).Trace(alias)
+		}
+		secretKey, err := decryptConfigSecret(hostCfg.SecretKey)
+		if err != nil {
+			return export, err.Trace(alias)
+		}
+		hostCfg.SecretKey = secretKey
+		// Encryption keys are sealed with the config password, they
+		// are not exported.
+		hostCfg.EncryptKeys = nil
+		if password != "" && hostCfg.SecretKey != "" {
+			secretKey, err = encryptSecret(password, hostCfg.SecretKey)
+			if err != nil {
+				return export, err.Trace(alias)
+			}
+			hostCfg.SecretKey = secretKey
+		}
+		export.Aliases[alias] = hostCfg
+	}
+	return export, nil
+}
+
+// mainAliasExport is the handle for "mc alias export" command.
+func mainAliasExport(ctx *cli.Context) error {
+	checkAliasExportSyntax(ctx)
+
+	console.SetColor("AliasMessage", color.New(color.FgGreen))
+
+	file := ctx.Args().Get(0)
+	conf, err := loadMcConfig()
+	fatalIf(err.Trace(globalMCConfigVersion), "Unable to load config version `"+globalMCConfigVersion+"`.")
+
+	var password string
+	if ctx.Bool("encrypt") {
+		password, err = readPassword(mcEnvAliasPassword, "Password: ")
+		fatalIf(err, "Unable to read password.")
+		if password == "" {
+			fatalIf(errInvalidArgument(), "Password cannot be empty.")
+		}
+	}
+
+	export, err := exportAliases(conf, ctx.Args().Tail(), password)
+	fatalIf(err, "Unable to export aliases.")
+
+	exportBytes, e := json.MarshalIndent(export, "", "  ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	exportBytes = append(exportBytes, '\n')
+
+	if file == "-" {
+		_, e = os.Stdout.Write(exportBytes)
+		fatalIf(probe.NewError(e), "Unable to write aliases.")
+		return nil
+	}
+	// Exports hold credentials, keep them private.
+	e = ioutil.WriteFile(file, exportBytes, 0600)
+	fatalIf(probe.NewError(e).Trace(file), "Unable to write aliases to `"+file+"`.")
+
+	var aliases []string
+	for alias := range export.Aliases {
+		aliases = append(aliases, alias)
+	}
+	sort.Strings(aliases)
+	printMsg(aliasExportMessage{File: file, Aliases: aliases, Encrypted: export.Encrypted})
+	return nil
+}
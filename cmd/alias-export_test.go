@@ -0,0 +1,82 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestAliasExportImport(t *testing.T) {
+	src := newConfigV9()
+	src.Hosts["myminio"] = hostConfigV9{URL: "http://localhost:9000", AccessKey: "minio", SecretKey: "minio123", API: "S3v4", Lookup: "auto"}
+	src.Hosts["anon"] = hostConfigV9{URL: "https://play.min.io", API: "S3v4", Lookup: "auto"}
+
+	testCases := []struct {
+		password     string
+		skipExisting bool
+		imported     []string
+		skipped      []string
+	}{
+		{"", false, []string{"anon", "myminio"}, nil},
+		{"secret", false, []string{"anon", "myminio"}, nil},
+		{"secret", true, []string{"myminio"}, []string{"anon"}},
+	}
+
+	for i, testCase := range testCases {
+		export, err := exportAliases(src, nil, testCase.password)
+		if err != nil {
+			t.Fatalf("Test %d: Unable to export aliases: %s", i+1, err)
+		}
+		if secretKey := export.Aliases["myminio"].SecretKey; (secretKey == "minio123") == export.Encrypted {
+			t.Fatalf("Test %d: Unexpected secret key %s", i+1, secretKey)
+		}
+		data, e := json.Marshal(export)
+		if e != nil {
+			t.Fatal(e)
+		}
+		export, err = readAliasExport(data)
+		if err != nil {
+			t.Fatalf("Test %d: Unable to read export: %s", i+1, err)
+		}
+
+		dst := newConfigV9()
+		dst.Hosts["anon"] = hostConfigV9{URL: "http://localhost:9001"}
+		imported, skipped, err := importAliases(dst, export, testCase.password, testCase.skipExisting)
+		if err != nil {
+			t.Fatalf("Test %d: Unable to import aliases: %s", i+1, err)
+		}
+		if !reflect.DeepEqual(imported, testCase.imported) || !reflect.DeepEqual(skipped, testCase.skipped) {
+			t.Fatalf("Test %d: Expected %v %v, got %v %v", i+1, testCase.imported, testCase.skipped, imported, skipped)
+		}
+		if dst.Hosts["myminio"] != src.Hosts["myminio"] {
+			t.Fatalf("Test %d: Expected %+v, got %+v", i+1, src.Hosts["myminio"], dst.Hosts["myminio"])
+		}
+	}
+
+	export, err := exportAliases(src, []string{"myminio"}, "secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err = importAliases(newConfigV9(), export, "wrong", false); err == nil {
+		t.Fatal("Expected import with wrong password to fail")
+	}
+	if _, err = exportAliases(src, []string{"missing"}, ""); err == nil {
+		t.Fatal("Expected export of unknown alias to fail")
+	}
+}
@@ -0,0 +1,182 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sort"
+
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	jsoncolor "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/console"
+	"github.com/minio/mc/pkg/probe"
+)
+
+var aliasImportFlags = []cli.Flag{
+	cli.BoolFlag{
+		Name:  "skip-existing",
+		Usage: "keep aliases which are already configured",
+	},
+}
+
+var aliasImportCmd = cli.Command{
+	Name:            "import",
+	Usage:           "import aliases from a file",
+	Action:          mainAliasImport,
+	Before:          setGlobalsFromContext,
+	Flags:           append(aliasImportFlags, globalFlags...),
+	HideHelpCommand: true,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [FLAGS] FILE
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+DESCRIPTION:
+  Import aliases exported with 'mc alias export' from FILE, or from stdin if FILE
+  is '-'. Imported aliases replace configured aliases of the same name unless
+  --skip-existing is given. The password of encrypted exports is read from the
+  MC_ALIAS_PASSWORD environment variable, or from the terminal.
+
+EXAMPLES:
+  1. Import aliases from a file.
+     $ {{.HelpName}} aliases.json
+
+  2. Import aliases on another host, keeping the ones configured there.
+     $ ssh backup01 "MC_ALIAS_PASSWORD=secret mc alias import --skip-existing -" < aliases.json
+`,
+}
+
+// aliasImportMessage container for alias import messages.
+type aliasImportMessage struct {
+	Status  string   `json:"status"`
+	File    string   `json:"file"`
+	Aliases []string `json:"aliases"`
+	Skipped []string `json:"skipped,omitempty"`
+}
+
+// String colorized alias import message.
+func (a aliasImportMessage) String() string {
+	msg := fmt.Sprintf("Imported %d alias(es) from `%s`", len(a.Aliases), a.File)
+	if len(a.Skipped) > 0 {
+		msg += fmt.Sprintf(", skipped %d existing", len(a.Skipped))
+	}
+	return console.Colorize("AliasMessage", msg+".")
+}
+
+// JSON jsonified alias import message.
+func (a aliasImportMessage) JSON() string {
+	a.Status = "success"
+	jsonMessageBytes, e := jsoncolor.MarshalIndent(a, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(jsonMessageBytes)
+}
+
+// checkAliasImportSyntax - verifies input arguments to 'alias import'.
+func checkAliasImportSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) != 1 {
+		cli.ShowCommandHelpAndExit(ctx, "import", 1) // last argument is exit code
+	}
+}
+
+// readAliasExport - reads and validates an alias export.
+func readAliasExport(data []byte) (aliasExport, *probe.Error) {
+	var export aliasExport
+	if e := json.Unmarshal(data, &export); e != nil {
+		return export, probe.NewError(e)
+	}
+	if export.Version != aliasExportVersion {
+		return export, probe.NewError(errors.New("unsupported alias export version `" + export.Version + "`"))
+	}
+	for alias, hostCfg := range export.Aliases {
+		if !isValidAlias(alias) {
+			return export, errInvalidAlias(alias).Trace(alias)
+		}
+		if !isValidHostURL(hostCfg.URL) {
+			return export, errInvalidURL(hostCfg.URL).Trace(alias)
+		}
+	}
+	return export, nil
+}
+
+// importAliases - adds the aliases of export to cfg, decrypting secret
+// keys with password, and returns the imported and skipped aliases.
+func importAliases(cfg *configV9, export aliasExport, password string, skipExisting bool) (imported, skipped []string, err *probe.Error) {
+	for alias, hostCfg := range export.Aliases {
+		if _, ok := cfg.Hosts[alias]; ok && skipExisting {
+			skipped = append(skipped, alias)
+			continue
+		}
+		if export.Encrypted && hostCfg.SecretKey != "" {
+			if hostCfg.SecretKey, err = decryptSecret(password, hostCfg.SecretKey); err != nil {
+				return nil, nil, err.Trace(alias)
+			}
+		}
+		cfg.Hosts[alias] = hostCfg
+		imported = append(imported, alias)
+	}
+	sort.Strings(imported)
+	sort.Strings(skipped)
+	return imported, skipped, nil
+}
+
+// mainAliasImport is the handle for "mc alias import" command.
+func mainAliasImport(ctx *cli.Context) error {
+	checkAliasImportSyntax(ctx)
+
+	console.SetColor("AliasMessage", color.New(color.FgGreen))
+
+	file := ctx.Args().Get(0)
+	var data []byte
+	var e error
+	if file == "-" {
+		data, e = ioutil.ReadAll(os.Stdin)
+	} else {
+		data, e = ioutil.ReadFile(file)
+	}
+	fatalIf(probe.NewError(e).Trace(file), "Unable to read aliases from `"+file+"`.")
+
+	export, err := readAliasExport(data)
+	fatalIf(err.Trace(file), "Invalid alias export `"+file+"`.")
+
+	var password string
+	if export.Encrypted {
+		password, err = readPassword(mcEnvAliasPassword, "Password: ")
+		fatalIf(err, "Unable to read password.")
+	}
+
+	conf, err := loadMcConfig()
+	fatalIf(err.Trace(globalMCConfigVersion), "Unable to load config version `"+globalMCConfigVersion+"`.")
+
+	imported, skipped, err := importAliases(conf, export, password, ctx.Bool("skip-existing"))
+	fatalIf(err, "Unable to import aliases, check the password.")
+
+	err = saveMcConfig(conf)
+	fatalIf(err.Trace(file), "Unable to update hosts in config version `"+mustGetMcConfigPath()+"`.")
+
+	printMsg(aliasImportMessage{File: file, Aliases: imported, Skipped: skipped})
+	return nil
+}
@@ -0,0 +1,141 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"os"
+
+	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/console"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio/pkg/madmin"
+	"golang.org/x/crypto/ssh/terminal"
+)
+
+// Environment variable holding the password of encrypted alias exports.
+const mcEnvAliasPassword = "MC_ALIAS_PASSWORD"
+
+var aliasCmd = cli.Command{
+	Name:            "alias",
+	Usage:           "set, remove, list, export and import aliases",
+	Action:          mainAlias,
+	Before:          setGlobalsFromContext,
+	Flags:           globalFlags,
+	HideHelpCommand: true,
+	Subcommands: []cli.Command{
+		aliasSetCmd,
+		aliasRemoveCmd,
+		aliasListCmd,
+		aliasExportCmd,
+		aliasImportCmd,
+	},
+}
+
+// 'mc alias set', 'remove' and 'list' are the same as the older
+// 'mc config host add', 'remove' and 'list' commands.
+var (
+	aliasSetCmd = cli.Command{
+		Name:               "set",
+		ShortName:          "s",
+		Usage:              "set a new alias to configuration file",
+		Action:             mainConfigHostAdd,
+		Before:             setGlobalsFromContext,
+		Flags:              append(hostAddFlags, globalFlags...),
+		HideHelpCommand:    true,
+		CustomHelpTemplate: configHostAddCmd.CustomHelpTemplate,
+	}
+	aliasRemoveCmd = cli.Command{
+		Name:               "remove",
+		ShortName:          "rm",
+		Usage:              "remove an alias from configuration file",
+		Action:             mainConfigHostRemove,
+		Before:             setGlobalsFromContext,
+		Flags:              globalFlags,
+		HideHelpCommand:    true,
+		CustomHelpTemplate: configHostRemoveCmd.CustomHelpTemplate,
+	}
+	aliasListCmd = cli.Command{
+		Name:               "list",
+		ShortName:          "ls",
+		Usage:              "list aliases in configuration file",
+		Action:             mainConfigHostList,
+		Before:             setGlobalsFromContext,
+		Flags:              globalFlags,
+		HideHelpCommand:    true,
+		CustomHelpTemplate: configHostListCmd.CustomHelpTemplate,
+	}
+)
+
+// mainAlias is the handle for "mc alias" command.
+func mainAlias(ctx *cli.Context) error {
+	cli.ShowCommandHelp(ctx, ctx.Args().First())
+	return nil
+	// Sub-commands like "set", "list" have their own main.
+}
+
+// aliasExport - contents of an alias export file.
+type aliasExport struct {
+	Version string `json:"version"`
+	// Encrypted is set when the secret keys are encrypted with a password.
+	Encrypted bool                    `json:"encrypted,omitempty"`
+	Aliases   map[string]hostConfigV9 `json:"aliases"`
+}
+
+const aliasExportVersion = "1"
+
+// readPassword reads a password from the environment variable envVar,
+// or prompts for it on a terminal.
+func readPassword(envVar, prompt string) (string, *probe.Error) {
+	if password, ok := os.LookupEnv(envVar); ok && envVar != "" {
+		return password, nil
+	}
+	if !isTerminal() {
+		return "", probe.NewError(errors.New("no terminal to read the password, set " + envVar))
+	}
+	console.Print(prompt)
+	password, e := terminal.ReadPassword(int(os.Stdin.Fd()))
+	console.Println()
+	if e != nil {
+		return "", probe.NewError(e)
+	}
+	return string(password), nil
+}
+
+// encryptSecret encrypts a secret key of an export with password.
+func encryptSecret(password, secret string) (string, *probe.Error) {
+	data, e := madmin.EncryptData(password, []byte(secret))
+	if e != nil {
+		return "", probe.NewError(e)
+	}
+	return base64.StdEncoding.EncodeToString(data), nil
+}
+
+// decryptSecret decrypts a secret key of an export with password.
+func decryptSecret(password, secret string) (string, *probe.Error) {
+	data, e := base64.StdEncoding.DecodeString(secret)
+	if e != nil {
+		return "", probe.NewError(e)
+	}
+	data, e = madmin.DecryptData(password, bytes.NewReader(data))
+	if e != nil {
+		return "", probe.NewError(e)
+	}
+	return string(data), nil
+}
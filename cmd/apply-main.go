@@ -0,0 +1,462 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/console"
+	"github.com/minio/mc/pkg/probe"
+	yaml "gopkg.in/yaml.v2"
+)
+
+var (
+	applyFlags = []cli.Flag{
+		cli.StringFlag{
+			Name:  "file, f",
+			Usage: "path to the YAML spec to apply",
+		},
+		cli.BoolFlag{
+			Name:  "dry-run",
+			Usage: "only print the changes needed to match the spec",
+		},
+	}
+)
+
+// Apply a declarative spec of buckets.
+var applyCmd = cli.Command{
+	Name:   "apply",
+	Usage:  "reconcile buckets with a declarative spec",
+	Action: mainApply,
+	Before: setGlobalsFromContext,
+	Flags:  append(applyFlags, globalFlags...),
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} -f SPEC [FLAGS] TARGET
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+SPEC:
+  buckets:
+  - name: photos
+    region: us-east-1
+    policy: download            # one of none, download, upload, public
+    lifecycle: |                # lifecycle configuration XML
+      <LifecycleConfiguration>...</LifecycleConfiguration>
+    notifications:
+    - arn: arn:minio:sqs::1:webhook
+      events: [put, delete]     # any of put, delete, get
+      prefix: 2019/
+      suffix: .jpg
+
+  Buckets are created if missing, buckets not in the spec are left untouched. Settings
+  omitted from a bucket spec are not managed, an empty 'notifications' list removes all
+  notifications. The changes are printed before they are applied, applying the same
+  spec again changes nothing.
+
+EXAMPLES:
+  1. Print the changes needed for buckets on MinIO cloud storage to match 'buckets.yaml'.
+     $ {{.HelpName}} --dry-run -f buckets.yaml myminio
+
+  2. Apply 'buckets.yaml' to MinIO cloud storage.
+     $ {{.HelpName}} -f buckets.yaml myminio
+`,
+}
+
+// applyNotificationSpec - desired bucket notification.
+type applyNotificationSpec struct {
+	ARN    string   `yaml:"arn" json:"arn"`
+	Events []string `yaml:"events" json:"events"`
+	Prefix string   `yaml:"prefix" json:"prefix,omitempty"`
+	Suffix string   `yaml:"suffix" json:"suffix,omitempty"`
+}
+
+// applyBucketSpec - desired state of a bucket.
+type applyBucketSpec struct {
+	Name          string                  `yaml:"name"`
+	Region        string                  `yaml:"region"`
+	Policy        string                  `yaml:"policy"`
+	Lifecycle     string                  `yaml:"lifecycle"`
+	Notifications []applyNotificationSpec `yaml:"notifications"`
+
+	// Not supported by the S3 API version of this client, listed to
+	// fail loudly instead of silently ignoring them.
+	Versioning interface{} `yaml:"versioning"`
+	Tags       interface{} `yaml:"tags"`
+	Quota      interface{} `yaml:"quota"`
+}
+
+// applySpec - desired state of all managed buckets.
+type applySpec struct {
+	Buckets []applyBucketSpec `yaml:"buckets"`
+}
+
+// parseApplySpec parses and validates a spec.
+func parseApplySpec(data []byte) (*applySpec, *probe.Error) {
+	spec := &applySpec{}
+	if e := yaml.UnmarshalStrict(data, spec); e != nil {
+		return nil, probe.NewError(e)
+	}
+	seen := make(map[string]bool)
+	for _, bucket := range spec.Buckets {
+		if bucket.Name == "" {
+			return nil, probe.NewError(errors.New("bucket name is missing"))
+		}
+		if seen[bucket.Name] {
+			return nil, probe.NewError(errors.New("bucket `" + bucket.Name + "` is listed more than once"))
+		}
+		seen[bucket.Name] = true
+		switch {
+		case bucket.Versioning != nil:
+			return nil, probe.NewError(errors.New("bucket `" + bucket.Name + "`: versioning is not supported yet"))
+		case bucket.Tags != nil:
+			return nil, probe.NewError(errors.New("bucket `" + bucket.Name + "`: tags are not supported yet"))
+		case bucket.Quota != nil:
+			return nil, probe.NewError(errors.New("bucket `" + bucket.Name + "`: quota is not supported yet"))
+		}
+		switch bucket.Policy {
+		case "", "none", "download", "upload", "public":
+		default:
+			return nil, probe.NewError(errors.New("bucket `" + bucket.Name + "`: unknown policy `" + bucket.Policy + "`"))
+		}
+		for _, notification := range bucket.Notifications {
+			if len(strings.Split(notification.ARN, ":")) != 6 {
+				return nil, probe.NewError(errors.New("bucket `" + bucket.Name + "`: invalid notification ARN `" + notification.ARN + "`"))
+			}
+			if len(notification.Events) == 0 {
+				return nil, probe.NewError(errors.New("bucket `" + bucket.Name + "`: notification `" + notification.ARN + "` has no events"))
+			}
+			for _, event := range notification.Events {
+				if _, ok := applyEventTypes[event]; !ok {
+					return nil, probe.NewError(errors.New("bucket `" + bucket.Name + "`: unknown notification event `" + event + "`"))
+				}
+			}
+		}
+	}
+	return spec, nil
+}
+
+// Event names used in specs and their S3 event types.
+var applyEventTypes = map[string]string{
+	"put":    "s3:ObjectCreated:*",
+	"delete": "s3:ObjectRemoved:*",
+	"get":    "s3:ObjectAccessed:*",
+}
+
+// notificationKey returns a key identifying a notification, events are
+// S3 event types.
+func notificationKey(arn string, events []string, prefix, suffix string) string {
+	events = append([]string{}, events...)
+	sort.Strings(events)
+	return strings.Join([]string{arn, strings.Join(events, ","), prefix, suffix}, "|")
+}
+
+// diffNotifications returns the ARNs to remove and the notifications to
+// add for the current notifications to match the desired ones. All
+// notifications of an ARN are removed at once, so ARNs with any
+// difference are removed and all their desired notifications added.
+func diffNotifications(current []notificationConfig, desired []applyNotificationSpec) (removeARNs []string, add []applyNotificationSpec) {
+	currentKeys := make(map[string][]string)
+	for _, config := range current {
+		currentKeys[config.Arn] = append(currentKeys[config.Arn], notificationKey(config.Arn, config.Events, config.Prefix, config.Suffix))
+	}
+	desiredKeys := make(map[string][]string)
+	desiredByARN := make(map[string][]applyNotificationSpec)
+	var arns []string
+	for _, notification := range desired {
+		var events []string
+		for _, event := range notification.Events {
+			events = append(events, applyEventTypes[event])
+		}
+		if _, ok := desiredKeys[notification.ARN]; !ok {
+			arns = append(arns, notification.ARN)
+		}
+		desiredKeys[notification.ARN] = append(desiredKeys[notification.ARN], notificationKey(notification.ARN, events, notification.Prefix, notification.Suffix))
+		desiredByARN[notification.ARN] = append(desiredByARN[notification.ARN], notification)
+	}
+
+	sameKeys := func(a, b []string) bool {
+		if len(a) != len(b) {
+			return false
+		}
+		a, b = append([]string{}, a...), append([]string{}, b...)
+		sort.Strings(a)
+		sort.Strings(b)
+		for i := range a {
+			if a[i] != b[i] {
+				return false
+			}
+		}
+		return true
+	}
+
+	for arn := range currentKeys {
+		if !sameKeys(currentKeys[arn], desiredKeys[arn]) {
+			removeARNs = append(removeARNs, arn)
+		}
+	}
+	sort.Strings(removeARNs)
+	for _, arn := range arns {
+		if !sameKeys(currentKeys[arn], desiredKeys[arn]) {
+			add = append(add, desiredByARN[arn]...)
+		}
+	}
+	return removeARNs, add
+}
+
+// normalizeXML removes the declaration and the whitespace between tags
+// of an XML document, for comparison.
+func normalizeXML(doc string) string {
+	doc = regexp.MustCompile(`<\?xml[^>]*\?>`).ReplaceAllString(doc, "")
+	doc = regexp.MustCompile(`>\s+<`).ReplaceAllString(doc, "><")
+	doc = regexp.MustCompile(` xmlns="[^"]*"`).ReplaceAllString(doc, "")
+	return strings.TrimSpace(doc)
+}
+
+// applyChange - a single change needed to match the spec.
+type applyChange struct {
+	Status   string `json:"status"`
+	Bucket   string `json:"bucket"`
+	Resource string `json:"resource"`
+	Action   string `json:"action"`
+	Detail   string `json:"detail,omitempty"`
+
+	apply func() *probe.Error
+}
+
+// String colorized apply change message.
+func (a applyChange) String() string {
+	var sign string
+	switch a.Action {
+	case "create":
+		sign = console.Colorize("ApplyCreate", "+")
+	case "remove":
+		sign = console.Colorize("ApplyRemove", "-")
+	default:
+		sign = console.Colorize("ApplyUpdate", "~")
+	}
+	msg := fmt.Sprintf("%s %s %s %s", sign, a.Action, a.Resource, console.Colorize("ApplyBucket", a.Bucket))
+	if a.Detail != "" {
+		msg += " (" + a.Detail + ")"
+	}
+	return msg
+}
+
+// JSON jsonified apply change message.
+func (a applyChange) JSON() string {
+	a.Status = "success"
+	applyChangeBytes, e := json.MarshalIndent(a, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(applyChangeBytes)
+}
+
+// planBucket returns the changes needed for a bucket to match its spec.
+func planBucket(alias string, bucket applyBucketSpec) ([]applyChange, *probe.Error) {
+	bucketURL := alias + "/" + bucket.Name
+	clnt, err := newClient(bucketURL)
+	if err != nil {
+		return nil, err.Trace(bucketURL)
+	}
+	s3Clnt, ok := clnt.(*s3Client)
+	if !ok {
+		return nil, probe.NewError(errors.New("`" + alias + "` is not an S3 alias"))
+	}
+
+	var changes []applyChange
+	exists := true
+	if _, err = s3Clnt.Stat(false, false, nil); err != nil {
+		if _, ok := err.ToGoError().(BucketDoesNotExist); !ok {
+			return nil, err.Trace(bucketURL)
+		}
+		exists = false
+		changes = append(changes, applyChange{
+			Bucket:   bucket.Name,
+			Resource: "bucket",
+			Action:   "create",
+			Detail:   bucket.Region,
+			apply: func() *probe.Error {
+				region := bucket.Region
+				if region == "" {
+					region = "us-east-1"
+				}
+				return s3Clnt.MakeBucket(region, true)
+			},
+		})
+	}
+
+	if bucket.Policy != "" {
+		current := "none"
+		if exists {
+			if current, _, err = s3Clnt.GetAccess(); err != nil {
+				return nil, err.Trace(bucketURL)
+			}
+		}
+		if current != bucket.Policy {
+			changes = append(changes, applyChange{
+				Bucket:   bucket.Name,
+				Resource: "policy",
+				Action:   "update",
+				Detail:   current + " -> " + bucket.Policy,
+				apply:    func() *probe.Error { return s3Clnt.SetAccess(bucket.Policy, false) },
+			})
+		}
+	}
+
+	if bucket.Lifecycle != "" {
+		var current string
+		if exists {
+			if current, err = s3Clnt.GetLifecycle(); err != nil {
+				return nil, err.Trace(bucketURL)
+			}
+		}
+		if normalizeXML(current) != normalizeXML(bucket.Lifecycle) {
+			action := "update"
+			if current == "" {
+				action = "create"
+			}
+			changes = append(changes, applyChange{
+				Bucket:   bucket.Name,
+				Resource: "lifecycle",
+				Action:   action,
+				apply:    func() *probe.Error { return s3Clnt.SetLifecycle(bucket.Lifecycle) },
+			})
+		}
+	}
+
+	if bucket.Notifications != nil {
+		var current []notificationConfig
+		if exists {
+			if current, err = s3Clnt.ListNotificationConfigs(""); err != nil {
+				return nil, err.Trace(bucketURL)
+			}
+		}
+		removeARNs, add := diffNotifications(current, bucket.Notifications)
+		for _, arn := range removeARNs {
+			arn := arn
+			changes = append(changes, applyChange{
+				Bucket:   bucket.Name,
+				Resource: "notification",
+				Action:   "remove",
+				Detail:   arn,
+				apply:    func() *probe.Error { return s3Clnt.RemoveNotificationConfig(arn) },
+			})
+		}
+		for _, notification := range add {
+			notification := notification
+			changes = append(changes, applyChange{
+				Bucket:   bucket.Name,
+				Resource: "notification",
+				Action:   "create",
+				Detail:   notification.ARN + " " + strings.Join(notification.Events, ","),
+				apply: func() *probe.Error {
+					return s3Clnt.AddNotificationConfig(notification.ARN, notification.Events, notification.Prefix, notification.Suffix, false)
+				},
+			})
+		}
+	}
+	return changes, nil
+}
+
+// applySummaryMessage container for the apply summary.
+type applySummaryMessage struct {
+	Status  string `json:"status"`
+	Changes int    `json:"changes"`
+	DryRun  bool   `json:"dryRun"`
+}
+
+// String colorized apply summary message.
+func (a applySummaryMessage) String() string {
+	switch {
+	case a.Changes == 0:
+		return console.Colorize("ApplyCreate", "All buckets match the spec.")
+	case a.DryRun:
+		return fmt.Sprintf("%d changes needed, run without --dry-run to apply them.", a.Changes)
+	}
+	return console.Colorize("ApplyCreate", fmt.Sprintf("%d changes applied.", a.Changes))
+}
+
+// JSON jsonified apply summary message.
+func (a applySummaryMessage) JSON() string {
+	a.Status = "success"
+	applySummaryBytes, e := json.MarshalIndent(a, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(applySummaryBytes)
+}
+
+// checkApplySyntax - validate all the passed arguments
+func checkApplySyntax(ctx *cli.Context) {
+	if len(ctx.Args()) != 1 || ctx.String("file") == "" {
+		cli.ShowCommandHelpAndExit(ctx, "apply", 1) // last argument is exit code
+	}
+}
+
+// mainApply is the entry point for apply command.
+func mainApply(ctx *cli.Context) error {
+	checkApplySyntax(ctx)
+
+	console.SetColor("ApplyCreate", color.New(color.FgGreen, color.Bold))
+	console.SetColor("ApplyUpdate", color.New(color.FgYellow, color.Bold))
+	console.SetColor("ApplyRemove", color.New(color.FgRed, color.Bold))
+	console.SetColor("ApplyBucket", color.New(color.Bold))
+
+	specFile := ctx.String("file")
+	specBytes, e := ioutil.ReadFile(specFile)
+	fatalIf(probe.NewError(e).Trace(specFile), "Unable to read spec `"+specFile+"`.")
+	spec, err := parseApplySpec(specBytes)
+	fatalIf(err.Trace(specFile), "Invalid spec `"+specFile+"`.")
+
+	alias := strings.TrimSuffix(ctx.Args().First(), "/")
+	if !isValidAlias(alias) {
+		fatalIf(errInvalidAlias(alias), "Invalid alias.")
+	}
+
+	// Plan all changes first, nothing is changed if any bucket
+	// cannot be planned.
+	var changes []applyChange
+	for _, bucket := range spec.Buckets {
+		bucketChanges, err := planBucket(alias, bucket)
+		fatalIf(err, "Unable to plan changes of bucket `"+bucket.Name+"`.")
+		changes = append(changes, bucketChanges...)
+	}
+
+	// Print the plan before changing anything.
+	for _, change := range changes {
+		printMsg(change)
+	}
+
+	isDryRun := ctx.Bool("dry-run")
+	if !isDryRun {
+		for _, change := range changes {
+			fatalIf(change.apply().Trace(change.Bucket, change.Resource), "Unable to "+change.Action+" "+change.Resource+" of bucket `"+change.Bucket+"`.")
+		}
+	}
+	printMsg(applySummaryMessage{Changes: len(changes), DryRun: isDryRun})
+	return nil
+}
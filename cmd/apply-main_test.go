@@ -0,0 +1,95 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseApplySpec(t *testing.T) {
+	testCases := []struct {
+		spec    string
+		success bool
+	}{
+		{"buckets:\n- name: photos\n  policy: download\n", true},
+		{"buckets:\n- name: photos\n  notifications:\n  - arn: arn:minio:sqs::1:webhook\n    events: [put, delete]\n", true},
+		{"buckets:\n- name: photos\n  notifications: []\n", true},
+		{"buckets:\n- policy: download\n", false},
+		{"buckets:\n- name: photos\n- name: photos\n", false},
+		{"buckets:\n- name: photos\n  policy: private\n", false},
+		{"buckets:\n- name: photos\n  versioning: enabled\n", false},
+		{"buckets:\n- name: photos\n  polcy: download\n", false},
+		{"buckets:\n- name: photos\n  notifications:\n  - arn: webhook\n    events: [put]\n", false},
+		{"buckets:\n- name: photos\n  notifications:\n  - arn: arn:minio:sqs::1:webhook\n    events: [copy]\n", false},
+	}
+	for i, testCase := range testCases {
+		_, err := parseApplySpec([]byte(testCase.spec))
+		if testCase.success && err != nil {
+			t.Errorf("Test %d: unexpected error %v", i+1, err)
+		}
+		if !testCase.success && err == nil {
+			t.Errorf("Test %d: expected an error", i+1)
+		}
+	}
+}
+
+func TestDiffNotifications(t *testing.T) {
+	webhook := "arn:minio:sqs::1:webhook"
+	kafka := "arn:minio:sqs::1:kafka"
+	current := []notificationConfig{
+		{Arn: webhook, Events: []string{"s3:ObjectRemoved:*", "s3:ObjectCreated:*"}, Prefix: "2019/"},
+		{Arn: kafka, Events: []string{"s3:ObjectCreated:*"}},
+	}
+	testCases := []struct {
+		desired    []applyNotificationSpec
+		removeARNs []string
+		add        []applyNotificationSpec
+	}{
+		// Unchanged notifications.
+		{
+			desired: []applyNotificationSpec{
+				{ARN: webhook, Events: []string{"put", "delete"}, Prefix: "2019/"},
+				{ARN: kafka, Events: []string{"put"}},
+			},
+		},
+		// Changed and removed notifications.
+		{
+			desired: []applyNotificationSpec{
+				{ARN: webhook, Events: []string{"put"}, Prefix: "2019/"},
+			},
+			removeARNs: []string{kafka, webhook},
+			add: []applyNotificationSpec{
+				{ARN: webhook, Events: []string{"put"}, Prefix: "2019/"},
+			},
+		},
+		// All notifications removed.
+		{
+			desired:    []applyNotificationSpec{},
+			removeARNs: []string{kafka, webhook},
+		},
+	}
+	for i, testCase := range testCases {
+		removeARNs, add := diffNotifications(current, testCase.desired)
+		if !reflect.DeepEqual(removeARNs, testCase.removeARNs) {
+			t.Errorf("Test %d: expected to remove %v, got %v", i+1, testCase.removeARNs, removeARNs)
+		}
+		if !reflect.DeepEqual(add, testCase.add) {
+			t.Errorf("Test %d: expected to add %v, got %v", i+1, testCase.add, add)
+		}
+	}
+}
@@ -0,0 +1,223 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	humanize "github.com/dustin/go-humanize"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/console"
+	"github.com/minio/mc/pkg/probe"
+)
+
+// Supported archive formats.
+const (
+	archiveTar   = "tar"
+	archiveTarGz = "tar.gz"
+	archiveZip   = "zip"
+)
+
+// archiveFormat guesses the archive format from a file name, tar
+// is used when the name does not carry a known extension.
+func archiveFormat(name string) string {
+	name = strings.ToLower(name)
+	switch {
+	case strings.HasSuffix(name, ".zip"):
+		return archiveZip
+	case strings.HasSuffix(name, ".tar.gz"), strings.HasSuffix(name, ".tgz"):
+		return archiveTarGz
+	}
+	return archiveTar
+}
+
+// archiveEntryName returns a safe relative object name for an archive
+// entry, leading separators and parent references are removed so that
+// entries never escape the target prefix.
+func archiveEntryName(name string) (string, bool) {
+	name = path.Clean("/" + filepath.ToSlash(name))
+	name = strings.TrimPrefix(name, "/")
+	if name == "" || name == "." {
+		return "", false
+	}
+	return name, true
+}
+
+// archiveMessage container for archive messages.
+type archiveMessage struct {
+	Status     string `json:"status"`
+	Source     string `json:"source"`
+	Target     string `json:"target"`
+	TotalCount int64  `json:"totalCount"`
+	TotalSize  int64  `json:"totalSize"`
+}
+
+// String colorized archive message.
+func (a archiveMessage) String() string {
+	return console.Colorize("Copy", fmt.Sprintf("`%s` -> `%s` (%d objects, %s)", a.Source, a.Target, a.TotalCount, humanize.IBytes(uint64(a.TotalSize))))
+}
+
+// JSON jsonified archive message.
+func (a archiveMessage) JSON() string {
+	a.Status = "success"
+	archiveMessageBytes, e := json.MarshalIndent(a, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(archiveMessageBytes)
+}
+
+// archiveWriter abstracts tar and zip writers.
+type archiveWriter interface {
+	WriteEntry(name string, content *clientContent, r io.Reader) error
+	Close() error
+}
+
+type tarArchiveWriter struct {
+	tw  *tar.Writer
+	gzw *gzip.Writer
+}
+
+func (t *tarArchiveWriter) WriteEntry(name string, content *clientContent, r io.Reader) error {
+	hdr := &tar.Header{
+		Name:     name,
+		Size:     content.Size,
+		Mode:     0644,
+		ModTime:  content.Time,
+		Typeflag: tar.TypeReg,
+	}
+	if e := t.tw.WriteHeader(hdr); e != nil {
+		return e
+	}
+	_, e := io.Copy(t.tw, r)
+	return e
+}
+
+func (t *tarArchiveWriter) Close() error {
+	if e := t.tw.Close(); e != nil {
+		return e
+	}
+	if t.gzw != nil {
+		return t.gzw.Close()
+	}
+	return nil
+}
+
+type zipArchiveWriter struct {
+	zw *zip.Writer
+}
+
+func (z *zipArchiveWriter) WriteEntry(name string, content *clientContent, r io.Reader) error {
+	w, e := z.zw.CreateHeader(&zip.FileHeader{
+		Name:     name,
+		Method:   zip.Deflate,
+		Modified: content.Time,
+	})
+	if e != nil {
+		return e
+	}
+	_, e = io.Copy(w, r)
+	return e
+}
+
+func (z *zipArchiveWriter) Close() error {
+	return z.zw.Close()
+}
+
+// newArchiveWriter returns an archive writer of the given format.
+func newArchiveWriter(w io.Writer, format string) archiveWriter {
+	switch format {
+	case archiveZip:
+		return &zipArchiveWriter{zw: zip.NewWriter(w)}
+	case archiveTarGz:
+		gzw := gzip.NewWriter(w)
+		return &tarArchiveWriter{tw: tar.NewWriter(gzw), gzw: gzw}
+	}
+	return &tarArchiveWriter{tw: tar.NewWriter(w)}
+}
+
+// archiveURL streams all objects found under sourceURL into a single
+// archive written to targetPath, or to stdout when targetPath is '-'.
+func archiveURL(sourceURL, targetPath string, encKeyDB map[string][]prefixSSEPair) *probe.Error {
+	clnt, err := newClient(sourceURL)
+	if err != nil {
+		return err.Trace(sourceURL)
+	}
+	alias, _ := url2Alias(sourceURL)
+	prefix := clnt.GetURL().Path
+
+	var w io.Writer = os.Stdout
+	if targetPath != "-" {
+		f, e := os.Create(targetPath)
+		if e != nil {
+			return probe.NewError(e).Trace(targetPath)
+		}
+		defer f.Close()
+		w = f
+	}
+
+	aw := newArchiveWriter(w, archiveFormat(targetPath))
+	var totalCount, totalSize int64
+	for content := range clnt.List(true, false, DirNone) {
+		if content.Err != nil {
+			return content.Err.Trace(sourceURL)
+		}
+		if content.Type.IsDir() {
+			continue
+		}
+		name, ok := archiveEntryName(strings.TrimPrefix(content.URL.Path, prefix))
+		if !ok {
+			// Single object archives keep the object base name.
+			name = path.Base(filepath.ToSlash(content.URL.Path))
+		}
+
+		contentPath := filepath.ToSlash(filepath.Join(alias, content.URL.Path))
+		sse := getSSE(contentPath, encKeyDB[alias])
+		reader, _, err := getSourceStream(alias, content.URL.String(), false, sse)
+		if err != nil {
+			return err.Trace(content.URL.String())
+		}
+		e := aw.WriteEntry(name, content, reader)
+		reader.Close()
+		if e != nil {
+			return probe.NewError(e).Trace(content.URL.String())
+		}
+		totalCount++
+		totalSize += content.Size
+	}
+	if e := aw.Close(); e != nil {
+		return probe.NewError(e).Trace(targetPath)
+	}
+
+	// Do not pollute the archive when it is streamed to stdout.
+	if targetPath != "-" {
+		printMsg(archiveMessage{
+			Source:     sourceURL,
+			Target:     targetPath,
+			TotalCount: totalCount,
+			TotalSize:  totalSize,
+		})
+	}
+	return nil
+}
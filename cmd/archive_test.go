@@ -0,0 +1,58 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import "testing"
+
+func TestArchiveFormat(t *testing.T) {
+	testCases := []struct {
+		name   string
+		format string
+	}{
+		{"backup.tar", archiveTar},
+		{"-", archiveTar},
+		{"backup.TAR.GZ", archiveTarGz},
+		{"backup.tgz", archiveTarGz},
+		{"photos.zip", archiveZip},
+	}
+	for i, testCase := range testCases {
+		if format := archiveFormat(testCase.name); format != testCase.format {
+			t.Errorf("Test %d: expected %s, got %s", i+1, testCase.format, format)
+		}
+	}
+}
+
+func TestArchiveEntryName(t *testing.T) {
+	testCases := []struct {
+		name     string
+		expected string
+		ok       bool
+	}{
+		{"dir/file.txt", "dir/file.txt", true},
+		{"/etc/passwd", "etc/passwd", true},
+		{"../../escape.txt", "escape.txt", true},
+		{"./a/../b", "b", true},
+		{"", "", false},
+		{"/", "", false},
+	}
+	for i, testCase := range testCases {
+		name, ok := archiveEntryName(testCase.name)
+		if name != testCase.expected || ok != testCase.ok {
+			t.Errorf("Test %d: expected (%s, %t), got (%s, %t)", i+1, testCase.expected, testCase.ok, name, ok)
+		}
+	}
+}
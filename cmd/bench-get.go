@@ -0,0 +1,75 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"math/rand"
+	"time"
+
+	"github.com/minio/cli"
+)
+
+var benchGetCmd = cli.Command{
+	Name:   "get",
+	Usage:  "benchmark object downloads",
+	Action: mainBenchGet,
+	Before: setGlobalsFromContext,
+	Flags:  append(append(benchFlags, benchPoolFlags...), globalFlags...),
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [FLAGS] TARGET
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+DESCRIPTION:
+  --objects objects are uploaded below a random prefix of TARGET first, then
+  random ones among them are downloaded for --duration. They are removed once
+  the benchmark completes.
+
+EXAMPLES:
+  1. Download 1MiB objects from the bucket 'testbucket' from 16 concurrent workers for a minute.
+     $ {{.HelpName}} play/testbucket
+
+  2. Download a thousand 64KiB objects from 128 concurrent workers.
+     $ {{.HelpName}} --size 64KiB --objects 1000 --concurrent 128 play/testbucket
+`,
+}
+
+// mainBenchGet - the entry function of bench get command
+func mainBenchGet(ctx *cli.Context) error {
+	b := newBench(ctx, "get")
+
+	printProgress("Uploading %d objects to %s...\n", b.objects, b.targetURL)
+	if err := b.prepare(); err != nil {
+		errorIf(b.cleanup(), "Unable to remove benchmark objects below `"+b.targetURL+b.prefix+"`.")
+		fatalIf(err, "Unable to upload benchmark objects.")
+	}
+
+	printProgress("Downloading objects from %s for %s...\n", b.targetURL, b.duration)
+	msg := b.run("get", func(rng *rand.Rand) {
+		start := time.Now()
+		size, err := b.get(poolKey(rng.Intn(b.objects)))
+		b.stats.record("GET", size, time.Since(start), err)
+	})
+	errorIf(b.cleanup(), "Unable to remove benchmark objects below `"+b.targetURL+b.prefix+"`.")
+
+	printMsg(msg)
+	return nil
+}
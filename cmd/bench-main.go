@@ -0,0 +1,409 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	humanize "github.com/dustin/go-humanize"
+	"github.com/minio/cli"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/console"
+	"github.com/minio/mc/pkg/probe"
+)
+
+// bench specific flags, shared by all benchmarks.
+var (
+	benchFlags = []cli.Flag{
+		cli.StringFlag{
+			Name:  "size",
+			Value: "1MiB",
+			Usage: "object sizes as comma separated SIZE[-SIZE][:WEIGHT] entries",
+		},
+		cli.IntFlag{
+			Name:  "concurrent",
+			Value: 16,
+			Usage: "number of concurrent requests",
+		},
+		cli.StringFlag{
+			Name:  "duration",
+			Value: "1m",
+			Usage: "duration of the benchmark",
+		},
+	}
+	benchPoolFlags = []cli.Flag{
+		cli.IntFlag{
+			Name:  "objects",
+			Value: 100,
+			Usage: "number of objects uploaded before the benchmark starts",
+		},
+	}
+)
+
+// Benchmark object storage from the client.
+var benchCmd = cli.Command{
+	Name:            "bench",
+	Usage:           "benchmark object uploads and downloads",
+	Action:          mainBench,
+	Before:          setGlobalsFromContext,
+	Flags:           globalFlags,
+	HideHelpCommand: true,
+	Subcommands: []cli.Command{
+		benchPutCmd,
+		benchGetCmd,
+		benchMixedCmd,
+	},
+}
+
+// mainBench - handle for the 'mc bench' command.
+func mainBench(ctx *cli.Context) error {
+	cli.ShowCommandHelp(ctx, ctx.Args().First())
+	return nil
+	// Sub-commands like "put", "get", "mixed" have their own main.
+}
+
+// benchSizeRange is a range of object sizes picked with a weight.
+type benchSizeRange struct {
+	min, max int64
+	weight   int
+}
+
+// benchSizes is a weighted distribution of object sizes.
+type benchSizes struct {
+	ranges      []benchSizeRange
+	totalWeight int
+}
+
+// parseBenchSizes parses comma separated SIZE[-SIZE][:WEIGHT] entries,
+// e.g. "4KiB:70,1MiB-8MiB:30". Sizes of a range are picked uniformly,
+// the weight of an entry defaults to 1.
+func parseBenchSizes(sizes string) (benchSizes, *probe.Error) {
+	var d benchSizes
+	for _, entry := range strings.Split(sizes, ",") {
+		parts := splitStr(strings.TrimSpace(entry), ":", 2)
+		sizeRange, weight := parts[0], parts[1]
+		r := benchSizeRange{weight: 1}
+		if weight != "" {
+			w, e := strconv.Atoi(weight)
+			if e != nil || w <= 0 {
+				return d, probe.NewError(fmt.Errorf("invalid weight `%s` in `%s`", weight, entry))
+			}
+			r.weight = w
+		}
+		parts = splitStr(sizeRange, "-", 2)
+		minSize, maxSize := parts[0], parts[1]
+		if maxSize == "" {
+			maxSize = minSize
+		}
+		min, e := humanize.ParseBytes(minSize)
+		if e != nil {
+			return d, probe.NewError(e).Trace(entry)
+		}
+		max, e := humanize.ParseBytes(maxSize)
+		if e != nil {
+			return d, probe.NewError(e).Trace(entry)
+		}
+		if min > max {
+			return d, probe.NewError(fmt.Errorf("invalid size range `%s`", sizeRange))
+		}
+		r.min, r.max = int64(min), int64(max)
+		d.ranges = append(d.ranges, r)
+		d.totalWeight += r.weight
+	}
+	return d, nil
+}
+
+// next picks a random object size.
+func (d benchSizes) next(rng *rand.Rand) int64 {
+	n := rng.Intn(d.totalWeight)
+	for _, r := range d.ranges {
+		if n < r.weight {
+			return r.min + rng.Int63n(r.max-r.min+1)
+		}
+		n -= r.weight
+	}
+	return d.ranges[len(d.ranges)-1].max
+}
+
+// maxSize returns the largest object size of the distribution.
+func (d benchSizes) maxSize() int64 {
+	var max int64
+	for _, r := range d.ranges {
+		if r.max > max {
+			max = r.max
+		}
+	}
+	return max
+}
+
+// benchMessage is the report of a benchmark.
+type benchMessage struct {
+	Status     string          `json:"status"`
+	Benchmark  string          `json:"benchmark"`
+	Target     string          `json:"target"`
+	Size       string          `json:"size"`
+	Concurrent int             `json:"concurrent"`
+	Duration   float64         `json:"durationSec"`
+	Operations []benchOpReport `json:"operations"`
+}
+
+// String colorized benchmark report.
+func (b benchMessage) String() string {
+	var s strings.Builder
+	fmt.Fprintf(&s, "%s %s %s, %s objects, %d concurrent, %.1fs\n", console.Colorize("Key", "Benchmark:"),
+		b.Benchmark, b.Target, b.Size, b.Concurrent, b.Duration)
+	for _, op := range b.Operations {
+		fmt.Fprintf(&s, "%s %d requests, %.1f req/s, %s/s, %d errors (%.2f%%)\n", console.Colorize("Key", op.Operation+":"),
+			op.Requests, op.RequestsPerSec, humanize.IBytes(uint64(op.Throughput)), op.Errors, op.ErrorRate*100)
+		fmt.Fprintf(&s, "  latency min %.1fms, avg %.1fms, p50 %.1fms, p90 %.1fms, p99 %.1fms, max %.1fms\n",
+			op.Latency.Min, op.Latency.Avg, op.Latency.P50, op.Latency.P90, op.Latency.P99, op.Latency.Max)
+		if op.LastError != "" {
+			fmt.Fprintf(&s, "  last error: %s\n", op.LastError)
+		}
+	}
+	return strings.TrimSuffix(s.String(), "\n")
+}
+
+// JSON jsonified benchmark report.
+func (b benchMessage) JSON() string {
+	b.Status = "success"
+	benchMessageBytes, e := json.MarshalIndent(b, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(benchMessageBytes)
+}
+
+// bench holds the state shared by the workers of a benchmark.
+type bench struct {
+	alias      string
+	targetURL  string
+	prefix     string
+	sizes      benchSizes
+	concurrent int
+	duration   time.Duration
+	data       []byte
+	stats      *benchStats
+
+	// Objects uploaded before the benchmark starts.
+	objects int
+}
+
+// newBench validates the flags shared by all benchmarks and prepares a
+// benchmark writing below a random prefix of the target.
+func newBench(ctx *cli.Context, name string) *bench {
+	if len(ctx.Args()) != 1 {
+		cli.ShowCommandHelpAndExit(ctx, name, 1) // last argument is exit code
+	}
+	aliasedURL := ctx.Args().Get(0)
+
+	sizes, err := parseBenchSizes(ctx.String("size"))
+	fatalIf(err, "Invalid object sizes `"+ctx.String("size")+"`.")
+	if ctx.Int("concurrent") <= 0 {
+		fatalIf(errInvalidArgument().Trace(ctx.Args()...), "Number of concurrent requests must be positive.")
+	}
+	duration, e := time.ParseDuration(ctx.String("duration"))
+	if e != nil || duration <= 0 {
+		fatalIf(errInvalidArgument().Trace(ctx.String("duration")), "Invalid duration `"+ctx.String("duration")+"`.")
+	}
+
+	alias, targetURL, _ := mustExpandAlias(aliasedURL)
+	if !strings.HasSuffix(targetURL, "/") {
+		targetURL += "/"
+	}
+
+	data := make([]byte, sizes.maxSize())
+	rand.New(rand.NewSource(time.Now().UnixNano())).Read(data)
+
+	return &bench{
+		alias:      alias,
+		targetURL:  targetURL,
+		prefix:     randString(20, rand.NewSource(time.Now().UnixNano()), "mc-bench-") + "/",
+		sizes:      sizes,
+		concurrent: ctx.Int("concurrent"),
+		duration:   duration,
+		data:       data,
+		stats:      newBenchStats(),
+		objects:    ctx.Int("objects"),
+	}
+}
+
+// objectURL returns the URL of the benchmark object named key.
+func (b *bench) objectURL(key string) string {
+	return b.targetURL + b.prefix + key
+}
+
+// poolKey returns the key of the i-th object uploaded before the
+// benchmark starts.
+func poolKey(i int) string {
+	return "obj-" + strconv.Itoa(i)
+}
+
+// put uploads an object of a random size as key.
+func (b *bench) put(key string, rng *rand.Rand) (int64, *probe.Error) {
+	clnt, err := newClientFromAlias(b.alias, b.objectURL(key))
+	if err != nil {
+		return 0, err
+	}
+	size := b.sizes.next(rng)
+	return clnt.Put(context.Background(), bytes.NewReader(b.data[:size]), size, nil, nil, nil)
+}
+
+// get downloads the object key.
+func (b *bench) get(key string) (int64, *probe.Error) {
+	clnt, err := newClientFromAlias(b.alias, b.objectURL(key))
+	if err != nil {
+		return 0, err
+	}
+	reader, err := clnt.Get(nil)
+	if err != nil {
+		return 0, err
+	}
+	defer reader.Close()
+	n, e := io.Copy(ioutil.Discard, reader)
+	return n, probe.NewError(e)
+}
+
+// prepare uploads the objects read by the benchmark.
+func (b *bench) prepare() *probe.Error {
+	if b.objects <= 0 {
+		return errInvalidArgument().Trace(strconv.Itoa(b.objects))
+	}
+	keyCh := make(chan int)
+	errCh := make(chan *probe.Error, b.concurrent)
+	var wg sync.WaitGroup
+	for i := 0; i < b.concurrent; i++ {
+		wg.Add(1)
+		go func(seed int64) {
+			defer wg.Done()
+			rng := rand.New(rand.NewSource(seed))
+			for i := range keyCh {
+				if _, err := b.put(poolKey(i), rng); err != nil {
+					errCh <- err
+					return
+				}
+			}
+		}(time.Now().UnixNano() + int64(i))
+	}
+
+	var err *probe.Error
+loop:
+	for i := 0; i < b.objects; i++ {
+		select {
+		case keyCh <- i:
+		case err = <-errCh:
+			break loop
+		}
+	}
+	close(keyCh)
+	wg.Wait()
+	if err == nil && len(errCh) > 0 {
+		err = <-errCh
+	}
+	return err
+}
+
+// run calls op from all workers until the benchmark duration elapses
+// or it is interrupted, and returns the benchmark report.
+func (b *bench) run(name string, op func(rng *rand.Rand)) benchMessage {
+	done := make(chan struct{})
+	trapCh := signalTrap(os.Interrupt, syscall.SIGTERM)
+	go func() {
+		select {
+		case <-time.After(b.duration):
+		case <-trapCh:
+		}
+		close(done)
+	}()
+
+	start := time.Now()
+	var wg sync.WaitGroup
+	for i := 0; i < b.concurrent; i++ {
+		wg.Add(1)
+		go func(seed int64) {
+			defer wg.Done()
+			rng := rand.New(rand.NewSource(seed))
+			for {
+				select {
+				case <-done:
+					return
+				default:
+					op(rng)
+				}
+			}
+		}(start.UnixNano() + int64(i))
+	}
+	wg.Wait()
+
+	sizes := make([]string, 0, len(b.sizes.ranges))
+	for _, r := range b.sizes.ranges {
+		size := humanize.IBytes(uint64(r.min))
+		if r.max != r.min {
+			size += "-" + humanize.IBytes(uint64(r.max))
+		}
+		sizes = append(sizes, size+":"+strconv.Itoa(r.weight))
+	}
+	elapsed := time.Since(start)
+	return benchMessage{
+		Benchmark:  name,
+		Target:     b.targetURL,
+		Size:       strings.Join(sizes, ","),
+		Concurrent: b.concurrent,
+		Duration:   elapsed.Seconds(),
+		Operations: b.stats.report(elapsed),
+	}
+}
+
+// cleanup removes all objects written by the benchmark.
+func (b *bench) cleanup() *probe.Error {
+	clnt, err := newClientFromAlias(b.alias, b.targetURL+b.prefix)
+	if err != nil {
+		return err
+	}
+	contentCh := make(chan *clientContent)
+	isIncomplete, isRemoveBucket := false, false
+	errorCh := clnt.Remove(isIncomplete, isRemoveBucket, contentCh)
+
+	isRecursive := true
+	for content := range clnt.List(isRecursive, isIncomplete, DirNone) {
+		if content.Err != nil {
+			close(contentCh)
+			return content.Err.Trace(b.targetURL + b.prefix)
+		}
+		select {
+		case contentCh <- content:
+		case err = <-errorCh:
+			close(contentCh)
+			return err.Trace(content.URL.String())
+		}
+	}
+	close(contentCh)
+	if err, ok := <-errorCh; ok {
+		return err.Trace(b.targetURL + b.prefix)
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestParseBenchSizes(t *testing.T) {
+	testCases := []struct {
+		sizes       string
+		ranges      []benchSizeRange
+		totalWeight int
+		success     bool
+	}{
+		{"1MiB", []benchSizeRange{{1 << 20, 1 << 20, 1}}, 1, true},
+		{"4KiB-8KiB", []benchSizeRange{{4 << 10, 8 << 10, 1}}, 1, true},
+		{"4KiB:70, 1MiB-8MiB:30", []benchSizeRange{{4 << 10, 4 << 10, 70}, {1 << 20, 8 << 20, 30}}, 100, true},
+		{"8KiB-4KiB", nil, 0, false},
+		{"1MiB:0", nil, 0, false},
+		{"1MiB:x", nil, 0, false},
+		{"big", nil, 0, false},
+		{"", nil, 0, false},
+	}
+	for i, testCase := range testCases {
+		sizes, err := parseBenchSizes(testCase.sizes)
+		if testCase.success != (err == nil) {
+			t.Fatalf("Test %d: expected success %v, got error %v", i+1, testCase.success, err)
+		}
+		if !testCase.success {
+			continue
+		}
+		if sizes.totalWeight != testCase.totalWeight || len(sizes.ranges) != len(testCase.ranges) {
+			t.Fatalf("Test %d: expected %v, got %v", i+1, testCase.ranges, sizes.ranges)
+		}
+		for j, r := range sizes.ranges {
+			if r != testCase.ranges[j] {
+				t.Errorf("Test %d: expected range %v, got %v", i+1, testCase.ranges[j], r)
+			}
+		}
+	}
+}
+
+func TestBenchSizesNext(t *testing.T) {
+	sizes, err := parseBenchSizes("1KiB:1,2KiB-4KiB:3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sizes.maxSize() != 4<<10 {
+		t.Errorf("expected max size %d, got %d", 4<<10, sizes.maxSize())
+	}
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 1000; i++ {
+		size := sizes.next(rng)
+		if size != 1<<10 && (size < 2<<10 || size > 4<<10) {
+			t.Fatalf("size %d is outside of the distribution", size)
+		}
+	}
+}
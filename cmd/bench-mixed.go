@@ -0,0 +1,93 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"math/rand"
+	"time"
+
+	"github.com/minio/cli"
+)
+
+var benchMixedFlags = []cli.Flag{
+	cli.IntFlag{
+		Name:  "put-ratio",
+		Value: 20,
+		Usage: "percentage of requests which upload objects",
+	},
+}
+
+var benchMixedCmd = cli.Command{
+	Name:   "mixed",
+	Usage:  "benchmark a mix of object uploads and downloads",
+	Action: mainBenchMixed,
+	Before: setGlobalsFromContext,
+	Flags:  append(append(append(benchFlags, benchPoolFlags...), benchMixedFlags...), globalFlags...),
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [FLAGS] TARGET
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+DESCRIPTION:
+  --objects objects are uploaded below a random prefix of TARGET first, then
+  random ones among them are downloaded or overwritten for --duration. They
+  are removed once the benchmark completes.
+
+EXAMPLES:
+  1. Download objects from the bucket 'testbucket' and overwrite them 20% of the time.
+     $ {{.HelpName}} play/testbucket
+
+  2. Run an even mix of uploads and downloads of 16KiB to 4MiB objects for 10 minutes.
+     $ {{.HelpName}} --put-ratio 50 --size 16KiB-4MiB --duration 10m play/testbucket
+`,
+}
+
+// mainBenchMixed - the entry function of bench mixed command
+func mainBenchMixed(ctx *cli.Context) error {
+	putRatio := ctx.Int("put-ratio")
+	if putRatio < 0 || putRatio > 100 {
+		fatalIf(errInvalidArgument().Trace(ctx.Args()...), "Ratio of uploads must be between 0 and 100.")
+	}
+	b := newBench(ctx, "mixed")
+
+	printProgress("Uploading %d objects to %s...\n", b.objects, b.targetURL)
+	if err := b.prepare(); err != nil {
+		errorIf(b.cleanup(), "Unable to remove benchmark objects below `"+b.targetURL+b.prefix+"`.")
+		fatalIf(err, "Unable to upload benchmark objects.")
+	}
+
+	printProgress("Uploading and downloading objects in %s for %s...\n", b.targetURL, b.duration)
+	msg := b.run("mixed", func(rng *rand.Rand) {
+		key := poolKey(rng.Intn(b.objects))
+		start := time.Now()
+		if rng.Intn(100) < putRatio {
+			size, err := b.put(key, rng)
+			b.stats.record("PUT", size, time.Since(start), err)
+			return
+		}
+		size, err := b.get(key)
+		b.stats.record("GET", size, time.Since(start), err)
+	})
+	errorIf(b.cleanup(), "Unable to remove benchmark objects below `"+b.targetURL+b.prefix+"`.")
+
+	printMsg(msg)
+	return nil
+}
@@ -0,0 +1,75 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"math/rand"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/minio/cli"
+)
+
+var benchPutCmd = cli.Command{
+	Name:   "put",
+	Usage:  "benchmark object uploads",
+	Action: mainBenchPut,
+	Before: setGlobalsFromContext,
+	Flags:  append(benchFlags, globalFlags...),
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [FLAGS] TARGET
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+DESCRIPTION:
+  New objects are uploaded below a random prefix of TARGET for --duration,
+  they are removed once the benchmark completes.
+
+EXAMPLES:
+  1. Upload 1MiB objects to the bucket 'testbucket' from 16 concurrent workers for a minute.
+     $ {{.HelpName}} play/testbucket
+
+  2. Upload 4KiB objects 70% of the time and 1MiB to 8MiB objects otherwise for 5 minutes.
+     $ {{.HelpName}} --size 4KiB:70,1MiB-8MiB:30 --duration 5m play/testbucket
+
+  3. Save a JSON report of uploads from 64 concurrent workers.
+     $ {{.HelpName}} --json --concurrent 64 play/testbucket > put-64.json
+`,
+}
+
+// mainBenchPut - the entry function of bench put command
+func mainBenchPut(ctx *cli.Context) error {
+	b := newBench(ctx, "put")
+
+	var n int64
+	printProgress("Uploading objects to %s for %s...\n", b.targetURL, b.duration)
+	msg := b.run("put", func(rng *rand.Rand) {
+		key := "put-" + strconv.FormatInt(atomic.AddInt64(&n, 1), 10)
+		start := time.Now()
+		size, err := b.put(key, rng)
+		b.stats.record("PUT", size, time.Since(start), err)
+	})
+	errorIf(b.cleanup(), "Unable to remove benchmark objects below `"+b.targetURL+b.prefix+"`.")
+
+	printMsg(msg)
+	return nil
+}
@@ -0,0 +1,153 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/minio/mc/pkg/probe"
+)
+
+// benchLatency holds latency statistics in milliseconds.
+type benchLatency struct {
+	Min float64 `json:"minMs"`
+	Avg float64 `json:"avgMs"`
+	P50 float64 `json:"p50Ms"`
+	P90 float64 `json:"p90Ms"`
+	P99 float64 `json:"p99Ms"`
+	Max float64 `json:"maxMs"`
+}
+
+// benchOpReport summarizes all requests of one operation.
+type benchOpReport struct {
+	Operation      string       `json:"operation"`
+	Requests       int64        `json:"requests"`
+	Errors         int64        `json:"errors"`
+	ErrorRate      float64      `json:"errorRate"`
+	Bytes          int64        `json:"bytes"`
+	Throughput     float64      `json:"throughput"`
+	RequestsPerSec float64      `json:"requestsPerSec"`
+	Latency        benchLatency `json:"latency"`
+	LastError      string       `json:"lastError,omitempty"`
+}
+
+// benchOpStats collects the requests of one operation.
+type benchOpStats struct {
+	latencies []time.Duration
+	bytes     int64
+	errors    int64
+	lastError string
+}
+
+// benchStats collects the requests of all operations of a benchmark,
+// it is safe for concurrent use.
+type benchStats struct {
+	mutex sync.Mutex
+	ops   map[string]*benchOpStats
+}
+
+func newBenchStats() *benchStats {
+	return &benchStats{ops: make(map[string]*benchOpStats)}
+}
+
+// record adds a request of operation op which transferred size bytes
+// in latency. Failed requests only count as errors.
+func (s *benchStats) record(op string, size int64, latency time.Duration, err *probe.Error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	stats, ok := s.ops[op]
+	if !ok {
+		stats = &benchOpStats{}
+		s.ops[op] = stats
+	}
+	if err != nil {
+		stats.errors++
+		stats.lastError = err.ToGoError().Error()
+		return
+	}
+	stats.latencies = append(stats.latencies, latency)
+	stats.bytes += size
+}
+
+// percentile returns the p-th percentile of sorted latencies using the
+// nearest rank method.
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := int(p/100*float64(len(sorted))+0.5) - 1
+	if rank < 0 {
+		rank = 0
+	}
+	if rank >= len(sorted) {
+		rank = len(sorted) - 1
+	}
+	return sorted[rank]
+}
+
+// milliseconds converts d to fractional milliseconds.
+func milliseconds(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
+
+// report summarizes all operations over elapsed, sorted by operation.
+func (s *benchStats) report(elapsed time.Duration) []benchOpReport {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	var reports []benchOpReport
+	for op, stats := range s.ops {
+		latencies := append([]time.Duration(nil), stats.latencies...)
+		sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+
+		requests := int64(len(latencies)) + stats.errors
+		report := benchOpReport{
+			Operation: op,
+			Requests:  requests,
+			Errors:    stats.errors,
+			Bytes:     stats.bytes,
+			LastError: stats.lastError,
+		}
+		if requests > 0 {
+			report.ErrorRate = float64(stats.errors) / float64(requests)
+		}
+		if elapsed > 0 {
+			report.Throughput = float64(stats.bytes) / elapsed.Seconds()
+			report.RequestsPerSec = float64(requests) / elapsed.Seconds()
+		}
+		if len(latencies) > 0 {
+			var total time.Duration
+			for _, latency := range latencies {
+				total += latency
+			}
+			report.Latency = benchLatency{
+				Min: milliseconds(latencies[0]),
+				Avg: milliseconds(total / time.Duration(len(latencies))),
+				P50: milliseconds(percentile(latencies, 50)),
+				P90: milliseconds(percentile(latencies, 90)),
+				P99: milliseconds(percentile(latencies, 99)),
+				Max: milliseconds(latencies[len(latencies)-1]),
+			}
+		}
+		reports = append(reports, report)
+	}
+	sort.Slice(reports, func(i, j int) bool { return reports[i].Operation < reports[j].Operation })
+	return reports
+}
@@ -0,0 +1,73 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/minio/mc/pkg/probe"
+)
+
+func TestPercentile(t *testing.T) {
+	var latencies []time.Duration
+	for i := 1; i <= 100; i++ {
+		latencies = append(latencies, time.Duration(i)*time.Millisecond)
+	}
+	testCases := []struct {
+		sorted   []time.Duration
+		p        float64
+		expected time.Duration
+	}{
+		{nil, 50, 0},
+		{[]time.Duration{time.Second}, 99, time.Second},
+		{latencies, 0, time.Millisecond},
+		{latencies, 50, 50 * time.Millisecond},
+		{latencies, 90, 90 * time.Millisecond},
+		{latencies, 99, 99 * time.Millisecond},
+		{latencies, 100, 100 * time.Millisecond},
+	}
+	for i, testCase := range testCases {
+		if got := percentile(testCase.sorted, testCase.p); got != testCase.expected {
+			t.Errorf("Test %d: expected %v, got %v", i+1, testCase.expected, got)
+		}
+	}
+}
+
+func TestBenchStatsReport(t *testing.T) {
+	stats := newBenchStats()
+	stats.record("PUT", 1000, 30*time.Millisecond, nil)
+	stats.record("PUT", 1000, 10*time.Millisecond, nil)
+	stats.record("PUT", 0, time.Millisecond, probe.NewError(errors.New("slow down")))
+	stats.record("GET", 2000, 20*time.Millisecond, nil)
+
+	reports := stats.report(2 * time.Second)
+	if len(reports) != 2 || reports[0].Operation != "GET" || reports[1].Operation != "PUT" {
+		t.Fatalf("unexpected reports %v", reports)
+	}
+	put := reports[1]
+	if put.Requests != 3 || put.Errors != 1 || put.Bytes != 2000 || put.LastError != "slow down" {
+		t.Errorf("unexpected PUT report %+v", put)
+	}
+	if put.Throughput != 1000 || put.RequestsPerSec != 1.5 {
+		t.Errorf("expected 1000 B/s and 1.5 req/s, got %v B/s and %v req/s", put.Throughput, put.RequestsPerSec)
+	}
+	if put.Latency.Min != 10 || put.Latency.Max != 30 || put.Latency.Avg != 20 {
+		t.Errorf("unexpected PUT latency %+v", put.Latency)
+	}
+}
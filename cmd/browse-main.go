@@ -0,0 +1,106 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"strings"
+	"time"
+
+	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/probe"
+)
+
+var browseFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:  "expire, E",
+		Value: "168h",
+		Usage: "expiry of the URLs generated with the share key",
+	},
+}
+
+// Browse aliases, buckets and objects interactively.
+var browseCmd = cli.Command{
+	Name:   "browse",
+	Usage:  "browse aliases, buckets and objects in a terminal UI",
+	Action: mainBrowse,
+	Before: setGlobalsFromContext,
+	Flags:  append(browseFlags, globalFlags...),
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [FLAGS] [TARGET]
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+KEYS:
+  Enter, Right     open a bucket or folder, show details of an object
+  Backspace, Left  go back to the parent folder
+  i                show details of the selected entry
+  c                copy the selected object to another location
+  d                remove the selected object
+  s                generate a download URL for the selected object
+  r                refresh the current listing
+  q, Esc           quit
+
+EXAMPLES:
+  1. Browse all configured aliases.
+     $ {{.HelpName}}
+
+  2. Browse the bucket "mybucket" on MinIO object storage server.
+     $ {{.HelpName}} myminio/mybucket
+
+  3. Browse a folder, sharing objects with a 2 hours expiry.
+     $ {{.HelpName}} --expire 2h myminio/mybucket/photos/
+`,
+}
+
+// checkBrowseSyntax - validate all the passed arguments
+func checkBrowseSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) > 1 {
+		cli.ShowCommandHelpAndExit(ctx, "browse", 1) // last argument is exit code
+	}
+	if globalJSON {
+		fatalIf(errInvalidArgument().Trace(), "JSON output is not supported by `browse`.")
+	}
+	if !isTerminal() {
+		fatalIf(errInvalidArgument().Trace(), "`browse` needs an interactive terminal.")
+	}
+	if _, e := time.ParseDuration(ctx.String("expire")); e != nil {
+		fatalIf(probe.NewError(e), "Unable to parse expire=`"+ctx.String("expire")+"`.")
+	}
+}
+
+// mainBrowse is the entry point for browse command.
+func mainBrowse(ctx *cli.Context) error {
+	checkBrowseSyntax(ctx)
+
+	expiry, _ := time.ParseDuration(ctx.String("expire"))
+
+	// Start at the alias list unless a target is given.
+	target := ctx.Args().First()
+	if target != "" && !strings.HasSuffix(target, "/") {
+		target += "/"
+	}
+
+	b := newBrowser(expiry)
+	if e := b.run(target); e != nil {
+		fatalIf(probe.NewError(e), "Unable to start the terminal UI.")
+	}
+	return nil
+}
@@ -0,0 +1,474 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	humanize "github.com/dustin/go-humanize"
+	"github.com/gdamore/tcell"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/rivo/tview"
+)
+
+// browseEntry is a single line in the browser listing.
+type browseEntry struct {
+	name string
+	url  string
+	// content is nil for aliases.
+	content *clientContent
+}
+
+func (e browseEntry) isDir() bool {
+	return e.content == nil || e.content.Type.IsDir()
+}
+
+// browser holds the state of the terminal UI.
+type browser struct {
+	app     *tview.Application
+	pages   *tview.Pages
+	list    *tview.List
+	details *tview.TextView
+	status  *tview.TextView
+
+	expiry time.Duration
+
+	// current is the aliased URL being listed, empty lists aliases.
+	current string
+	entries []browseEntry
+}
+
+// browseJoin returns the aliased URL of name inside dir.
+func browseJoin(dir, name string) string {
+	if dir == "" {
+		return name
+	}
+	if !strings.HasSuffix(dir, "/") {
+		dir += "/"
+	}
+	return dir + name
+}
+
+// browseParent returns the aliased URL of the parent folder, the
+// parent of an alias is the alias list represented by "".
+func browseParent(url string) string {
+	url = strings.TrimSuffix(url, "/")
+	i := strings.LastIndex(url, "/")
+	if i < 0 {
+		return ""
+	}
+	return url[:i+1]
+}
+
+// browseList lists aliases when url is empty, otherwise the entries
+// directly below url with folders first.
+func browseList(url string) ([]browseEntry, *probe.Error) {
+	var entries []browseEntry
+	if url == "" {
+		conf, err := loadMcConfig()
+		if err != nil {
+			return nil, err.Trace()
+		}
+		for alias := range conf.Hosts {
+			entries = append(entries, browseEntry{name: alias + "/", url: alias + "/"})
+		}
+		sort.Slice(entries, func(i, j int) bool { return entries[i].name < entries[j].name })
+		return entries, nil
+	}
+
+	clnt, err := newClient(url)
+	if err != nil {
+		return nil, err.Trace(url)
+	}
+	for content := range clnt.List(false, false, DirNone) {
+		if content.Err != nil {
+			return nil, content.Err.Trace(url)
+		}
+		name := path.Base(strings.TrimSuffix(content.URL.Path, string(content.URL.Separator)))
+		if content.Type.IsDir() {
+			name += "/"
+		}
+		entries = append(entries, browseEntry{name: name, url: browseJoin(url, name), content: content})
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		if entries[i].isDir() != entries[j].isDir() {
+			return entries[i].isDir()
+		}
+		return entries[i].name < entries[j].name
+	})
+	return entries, nil
+}
+
+// newBrowser lays out the list, details and status views.
+func newBrowser(expiry time.Duration) *browser {
+	b := &browser{
+		app:     tview.NewApplication(),
+		pages:   tview.NewPages(),
+		list:    tview.NewList().ShowSecondaryText(false),
+		details: tview.NewTextView().SetDynamicColors(true).SetWordWrap(true),
+		status:  tview.NewTextView().SetDynamicColors(true),
+		expiry:  expiry,
+	}
+	b.list.SetBorder(true)
+	b.details.SetBorder(true).SetTitle(" Details ")
+	b.list.SetChangedFunc(func(i int, _, _ string, _ rune) {
+		b.summarize(i)
+	})
+	b.list.SetSelectedFunc(func(i int, _, _ string, _ rune) {
+		b.open(i)
+	})
+	b.list.SetInputCapture(b.handleKey)
+
+	body := tview.NewFlex().
+		AddItem(b.list, 0, 2, true).
+		AddItem(b.details, 0, 1, false)
+	layout := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(body, 0, 1, true).
+		AddItem(b.status, 1, 0, false)
+	b.pages.AddPage("main", layout, true, true)
+	b.app.SetRoot(b.pages, true)
+	return b
+}
+
+// run shows url and blocks until the user quits.
+func (b *browser) run(url string) error {
+	b.load(url, "")
+	return b.app.Run()
+}
+
+// setStatus shows msg in the status line, it must run on the UI goroutine.
+func (b *browser) setStatus(msg string) {
+	b.status.SetText(tview.Escape(msg))
+}
+
+// setError shows err in the status line, it must run on the UI goroutine.
+func (b *browser) setError(msg string, err *probe.Error) {
+	b.status.SetText("[red]" + tview.Escape(msg+" "+err.ToGoError().Error()))
+}
+
+// background runs fn outside of the UI goroutine and applies its
+// result with done on the UI goroutine.
+func (b *browser) background(msg string, fn func() func()) {
+	b.setStatus(msg)
+	go func() {
+		done := fn()
+		b.app.QueueUpdateDraw(done)
+	}()
+}
+
+// load lists url and selects the entry called selectName if present.
+func (b *browser) load(url, selectName string) {
+	b.background("Loading "+url+" ...", func() func() {
+		entries, err := browseList(url)
+		return func() {
+			if err != nil {
+				b.setError("Unable to list `"+url+"`.", err)
+				return
+			}
+			b.current = url
+			b.entries = entries
+			title := url
+			if title == "" {
+				title = "aliases"
+			}
+			b.list.Clear()
+			b.list.SetTitle(" " + tview.Escape(title) + " ")
+			selected := 0
+			for i, entry := range entries {
+				b.list.AddItem(tview.Escape(entry.name), "", 0, nil)
+				if entry.name == selectName {
+					selected = i
+				}
+			}
+			b.list.SetCurrentItem(selected)
+			b.summarize(selected)
+			b.setStatus(fmt.Sprintf("%d entries. Enter: open  Backspace: back  i: info  c: copy  d: remove  s: share  r: refresh  q: quit", len(entries)))
+		}
+	})
+}
+
+// selected returns the entry under the cursor.
+func (b *browser) selected() (browseEntry, bool) {
+	i := b.list.GetCurrentItem()
+	if i < 0 || i >= len(b.entries) {
+		return browseEntry{}, false
+	}
+	return b.entries[i], true
+}
+
+// summarize shows what is known about entry i from the listing.
+func (b *browser) summarize(i int) {
+	if i < 0 || i >= len(b.entries) {
+		b.details.SetText("")
+		return
+	}
+	entry := b.entries[i]
+	text := "[yellow]Name:[white] " + tview.Escape(entry.url) + "\n"
+	if entry.content != nil && !entry.isDir() {
+		text += fmt.Sprintf("[yellow]Size:[white] %s\n", humanize.IBytes(uint64(entry.content.Size)))
+		text += fmt.Sprintf("[yellow]Date:[white] %s\n", entry.content.Time.Local().Format(printDate))
+	}
+	b.details.SetText(text)
+}
+
+// open enters folders and shows details of objects.
+func (b *browser) open(i int) {
+	if i < 0 || i >= len(b.entries) {
+		return
+	}
+	if entry := b.entries[i]; entry.isDir() {
+		b.load(entry.url, "")
+		return
+	}
+	b.info()
+}
+
+// up goes back to the parent folder and keeps the current folder selected.
+func (b *browser) up() {
+	if b.current == "" {
+		return
+	}
+	parent := browseParent(b.current)
+	b.load(parent, strings.TrimPrefix(b.current, parent))
+}
+
+// info fetches and shows the full metadata of the selected entry.
+func (b *browser) info() {
+	entry, ok := b.selected()
+	if !ok || entry.content == nil {
+		return
+	}
+	b.background("Fetching details of "+entry.url+" ...", func() func() {
+		var st *clientContent
+		clnt, err := newClient(entry.url)
+		if err == nil {
+			st, err = clnt.Stat(false, true, nil)
+		}
+		return func() {
+			if err != nil {
+				b.setError("Unable to stat `"+entry.url+"`.", err)
+				return
+			}
+			b.details.SetText(browseDetails(entry.url, st))
+			b.setStatus("")
+		}
+	})
+}
+
+// browseDetails formats the stat of an entry for the details view.
+func browseDetails(url string, st *clientContent) string {
+	var sb strings.Builder
+	field := func(name, value string) {
+		if value != "" {
+			fmt.Fprintf(&sb, "[yellow]%s:[white] %s\n", name, tview.Escape(value))
+		}
+	}
+	field("Name", url)
+	if st.Type.IsDir() {
+		field("Type", "folder")
+		return sb.String()
+	}
+	field("Type", "file")
+	field("Size", humanize.IBytes(uint64(st.Size)))
+	field("Date", st.Time.Local().Format(printDate))
+	field("ETag", st.ETag)
+	field("Class", st.StorageClass)
+	if len(st.Metadata) > 0 {
+		keys := make([]string, 0, len(st.Metadata))
+		for k := range st.Metadata {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		sb.WriteString("[yellow]Metadata:[white]\n")
+		for _, k := range keys {
+			fmt.Fprintf(&sb, "  %s: %s\n", tview.Escape(k), tview.Escape(st.Metadata[k]))
+		}
+	}
+	return sb.String()
+}
+
+// share generates a download URL for the selected object.
+func (b *browser) share() {
+	entry, ok := b.selected()
+	if !ok || entry.isDir() {
+		return
+	}
+	b.background("Sharing "+entry.url+" ...", func() func() {
+		var shareURL string
+		clnt, err := newClient(entry.url)
+		if err == nil {
+			shareURL, err = clnt.ShareDownload(b.expiry)
+		}
+		return func() {
+			if err != nil {
+				b.setError("Unable to share `"+entry.url+"`.", err)
+				return
+			}
+			b.details.SetText(fmt.Sprintf("[yellow]Share:[white] %s\n[yellow]Expire:[white] %s\n\n%s\n",
+				tview.Escape(entry.url), timeDurationToHumanizedDuration(b.expiry), tview.Escape(shareURL)))
+			b.setStatus("")
+		}
+	})
+}
+
+// remove asks for confirmation and removes the selected object.
+func (b *browser) remove() {
+	entry, ok := b.selected()
+	if !ok || entry.content == nil {
+		return
+	}
+	if entry.isDir() {
+		b.setStatus("Folders are not removed from the browser, use `mc rm --recursive`.")
+		return
+	}
+	b.confirm("Remove `"+entry.url+"`?", func() {
+		b.background("Removing "+entry.url+" ...", func() func() {
+			err := browseRemove(entry.url)
+			return func() {
+				if err != nil {
+					b.setError("Unable to remove `"+entry.url+"`.", err)
+					return
+				}
+				b.load(b.current, "")
+			}
+		})
+	})
+}
+
+// browseRemove removes a single object.
+func browseRemove(url string) *probe.Error {
+	alias, urlStrFull, _, err := expandAlias(url)
+	if err != nil {
+		return err.Trace(url)
+	}
+	clnt, err := newClientFromAlias(alias, urlStrFull)
+	if err != nil {
+		return err.Trace(url)
+	}
+	contentCh := make(chan *clientContent, 1)
+	contentCh <- &clientContent{URL: *newClientURL(urlStrFull)}
+	close(contentCh)
+	for err = range clnt.Remove(false, false, contentCh) {
+		if err != nil {
+			return err.Trace(url)
+		}
+	}
+	return nil
+}
+
+// copy asks for a target and copies the selected object there.
+func (b *browser) copy() {
+	entry, ok := b.selected()
+	if !ok || entry.isDir() {
+		return
+	}
+	b.prompt("Copy to: ", entry.url, func(target string) {
+		if strings.HasSuffix(target, "/") {
+			target += path.Base(entry.url)
+		}
+		b.background("Copying "+entry.url+" to "+target+" ...", func() func() {
+			err := browseCopy(entry.url, target, entry.content.Size)
+			return func() {
+				if err != nil {
+					b.setError("Unable to copy `"+entry.url+"`.", err)
+					return
+				}
+				b.load(b.current, entry.name)
+			}
+		})
+	})
+}
+
+// browseCopy streams source to target, which may be on another alias.
+func browseCopy(source, target string, size int64) *probe.Error {
+	reader, err := getSourceStreamFromURL(source, nil)
+	if err != nil {
+		return err.Trace(source)
+	}
+	defer reader.Close()
+	if _, err = putTargetStreamWithURL(target, reader, size, nil, "", nil); err != nil {
+		return err.Trace(source, target)
+	}
+	return nil
+}
+
+// confirm shows a yes/no dialog and calls yes on confirmation.
+func (b *browser) confirm(text string, yes func()) {
+	modal := tview.NewModal().
+		SetText(text).
+		AddButtons([]string{"No", "Yes"}).
+		SetDoneFunc(func(_ int, label string) {
+			b.pages.RemovePage("dialog")
+			if label == "Yes" {
+				yes()
+			}
+		})
+	b.pages.AddPage("dialog", modal, false, true)
+}
+
+// prompt asks for a line of input and calls done unless cancelled.
+func (b *browser) prompt(label, value string, done func(string)) {
+	input := tview.NewInputField().SetLabel(label).SetText(value)
+	input.SetBorder(true)
+	input.SetDoneFunc(func(key tcell.Key) {
+		b.pages.RemovePage("dialog")
+		if key == tcell.KeyEnter && input.GetText() != "" {
+			done(input.GetText())
+		}
+	})
+	dialog := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(nil, 0, 1, false).
+		AddItem(input, 3, 0, true).
+		AddItem(nil, 0, 1, false)
+	b.pages.AddPage("dialog", dialog, true, true)
+}
+
+// handleKey maps the browser key bindings on the listing.
+func (b *browser) handleKey(event *tcell.EventKey) *tcell.EventKey {
+	switch event.Key() {
+	case tcell.KeyBackspace, tcell.KeyBackspace2, tcell.KeyLeft:
+		b.up()
+		return nil
+	case tcell.KeyRight:
+		b.open(b.list.GetCurrentItem())
+		return nil
+	case tcell.KeyEscape:
+		b.app.Stop()
+		return nil
+	}
+	switch event.Rune() {
+	case 'q':
+		b.app.Stop()
+	case 'i':
+		b.info()
+	case 'c':
+		b.copy()
+	case 'd':
+		b.remove()
+	case 's':
+		b.share()
+	case 'r':
+		b.load(b.current, "")
+	default:
+		return event
+	}
+	return nil
+}
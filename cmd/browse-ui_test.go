@@ -0,0 +1,55 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import "testing"
+
+func TestBrowseParent(t *testing.T) {
+	testCases := []struct {
+		url    string
+		parent string
+	}{
+		{"", ""},
+		{"myminio/", ""},
+		{"myminio", ""},
+		{"myminio/mybucket/", "myminio/"},
+		{"myminio/mybucket/photos/2019/", "myminio/mybucket/photos/"},
+		{"myminio/mybucket/photos/image.jpg", "myminio/mybucket/photos/"},
+	}
+	for i, testCase := range testCases {
+		if parent := browseParent(testCase.url); parent != testCase.parent {
+			t.Errorf("Test %d: expected parent `%s`, got `%s`", i+1, testCase.parent, parent)
+		}
+	}
+}
+
+func TestBrowseJoin(t *testing.T) {
+	testCases := []struct {
+		dir  string
+		name string
+		url  string
+	}{
+		{"", "myminio/", "myminio/"},
+		{"myminio/", "mybucket/", "myminio/mybucket/"},
+		{"myminio/mybucket", "image.jpg", "myminio/mybucket/image.jpg"},
+	}
+	for i, testCase := range testCases {
+		if url := browseJoin(testCase.dir, testCase.name); url != testCase.url {
+			t.Errorf("Test %d: expected `%s`, got `%s`", i+1, testCase.url, url)
+		}
+	}
+}
@@ -0,0 +1,108 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/console"
+	"github.com/minio/mc/pkg/probe"
+)
+
+var cacheClearCmd = cli.Command{
+	Name:   "clear",
+	Usage:  "remove cached listings",
+	Action: mainCacheClear,
+	Before: setGlobalsFromContext,
+	Flags:  globalFlags,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [ALIAS]
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+ENVIRONMENT VARIABLES:
+  MC_CACHE_TTL:  time a cached listing is used for, i.e. 30s or 1h (default 5m)
+
+EXAMPLES:
+  1. Remove all cached listings.
+     $ {{.HelpName}}
+
+  2. Remove cached listings of alias 'myminio' after uploading new objects.
+     $ {{.HelpName}} myminio
+`,
+}
+
+// cacheMessage container for cache messages.
+type cacheMessage struct {
+	Status string `json:"status"`
+	Alias  string `json:"alias,omitempty"`
+	op     string
+}
+
+// String colorized cache message.
+func (c cacheMessage) String() string {
+	target := "all aliases"
+	if c.Alias != "" {
+		target = "`" + c.Alias + "`"
+	}
+	switch c.op {
+	case "disable":
+		return console.Colorize("Cache", "Caching listings of "+target+" is disabled.")
+	case "enable":
+		return console.Colorize("Cache", "Caching listings of "+target+" is enabled.")
+	}
+	return console.Colorize("Cache", "Cached listings of "+target+" are removed.")
+}
+
+// JSON jsonified cache message.
+func (c cacheMessage) JSON() string {
+	c.Status = "success"
+	cacheMessageBytes, e := json.MarshalIndent(c, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(cacheMessageBytes)
+}
+
+// checkCacheClearSyntax - validate all the passed arguments
+func checkCacheClearSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) > 1 {
+		cli.ShowCommandHelpAndExit(ctx, "clear", 1) // last argument is exit code
+	}
+}
+
+// mainCacheClear is the handle for "mc cache clear" command.
+func mainCacheClear(ctx *cli.Context) error {
+	checkCacheClearSyntax(ctx)
+
+	console.SetColor("Cache", color.New(color.FgGreen, color.Bold))
+
+	alias := strings.TrimSuffix(ctx.Args().First(), "/")
+	if alias != "" && !isValidAlias(alias) {
+		fatalIf(errInvalidAlias(alias), "Invalid alias.")
+	}
+	fatalIf(clearCache(alias).Trace(alias), "Unable to remove cached listings.")
+
+	printMsg(cacheMessage{Alias: alias})
+	return nil
+}
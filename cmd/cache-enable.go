@@ -0,0 +1,70 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/console"
+)
+
+var cacheEnableCmd = cli.Command{
+	Name:   "enable",
+	Usage:  "cache listings of an alias",
+	Action: mainCacheEnableDisable,
+	Before: setGlobalsFromContext,
+	Flags:  globalFlags,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} ALIAS
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+  1. Cache listings of alias 'myminio' again after disabling it.
+     $ {{.HelpName}} myminio
+`,
+}
+
+// checkCacheEnableSyntax - validate all the passed arguments
+func checkCacheEnableSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) != 1 {
+		cli.ShowCommandHelpAndExit(ctx, ctx.Command.Name, 1) // last argument is exit code
+	}
+}
+
+// mainCacheEnableDisable is the handle for "mc cache enable|disable" command.
+func mainCacheEnableDisable(ctx *cli.Context) error {
+	checkCacheEnableSyntax(ctx)
+
+	console.SetColor("Cache", color.New(color.FgGreen, color.Bold))
+
+	alias := strings.TrimSuffix(ctx.Args().First(), "/")
+	if !isValidAlias(alias) {
+		fatalIf(errInvalidAlias(alias), "Invalid alias.")
+	}
+	isDisable := ctx.Command.Name == "disable"
+	fatalIf(setCacheDisabled(alias, isDisable).Trace(alias), "Unable to "+ctx.Command.Name+" caching of `"+alias+"`.")
+
+	printMsg(cacheMessage{Alias: alias, op: ctx.Command.Name})
+	return nil
+}
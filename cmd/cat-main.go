@@ -44,7 +44,7 @@ var catCmd = cli.Command{
 	Usage:  "display object contents",
 	Action: mainCat,
 	Before: setGlobalsFromContext,
-	Flags:  append(append(catFlags, ioFlags...), globalFlags...),
+	Flags:  append(append(append(catFlags, cseFlags...), ioFlags...), globalFlags...),
 	CustomHelpTemplate: `NAME:
   {{.HelpName}} - {{.Usage}}
 
@@ -56,6 +56,7 @@ FLAGS:
   {{end}}{{end}}
 ENVIRONMENT VARIABLES:
   MC_ENCRYPT_KEY:  list of comma delimited prefix=secret values
+  MC_ENCRYPT_CLIENT_KEY:  key encrypting objects client-side
 
 EXAMPLES:
   1. Stream an object from Amazon S3 cloud storage to mplayer standard input.
@@ -73,6 +74,9 @@ EXAMPLES:
   5. Display the content of encrypted object. In case the encryption key contains non-printable character like tab, pass the
      base64 encoded string as key.
      $ {{.HelpName}} --encrypt-key "play/my-bucket/=MzJieXRlc2xvbmdzZWNyZXRrZQltdXN0YmVnaXZlbjE="  play/my-bucket/my-object
+
+  6. Display the content of an object encrypted client-side, with a key read from a file.
+     $ {{.HelpName}} --encrypt-client-key-file ~/.mc/backup.key s3/mybucket/backup/db.sql
 `,
 }
 
@@ -139,7 +143,7 @@ func checkCatSyntax(ctx *cli.Context) {
 }
 
 // catURL displays contents of a URL to stdout.
-func catURL(sourceURL string, encKeyDB map[string][]prefixSSEPair) *probe.Error {
+func catURL(sourceURL string, encKeyDB map[string][]prefixSSEPair, cseKey []byte) *probe.Error {
 	var reader io.ReadCloser
 	size := int64(-1)
 	switch sourceURL {
@@ -152,14 +156,35 @@ func catURL(sourceURL string, encKeyDB map[string][]prefixSSEPair) *probe.Error
 		// downloaded object is equal to the original one. FS files
 		// are ignored since some of them have zero size though they
 		// have contents like files under /proc.
-		client, content, err := url2Stat(sourceURL, false, encKeyDB)
+		var encoding string
+		var metadata map[string]string
+		client, content, err := url2Stat(sourceURL, true, encKeyDB)
 		if err == nil && client.GetURL().Type == objectStorage {
 			size = content.Size
+			encoding = content.Metadata["Content-Encoding"]
+			metadata = content.Metadata
 		}
 		if reader, err = getSourceStreamFromURL(sourceURL, encKeyDB); err != nil {
 			return err.Trace(sourceURL)
 		}
 		defer reader.Close()
+
+		// Transparently decrypt objects encrypted client-side.
+		if isCSEEncrypted(metadata) {
+			if reader, err = newCSEDecryptReader(reader, cseKey, metadata); err != nil {
+				return err.Trace(sourceURL)
+			}
+			size = cseDecryptedSize(size)
+		}
+
+		// Transparently decompress objects uploaded with compression.
+		if isDecompressible(encoding) {
+			if reader, err = newDecompressReader(reader, encoding); err != nil {
+				return err.Trace(sourceURL)
+			}
+			defer reader.Close()
+			size = -1
+		}
 	}
 	return catOut(reader, size).Trace(sourceURL)
 }
@@ -218,6 +243,9 @@ func mainCat(ctx *cli.Context) error {
 	// check 'cat' cli arguments.
 	checkCatSyntax(ctx)
 
+	cseKey, err := getCSEKey(ctx)
+	fatalIf(err, "Unable to parse client-side encryption key.")
+
 	// Set command flags from context.
 	stdinMode := false
 	if !ctx.Args().Present() {
@@ -244,7 +272,7 @@ func mainCat(ctx *cli.Context) error {
 
 	// Convert arguments to URLs: expand alias, fix format.
 	for _, url := range args {
-		fatalIf(catURL(url, encKeyDB).Trace(url), "Unable to read from `"+url+"`.")
+		fatalIf(catURL(url, encKeyDB, cseKey).Trace(url), "Unable to read from `"+url+"`.")
 	}
 
 	return nil
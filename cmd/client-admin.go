@@ -17,7 +17,6 @@
 package cmd
 
 import (
-	"crypto/tls"
 	"fmt"
 	"hash/fnv"
 	"net"
@@ -54,7 +53,7 @@ func newAdminFactory() func(config *Config) (*madmin.AdminClient, *probe.Error)
 
 		// Generate a hash out of s3Conf.
 		confHash := fnv.New32a()
-		confHash.Write([]byte(hostName + config.AccessKey + config.SecretKey))
+		confHash.Write([]byte(config.Alias + hostName + config.AccessKey + config.SecretKey))
 		confSum := confHash.Sum32()
 
 		// Lookup previous cache by hash.
@@ -71,9 +70,9 @@ func newAdminFactory() func(config *Config) (*madmin.AdminClient, *probe.Error)
 			}
 
 			// Keep TLS config.
-			tlsConfig := &tls.Config{RootCAs: globalRootCAs}
-			if config.Insecure {
-				tlsConfig.InsecureSkipVerify = true
+			tlsConfig, err := newTLSConfig(config)
+			if err != nil {
+				return nil, err.Trace(config.Alias)
 			}
 
 			var transport http.RoundTripper = &http.Transport{
@@ -93,6 +92,8 @@ func newAdminFactory() func(config *Config) (*madmin.AdminClient, *probe.Error)
 				transport = httptracer.GetNewTraceTransport(newTraceV4(), transport)
 			}
 
+			transport = newHTTPLogTransport(transport)
+
 			// Set custom transport.
 			api.SetCustomTransport(transport)
 
@@ -125,6 +126,7 @@ func newAdminClient(aliasedURL string) (*madmin.AdminClient, *probe.Error) {
 	}
 
 	s3Config := newS3Config(urlStrFull, hostCfg)
+	s3Config.Alias = alias
 
 	s3Client, err := s3AdminNew(s3Config)
 	if err != nil {
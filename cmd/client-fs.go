@@ -140,6 +140,13 @@ func (f *fsClient) Watch(params watchParams) (*watchObject, *probe.Error) {
 		}
 	}
 
+	// Prefix and suffix filters apply to paths relative to the watched
+	// directory, the same way they apply to object names on a bucket.
+	rootPath, e := filepath.Abs(f.PathURL.Path)
+	if e != nil {
+		return nil, probe.NewError(e)
+	}
+
 	// Set up a watchpoint listening for events within a directory tree rooted
 	// at current working directory. Dispatch remove events to c.
 	recursivePath := f.PathURL.Path
@@ -168,6 +175,10 @@ func (f *fsClient) Watch(params watchParams) (*watchObject, *probe.Error) {
 			if isIgnoredFile(event.Path()) {
 				continue
 			}
+			relPath, e := filepath.Rel(rootPath, event.Path())
+			if e != nil || !params.matches(filepath.ToSlash(relPath)) {
+				continue
+			}
 			var i os.FileInfo
 			if IsPutEvent(event.Event()) {
 				// Look for any writes, send a response to indicate a full copy.
@@ -363,6 +374,22 @@ func (f *fsClient) ShareUpload(startsWith bool, expires time.Duration, contentTy
 	})
 }
 
+// Restore - restore not implemented for filesystem.
+func (f *fsClient) Restore(days int) *probe.Error {
+	return probe.NewError(APINotImplemented{
+		API:     "Restore",
+		APIType: "filesystem",
+	})
+}
+
+// GetTags - object tagging not implemented for filesystem.
+func (f *fsClient) GetTags() (map[string]string, *probe.Error) {
+	return nil, probe.NewError(APINotImplemented{
+		API:     "GetTags",
+		APIType: "filesystem",
+	})
+}
+
 // readFile reads and returns the data inside the file located
 // at the provided filepath.
 func readFile(fpath string) (io.ReadCloser, error) {
@@ -0,0 +1,274 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Request headers and query parameters carrying credentials, their
+// values are redacted by --debug-http and --debug-http-har.
+var (
+	redactedHeaders = map[string]bool{
+		"X-Amz-Security-Token":                                  true,
+		"X-Amz-Server-Side-Encryption-Customer-Key":             true,
+		"X-Amz-Copy-Source-Server-Side-Encryption-Customer-Key": true,
+	}
+	redactedQueryParams = map[string]bool{
+		"X-Amz-Signature":      true,
+		"X-Amz-Credential":     true,
+		"X-Amz-Security-Token": true,
+		"Signature":            true,
+		"AWSAccessKeyId":       true,
+	}
+	// Credential and signature of V4 and V2 authorization headers.
+	authCredentialRegex = regexp.MustCompile("(Credential=|AWS )[^/:]+")
+	authSignatureRegex  = regexp.MustCompile("(Signature=|:)[0-9a-zA-Z+/=]+$")
+)
+
+const redactedValue = "**REDACTED**"
+
+// redactHeaders - returns a copy of headers with credentials redacted.
+func redactHeaders(headers http.Header) http.Header {
+	redacted := make(http.Header, len(headers))
+	for name, values := range headers {
+		values = append([]string(nil), values...)
+		for i, value := range values {
+			switch {
+			case name == "Authorization":
+				value = authCredentialRegex.ReplaceAllString(value, "${1}"+redactedValue)
+				values[i] = authSignatureRegex.ReplaceAllString(value, "${1}"+redactedValue)
+			case redactedHeaders[name]:
+				values[i] = redactedValue
+			}
+		}
+		redacted[name] = values
+	}
+	return redacted
+}
+
+// redactURL - returns u as string with credentials in the query redacted.
+func redactURL(u *url.URL) string {
+	if u.RawQuery == "" {
+		return u.String()
+	}
+	redacted := *u
+	query := u.Query()
+	for name := range query {
+		if redactedQueryParams[name] {
+			query.Set(name, redactedValue)
+		}
+	}
+	redacted.RawQuery = query.Encode()
+	return redacted.String()
+}
+
+// harNameValue - a header or query parameter of a HAR entry.
+type harNameValue struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+func harHeaders(headers http.Header) []harNameValue {
+	nvs := []harNameValue{}
+	for name, values := range headers {
+		for _, value := range values {
+			nvs = append(nvs, harNameValue{Name: name, Value: value})
+		}
+	}
+	sort.Slice(nvs, func(i, j int) bool { return nvs[i].Name < nvs[j].Name })
+	return nvs
+}
+
+// harEntry - an HTTP request and its response in HAR 1.2 format.
+type harEntry struct {
+	StartedDateTime time.Time `json:"startedDateTime"`
+	Time            float64   `json:"time"`
+	Request         struct {
+		Method      string         `json:"method"`
+		URL         string         `json:"url"`
+		HTTPVersion string         `json:"httpVersion"`
+		Headers     []harNameValue `json:"headers"`
+		QueryString []harNameValue `json:"queryString"`
+		Cookies     []harNameValue `json:"cookies"`
+		HeadersSize int            `json:"headersSize"`
+		BodySize    int64          `json:"bodySize"`
+	} `json:"request"`
+	Response struct {
+		Status      int            `json:"status"`
+		StatusText  string         `json:"statusText"`
+		HTTPVersion string         `json:"httpVersion"`
+		Headers     []harNameValue `json:"headers"`
+		Cookies     []harNameValue `json:"cookies"`
+		Content     struct {
+			Size     int64  `json:"size"`
+			MimeType string `json:"mimeType"`
+		} `json:"content"`
+		RedirectURL string `json:"redirectURL"`
+		HeadersSize int    `json:"headersSize"`
+		BodySize    int64  `json:"bodySize"`
+	} `json:"response"`
+	Cache   struct{} `json:"cache"`
+	Timings struct {
+		Send    float64 `json:"send"`
+		Wait    float64 `json:"wait"`
+		Receive float64 `json:"receive"`
+	} `json:"timings"`
+}
+
+// harLog - HTTP requests of this command, rewritten to the HAR file
+// after every request so that it is complete even if mc exits on error.
+type harLog struct {
+	mutex   sync.Mutex
+	file    string
+	entries []harEntry
+}
+
+func (h *harLog) add(entry harEntry) error {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	h.entries = append(h.entries, entry)
+
+	var har struct {
+		Log struct {
+			Version string `json:"version"`
+			Creator struct {
+				Name    string `json:"name"`
+				Version string `json:"version"`
+			} `json:"creator"`
+			Entries []harEntry `json:"entries"`
+		} `json:"log"`
+	}
+	har.Log.Version = "1.2"
+	har.Log.Creator.Name = "mc"
+	har.Log.Creator.Version = Version
+	har.Log.Entries = h.entries
+	harBytes, e := json.MarshalIndent(har, "", "  ")
+	if e != nil {
+		return e
+	}
+	return ioutil.WriteFile(h.file, harBytes, 0600)
+}
+
+var (
+	globalHARLogOnce sync.Once
+	globalHARLog     *harLog
+)
+
+// httpLogTransport - logs all requests to stderr and/or a HAR file.
+type httpLogTransport struct {
+	transport http.RoundTripper
+	har       *harLog
+}
+
+// newHTTPLogTransport - returns transport wrapped to log requests if
+// --debug-http or --debug-http-har is given.
+func newHTTPLogTransport(transport http.RoundTripper) http.RoundTripper {
+	if !globalDebugHTTP && globalDebugHTTPHAR == "" {
+		return transport
+	}
+	t := httpLogTransport{transport: transport}
+	if globalDebugHTTPHAR != "" {
+		globalHARLogOnce.Do(func() {
+			globalHARLog = &harLog{file: globalDebugHTTPHAR}
+		})
+		t.har = globalHARLog
+	}
+	return t
+}
+
+// RoundTrip logs req and its response.
+func (t httpLogTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	startTime := time.Now()
+	resp, err := t.transport.RoundTrip(req)
+	duration := time.Since(startTime)
+
+	reqURL := redactURL(req.URL)
+	reqHeaders := redactHeaders(req.Header)
+	if globalDebugHTTP {
+		var b strings.Builder
+		fmt.Fprintf(&b, "HTTP %s %s", req.Method, reqURL)
+		if err != nil {
+			fmt.Fprintf(&b, " failed after %s: %v\n", duration, err)
+		} else {
+			fmt.Fprintf(&b, " %s in %s\n", resp.Status, duration)
+		}
+		writeHTTPLogHeaders(&b, "> ", reqHeaders)
+		if resp != nil {
+			writeHTTPLogHeaders(&b, "< ", resp.Header)
+		}
+		fmt.Fprint(os.Stderr, b.String())
+	}
+
+	if t.har != nil {
+		var entry harEntry
+		entry.StartedDateTime = startTime
+		entry.Time = float64(duration) / float64(time.Millisecond)
+		entry.Timings.Wait = entry.Time
+		entry.Request.Method = req.Method
+		entry.Request.URL = reqURL
+		entry.Request.HTTPVersion = req.Proto
+		entry.Request.Headers = harHeaders(reqHeaders)
+		entry.Request.QueryString = []harNameValue{}
+		if u, e := url.Parse(reqURL); e == nil {
+			for name, values := range u.Query() {
+				for _, value := range values {
+					entry.Request.QueryString = append(entry.Request.QueryString, harNameValue{Name: name, Value: value})
+				}
+			}
+		}
+		entry.Request.Cookies = []harNameValue{}
+		entry.Request.HeadersSize = -1
+		entry.Request.BodySize = req.ContentLength
+		entry.Response.Headers = []harNameValue{}
+		entry.Response.Cookies = []harNameValue{}
+		entry.Response.HeadersSize = -1
+		if err != nil {
+			entry.Response.StatusText = err.Error()
+		} else {
+			entry.Response.Status = resp.StatusCode
+			entry.Response.StatusText = http.StatusText(resp.StatusCode)
+			entry.Response.HTTPVersion = resp.Proto
+			entry.Response.Headers = harHeaders(resp.Header)
+			entry.Response.Content.Size = resp.ContentLength
+			entry.Response.Content.MimeType = resp.Header.Get("Content-Type")
+			entry.Response.BodySize = resp.ContentLength
+		}
+		if e := t.har.add(entry); e != nil {
+			fmt.Fprintf(os.Stderr, "Unable to write HAR file `%s`: %v\n", t.har.file, e)
+		}
+	}
+	return resp, err
+}
+
+// writeHTTPLogHeaders - writes headers sorted by name, each line
+// starting with prefix.
+func writeHTTPLogHeaders(b *strings.Builder, prefix string, headers http.Header) {
+	for _, nv := range harHeaders(headers) {
+		fmt.Fprintf(b, "%s%s: %s\n", prefix, nv.Name, nv.Value)
+	}
+}
@@ -19,8 +19,10 @@ package cmd
 import (
 	"bytes"
 	"context"
-	"crypto/tls"
+	"crypto/md5"
+	"encoding/base64"
 	"encoding/json"
+	"encoding/xml"
 	"errors"
 	"hash/fnv"
 	"io"
@@ -31,6 +33,8 @@ import (
 	"path"
 	"path/filepath"
 	"regexp"
+	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -50,6 +54,7 @@ type s3Client struct {
 	mutex        *sync.Mutex
 	targetURL    *clientURL
 	api          *minio.Client
+	httpClient   *http.Client
 	virtualStyle bool
 }
 
@@ -84,6 +89,7 @@ var cseHeaders = []string{
 // newFactory encloses New function with client cache.
 func newFactory() func(config *Config) (Client, *probe.Error) {
 	clientCache := make(map[uint32]*minio.Client)
+	httpClientCache := make(map[uint32]*http.Client)
 	mutex := &sync.Mutex{}
 
 	// Return New function.
@@ -116,7 +122,7 @@ func newFactory() func(config *Config) (Client, *probe.Error) {
 		}
 		// Generate a hash out of s3Conf.
 		confHash := fnv.New32a()
-		confHash.Write([]byte(hostName + config.AccessKey + config.SecretKey))
+		confHash.Write([]byte(config.Alias + hostName + config.AccessKey + config.SecretKey))
 		confSum := confHash.Sum32()
 
 		// Lookup previous cache by hash.
@@ -131,13 +137,22 @@ func newFactory() func(config *Config) (Client, *probe.Error) {
 			if strings.ToUpper(config.Signature) == "S3V2" {
 				creds = credentials.NewStaticV2(config.AccessKey, config.SecretKey, "")
 			}
+			// Aliases use credentials which can be refreshed once they expire.
+			if config.RoleARN != "" || config.WebIdentityToken != "" {
+				var err *probe.Error
+				if creds, err = newSTSCredentials(config); err != nil {
+					return nil, err.Trace(config.Alias)
+				}
+			} else if config.Alias != "" {
+				creds = newAliasCredentials(config)
+			}
 			// Not found. Instantiate a new MinIO
 			var e error
 
 			options := minio.Options{
 				Creds:        creds,
 				Secure:       useTLS,
-				Region:       "",
+				Region:       config.Region,
 				BucketLookup: config.Lookup,
 			}
 
@@ -168,15 +183,9 @@ func newFactory() func(config *Config) (Client, *probe.Error) {
 
 			if useTLS {
 				// Keep TLS config.
-				tlsConfig := &tls.Config{
-					RootCAs: globalRootCAs,
-					// Can't use SSLv3 because of POODLE and BEAST
-					// Can't use TLSv1.0 because of POODLE and BEAST using CBC cipher
-					// Can't use TLSv1.1 because of RC4 cipher usage
-					MinVersion: tls.VersionTLS12,
-				}
-				if config.Insecure {
-					tlsConfig.InsecureSkipVerify = true
+				tlsConfig, err := newTLSConfig(config)
+				if err != nil {
+					return nil, err.Trace(config.Alias)
 				}
 				tr.TLSClientConfig = tlsConfig
 
@@ -199,6 +208,8 @@ func newFactory() func(config *Config) (Client, *probe.Error) {
 				}
 			}
 
+			transport = newHTTPLogTransport(transport)
+
 			// Set the new transport.
 			api.SetCustomTransport(transport)
 
@@ -212,10 +223,14 @@ func newFactory() func(config *Config) (Client, *probe.Error) {
 
 			// Cache the new MinIO Client with hash of config as key.
 			clientCache[confSum] = api
+			// Cache an http client sharing the same transport, used
+			// for requests not supported by the MinIO Client.
+			httpClientCache[confSum] = &http.Client{Transport: transport}
 		}
 
 		// Store the new api object.
 		s3Clnt.api = api
+		s3Clnt.httpClient = httpClientCache[confSum]
 
 		return s3Clnt, nil
 	}
@@ -856,6 +871,471 @@ func (c *s3Client) Put(ctx context.Context, reader io.Reader, size int64, metada
 	return n, nil
 }
 
+// presignedRequest - sends a request which is not part of the MinIO
+// Client API, the request is presigned and sent with our own http client.
+// Responses other than 200 OK, 202 Accepted and 204 No Content are
+// returned as errors.
+func (c *s3Client) presignedRequest(method, bucket, object string, reqParams url.Values, body []byte) (*http.Response, *probe.Error) {
+	presignedURL, e := c.api.Presign(method, bucket, object, 15*time.Minute, reqParams)
+	if e != nil {
+		return nil, probe.NewError(e)
+	}
+	req, e := http.NewRequest(method, presignedURL.String(), bytes.NewReader(body))
+	if e != nil {
+		return nil, probe.NewError(e)
+	}
+	if body != nil {
+		sum := md5.Sum(body)
+		req.Header.Set("Content-Type", "application/xml")
+		req.Header.Set("Content-Md5", base64.StdEncoding.EncodeToString(sum[:]))
+	}
+	resp, e := c.httpClient.Do(req)
+	if e != nil {
+		return nil, probe.NewError(e)
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusAccepted, http.StatusNoContent:
+		return resp, nil
+	}
+	defer resp.Body.Close()
+	errResp := minio.ErrorResponse{StatusCode: resp.StatusCode}
+	if e = xml.NewDecoder(resp.Body).Decode(&errResp); e != nil {
+		return nil, probe.NewError(errors.New(resp.Status))
+	}
+	return nil, probe.NewError(errResp)
+}
+
+// healthCheck - sends an unauthenticated request to a MinIO health
+// endpoint such as "/minio/health/live". Servers without health
+// endpoints are sent a HEAD request instead, where any response means
+// the service is up.
+func (c *s3Client) healthCheck(ctx context.Context, endpoint string) *probe.Error {
+	do := func(method, urlStr string) (int, *probe.Error) {
+		req, e := http.NewRequest(method, urlStr, nil)
+		if e != nil {
+			return 0, probe.NewError(e)
+		}
+		resp, e := c.httpClient.Do(req.WithContext(ctx))
+		if e != nil {
+			return 0, probe.NewError(e)
+		}
+		resp.Body.Close()
+		return resp.StatusCode, nil
+	}
+
+	baseURL := c.targetURL.Scheme + "://" + c.targetURL.Host
+	statusCode, err := do(http.MethodGet, baseURL+endpoint)
+	if err != nil {
+		return err
+	}
+	switch statusCode {
+	case http.StatusOK:
+		return nil
+	case http.StatusNotFound, http.StatusForbidden, http.StatusMethodNotAllowed:
+		// Not a MinIO server.
+		_, err = do(http.MethodHead, baseURL+"/")
+		return err
+	}
+	return probe.NewError(errors.New(http.StatusText(statusCode)))
+}
+
+// restoreRequest - container for the restore request body.
+type restoreRequest struct {
+	XMLName              xml.Name `xml:"http://s3.amazonaws.com/doc/2006-03-01/ RestoreRequest"`
+	Days                 int      `xml:"Days"`
+	GlacierJobParameters struct {
+		Tier string `xml:"Tier"`
+	} `xml:"GlacierJobParameters"`
+}
+
+// Restore - initiate a restore of an archived object, the restored
+// copy stays available for the given number of days.
+func (c *s3Client) Restore(days int) *probe.Error {
+	bucket, object := c.url2BucketAndObject()
+	if bucket == "" {
+		return probe.NewError(BucketNameEmpty{})
+	}
+	if object == "" {
+		return probe.NewError(ObjectMissing{})
+	}
+
+	restoreReq := restoreRequest{Days: days}
+	restoreReq.GlacierJobParameters.Tier = "Standard"
+	restoreBytes, e := xml.Marshal(restoreReq)
+	if e != nil {
+		return probe.NewError(e)
+	}
+
+	reqParams := make(url.Values)
+	reqParams.Set("restore", "")
+	resp, err := c.presignedRequest(http.MethodPost, bucket, object, reqParams, restoreBytes)
+	if err != nil {
+		// A restore is already running, nothing more to do.
+		if minio.ToErrorResponse(err.ToGoError()).Code == "RestoreAlreadyInProgress" {
+			return nil
+		}
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
+
+// tagging - container for the object tagging response.
+type tagging struct {
+	XMLName xml.Name `xml:"Tagging"`
+	TagSet  struct {
+		Tags []struct {
+			Key   string `xml:"Key"`
+			Value string `xml:"Value"`
+		} `xml:"Tag"`
+	} `xml:"TagSet"`
+}
+
+// GetTags - returns the tags set on an object.
+func (c *s3Client) GetTags() (map[string]string, *probe.Error) {
+	bucket, object := c.url2BucketAndObject()
+	if bucket == "" {
+		return nil, probe.NewError(BucketNameEmpty{})
+	}
+	if object == "" {
+		return nil, probe.NewError(ObjectMissing{})
+	}
+
+	reqParams := make(url.Values)
+	reqParams.Set("tagging", "")
+	resp, err := c.presignedRequest(http.MethodGet, bucket, object, reqParams, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var t tagging
+	if e := xml.NewDecoder(resp.Body).Decode(&t); e != nil {
+		return nil, probe.NewError(e)
+	}
+	tags := make(map[string]string, len(t.TagSet.Tags))
+	for _, tag := range t.TagSet.Tags {
+		tags[tag.Key] = tag.Value
+	}
+	return tags, nil
+}
+
+// objectVersion - a single version of an object.
+type objectVersion struct {
+	Key          string    `xml:"Key"`
+	VersionID    string    `xml:"VersionId"`
+	IsLatest     bool      `xml:"IsLatest"`
+	LastModified time.Time `xml:"LastModified"`
+	ETag         string    `xml:"ETag"`
+	Size         int64     `xml:"Size"`
+}
+
+// listVersionsResult - container for the list object versions response.
+type listVersionsResult struct {
+	XMLName             xml.Name        `xml:"ListVersionsResult"`
+	IsTruncated         bool            `xml:"IsTruncated"`
+	NextKeyMarker       string          `xml:"NextKeyMarker"`
+	NextVersionIDMarker string          `xml:"NextVersionIdMarker"`
+	Versions            []objectVersion `xml:"Version"`
+}
+
+// ListVersions - list all the versions of the objects directly under the
+// current prefix, objects below nested prefixes are listed only if
+// isRecursive is set.
+func (c *s3Client) ListVersions(isRecursive bool) ([]objectVersion, *probe.Error) {
+	bucket, object := c.url2BucketAndObject()
+	if bucket == "" {
+		return nil, probe.NewError(BucketNameEmpty{})
+	}
+
+	var versions []objectVersion
+	var keyMarker, versionIDMarker string
+	for {
+		reqParams := make(url.Values)
+		reqParams.Set("versions", "")
+		reqParams.Set("prefix", object)
+		if !isRecursive {
+			reqParams.Set("delimiter", string(c.targetURL.Separator))
+		}
+		if keyMarker != "" {
+			reqParams.Set("key-marker", keyMarker)
+		}
+		if versionIDMarker != "" {
+			reqParams.Set("version-id-marker", versionIDMarker)
+		}
+		resp, err := c.presignedRequest(http.MethodGet, bucket, "", reqParams, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var result listVersionsResult
+		e := xml.NewDecoder(resp.Body).Decode(&result)
+		resp.Body.Close()
+		if e != nil {
+			return nil, probe.NewError(e)
+		}
+
+		versions = append(versions, result.Versions...)
+		if !result.IsTruncated {
+			return versions, nil
+		}
+		keyMarker, versionIDMarker = result.NextKeyMarker, result.NextVersionIDMarker
+	}
+}
+
+// ListPage - list at most maxKeys entries after the key startAfter, all
+// remaining entries are listed if maxKeys is not positive. The key to
+// resume listing from is returned if more entries are available.
+func (c *s3Client) ListPage(isRecursive bool, startAfter string, maxKeys int) ([]*clientContent, string, *probe.Error) {
+	bucket, object := c.url2BucketAndObject()
+	if bucket == "" {
+		return nil, "", probe.NewError(BucketNameEmpty{})
+	}
+
+	var contents []*clientContent
+	var keys []string
+	var continuationToken string
+	for {
+		reqParams := make(url.Values)
+		reqParams.Set("list-type", "2")
+		reqParams.Set("prefix", object)
+		if !isRecursive {
+			reqParams.Set("delimiter", string(c.targetURL.Separator))
+		}
+		if continuationToken != "" {
+			reqParams.Set("continuation-token", continuationToken)
+		} else if startAfter != "" {
+			reqParams.Set("start-after", startAfter)
+		}
+		if maxKeys > 0 {
+			// One more entry is requested, the prefix startAfter
+			// is listed again when it is a common prefix.
+			pageKeys := maxKeys - len(contents) + 1
+			if pageKeys > 1000 {
+				pageKeys = 1000
+			}
+			reqParams.Set("max-keys", strconv.Itoa(pageKeys))
+		}
+		resp, err := c.presignedRequest(http.MethodGet, bucket, "", reqParams, nil)
+		if err != nil {
+			return nil, "", err
+		}
+
+		var result minio.ListBucketV2Result
+		e := xml.NewDecoder(resp.Body).Decode(&result)
+		resp.Body.Close()
+		if e != nil {
+			return nil, "", probe.NewError(e)
+		}
+
+		// Objects and common prefixes are returned separately, merge
+		// them in the order they were counted by the server.
+		entries := result.Contents
+		for _, prefix := range result.CommonPrefixes {
+			entries = append(entries, minio.ObjectInfo{Key: prefix.Prefix})
+		}
+		sort.Slice(entries, func(i, j int) bool { return entries[i].Key < entries[j].Key })
+		for _, entry := range entries {
+			if entry.Key <= startAfter || entry.Key == object && strings.HasSuffix(object, string(c.targetURL.Separator)) {
+				continue
+			}
+			content := c.objectInfo2ClientContent(bucket, entry)
+			contents = append(contents, &content)
+			keys = append(keys, entry.Key)
+		}
+
+		if maxKeys > 0 && len(contents) >= maxKeys {
+			if len(contents) > maxKeys || result.IsTruncated {
+				return contents[:maxKeys], keys[maxKeys-1], nil
+			}
+			return contents, "", nil
+		}
+		if !result.IsTruncated {
+			return contents, "", nil
+		}
+		continuationToken = result.NextContinuationToken
+	}
+}
+
+// aclGrantee - the grantee of an access control grant, grantees are
+// either canonical users identified by ID or groups identified by URI.
+type aclGrantee struct {
+	XMLNSXsi    string `xml:"xmlns:xsi,attr,omitempty" json:"-"`
+	Type        string `xml:"xsi:type,attr,omitempty" json:"-"`
+	ID          string `xml:"ID,omitempty" json:"id,omitempty"`
+	DisplayName string `xml:"DisplayName,omitempty" json:"displayName,omitempty"`
+	URI         string `xml:"URI,omitempty" json:"uri,omitempty"`
+}
+
+// aclGrant - a single permission granted on an object.
+type aclGrant struct {
+	Grantee    aclGrantee `xml:"Grantee" json:"grantee"`
+	Permission string     `xml:"Permission" json:"permission"`
+}
+
+// accessControlPolicy - container for object access control lists.
+type accessControlPolicy struct {
+	XMLName xml.Name `xml:"AccessControlPolicy"`
+	XMLNS   string   `xml:"xmlns,attr,omitempty"`
+	Owner   struct {
+		ID          string `xml:"ID"`
+		DisplayName string `xml:"DisplayName,omitempty"`
+	} `xml:"Owner"`
+	Grants []aclGrant `xml:"AccessControlList>Grant"`
+}
+
+// GetObjectACL - returns the access control list of an object.
+func (c *s3Client) GetObjectACL() (*accessControlPolicy, *probe.Error) {
+	bucket, object := c.url2BucketAndObject()
+	if bucket == "" {
+		return nil, probe.NewError(BucketNameEmpty{})
+	}
+	if object == "" {
+		return nil, probe.NewError(ObjectMissing{})
+	}
+
+	reqParams := make(url.Values)
+	reqParams.Set("acl", "")
+	resp, err := c.presignedRequest(http.MethodGet, bucket, object, reqParams, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	acp := &accessControlPolicy{}
+	if e := xml.NewDecoder(resp.Body).Decode(acp); e != nil {
+		return nil, probe.NewError(e)
+	}
+	return acp, nil
+}
+
+// SetObjectACL - replaces the access control list of an object.
+func (c *s3Client) SetObjectACL(acp *accessControlPolicy) *probe.Error {
+	bucket, object := c.url2BucketAndObject()
+	if bucket == "" {
+		return probe.NewError(BucketNameEmpty{})
+	}
+	if object == "" {
+		return probe.NewError(ObjectMissing{})
+	}
+
+	acp.XMLNS = "http://s3.amazonaws.com/doc/2006-03-01/"
+	for i := range acp.Grants {
+		acp.Grants[i].Grantee.XMLNSXsi = "http://www.w3.org/2001/XMLSchema-instance"
+		acp.Grants[i].Grantee.Type = "CanonicalUser"
+		if acp.Grants[i].Grantee.URI != "" {
+			acp.Grants[i].Grantee.Type = "Group"
+		}
+	}
+	acpBytes, e := xml.Marshal(acp)
+	if e != nil {
+		return probe.NewError(e)
+	}
+
+	reqParams := make(url.Values)
+	reqParams.Set("acl", "")
+	resp, err := c.presignedRequest(http.MethodPut, bucket, object, reqParams, acpBytes)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
+
+// Default encryption algorithms of buckets.
+const (
+	sseAlgorithmAES256 = "AES256"
+	sseAlgorithmKMS    = "aws:kms"
+)
+
+// bucketEncryptionRule - a default encryption rule of a bucket.
+type bucketEncryptionRule struct {
+	SSEAlgorithm   string `xml:"ApplyServerSideEncryptionByDefault>SSEAlgorithm"`
+	KMSMasterKeyID string `xml:"ApplyServerSideEncryptionByDefault>KMSMasterKeyID,omitempty"`
+}
+
+// bucketEncryption - container for the default encryption of a bucket.
+type bucketEncryption struct {
+	XMLName xml.Name               `xml:"ServerSideEncryptionConfiguration"`
+	XMLNS   string                 `xml:"xmlns,attr,omitempty"`
+	Rules   []bucketEncryptionRule `xml:"Rule"`
+}
+
+// GetBucketEncryption - returns the default encryption algorithm of a
+// bucket and its KMS key, the algorithm is empty if the bucket has no
+// default encryption.
+func (c *s3Client) GetBucketEncryption() (algorithm, keyID string, err *probe.Error) {
+	bucket, _ := c.url2BucketAndObject()
+	if bucket == "" {
+		return "", "", probe.NewError(BucketNameEmpty{})
+	}
+
+	reqParams := make(url.Values)
+	reqParams.Set("encryption", "")
+	resp, err := c.presignedRequest(http.MethodGet, bucket, "", reqParams, nil)
+	if err != nil {
+		if minio.ToErrorResponse(err.ToGoError()).Code == "ServerSideEncryptionConfigurationNotFoundError" {
+			return "", "", nil
+		}
+		return "", "", err
+	}
+	defer resp.Body.Close()
+
+	var config bucketEncryption
+	if e := xml.NewDecoder(resp.Body).Decode(&config); e != nil {
+		return "", "", probe.NewError(e)
+	}
+	if len(config.Rules) == 0 {
+		return "", "", nil
+	}
+	return config.Rules[0].SSEAlgorithm, config.Rules[0].KMSMasterKeyID, nil
+}
+
+// SetBucketEncryption - sets the default encryption algorithm of a
+// bucket, keyID is the KMS key used by the aws:kms algorithm.
+func (c *s3Client) SetBucketEncryption(algorithm, keyID string) *probe.Error {
+	bucket, _ := c.url2BucketAndObject()
+	if bucket == "" {
+		return probe.NewError(BucketNameEmpty{})
+	}
+
+	config := bucketEncryption{
+		XMLNS: "http://s3.amazonaws.com/doc/2006-03-01/",
+		Rules: []bucketEncryptionRule{{SSEAlgorithm: algorithm, KMSMasterKeyID: keyID}},
+	}
+	configBytes, e := xml.Marshal(config)
+	if e != nil {
+		return probe.NewError(e)
+	}
+
+	reqParams := make(url.Values)
+	reqParams.Set("encryption", "")
+	resp, err := c.presignedRequest(http.MethodPut, bucket, "", reqParams, configBytes)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
+
+// RemoveBucketEncryption - removes the default encryption of a bucket.
+func (c *s3Client) RemoveBucketEncryption() *probe.Error {
+	bucket, _ := c.url2BucketAndObject()
+	if bucket == "" {
+		return probe.NewError(BucketNameEmpty{})
+	}
+
+	reqParams := make(url.Values)
+	reqParams.Set("encryption", "")
+	resp, err := c.presignedRequest(http.MethodDelete, bucket, "", reqParams, nil)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
+
 // Remove incomplete uploads.
 func (c *s3Client) removeIncompleteObjects(bucket string, objectsCh <-chan string) <-chan minio.RemoveObjectError {
 	removeObjectErrorCh := make(chan minio.RemoveObjectError)
@@ -1067,6 +1547,36 @@ func (c *s3Client) GetAccess() (string, string, *probe.Error) {
 	return pType, policyStr, nil
 }
 
+// GetLifecycle - returns the lifecycle configuration of a bucket as XML.
+func (c *s3Client) GetLifecycle() (string, *probe.Error) {
+	bucket, _ := c.url2BucketAndObject()
+	if bucket == "" {
+		return "", probe.NewError(BucketNameEmpty{})
+	}
+	lifecycle, e := c.api.GetBucketLifecycle(bucket)
+	if e != nil {
+		// Buckets without lifecycle configuration are not an error.
+		if minio.ToErrorResponse(e).Code == "NoSuchLifecycleConfiguration" {
+			return "", nil
+		}
+		return "", probe.NewError(e)
+	}
+	return lifecycle, nil
+}
+
+// SetLifecycle - sets the lifecycle configuration of a bucket, an empty
+// configuration removes it.
+func (c *s3Client) SetLifecycle(lifecycle string) *probe.Error {
+	bucket, _ := c.url2BucketAndObject()
+	if bucket == "" {
+		return probe.NewError(BucketNameEmpty{})
+	}
+	if e := c.api.SetBucketLifecycle(bucket, lifecycle); e != nil {
+		return probe.NewError(e)
+	}
+	return nil
+}
+
 // SetAccess set access policy permissions.
 func (c *s3Client) SetAccess(bucketPolicy string, isJSON bool) *probe.Error {
 	bucket, object := c.url2BucketAndObject()
@@ -1202,6 +1712,7 @@ func (c *s3Client) Stat(isIncomplete, isFetchMeta bool, sse encrypt.ServerSide)
 			objectMetadata.Time = objectStat.LastModified
 			objectMetadata.Size = objectStat.Size
 			objectMetadata.ETag = objectStat.ETag
+			objectMetadata.StorageClass = objectStat.StorageClass
 			objectMetadata.Type = os.FileMode(0664)
 			objectMetadata.Metadata = map[string]string{}
 			objectMetadata.Expires = objectStat.Expires
@@ -1250,6 +1761,7 @@ func (c *s3Client) getObjectStat(bucket, object string, opts minio.StatObjectOpt
 	objectMetadata.Size = objectStat.Size
 	objectMetadata.ETag = objectStat.ETag
 	objectMetadata.Expires = objectStat.Expires
+	objectMetadata.StorageClass = objectStat.StorageClass
 	objectMetadata.Type = os.FileMode(0664)
 	objectMetadata.Metadata = map[string]string{}
 	objectMetadata.EncryptionHeaders = map[string]string{}
@@ -1654,6 +2166,7 @@ func (c *s3Client) objectInfo2ClientContent(bucket string, entry minio.ObjectInf
 	content.URL = url
 	content.Size = entry.Size
 	content.ETag = entry.ETag
+	content.StorageClass = entry.StorageClass
 	content.Time = entry.LastModified
 
 	if strings.HasSuffix(entry.Key, "/") && entry.Size == 0 && entry.LastModified.IsZero() {
@@ -1834,6 +2347,7 @@ func (c *s3Client) listInRoutine(contentCh chan *clientContent) {
 				content.URL = url
 				content.Size = object.Size
 				content.ETag = object.ETag
+				content.StorageClass = object.StorageClass
 				content.Time = object.LastModified
 				content.Type = os.FileMode(0664)
 			}
@@ -1853,6 +2367,8 @@ const (
 	// s3StorageClassRedundancy = "REDUCED_REDUNDANCY"
 	// Archive access.
 	s3StorageClassGlacier = "GLACIER"
+	// Long term archive access.
+	s3StorageClassDeepArchive = "DEEP_ARCHIVE"
 )
 
 func (c *s3Client) listRecursiveInRoutine(contentCh chan *clientContent) {
@@ -1890,29 +2406,102 @@ func (c *s3Client) listRecursiveInRoutine(contentCh chan *clientContent) {
 				content.URL = objectURL
 				content.Size = object.Size
 				content.ETag = object.ETag
+				content.StorageClass = object.StorageClass
 				content.Time = object.LastModified
 				content.Type = os.FileMode(0664)
 				contentCh <- content
 			}
 		}
 	default:
-		isRecursive := true
-		for object := range c.listObjectWrapper(b, o, isRecursive, nil) {
-			if object.Err != nil {
-				contentCh <- &clientContent{
-					Err: probe.NewError(object.Err),
-				}
+		c.listRecursiveParallel(contentCh, b, o)
+	}
+}
+
+// objectToClientContent - converts an object of a recursive listing.
+func (c *s3Client) objectToClientContent(bucket string, object minio.ObjectInfo) *clientContent {
+	if object.Err != nil {
+		return &clientContent{Err: probe.NewError(object.Err)}
+	}
+	content := &clientContent{}
+	url := *c.targetURL
+	// Join bucket and incoming object key.
+	url.Path = c.joinPath(bucket, object.Key)
+	content.URL = url
+	content.Size = object.Size
+	content.ETag = object.ETag
+	content.StorageClass = object.StorageClass
+	content.Time = object.LastModified
+	content.Type = os.FileMode(0664)
+	return content
+}
+
+// Number of prefixes listed concurrently by recursive listings, set
+// with MC_LIST_WORKERS.
+func getListWorkers() int {
+	if workers, e := strconv.Atoi(os.Getenv("MC_LIST_WORKERS")); e == nil && workers > 0 {
+		return workers
+	}
+	return 8
+}
+
+// listRecursiveParallel - recursively lists all objects under prefix.
+// The first level of prefixes is listed first, each prefix is then
+// listed by a bounded pool of workers. Since all keys under a prefix
+// sort together, results are merged in the order of the first level
+// listing and objects are sent in the same order as a single listing.
+func (c *s3Client) listRecursiveParallel(contentCh chan *clientContent, bucket, prefix string) {
+	workers := getListWorkers()
+	if workers == 1 {
+		for object := range c.listObjectWrapper(bucket, prefix, true, nil) {
+			contentCh <- c.objectToClientContent(bucket, object)
+		}
+		return
+	}
+
+	doneCh := make(chan struct{})
+	defer close(doneCh)
+
+	sem := make(chan struct{}, workers)
+	// Each entry of the first level is either an object, sent as is,
+	// or the listing of a prefix.
+	resultsCh := make(chan chan *clientContent, workers)
+	go func() {
+		defer close(resultsCh)
+		for object := range c.listObjectWrapper(bucket, prefix, false, doneCh) {
+			objectCh := make(chan *clientContent, 1000)
+			select {
+			case resultsCh <- objectCh:
+			case <-doneCh:
+				return
+			}
+			// A folder object named like the listed prefix is sent
+			// as is, it would list the prefix again otherwise.
+			if object.Err != nil || !strings.HasSuffix(object.Key, "/") || object.Key == prefix {
+				objectCh <- c.objectToClientContent(bucket, object)
+				close(objectCh)
 				continue
 			}
-			content := &clientContent{}
-			url := *c.targetURL
-			// Join bucket and incoming object key.
-			url.Path = c.joinPath(b, object.Key)
-			content.URL = url
-			content.Size = object.Size
-			content.ETag = object.ETag
-			content.Time = object.LastModified
-			content.Type = os.FileMode(0664)
+			select {
+			case sem <- struct{}{}:
+			case <-doneCh:
+				return
+			}
+			go func(prefix string) {
+				defer func() { <-sem }()
+				defer close(objectCh)
+				for object := range c.listObjectWrapper(bucket, prefix, true, doneCh) {
+					select {
+					case objectCh <- c.objectToClientContent(bucket, object):
+					case <-doneCh:
+						return
+					}
+				}
+			}(object.Key)
+		}
+	}()
+
+	for objectCh := range resultsCh {
+		for content := range objectCh {
 			contentCh <- content
 		}
 	}
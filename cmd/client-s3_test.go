@@ -20,10 +20,13 @@ package cmd
 import (
 	"bytes"
 	"context"
+	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"os"
 	"strconv"
+	"strings"
 
 	minio "github.com/minio/minio-go/v6"
 	. "gopkg.in/check.v1"
@@ -261,3 +264,69 @@ func (s *TestSuite) TestSelectCompressionType(c *C) {
 		c.Assert(cType, DeepEquals, test.compressionType)
 	}
 }
+
+// listHandler is an http.Handler that lists the objects of a bucket with
+// the given keys, honoring prefix and delimiter.
+type listHandler struct {
+	keys []string
+}
+
+func (h listHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if _, ok := r.URL.Query()["location"]; ok {
+		w.Write([]byte("<LocationConstraint xmlns=\"http://doc.s3.amazonaws.com/2006-03-01\"></LocationConstraint>"))
+		return
+	}
+	prefix, delimiter := r.URL.Query().Get("prefix"), r.URL.Query().Get("delimiter")
+	var contents, prefixes strings.Builder
+	seen := make(map[string]bool)
+	for _, key := range h.keys {
+		if !strings.HasPrefix(key, prefix) {
+			continue
+		}
+		if i := strings.Index(key[len(prefix):], delimiter); delimiter != "" && i >= 0 {
+			commonPrefix := key[:len(prefix)+i+1]
+			if !seen[commonPrefix] {
+				seen[commonPrefix] = true
+				fmt.Fprintf(&prefixes, "<CommonPrefixes><Prefix>%s</Prefix></CommonPrefixes>", commonPrefix)
+			}
+			continue
+		}
+		fmt.Fprintf(&contents, "<Contents><Key>%s</Key><LastModified>2015-05-21T18:24:21.097Z</LastModified><Size>1</Size></Contents>", key)
+	}
+	fmt.Fprintf(w, "<ListBucketResult xmlns=\"http://doc.s3.amazonaws.com/2006-03-01\">%s%s<IsTruncated>false</IsTruncated></ListBucketResult>",
+		contents.String(), prefixes.String())
+}
+
+// Test that recursive listings list prefixes in parallel in order.
+func (s *TestSuite) TestListRecursiveParallel(c *C) {
+	keys := []string{"a", "b/", "b/1", "b/2/x", "b0", "c/1", "c/2", "d/e/f/g", "z"}
+	server := httptest.NewServer(listHandler{keys: keys})
+	defer server.Close()
+
+	defer os.Setenv("MC_LIST_WORKERS", os.Getenv("MC_LIST_WORKERS"))
+	for _, workers := range []string{"1", "2", "8"} {
+		os.Setenv("MC_LIST_WORKERS", workers)
+		for _, prefix := range []string{"", "b/", "c"} {
+			conf := new(Config)
+			conf.HostURL = server.URL + "/bucket/" + prefix
+			conf.AccessKey = "WLGDGYAQYIGI833EV05A"
+			conf.SecretKey = "BYvgJM101sHngl2uzjXS/OBF/aMxAN06JrJ3qJlF"
+			conf.Signature = "S3v4"
+			s3c, err := s3New(conf)
+			c.Assert(err, IsNil)
+
+			var listed []string
+			for content := range s3c.List(true, false, DirNone) {
+				c.Assert(content.Err, IsNil)
+				listed = append(listed, strings.TrimPrefix(content.URL.Path, "/bucket/"))
+			}
+			var expected []string
+			for _, key := range keys {
+				if strings.HasPrefix(key, prefix) {
+					expected = append(expected, key)
+				}
+			}
+			c.Assert(listed, DeepEquals, expected, Commentf("workers %s, prefix %q", workers, prefix))
+		}
+	}
+}
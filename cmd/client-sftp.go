@@ -0,0 +1,545 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"hash/fnv"
+	"io"
+	"io/ioutil"
+	"net"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mitchellh/go-homedir"
+	"github.com/pkg/sftp"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+	"golang.org/x/crypto/ssh/knownhosts"
+
+	"github.com/minio/mc/pkg/hookreader"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio-go/v6/pkg/encrypt"
+)
+
+const (
+	sftpScheme      = "sftp"
+	sftpDefaultPort = "22"
+
+	// SSH_FX_PERMISSION_DENIED status code of the SFTP protocol.
+	sftpStatusPermissionDenied = 3
+)
+
+// Private keys tried from ~/.ssh when no password is configured
+// and no ssh-agent is available.
+var sftpDefaultIdentities = []string{"id_ed25519", "id_ecdsa", "id_rsa"}
+
+// sftp client
+type sftpClient struct {
+	targetURL *clientURL
+	client    *sftp.Client
+}
+
+// isSFTPHostURL - returns true if hostURL points to a SFTP server.
+func isSFTPHostURL(hostURL string) bool {
+	return newClientURL(hostURL).Scheme == sftpScheme
+}
+
+// sftpHostAddr - returns host with the default SFTP port added
+// when it has none.
+func sftpHostAddr(host string) string {
+	if _, _, e := net.SplitHostPort(host); e != nil {
+		return net.JoinHostPort(host, sftpDefaultPort)
+	}
+	return host
+}
+
+// sftpAuthMethods - returns the SSH authentication methods for config,
+// a password when a secret key is configured, otherwise the keys of
+// a running ssh-agent or the default private keys in ~/.ssh.
+func sftpAuthMethods(config *Config) []ssh.AuthMethod {
+	if config.SecretKey != "" {
+		return []ssh.AuthMethod{ssh.Password(config.SecretKey)}
+	}
+	if sock := os.Getenv("SSH_AUTH_SOCK"); sock != "" {
+		if conn, e := net.Dial("unix", sock); e == nil {
+			return []ssh.AuthMethod{ssh.PublicKeysCallback(agent.NewClient(conn).Signers)}
+		}
+	}
+	homeDir, e := homedir.Dir()
+	if e != nil {
+		return nil
+	}
+	var signers []ssh.Signer
+	for _, identity := range sftpDefaultIdentities {
+		data, e := ioutil.ReadFile(filepath.Join(homeDir, ".ssh", identity))
+		if e != nil {
+			continue
+		}
+		// Keys protected by a passphrase are skipped, load them
+		// into ssh-agent instead.
+		signer, e := ssh.ParsePrivateKey(data)
+		if e != nil {
+			continue
+		}
+		signers = append(signers, signer)
+	}
+	if len(signers) == 0 {
+		return nil
+	}
+	return []ssh.AuthMethod{ssh.PublicKeys(signers...)}
+}
+
+// sftpHostKeyCallback - verifies server host keys against
+// ~/.ssh/known_hosts, any host key is accepted with --insecure.
+func sftpHostKeyCallback() (ssh.HostKeyCallback, *probe.Error) {
+	if globalInsecure {
+		return ssh.InsecureIgnoreHostKey(), nil
+	}
+	homeDir, e := homedir.Dir()
+	if e != nil {
+		return nil, probe.NewError(e)
+	}
+	callback, e := knownhosts.New(filepath.Join(homeDir, ".ssh", "known_hosts"))
+	if e != nil {
+		return nil, probe.NewError(e)
+	}
+	return callback, nil
+}
+
+// newSFTPFactory encloses sftpNew function with client cache, one SSH
+// connection is opened per alias and credentials.
+func newSFTPFactory() func(config *Config) (Client, *probe.Error) {
+	clientCache := make(map[uint32]*sftp.Client)
+	mutex := &sync.Mutex{}
+
+	return func(config *Config) (Client, *probe.Error) {
+		targetURL := newClientURL(config.HostURL)
+		hostAddr := sftpHostAddr(targetURL.Host)
+
+		confHash := fnv.New32a()
+		confHash.Write([]byte(config.Alias + hostAddr + config.AccessKey + config.SecretKey))
+		confSum := confHash.Sum32()
+
+		mutex.Lock()
+		defer mutex.Unlock()
+		client, found := clientCache[confSum]
+		if !found {
+			hostKeyCallback, err := sftpHostKeyCallback()
+			if err != nil {
+				return nil, err.Trace(hostAddr)
+			}
+			conn, e := ssh.Dial("tcp", hostAddr, &ssh.ClientConfig{
+				User:            config.AccessKey,
+				Auth:            sftpAuthMethods(config),
+				HostKeyCallback: hostKeyCallback,
+				ClientVersion:   "SSH-2.0-" + config.AppName + "_" + config.AppVersion,
+			})
+			if e != nil {
+				return nil, probe.NewError(e).Trace(hostAddr)
+			}
+			client, e = sftp.NewClient(conn)
+			if e != nil {
+				conn.Close()
+				return nil, probe.NewError(e).Trace(hostAddr)
+			}
+			clientCache[confSum] = client
+		}
+		return &sftpClient{
+			targetURL: targetURL,
+			client:    client,
+		}, nil
+	}
+}
+
+// sftpNew returns an initialized sftpClient structure.
+var sftpNew = newSFTPFactory()
+
+// GetURL get url.
+func (c *sftpClient) GetURL() clientURL {
+	return *c.targetURL
+}
+
+// toClientError error constructs a typed client error for known SFTP errors.
+func (c *sftpClient) toClientError(e error, fpath string) *probe.Error {
+	if os.IsNotExist(e) {
+		return probe.NewError(PathNotFound{Path: fpath})
+	}
+	if statusErr, ok := e.(*sftp.StatusError); ok && statusErr.Code == sftpStatusPermissionDenied {
+		return probe.NewError(PathInsufficientPermission{Path: fpath})
+	}
+	return probe.NewError(e)
+}
+
+// fileInfo2ClientContent - converts a remote file info to a clientContent.
+func (c *sftpClient) fileInfo2ClientContent(fpath string, fi os.FileInfo) *clientContent {
+	url := *c.targetURL
+	url.Path = fpath
+	return &clientContent{
+		URL:  url,
+		Time: fi.ModTime(),
+		Size: fi.Size(),
+		Type: fi.Mode(),
+	}
+}
+
+// Stat - get metadata from path.
+func (c *sftpClient) Stat(isIncomplete, isFetchMeta bool, sse encrypt.ServerSide) (*clientContent, *probe.Error) {
+	fpath := c.targetURL.Path
+	fi, e := c.client.Stat(fpath)
+	if e == nil && fi.IsDir() {
+		return c.fileInfo2ClientContent(fpath, fi), nil
+	}
+	if isIncomplete {
+		fi, e = c.client.Stat(fpath + partSuffix)
+	}
+	if e != nil {
+		return nil, c.toClientError(e, fpath).Trace(c.targetURL.String())
+	}
+	content := c.fileInfo2ClientContent(fpath, fi)
+	content.Metadata = map[string]string{
+		"Content-Type": guessURLContentType(fpath),
+	}
+	return content, nil
+}
+
+// readDir - reads the remote directory dirname and returns a list of
+// sorted directory entries.
+func (c *sftpClient) readDir(dirname string) ([]os.FileInfo, error) {
+	list, e := c.client.ReadDir(dirname)
+	if e != nil {
+		return nil, e
+	}
+	sort.Sort(byDirName(list))
+	return list, nil
+}
+
+// List - list files and folders.
+func (c *sftpClient) List(isRecursive, isIncomplete bool, showDir DirOpt) <-chan *clientContent {
+	contentCh := make(chan *clientContent)
+	filteredCh := make(chan *clientContent)
+
+	if isRecursive {
+		go c.listRecursiveInRoutine(contentCh, showDir)
+	} else {
+		go c.listInRoutine(contentCh)
+	}
+
+	// Filter out partly uploaded files, or only show them
+	// when isIncomplete is set.
+	go func() {
+		defer close(filteredCh)
+		for content := range contentCh {
+			if content.Err == nil {
+				if isIncomplete != strings.HasSuffix(content.URL.Path, partSuffix) {
+					continue
+				}
+				content.URL.Path = strings.TrimSuffix(content.URL.Path, partSuffix)
+			}
+			filteredCh <- content
+		}
+	}()
+
+	return filteredCh
+}
+
+// listPrefixes - list all entries of the parent directory matching prefix.
+func (c *sftpClient) listPrefixes(prefix string, contentCh chan<- *clientContent) {
+	dirName := path.Dir(prefix)
+	files, e := c.readDir(dirName)
+	if e != nil {
+		contentCh <- &clientContent{Err: c.toClientError(e, dirName).Trace(dirName)}
+		return
+	}
+	for _, fi := range files {
+		if fpath := path.Join(dirName, fi.Name()); strings.HasPrefix(fpath, prefix) {
+			contentCh <- c.fileInfo2ClientContent(fpath, fi)
+		}
+	}
+}
+
+func (c *sftpClient) listInRoutine(contentCh chan<- *clientContent) {
+	defer close(contentCh)
+
+	fpath := c.targetURL.Path
+	fi, e := c.client.Stat(fpath)
+	if e != nil {
+		if os.IsNotExist(e) {
+			// If file does not exist treat it like a prefix.
+			c.listPrefixes(fpath, contentCh)
+			return
+		}
+		contentCh <- &clientContent{Err: c.toClientError(e, fpath).Trace(fpath)}
+		return
+	}
+
+	if !fi.IsDir() {
+		contentCh <- c.fileInfo2ClientContent(fpath, fi)
+		return
+	}
+	// Do not traverse directories not ending with a separator.
+	if !strings.HasSuffix(fpath, "/") {
+		c.listPrefixes(fpath, contentCh)
+		return
+	}
+
+	files, e := c.readDir(fpath)
+	if e != nil {
+		contentCh <- &clientContent{Err: c.toClientError(e, fpath).Trace(fpath)}
+		return
+	}
+	for _, fi := range files {
+		if fi.Mode().IsRegular() || fi.IsDir() {
+			contentCh <- c.fileInfo2ClientContent(path.Join(fpath, fi.Name()), fi)
+		}
+	}
+}
+
+// listRecursiveInRoutine - walks the remote tree below the target
+// path, a target not ending with a separator is used as a prefix.
+func (c *sftpClient) listRecursiveInRoutine(contentCh chan<- *clientContent, dirOpt DirOpt) {
+	defer close(contentCh)
+
+	var listDir func(dirName, prefix string) bool
+	listDir = func(dirName, prefix string) (isStop bool) {
+		files, e := c.readDir(dirName)
+		if e != nil {
+			err := c.toClientError(e, dirName)
+			contentCh <- &clientContent{Err: err.Trace(dirName)}
+			_, ok := err.ToGoError().(PathInsufficientPermission)
+			return !ok
+		}
+		for _, fi := range files {
+			fpath := path.Join(dirName, fi.Name())
+			if !strings.HasPrefix(fpath, prefix) {
+				continue
+			}
+			content := c.fileInfo2ClientContent(fpath, fi)
+			switch {
+			case fi.IsDir():
+				if dirOpt == DirFirst {
+					contentCh <- content
+				}
+				if listDir(fpath, "") {
+					return true
+				}
+				if dirOpt == DirLast {
+					contentCh <- content
+				}
+			case fi.Mode().IsRegular():
+				contentCh <- content
+			}
+		}
+		return false
+	}
+
+	fpath := c.targetURL.Path
+	if !strings.HasSuffix(fpath, "/") {
+		listDir(path.Dir(fpath), fpath)
+		return
+	}
+
+	root := &clientContent{URL: *c.targetURL, Type: os.ModeDir}
+	if dirOpt == DirFirst {
+		contentCh <- root
+	}
+	listDir(fpath, "")
+	if dirOpt == DirLast {
+		contentCh <- root
+	}
+}
+
+// Get returns reader of the remote file.
+func (c *sftpClient) Get(sse encrypt.ServerSide) (io.ReadCloser, *probe.Error) {
+	file, e := c.client.Open(c.targetURL.Path)
+	if e != nil {
+		return nil, c.toClientError(e, c.targetURL.Path).Trace(c.targetURL.String())
+	}
+	return file, nil
+}
+
+// Put - uploads reader to the remote path. Data is written to a
+// partial file first, which is renamed once the upload completed.
+func (c *sftpClient) Put(ctx context.Context, reader io.Reader, size int64, metadata map[string]string, progress io.Reader, sse encrypt.ServerSide) (int64, *probe.Error) {
+	fpath := c.targetURL.Path
+	dirName, name := path.Split(fpath)
+	if dirName != "" {
+		if e := c.client.MkdirAll(dirName); e != nil {
+			return 0, c.toClientError(e, dirName).Trace(fpath)
+		}
+	}
+	// Object name is empty, it must be a directory.
+	if name == "" {
+		return 0, nil
+	}
+
+	partPath := fpath + partSuffix
+	partFile, e := c.client.Create(partPath)
+	if e != nil {
+		return 0, c.toClientError(e, fpath).Trace(partPath)
+	}
+	n, e := io.Copy(partFile, hookreader.NewHook(reader, progress))
+	if e != nil {
+		partFile.Close()
+		return n, probe.NewError(e).Trace(partPath)
+	}
+	if e = partFile.Close(); e != nil {
+		return n, probe.NewError(e).Trace(partPath)
+	}
+
+	if size > 0 && n < size {
+		return n, probe.NewError(UnexpectedEOF{
+			TotalSize:    size,
+			TotalWritten: n,
+		})
+	}
+
+	// Plain SFTP rename fails if the target exists, servers without
+	// the posix-rename extension get the old file removed first.
+	if e = c.client.PosixRename(partPath, fpath); e != nil {
+		c.client.Remove(fpath)
+		if e = c.client.Rename(partPath, fpath); e != nil {
+			return n, c.toClientError(e, fpath).Trace(partPath, fpath)
+		}
+	}
+	return n, nil
+}
+
+// Copy - copy a file on the same SFTP server. SFTP has no server side
+// copy, the data is read back and uploaded again.
+func (c *sftpClient) Copy(source string, size int64, progress io.Reader, srcSSE, tgtSSE encrypt.ServerSide, metadata map[string]string) *probe.Error {
+	file, e := c.client.Open(source)
+	if e != nil {
+		return c.toClientError(e, source).Trace(source)
+	}
+	defer file.Close()
+
+	if _, err := c.Put(context.Background(), file, size, metadata, progress, tgtSSE); err != nil {
+		return err.Trace(source, c.targetURL.Path)
+	}
+	return nil
+}
+
+// Remove - remove entries read from the clientContent channel.
+func (c *sftpClient) Remove(isIncomplete, isRemoveBucket bool, contentCh <-chan *clientContent) <-chan *probe.Error {
+	errorCh := make(chan *probe.Error)
+
+	go func() {
+		defer close(errorCh)
+
+		for content := range contentCh {
+			name := content.URL.Path
+			if isIncomplete {
+				name += partSuffix
+			}
+			if e := c.client.Remove(name); e != nil {
+				if os.IsNotExist(e) && isRemoveBucket {
+					// Ignore PathNotFound for directory removal.
+					continue
+				}
+				errorCh <- c.toClientError(e, content.URL.Path).Trace(name)
+			}
+		}
+	}()
+
+	return errorCh
+}
+
+// MakeBucket - create a new directory.
+func (c *sftpClient) MakeBucket(region string, ignoreExisting bool) *probe.Error {
+	if e := c.client.MkdirAll(c.targetURL.Path); e != nil {
+		return c.toClientError(e, c.targetURL.Path).Trace(c.targetURL.String())
+	}
+	return nil
+}
+
+// GetAccessRules - unsupported API
+func (c *sftpClient) GetAccessRules() (map[string]string, *probe.Error) {
+	return map[string]string{}, probe.NewError(APINotImplemented{
+		API:     "ListBucketPolicies",
+		APIType: sftpScheme,
+	})
+}
+
+// GetAccess - unsupported API
+func (c *sftpClient) GetAccess() (string, string, *probe.Error) {
+	return "", "", probe.NewError(APINotImplemented{
+		API:     "GetAccess",
+		APIType: sftpScheme,
+	})
+}
+
+// SetAccess - unsupported API
+func (c *sftpClient) SetAccess(access string, isJSON bool) *probe.Error {
+	return probe.NewError(APINotImplemented{
+		API:     "SetAccess",
+		APIType: sftpScheme,
+	})
+}
+
+// Select - unsupported API
+func (c *sftpClient) Select(expression string, sse encrypt.ServerSide, opts SelectObjectOpts) (io.ReadCloser, *probe.Error) {
+	return nil, probe.NewError(APINotImplemented{
+		API:     "Select",
+		APIType: sftpScheme,
+	})
+}
+
+// ShareDownload - unsupported API
+func (c *sftpClient) ShareDownload(expires time.Duration) (string, *probe.Error) {
+	return "", probe.NewError(APINotImplemented{
+		API:     "ShareDownload",
+		APIType: sftpScheme,
+	})
+}
+
+// ShareUpload - unsupported API
+func (c *sftpClient) ShareUpload(startsWith bool, expires time.Duration, contentType string) (string, map[string]string, *probe.Error) {
+	return "", nil, probe.NewError(APINotImplemented{
+		API:     "ShareUpload",
+		APIType: sftpScheme,
+	})
+}
+
+// Watch - unsupported API
+func (c *sftpClient) Watch(params watchParams) (*watchObject, *probe.Error) {
+	return nil, probe.NewError(APINotImplemented{
+		API:     "Watch",
+		APIType: sftpScheme,
+	})
+}
+
+// Restore - unsupported API
+func (c *sftpClient) Restore(days int) *probe.Error {
+	return probe.NewError(APINotImplemented{
+		API:     "Restore",
+		APIType: sftpScheme,
+	})
+}
+
+// GetTags - unsupported API
+func (c *sftpClient) GetTags() (map[string]string, *probe.Error) {
+	return nil, probe.NewError(APINotImplemented{
+		API:     "GetTags",
+		APIType: sftpScheme,
+	})
+}
@@ -0,0 +1,128 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/pkg/sftp"
+)
+
+func TestSFTPHostAddr(t *testing.T) {
+	testCases := []struct {
+		host     string
+		expected string
+	}{
+		{"sftp.example.com", "sftp.example.com:22"},
+		{"sftp.example.com:2222", "sftp.example.com:2222"},
+		{"10.0.0.1", "10.0.0.1:22"},
+		{"[::1]:2222", "[::1]:2222"},
+	}
+	for i, testCase := range testCases {
+		if addr := sftpHostAddr(testCase.host); addr != testCase.expected {
+			t.Errorf("Test %d: expected %s, got %s", i+1, testCase.expected, addr)
+		}
+	}
+}
+
+// sftpPipe - joins a reader and a writer of two pipes.
+type sftpPipe struct {
+	io.Reader
+	io.WriteCloser
+}
+
+// newTestSFTPClient - returns a SFTP client connected to an in-process
+// server serving the local filesystem.
+func newTestSFTPClient(t *testing.T) *sftp.Client {
+	clientReader, serverWriter := io.Pipe()
+	serverReader, clientWriter := io.Pipe()
+	server, e := sftp.NewServer(sftpPipe{serverReader, serverWriter})
+	if e != nil {
+		t.Fatal(e)
+	}
+	go server.Serve()
+	client, e := sftp.NewClientPipe(clientReader, clientWriter)
+	if e != nil {
+		t.Fatal(e)
+	}
+	return client
+}
+
+func TestSFTPClientPutList(t *testing.T) {
+	root, e := ioutil.TempDir("", "sftp-")
+	if e != nil {
+		t.Fatal(e)
+	}
+	defer os.RemoveAll(root)
+	root = filepath.ToSlash(root)
+
+	client := newTestSFTPClient(t)
+	newTestClient := func(fpath string) *sftpClient {
+		return &sftpClient{
+			targetURL: &clientURL{Type: objectStorage, Scheme: sftpScheme, Host: "localhost", Path: fpath, Separator: '/'},
+			client:    client,
+		}
+	}
+
+	for _, object := range []string{"drop/a.csv", "drop/daily/b.csv", "dropbox/c.csv"} {
+		data := "data of " + object
+		n, err := newTestClient(root+"/"+object).Put(context.Background(), strings.NewReader(data), int64(len(data)), nil, nil, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if n != int64(len(data)) {
+			t.Fatalf("%s: expected %d bytes written, got %d", object, len(data), n)
+		}
+	}
+
+	testCases := []struct {
+		fpath       string
+		isRecursive bool
+		expected    []string
+	}{
+		{"/drop", false, []string{"/drop", "/dropbox"}},
+		{"/drop/", false, []string{"/drop/a.csv", "/drop/daily"}},
+		{"/drop/a", false, []string{"/drop/a.csv"}},
+		{"/drop", true, []string{"/drop/a.csv", "/drop/daily/b.csv", "/dropbox/c.csv"}},
+		{"/drop/", true, []string{"/drop/a.csv", "/drop/daily/b.csv"}},
+	}
+	for i, testCase := range testCases {
+		var listed []string
+		for content := range newTestClient(root+testCase.fpath).List(testCase.isRecursive, false, DirNone) {
+			if content.Err != nil {
+				t.Fatalf("Test %d: %s", i+1, content.Err)
+			}
+			listed = append(listed, strings.TrimPrefix(content.URL.Path, root))
+		}
+		if !reflect.DeepEqual(listed, testCase.expected) {
+			t.Errorf("Test %d: expected %v, got %v", i+1, testCase.expected, listed)
+		}
+	}
+
+	if _, err := newTestClient(root+"/drop/missing.csv").Stat(false, false, nil); err == nil {
+		t.Fatal("expected an error for a missing file")
+	} else if _, ok := err.ToGoError().(PathNotFound); !ok {
+		t.Fatalf("expected PathNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,158 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/minio/mc/pkg/probe"
+)
+
+// tlsVersions - TLS versions accepted as minimum version of an alias.
+var tlsVersions = map[string]uint16{
+	"1.0": tls.VersionTLS10,
+	"1.1": tls.VersionTLS11,
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+// tlsCipherSuites - cipher suites which can be preferred by an alias,
+// TLS 1.3 cipher suites are not configurable.
+var tlsCipherSuites = map[string]uint16{
+	"TLS_RSA_WITH_AES_128_CBC_SHA":                  tls.TLS_RSA_WITH_AES_128_CBC_SHA,
+	"TLS_RSA_WITH_AES_256_CBC_SHA":                  tls.TLS_RSA_WITH_AES_256_CBC_SHA,
+	"TLS_RSA_WITH_AES_128_GCM_SHA256":               tls.TLS_RSA_WITH_AES_128_GCM_SHA256,
+	"TLS_RSA_WITH_AES_256_GCM_SHA384":               tls.TLS_RSA_WITH_AES_256_GCM_SHA384,
+	"TLS_ECDHE_ECDSA_WITH_AES_128_CBC_SHA":          tls.TLS_ECDHE_ECDSA_WITH_AES_128_CBC_SHA,
+	"TLS_ECDHE_ECDSA_WITH_AES_256_CBC_SHA":          tls.TLS_ECDHE_ECDSA_WITH_AES_256_CBC_SHA,
+	"TLS_ECDHE_RSA_WITH_AES_128_CBC_SHA":            tls.TLS_ECDHE_RSA_WITH_AES_128_CBC_SHA,
+	"TLS_ECDHE_RSA_WITH_AES_256_CBC_SHA":            tls.TLS_ECDHE_RSA_WITH_AES_256_CBC_SHA,
+	"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256":         tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256,
+	"TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256":       tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256,
+	"TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384":         tls.TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384,
+	"TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384":       tls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384,
+	"TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305":          tls.TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305,
+	"TLS_ECDHE_ECDSA_WITH_CHACHA20_POLY1305":        tls.TLS_ECDHE_ECDSA_WITH_CHACHA20_POLY1305,
+	"TLS_ECDHE_ECDSA_WITH_AES_128_CBC_SHA256":       tls.TLS_ECDHE_ECDSA_WITH_AES_128_CBC_SHA256,
+	"TLS_ECDHE_RSA_WITH_AES_128_CBC_SHA256":         tls.TLS_ECDHE_RSA_WITH_AES_128_CBC_SHA256,
+	"TLS_RSA_WITH_AES_128_CBC_SHA256":               tls.TLS_RSA_WITH_AES_128_CBC_SHA256,
+	"TLS_ECDHE_ECDSA_WITH_CHACHA20_POLY1305_SHA256": tls.TLS_ECDHE_ECDSA_WITH_CHACHA20_POLY1305,
+	"TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305_SHA256":   tls.TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305,
+}
+
+// parseTLSVersion - returns the TLS version named version, such as "1.2".
+func parseTLSVersion(version string) (uint16, *probe.Error) {
+	v, ok := tlsVersions[version]
+	if !ok {
+		return 0, probe.NewError(fmt.Errorf("unknown TLS version `%s`, valid options are `[1.0, 1.1, 1.2, 1.3]`", version))
+	}
+	return v, nil
+}
+
+// parseCipherSuites - returns the cipher suites named by ciphers.
+func parseCipherSuites(ciphers []string) ([]uint16, *probe.Error) {
+	var suites []uint16
+	for _, cipher := range ciphers {
+		suite, ok := tlsCipherSuites[strings.ToUpper(strings.TrimSpace(cipher))]
+		if !ok {
+			return nil, probe.NewError(fmt.Errorf("unknown cipher suite `%s`", cipher))
+		}
+		suites = append(suites, suite)
+	}
+	return suites, nil
+}
+
+// checkHostTLSConfig - verifies the TLS options of an alias.
+func checkHostTLSConfig(tlsCfg *hostTLSConfigV9) *probe.Error {
+	if tlsCfg == nil {
+		return nil
+	}
+	if (tlsCfg.CertFile == "") != (tlsCfg.KeyFile == "") {
+		return probe.NewError(errors.New("client certificate and key need to be given together"))
+	}
+	if tlsCfg.MinVersion != "" {
+		if _, err := parseTLSVersion(tlsCfg.MinVersion); err != nil {
+			return err
+		}
+	}
+	_, err := parseCipherSuites(tlsCfg.CipherSuites)
+	return err
+}
+
+// newTLSConfig - returns the TLS configuration of a client, which
+// applies the TLS options of its alias to the global settings.
+func newTLSConfig(config *Config) (*tls.Config, *probe.Error) {
+	tlsConfig := &tls.Config{
+		RootCAs: globalRootCAs,
+		// Can't use SSLv3 because of POODLE and BEAST
+		// Can't use TLSv1.0 because of POODLE and BEAST using CBC cipher
+		// Can't use TLSv1.1 because of RC4 cipher usage
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: config.Insecure,
+	}
+	tlsCfg := config.TLS
+	if tlsCfg == nil {
+		return tlsConfig, nil
+	}
+
+	if tlsCfg.SkipVerify {
+		tlsConfig.InsecureSkipVerify = true
+	}
+	if tlsCfg.CAFile != "" {
+		caCert, e := ioutil.ReadFile(tlsCfg.CAFile)
+		if e != nil {
+			return nil, probe.NewError(e).Trace(tlsCfg.CAFile)
+		}
+		// The CA file is trusted in addition to the system CAs and
+		// the CAs in the config folder.
+		tlsConfig.RootCAs = mustGetSystemCertPool()
+		for _, caFile := range mustGetCAFiles() {
+			if caCert, e := ioutil.ReadFile(caFile); e == nil {
+				tlsConfig.RootCAs.AppendCertsFromPEM(caCert)
+			}
+		}
+		if !tlsConfig.RootCAs.AppendCertsFromPEM(caCert) {
+			return nil, probe.NewError(errors.New("no certificates found in `" + tlsCfg.CAFile + "`"))
+		}
+	}
+	if tlsCfg.CertFile != "" {
+		cert, e := tls.LoadX509KeyPair(tlsCfg.CertFile, tlsCfg.KeyFile)
+		if e != nil {
+			return nil, probe.NewError(e).Trace(tlsCfg.CertFile, tlsCfg.KeyFile)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	if tlsCfg.MinVersion != "" {
+		version, err := parseTLSVersion(tlsCfg.MinVersion)
+		if err != nil {
+			return nil, err.Trace(tlsCfg.MinVersion)
+		}
+		tlsConfig.MinVersion = version
+	}
+	if len(tlsCfg.CipherSuites) > 0 {
+		suites, err := parseCipherSuites(tlsCfg.CipherSuites)
+		if err != nil {
+			return nil, err.Trace(tlsCfg.CipherSuites...)
+		}
+		tlsConfig.CipherSuites = suites
+	}
+	return tlsConfig, nil
+}
@@ -0,0 +1,70 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"crypto/tls"
+	"testing"
+)
+
+func TestCheckHostTLSConfig(t *testing.T) {
+	testCases := []struct {
+		tlsCfg      *hostTLSConfigV9
+		expectedErr bool
+	}{
+		{nil, false},
+		{&hostTLSConfigV9{MinVersion: "1.3"}, false},
+		{&hostTLSConfigV9{MinVersion: "1.4"}, true},
+		{&hostTLSConfigV9{CertFile: "mc.crt", KeyFile: "mc.key"}, false},
+		{&hostTLSConfigV9{CertFile: "mc.crt"}, true},
+		{&hostTLSConfigV9{CipherSuites: []string{"tls_ecdhe_rsa_with_aes_128_gcm_sha256", " TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305"}}, false},
+		{&hostTLSConfigV9{CipherSuites: []string{"TLS_NULL"}}, true},
+	}
+	for i, testCase := range testCases {
+		err := checkHostTLSConfig(testCase.tlsCfg)
+		if testCase.expectedErr != (err != nil) {
+			t.Errorf("Test %d: Expected error %t, got %v", i+1, testCase.expectedErr, err)
+		}
+	}
+}
+
+func TestNewTLSConfig(t *testing.T) {
+	tlsConfig, err := newTLSConfig(&Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tlsConfig.MinVersion != tls.VersionTLS12 || tlsConfig.InsecureSkipVerify {
+		t.Fatalf("Unexpected default TLS config %+v", tlsConfig)
+	}
+
+	tlsConfig, err = newTLSConfig(&Config{TLS: &hostTLSConfigV9{
+		MinVersion:   "1.3",
+		CipherSuites: []string{"TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384"},
+		SkipVerify:   true,
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tlsConfig.MinVersion != tls.VersionTLS13 || !tlsConfig.InsecureSkipVerify ||
+		len(tlsConfig.CipherSuites) != 1 || tlsConfig.CipherSuites[0] != tls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384 {
+		t.Fatalf("Unexpected TLS config %+v", tlsConfig)
+	}
+
+	if _, err = newTLSConfig(&Config{TLS: &hostTLSConfigV9{CAFile: "/nonexistent/ca.pem"}}); err == nil {
+		t.Fatal("Expected missing CA file to fail")
+	}
+}
@@ -90,6 +90,16 @@ func getHost(authority string) (host string) {
 	return authority
 }
 
+// isValidScheme - returns true for the URL schemes of object storage
+// and remote filesystems supported by aliases.
+func isValidScheme(scheme string) bool {
+	switch scheme {
+	case "http", "https", sftpScheme, webhdfsScheme, webhdfsSecureScheme:
+		return true
+	}
+	return false
+}
+
 // newClientURL returns an abstracted URL for filesystems and object storage.
 func newClientURL(urlStr string) *clientURL {
 	scheme, rest := getScheme(urlStr)
@@ -101,7 +111,7 @@ func newClientURL(urlStr string) *clientURL {
 			rest = "/"
 		}
 		host := getHost(authority)
-		if host != "" && (scheme == "http" || scheme == "https") {
+		if host != "" && isValidScheme(scheme) {
 			return &clientURL{
 				Scheme:          scheme,
 				Type:            objectStorage,
@@ -29,6 +29,13 @@ func (s *TestSuite) TestURL(c *C) {
 	c.Assert(url.Scheme, Equals, "https")
 	c.Assert(url.Host, Equals, "s3.amazonaws.com")
 	c.Assert(url.Path, Equals, "/mybucket/foo?.go")
+
+	urlStr = "sftp://sftp.example.com:2222/upload/foo.go"
+	url = newClientURL(urlStr)
+	c.Assert(url.Type, Equals, clientURLType(objectStorage))
+	c.Assert(url.Scheme, Equals, "sftp")
+	c.Assert(url.Host, Equals, "sftp.example.com:2222")
+	c.Assert(url.Path, Equals, "/upload/foo.go")
 }
 
 // TestURLJoinPath - tests joining two different urls.
@@ -0,0 +1,674 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"hash/fnv"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/minio/mc/pkg/hookreader"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio-go/v6/pkg/encrypt"
+)
+
+const (
+	webhdfsScheme       = "webhdfs"
+	webhdfsSecureScheme = "swebhdfs"
+	webhdfsPathPrefix   = "/webhdfs/v1"
+)
+
+// webhdfs client
+type webhdfsClient struct {
+	targetURL  *clientURL
+	endpoint   string
+	user       string
+	httpClient *http.Client
+}
+
+// isWebHDFSHostURL - returns true if hostURL points to a WebHDFS
+// REST endpoint.
+func isWebHDFSHostURL(hostURL string) bool {
+	scheme := newClientURL(hostURL).Scheme
+	return scheme == webhdfsScheme || scheme == webhdfsSecureScheme
+}
+
+// webhdfsFileStatus - file status returned by the WebHDFS REST API,
+// implements os.FileInfo.
+type webhdfsFileStatus struct {
+	PathSuffix       string `json:"pathSuffix"`
+	Type             string `json:"type"`
+	Length           int64  `json:"length"`
+	ModificationTime int64  `json:"modificationTime"`
+	Permission       string `json:"permission"`
+}
+
+func (st webhdfsFileStatus) Name() string { return st.PathSuffix }
+func (st webhdfsFileStatus) Size() int64  { return st.Length }
+func (st webhdfsFileStatus) IsDir() bool  { return st.Type == "DIRECTORY" }
+func (st webhdfsFileStatus) Sys() interface{} {
+	return nil
+}
+
+func (st webhdfsFileStatus) ModTime() time.Time {
+	return time.Unix(0, st.ModificationTime*int64(time.Millisecond))
+}
+
+func (st webhdfsFileStatus) Mode() os.FileMode {
+	perm, _ := strconv.ParseUint(st.Permission, 8, 32)
+	mode := os.FileMode(perm) & os.ModePerm
+	switch st.Type {
+	case "DIRECTORY":
+		mode |= os.ModeDir
+	case "SYMLINK":
+		mode |= os.ModeSymlink
+	}
+	return mode
+}
+
+// webhdfsRemoteException - error returned by the WebHDFS REST API.
+type webhdfsRemoteException struct {
+	RemoteException struct {
+		Exception string `json:"exception"`
+		Message   string `json:"message"`
+	} `json:"RemoteException"`
+}
+
+// newWebHDFSFactory encloses webhdfsNew function with client cache.
+func newWebHDFSFactory() func(config *Config) (Client, *probe.Error) {
+	httpClientCache := make(map[uint32]*http.Client)
+	mutex := &sync.Mutex{}
+
+	return func(config *Config) (Client, *probe.Error) {
+		targetURL := newClientURL(config.HostURL)
+		scheme := "http"
+		if targetURL.Scheme == webhdfsSecureScheme {
+			scheme = "https"
+		}
+
+		confHash := fnv.New32a()
+		confHash.Write([]byte(config.Alias + targetURL.Host + config.AccessKey))
+		confSum := confHash.Sum32()
+
+		mutex.Lock()
+		defer mutex.Unlock()
+		httpClient, found := httpClientCache[confSum]
+		if !found {
+			tr := &http.Transport{
+				Proxy: http.ProxyFromEnvironment,
+				DialContext: (&net.Dialer{
+					Timeout:   30 * time.Second,
+					KeepAlive: 30 * time.Second,
+				}).DialContext,
+				MaxIdleConns:          256,
+				MaxIdleConnsPerHost:   256,
+				IdleConnTimeout:       90 * time.Second,
+				TLSHandshakeTimeout:   10 * time.Second,
+				ExpectContinueTimeout: 1 * time.Second,
+				DisableCompression:    true,
+			}
+			if scheme == "https" {
+				tlsConfig, err := newTLSConfig(config)
+				if err != nil {
+					return nil, err.Trace(config.Alias)
+				}
+				tr.TLSClientConfig = tlsConfig
+			}
+			httpClient = &http.Client{
+				Transport: newHTTPLogTransport(tr),
+				// Writes are redirected to a datanode, the request
+				// is sent again with the data by webhdfsClient.
+				CheckRedirect: func(req *http.Request, via []*http.Request) error {
+					if req.Method != http.MethodGet {
+						return http.ErrUseLastResponse
+					}
+					return nil
+				},
+			}
+			httpClientCache[confSum] = httpClient
+		}
+		return &webhdfsClient{
+			targetURL:  targetURL,
+			endpoint:   scheme + "://" + targetURL.Host,
+			user:       config.AccessKey,
+			httpClient: httpClient,
+		}, nil
+	}
+}
+
+// webhdfsNew returns an initialized webhdfsClient structure.
+var webhdfsNew = newWebHDFSFactory()
+
+// GetURL get url.
+func (c *webhdfsClient) GetURL() clientURL {
+	return *c.targetURL
+}
+
+// opURL - returns the REST URL of operation op on fpath.
+func (c *webhdfsClient) opURL(fpath, op string, params url.Values) string {
+	if params == nil {
+		params = url.Values{}
+	}
+	params.Set("op", op)
+	if c.user != "" {
+		params.Set("user.name", c.user)
+	}
+	u := url.URL{Path: webhdfsPathPrefix + path.Join("/", fpath)}
+	return c.endpoint + u.EscapedPath() + "?" + params.Encode()
+}
+
+// toClientError - constructs a typed client error from a WebHDFS error response.
+func (c *webhdfsClient) toClientError(resp *http.Response, fpath string) *probe.Error {
+	var exception webhdfsRemoteException
+	if e := json.NewDecoder(resp.Body).Decode(&exception); e != nil || exception.RemoteException.Exception == "" {
+		return probe.NewError(errors.New(resp.Status)).Trace(fpath)
+	}
+	switch exception.RemoteException.Exception {
+	case "FileNotFoundException":
+		return probe.NewError(PathNotFound{Path: fpath})
+	case "AccessControlException", "SecurityException":
+		return probe.NewError(PathInsufficientPermission{Path: fpath})
+	}
+	return probe.NewError(errors.New(exception.RemoteException.Message)).Trace(fpath)
+}
+
+// do - sends a request to the WebHDFS REST API, responses with an
+// error status are converted to a client error.
+func (c *webhdfsClient) do(method, rawURL, fpath string, body io.Reader) (*http.Response, *probe.Error) {
+	req, e := http.NewRequest(method, rawURL, body)
+	if e != nil {
+		return nil, probe.NewError(e).Trace(fpath)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/octet-stream")
+	}
+	resp, e := c.httpClient.Do(req)
+	if e != nil {
+		return nil, probe.NewError(e).Trace(fpath)
+	}
+	if resp.StatusCode >= http.StatusBadRequest {
+		defer resp.Body.Close()
+		return nil, c.toClientError(resp, fpath)
+	}
+	return resp, nil
+}
+
+// doJSON - sends a request without a body and decodes the JSON response into v.
+func (c *webhdfsClient) doJSON(method, fpath, op string, params url.Values, v interface{}) *probe.Error {
+	resp, err := c.do(method, c.opURL(fpath, op, params), fpath, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if e := json.NewDecoder(resp.Body).Decode(v); e != nil {
+		return probe.NewError(e).Trace(fpath)
+	}
+	return nil
+}
+
+// doBoolean - sends a request answered with a boolean result.
+func (c *webhdfsClient) doBoolean(method, fpath, op string, params url.Values) (bool, *probe.Error) {
+	var result struct {
+		Boolean bool `json:"boolean"`
+	}
+	if err := c.doJSON(method, fpath, op, params, &result); err != nil {
+		return false, err
+	}
+	return result.Boolean, nil
+}
+
+// mkdirs - creates the directory fpath and any missing parents.
+func (c *webhdfsClient) mkdirs(fpath string) *probe.Error {
+	ok, err := c.doBoolean(http.MethodPut, fpath, "MKDIRS", nil)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return probe.NewError(errors.New("unable to create directory")).Trace(fpath)
+	}
+	return nil
+}
+
+// fileStatus - returns the status of the file or directory at fpath.
+func (c *webhdfsClient) fileStatus(fpath string) (os.FileInfo, *probe.Error) {
+	var result struct {
+		FileStatus webhdfsFileStatus `json:"FileStatus"`
+	}
+	if err := c.doJSON(http.MethodGet, fpath, "GETFILESTATUS", nil, &result); err != nil {
+		return nil, err
+	}
+	return result.FileStatus, nil
+}
+
+// readDir - reads the directory dirname and returns a list of sorted
+// directory entries.
+func (c *webhdfsClient) readDir(dirname string) ([]os.FileInfo, *probe.Error) {
+	var result struct {
+		FileStatuses struct {
+			FileStatus []webhdfsFileStatus `json:"FileStatus"`
+		} `json:"FileStatuses"`
+	}
+	if err := c.doJSON(http.MethodGet, dirname, "LISTSTATUS", nil, &result); err != nil {
+		return nil, err
+	}
+	list := make([]os.FileInfo, 0, len(result.FileStatuses.FileStatus))
+	for _, st := range result.FileStatuses.FileStatus {
+		list = append(list, st)
+	}
+	sort.Sort(byDirName(list))
+	return list, nil
+}
+
+// fileInfo2ClientContent - converts a file status to a clientContent.
+func (c *webhdfsClient) fileInfo2ClientContent(fpath string, fi os.FileInfo) *clientContent {
+	url := *c.targetURL
+	url.Path = fpath
+	return &clientContent{
+		URL:  url,
+		Time: fi.ModTime(),
+		Size: fi.Size(),
+		Type: fi.Mode(),
+	}
+}
+
+// isPathNotFound - returns true if err is a PathNotFound error.
+func isPathNotFound(err *probe.Error) bool {
+	_, ok := err.ToGoError().(PathNotFound)
+	return ok
+}
+
+// Stat - get metadata from path.
+func (c *webhdfsClient) Stat(isIncomplete, isFetchMeta bool, sse encrypt.ServerSide) (*clientContent, *probe.Error) {
+	fpath := c.targetURL.Path
+	fi, err := c.fileStatus(fpath)
+	if err == nil && fi.IsDir() {
+		return c.fileInfo2ClientContent(fpath, fi), nil
+	}
+	if isIncomplete {
+		fi, err = c.fileStatus(fpath + partSuffix)
+	}
+	if err != nil {
+		return nil, err.Trace(c.targetURL.String())
+	}
+	content := c.fileInfo2ClientContent(fpath, fi)
+	content.Metadata = map[string]string{
+		"Content-Type": guessURLContentType(fpath),
+	}
+	return content, nil
+}
+
+// List - list files and folders.
+func (c *webhdfsClient) List(isRecursive, isIncomplete bool, showDir DirOpt) <-chan *clientContent {
+	contentCh := make(chan *clientContent)
+	filteredCh := make(chan *clientContent)
+
+	if isRecursive {
+		go c.listRecursiveInRoutine(contentCh, showDir)
+	} else {
+		go c.listInRoutine(contentCh)
+	}
+
+	// Filter out partly uploaded files, or only show them
+	// when isIncomplete is set.
+	go func() {
+		defer close(filteredCh)
+		for content := range contentCh {
+			if content.Err == nil {
+				if isIncomplete != strings.HasSuffix(content.URL.Path, partSuffix) {
+					continue
+				}
+				content.URL.Path = strings.TrimSuffix(content.URL.Path, partSuffix)
+			}
+			filteredCh <- content
+		}
+	}()
+
+	return filteredCh
+}
+
+// listPrefixes - list all entries of the parent directory matching prefix.
+func (c *webhdfsClient) listPrefixes(prefix string, contentCh chan<- *clientContent) {
+	dirName := path.Dir(prefix)
+	files, err := c.readDir(dirName)
+	if err != nil {
+		contentCh <- &clientContent{Err: err.Trace(dirName)}
+		return
+	}
+	for _, fi := range files {
+		if fpath := path.Join(dirName, fi.Name()); strings.HasPrefix(fpath, prefix) {
+			contentCh <- c.fileInfo2ClientContent(fpath, fi)
+		}
+	}
+}
+
+func (c *webhdfsClient) listInRoutine(contentCh chan<- *clientContent) {
+	defer close(contentCh)
+
+	fpath := c.targetURL.Path
+	fi, err := c.fileStatus(fpath)
+	if err != nil {
+		if isPathNotFound(err) {
+			// If file does not exist treat it like a prefix.
+			c.listPrefixes(fpath, contentCh)
+			return
+		}
+		contentCh <- &clientContent{Err: err.Trace(fpath)}
+		return
+	}
+
+	if !fi.IsDir() {
+		contentCh <- c.fileInfo2ClientContent(fpath, fi)
+		return
+	}
+	// Do not traverse directories not ending with a separator.
+	if !strings.HasSuffix(fpath, "/") {
+		c.listPrefixes(fpath, contentCh)
+		return
+	}
+
+	files, err := c.readDir(fpath)
+	if err != nil {
+		contentCh <- &clientContent{Err: err.Trace(fpath)}
+		return
+	}
+	for _, fi := range files {
+		if fi.Mode().IsRegular() || fi.IsDir() {
+			contentCh <- c.fileInfo2ClientContent(path.Join(fpath, fi.Name()), fi)
+		}
+	}
+}
+
+// listRecursiveInRoutine - walks the tree below the target path, a
+// target not ending with a separator is used as a prefix.
+func (c *webhdfsClient) listRecursiveInRoutine(contentCh chan<- *clientContent, dirOpt DirOpt) {
+	defer close(contentCh)
+
+	var listDir func(dirName, prefix string) bool
+	listDir = func(dirName, prefix string) (isStop bool) {
+		files, err := c.readDir(dirName)
+		if err != nil {
+			contentCh <- &clientContent{Err: err.Trace(dirName)}
+			_, ok := err.ToGoError().(PathInsufficientPermission)
+			return !ok
+		}
+		for _, fi := range files {
+			fpath := path.Join(dirName, fi.Name())
+			if !strings.HasPrefix(fpath, prefix) {
+				continue
+			}
+			content := c.fileInfo2ClientContent(fpath, fi)
+			switch {
+			case fi.IsDir():
+				if dirOpt == DirFirst {
+					contentCh <- content
+				}
+				if listDir(fpath, "") {
+					return true
+				}
+				if dirOpt == DirLast {
+					contentCh <- content
+				}
+			case fi.Mode().IsRegular():
+				contentCh <- content
+			}
+		}
+		return false
+	}
+
+	fpath := c.targetURL.Path
+	if !strings.HasSuffix(fpath, "/") {
+		listDir(path.Dir(fpath), fpath)
+		return
+	}
+
+	root := &clientContent{URL: *c.targetURL, Type: os.ModeDir}
+	if dirOpt == DirFirst {
+		contentCh <- root
+	}
+	listDir(fpath, "")
+	if dirOpt == DirLast {
+		contentCh <- root
+	}
+}
+
+// Get returns reader of the file, the namenode redirects the request
+// to a datanode holding the data.
+func (c *webhdfsClient) Get(sse encrypt.ServerSide) (io.ReadCloser, *probe.Error) {
+	fpath := c.targetURL.Path
+	resp, err := c.do(http.MethodGet, c.opURL(fpath, "OPEN", nil), fpath, nil)
+	if err != nil {
+		return nil, err.Trace(c.targetURL.String())
+	}
+	return resp.Body, nil
+}
+
+// create - writes reader to a new file at fpath, overwriting any
+// existing file. The namenode answers with the location of a
+// datanode, where the data is sent to.
+func (c *webhdfsClient) create(fpath string, reader io.Reader, size int64) *probe.Error {
+	params := url.Values{}
+	params.Set("overwrite", "true")
+	resp, err := c.do(http.MethodPut, c.opURL(fpath, "CREATE", params), fpath, nil)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	location, e := resp.Location()
+	if resp.StatusCode != http.StatusTemporaryRedirect || e != nil {
+		return probe.NewError(errors.New("no datanode location returned for CREATE, got " + resp.Status)).Trace(fpath)
+	}
+
+	req, e := http.NewRequest(http.MethodPut, location.String(), reader)
+	if e != nil {
+		return probe.NewError(e).Trace(fpath)
+	}
+	req.Header.Set("Content-Type", "application/octet-stream")
+	if size > 0 {
+		req.ContentLength = size
+	}
+	resp, e = c.httpClient.Do(req)
+	if e != nil {
+		return probe.NewError(e).Trace(fpath)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= http.StatusBadRequest {
+		return c.toClientError(resp, fpath)
+	}
+	return nil
+}
+
+// Put - uploads reader to the target path. Data is written to a
+// partial file first, which is renamed once the upload completed.
+func (c *webhdfsClient) Put(ctx context.Context, reader io.Reader, size int64, metadata map[string]string, progress io.Reader, sse encrypt.ServerSide) (int64, *probe.Error) {
+	fpath := c.targetURL.Path
+	dirName, name := path.Split(fpath)
+	if dirName != "" {
+		if err := c.mkdirs(dirName); err != nil {
+			return 0, err.Trace(fpath)
+		}
+	}
+	// Object name is empty, it must be a directory.
+	if name == "" {
+		return 0, nil
+	}
+
+	partPath := fpath + partSuffix
+	if err := c.create(partPath, hookreader.NewHook(reader, progress), size); err != nil {
+		return 0, err.Trace(fpath)
+	}
+	fi, err := c.fileStatus(partPath)
+	if err != nil {
+		return 0, err.Trace(fpath)
+	}
+	n := fi.Size()
+	if size > 0 && n < size {
+		return n, probe.NewError(UnexpectedEOF{
+			TotalSize:    size,
+			TotalWritten: n,
+		})
+	}
+
+	// Rename fails if the target exists, remove it first.
+	if _, err = c.doBoolean(http.MethodDelete, fpath, "DELETE", nil); err != nil {
+		return n, err.Trace(fpath)
+	}
+	params := url.Values{}
+	params.Set("destination", path.Join("/", fpath))
+	renamed, err := c.doBoolean(http.MethodPut, partPath, "RENAME", params)
+	if err != nil {
+		return n, err.Trace(partPath, fpath)
+	}
+	if !renamed {
+		return n, probe.NewError(errors.New("unable to rename to `" + fpath + "`")).Trace(partPath)
+	}
+	return n, nil
+}
+
+// Copy - copy a file on the same cluster. WebHDFS has no copy
+// operation, the data is read back and uploaded again.
+func (c *webhdfsClient) Copy(source string, size int64, progress io.Reader, srcSSE, tgtSSE encrypt.ServerSide, metadata map[string]string) *probe.Error {
+	resp, err := c.do(http.MethodGet, c.opURL(source, "OPEN", nil), source, nil)
+	if err != nil {
+		return err.Trace(source)
+	}
+	defer resp.Body.Close()
+
+	if _, err = c.Put(context.Background(), resp.Body, size, metadata, progress, tgtSSE); err != nil {
+		return err.Trace(source, c.targetURL.Path)
+	}
+	return nil
+}
+
+// Remove - remove entries read from the clientContent channel.
+func (c *webhdfsClient) Remove(isIncomplete, isRemoveBucket bool, contentCh <-chan *clientContent) <-chan *probe.Error {
+	errorCh := make(chan *probe.Error)
+
+	go func() {
+		defer close(errorCh)
+
+		for content := range contentCh {
+			name := content.URL.Path
+			if isIncomplete {
+				name += partSuffix
+			}
+			deleted, err := c.doBoolean(http.MethodDelete, name, "DELETE", nil)
+			if err != nil {
+				errorCh <- err.Trace(name)
+				continue
+			}
+			// DELETE reports false for a missing path, which is
+			// ignored for directory removal.
+			if !deleted && !isRemoveBucket {
+				errorCh <- probe.NewError(PathNotFound{Path: content.URL.Path})
+			}
+		}
+	}()
+
+	return errorCh
+}
+
+// MakeBucket - create a new directory.
+func (c *webhdfsClient) MakeBucket(region string, ignoreExisting bool) *probe.Error {
+	if err := c.mkdirs(c.targetURL.Path); err != nil {
+		return err.Trace(c.targetURL.String())
+	}
+	return nil
+}
+
+// GetAccessRules - unsupported API
+func (c *webhdfsClient) GetAccessRules() (map[string]string, *probe.Error) {
+	return map[string]string{}, probe.NewError(APINotImplemented{
+		API:     "ListBucketPolicies",
+		APIType: webhdfsScheme,
+	})
+}
+
+// GetAccess - unsupported API
+func (c *webhdfsClient) GetAccess() (string, string, *probe.Error) {
+	return "", "", probe.NewError(APINotImplemented{
+		API:     "GetAccess",
+		APIType: webhdfsScheme,
+	})
+}
+
+// SetAccess - unsupported API
+func (c *webhdfsClient) SetAccess(access string, isJSON bool) *probe.Error {
+	return probe.NewError(APINotImplemented{
+		API:     "SetAccess",
+		APIType: webhdfsScheme,
+	})
+}
+
+// Select - unsupported API
+func (c *webhdfsClient) Select(expression string, sse encrypt.ServerSide, opts SelectObjectOpts) (io.ReadCloser, *probe.Error) {
+	return nil, probe.NewError(APINotImplemented{
+		API:     "Select",
+		APIType: webhdfsScheme,
+	})
+}
+
+// ShareDownload - unsupported API
+func (c *webhdfsClient) ShareDownload(expires time.Duration) (string, *probe.Error) {
+	return "", probe.NewError(APINotImplemented{
+		API:     "ShareDownload",
+		APIType: webhdfsScheme,
+	})
+}
+
+// ShareUpload - unsupported API
+func (c *webhdfsClient) ShareUpload(startsWith bool, expires time.Duration, contentType string) (string, map[string]string, *probe.Error) {
+	return "", nil, probe.NewError(APINotImplemented{
+		API:     "ShareUpload",
+		APIType: webhdfsScheme,
+	})
+}
+
+// Watch - unsupported API
+func (c *webhdfsClient) Watch(params watchParams) (*watchObject, *probe.Error) {
+	return nil, probe.NewError(APINotImplemented{
+		API:     "Watch",
+		APIType: webhdfsScheme,
+	})
+}
+
+// Restore - unsupported API
+func (c *webhdfsClient) Restore(days int) *probe.Error {
+	return probe.NewError(APINotImplemented{
+		API:     "Restore",
+		APIType: webhdfsScheme,
+	})
+}
+
+// GetTags - unsupported API
+func (c *webhdfsClient) GetTags() (map[string]string, *probe.Error) {
+	return nil, probe.NewError(APINotImplemented{
+		API:     "GetTags",
+		APIType: webhdfsScheme,
+	})
+}
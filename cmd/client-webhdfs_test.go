@@ -0,0 +1,170 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// fakeWebHDFS - in-memory WebHDFS REST API, directories are
+// stored with a nil content.
+type fakeWebHDFS struct {
+	sync.Mutex
+	files map[string][]byte
+}
+
+func (f *fakeWebHDFS) status(fpath string) webhdfsFileStatus {
+	st := webhdfsFileStatus{PathSuffix: path.Base(fpath), Type: "FILE", Permission: "644"}
+	if f.files[fpath] == nil {
+		st.Type, st.Permission = "DIRECTORY", "755"
+	}
+	st.Length = int64(len(f.files[fpath]))
+	return st
+}
+
+func (f *fakeWebHDFS) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.Lock()
+	defer f.Unlock()
+
+	fpath := strings.TrimPrefix(r.URL.Path, webhdfsPathPrefix)
+	query := r.URL.Query()
+	_, found := f.files[fpath]
+	if op := query.Get("op"); !found && (op == "GETFILESTATUS" || op == "LISTSTATUS" || op == "OPEN") {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"RemoteException":{"exception":"FileNotFoundException","message":"File does not exist"}}`))
+		return
+	}
+	switch query.Get("op") {
+	case "GETFILESTATUS":
+		json.NewEncoder(w).Encode(map[string]interface{}{"FileStatus": f.status(fpath)})
+	case "LISTSTATUS":
+		var list []webhdfsFileStatus
+		for name := range f.files {
+			if path.Dir(name) == fpath && name != fpath {
+				list = append(list, f.status(name))
+			}
+		}
+		sort.Slice(list, func(i, j int) bool { return list[i].PathSuffix < list[j].PathSuffix })
+		json.NewEncoder(w).Encode(map[string]interface{}{"FileStatuses": map[string]interface{}{"FileStatus": list}})
+	case "MKDIRS":
+		for dir := fpath; dir != "/"; dir = path.Dir(dir) {
+			f.files[dir] = nil
+		}
+		w.Write([]byte(`{"boolean":true}`))
+	case "CREATE":
+		if query.Get("datanode") == "" {
+			w.Header().Set("Location", r.URL.String()+"&datanode=true")
+			w.WriteHeader(http.StatusTemporaryRedirect)
+			return
+		}
+		data, _ := ioutil.ReadAll(r.Body)
+		f.files[fpath] = append([]byte{}, data...)
+		w.WriteHeader(http.StatusCreated)
+	case "OPEN":
+		w.Write(f.files[fpath])
+	case "DELETE":
+		delete(f.files, fpath)
+		json.NewEncoder(w).Encode(map[string]bool{"boolean": found})
+	case "RENAME":
+		destination := query.Get("destination")
+		if _, ok := f.files[destination]; ok || !found {
+			w.Write([]byte(`{"boolean":false}`))
+			return
+		}
+		f.files[destination] = f.files[fpath]
+		delete(f.files, fpath)
+		w.Write([]byte(`{"boolean":true}`))
+	default:
+		w.WriteHeader(http.StatusBadRequest)
+	}
+}
+
+func TestWebHDFSClient(t *testing.T) {
+	server := httptest.NewServer(&fakeWebHDFS{files: map[string][]byte{"/": nil}})
+	defer server.Close()
+
+	newTestClient := func(fpath string) Client {
+		client, err := webhdfsNew(&Config{
+			HostURL:   "webhdfs://" + strings.TrimPrefix(server.URL, "http://") + fpath,
+			AccessKey: "etl",
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return client
+	}
+
+	for _, object := range []string{"/warehouse/a.orc", "/warehouse/2019/b.orc", "/warehouse/2019/b.orc", "/warehousex/c.orc"} {
+		data := "data of " + object
+		n, err := newTestClient(object).Put(context.Background(), strings.NewReader(data), int64(len(data)), nil, nil, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if n != int64(len(data)) {
+			t.Fatalf("%s: expected %d bytes written, got %d", object, len(data), n)
+		}
+	}
+
+	testCases := []struct {
+		fpath       string
+		isRecursive bool
+		expected    []string
+	}{
+		{"/warehouse", false, []string{"/warehouse", "/warehousex"}},
+		{"/warehouse/", false, []string{"/warehouse/2019", "/warehouse/a.orc"}},
+		{"/warehouse", true, []string{"/warehouse/2019/b.orc", "/warehouse/a.orc", "/warehousex/c.orc"}},
+		{"/warehouse/", true, []string{"/warehouse/2019/b.orc", "/warehouse/a.orc"}},
+	}
+	for i, testCase := range testCases {
+		var listed []string
+		for content := range newTestClient(testCase.fpath).List(testCase.isRecursive, false, DirNone) {
+			if content.Err != nil {
+				t.Fatalf("Test %d: %s", i+1, content.Err)
+			}
+			listed = append(listed, content.URL.Path)
+		}
+		if !reflect.DeepEqual(listed, testCase.expected) {
+			t.Errorf("Test %d: expected %v, got %v", i+1, testCase.expected, listed)
+		}
+	}
+
+	reader, err := newTestClient("/warehouse/2019/b.orc").Get(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, e := ioutil.ReadAll(reader)
+	reader.Close()
+	if e != nil || string(data) != "data of /warehouse/2019/b.orc" {
+		t.Fatalf("unexpected data %q, %v", data, e)
+	}
+
+	if _, err = newTestClient("/warehouse/missing.orc").Stat(false, false, nil); err == nil {
+		t.Fatal("expected an error for a missing file")
+	} else if !isPathNotFound(err) {
+		t.Fatalf("expected PathNotFound, got %v", err)
+	}
+}
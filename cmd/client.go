@@ -73,6 +73,12 @@ type Client interface {
 	// Watch events
 	Watch(params watchParams) (*watchObject, *probe.Error)
 
+	// Restore operations for archived objects
+	Restore(days int) *probe.Error
+
+	// Object tagging operations
+	GetTags() (map[string]string, *probe.Error)
+
 	// Delete operations
 	Remove(isIncomplete, isRemoveBucket bool, contentCh <-chan *clientContent) (errorCh <-chan *probe.Error)
 
@@ -89,6 +95,7 @@ type clientContent struct {
 	Metadata          map[string]string
 	UserMetadata      map[string]string
 	ETag              string
+	StorageClass      string
 	Expires           time.Time
 	EncryptionHeaders map[string]string
 	Err               *probe.Error
@@ -96,16 +103,23 @@ type clientContent struct {
 
 // Config - see http://docs.amazonwebservices.com/AmazonS3/latest/dev/index.html?RESTAuthentication.html
 type Config struct {
-	AccessKey   string
-	SecretKey   string
-	Signature   string
-	HostURL     string
-	AppName     string
-	AppVersion  string
-	AppComments []string
-	Debug       bool
-	Insecure    bool
-	Lookup      minio.BucketLookupType
+	Alias             string
+	AccessKey         string
+	SecretKey         string
+	CredentialProcess string
+	RoleARN           string
+	WebIdentityToken  string
+	STSEndpoint       string
+	TLS               *hostTLSConfigV9
+	Signature         string
+	HostURL           string
+	AppName           string
+	AppVersion        string
+	AppComments       []string
+	Debug             bool
+	Insecure          bool
+	Lookup            minio.BucketLookupType
+	Region            string
 }
 
 // SelectObjectOpts - opts entered for select API
@@ -21,6 +21,7 @@ import (
 	"encoding/base64"
 	"errors"
 	"io"
+	"io/ioutil"
 	"net/http"
 	"os"
 	"path/filepath"
@@ -31,6 +32,7 @@ import (
 	"gopkg.in/h2non/filetype.v1"
 
 	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/hookreader"
 	"github.com/minio/mc/pkg/probe"
 	"github.com/minio/minio-go/v6/pkg/encrypt"
 )
@@ -65,6 +67,19 @@ func parseKey(sseKeys string) (sse string, err *probe.Error) {
 	return encryptString[0] + "=" + string(decodedString), nil
 }
 
+// decodeEncryptionKey validates an encryption key, either 32 bytes of
+// plain text or 44 bytes of base64 encoded text.
+func decodeEncryptionKey(key string) ([]byte, *probe.Error) {
+	if len(key) == 32 {
+		return []byte(key), nil
+	}
+	decodedKey, e := base64.StdEncoding.DecodeString(key)
+	if e != nil || len(decodedKey) != 32 {
+		return nil, probe.NewError(errors.New("Encryption key should be 32 bytes plain text key or 44 bytes base64 encoded key"))
+	}
+	return decodedKey, nil
+}
+
 // parse and return encryption key pairs per alias.
 func getEncKeys(ctx *cli.Context) (map[string][]prefixSSEPair, *probe.Error) {
 	sseServer := os.Getenv("MC_ENCRYPT")
@@ -92,6 +107,24 @@ func getEncKeys(ctx *cli.Context) (map[string][]prefixSSEPair, *probe.Error) {
 		return nil, err.Trace(sseKeys)
 	}
 
+	// Keys saved in the config are used for the aliases of the
+	// arguments, keys passed on the command line take precedence.
+	seen := make(map[string]bool)
+	for _, arg := range ctx.Args() {
+		alias, _ := url2Alias(arg)
+		if seen[alias] {
+			continue
+		}
+		seen[alias] = true
+		pairs, err := getConfigEncKeys(alias)
+		if err != nil {
+			return nil, err.Trace(arg)
+		}
+		if len(pairs) > 0 {
+			encKeyDB[alias] = append(encKeyDB[alias], pairs...)
+		}
+	}
+
 	return encKeyDB, nil
 }
 
@@ -221,7 +254,9 @@ func putTargetStream(ctx context.Context, alias string, urlStr string, reader io
 }
 
 // putTargetStreamWithURL writes to URL from reader. If length=-1, read until EOF.
-func putTargetStreamWithURL(urlStr string, reader io.Reader, size int64, sse encrypt.ServerSide) (int64, *probe.Error) {
+// A non empty compress indicates that reader is already compressed with
+// the given format, extra metadata is stored along with the object.
+func putTargetStreamWithURL(urlStr string, reader io.Reader, size int64, sse encrypt.ServerSide, compress string, extraMetadata map[string]string) (int64, *probe.Error) {
 	alias, urlStrFull, _, err := expandAlias(urlStr)
 	if err != nil {
 		return 0, err.Trace(alias, urlStr)
@@ -230,6 +265,13 @@ func putTargetStreamWithURL(urlStr string, reader io.Reader, size int64, sse enc
 	metadata := map[string]string{
 		"Content-Type": contentType,
 	}
+	for k, v := range extraMetadata {
+		metadata[k] = v
+	}
+	if compress != "" {
+		urlStrFull = compressedName(urlStrFull, compress)
+		metadata["Content-Encoding"] = strings.ToLower(compress)
+	}
 	return putTargetStream(context.Background(), alias, urlStrFull, reader, size, metadata, nil, sse)
 }
 
@@ -298,8 +340,17 @@ func uploadSourceToTargetURL(ctx context.Context, urls URLs, progress io.Reader,
 	var err *probe.Error
 	var metadata map[string]string
 
-	// Optimize for server side copy if the host is same.
-	if sourceAlias == targetAlias {
+	// Archived objects have to be restored before they can be read.
+	if isArchivedStorageClass(urls.SourceContent.StorageClass) {
+		if err = waitForRestore(ctx, sourceAlias, sourceURL.String(), srcSSE, urls.restoreDays); err != nil {
+			return urls.WithError(err.Trace(sourceURL.String()))
+		}
+	}
+
+	// Optimize for server side copy if the host is same, compressed
+	// and client-side encrypted uploads always have to go through
+	// the client.
+	if (sourceAlias == targetAlias || urls.serverSide) && urls.compress == "" && urls.cseKey == nil {
 		metadata, err = getAllMetadata(sourceAlias, sourceURL.String(), srcSSE, urls)
 		if err != nil {
 			return urls.WithError(err.Trace(sourceURL.String()))
@@ -316,6 +367,38 @@ func uploadSourceToTargetURL(ctx context.Context, urls URLs, progress io.Reader,
 			return urls.WithError(err.Trace(sourceURL.String()))
 		}
 		defer reader.Close()
+
+		// Transparently decrypt downloads of client-side encrypted
+		// objects, without a key they are copied as is.
+		if targetURL.Type == fileSystem && urls.cseKey != nil && isCSEEncrypted(metadata) {
+			reader, err = newCSEDecryptReader(reader, urls.cseKey, metadata)
+			if err != nil {
+				return urls.WithError(err.Trace(sourceURL.String()))
+			}
+			removeCSEMetadata(metadata)
+			length = cseDecryptedSize(length)
+		}
+
+		switch {
+		case urls.compress != "":
+			// Account progress against the uncompressed stream since
+			// the compressed size is not known in advance.
+			reader = newCompressReader(hookreader.NewHook(reader, progress), urls.compress)
+			defer reader.Close()
+			progress = nil
+			length = -1
+			metadata["Content-Encoding"] = strings.ToLower(urls.compress)
+		case targetURL.Type == fileSystem && isDecompressible(metadata["Content-Encoding"]):
+			// Transparently decompress downloads of compressed objects.
+			reader, err = newDecompressReader(reader, metadata["Content-Encoding"])
+			if err != nil {
+				return urls.WithError(err.Trace(sourceURL.String()))
+			}
+			defer reader.Close()
+			targetURL.Path = decompressedName(targetURL.Path, metadata["Content-Encoding"])
+			length = -1
+		}
+
 		// Get metadata from target content as well
 		for k, v := range urls.TargetContent.Metadata {
 			metadata[k] = v
@@ -329,6 +412,22 @@ func uploadSourceToTargetURL(ctx context.Context, urls URLs, progress io.Reader,
 				delete(metadata, k)
 			}
 		}
+
+		// Encrypt uploads client-side, objects already encrypted
+		// are copied as is.
+		if targetURL.Type == objectStorage && urls.cseKey != nil && !isCSEEncrypted(metadata) {
+			// Account progress against the plain text stream
+			// since the encrypted stream is larger.
+			if progress != nil {
+				reader = ioutil.NopCloser(hookreader.NewHook(reader, progress))
+				progress = nil
+			}
+			reader, err = newCSEEncryptReader(reader, urls.cseKey, metadata)
+			if err != nil {
+				return urls.WithError(err.Trace(sourceURL.String()))
+			}
+			length = cseEncryptedSize(length)
+		}
 		_, err = putTargetStream(ctx, targetAlias, targetURL.String(), reader, length, metadata, progress, tgtSSE)
 	}
 	if err != nil {
@@ -358,16 +457,25 @@ func newClientFromAlias(alias, urlStr string) (Client, *probe.Error) {
 	}
 
 	s3Config := newS3Config(urlStr, hostCfg)
+	s3Config.Alias = alias
 
-	s3Client, err := s3New(s3Config)
+	var client Client
+	switch {
+	case isSFTPHostURL(hostCfg.URL):
+		client, err = sftpNew(s3Config)
+	case isWebHDFSHostURL(hostCfg.URL):
+		client, err = webhdfsNew(s3Config)
+	default:
+		client, err = s3New(s3Config)
+	}
 	if err != nil {
 		return nil, err.Trace(alias, urlStr)
 	}
-	return s3Client, nil
+	return client, nil
 }
 
 // urlRgx - verify if aliased url is real URL.
-var urlRgx = regexp.MustCompile("^https?://")
+var urlRgx = regexp.MustCompile("^(https?|sftp|s?webhdfs)://")
 
 // newClient gives a new client interface
 func newClient(aliasedURL string) (Client, *probe.Error) {
@@ -40,23 +40,13 @@ func completeS3Path(s3Path string) (prediction []string) {
 
 	// Convert alias/bucket/incompl to alias/bucket/ to list its contents
 	parentDirPath := filepath.Dir(s3Path) + "/"
-	clnt, err := newClient(parentDirPath)
-	if err != nil {
-		return nil
-	}
 
 	// Calculate alias from the path
 	alias := splitStr(s3Path, "/", 3)[0]
 
-	// List dirPath content and only pick elements that corresponds
-	// to the path that we want to complete
-	for content := range clnt.List(false, false, DirFirst) {
-		completeS3Path := alias + getKey(content)
-		if content.Type.IsDir() {
-			if !strings.HasSuffix(completeS3Path, "/") {
-				completeS3Path += "/"
-			}
-		}
+	// List dirPath content, possibly cached, and only pick elements
+	// that corresponds to the path that we want to complete
+	for _, completeS3Path := range listPrefixEntries(alias, parentDirPath) {
 		if strings.HasPrefix(completeS3Path, s3Path) {
 			prediction = append(prediction, completeS3Path)
 		}
@@ -136,38 +126,82 @@ func (al aliasComplete) Predict(a complete.Args) (prediction []string) {
 	return
 }
 
+// profileComplete only completes profile names
+type profileComplete struct{}
+
+func (p profileComplete) Predict(a complete.Args) (prediction []string) {
+	defer func() {
+		sort.Strings(prediction)
+	}()
+
+	loadMcConfig = loadMcConfigFactory()
+	conf, err := loadMcConfig()
+	if err != nil {
+		return nil
+	}
+
+	for profile := range conf.Profiles {
+		if strings.HasPrefix(profile, a.Last) {
+			prediction = append(prediction, profile)
+		}
+	}
+
+	return
+}
+
 var s3Completer = s3Complete{}
 var aliasCompleter = aliasComplete{}
+var profileCompleter = profileComplete{}
 var fsCompleter = fsComplete{}
 
 // The list of all commands supported by mc with their mapping
 // with their bash completer function
 var completeCmds = map[string]complete.Predictor{
-	"/ls":     complete.PredictOr(s3Completer, fsCompleter),
-	"/cp":     complete.PredictOr(s3Completer, fsCompleter),
-	"/rm":     complete.PredictOr(s3Completer, fsCompleter),
-	"/rb":     complete.PredictOr(s3Complete{deepLevel: 2}, fsCompleter),
-	"/cat":    complete.PredictOr(s3Completer, fsCompleter),
-	"/head":   complete.PredictOr(s3Completer, fsCompleter),
-	"/diff":   complete.PredictOr(s3Completer, fsCompleter),
-	"/find":   complete.PredictOr(s3Completer, fsCompleter),
-	"/mirror": complete.PredictOr(s3Completer, fsCompleter),
-	"/pipe":   complete.PredictOr(s3Completer, fsCompleter),
-	"/stat":   complete.PredictOr(s3Completer, fsCompleter),
-	"/watch":  complete.PredictOr(s3Completer, fsCompleter),
-	"/policy": complete.PredictOr(s3Completer, fsCompleter),
-	"/tree":   complete.PredictOr(s3Complete{deepLevel: 2}, fsCompleter),
-	"/du":     complete.PredictOr(s3Complete{deepLevel: 2}, fsCompleter),
-
-	"/mb":  aliasCompleter,
-	"/sql": s3Completer,
+	"/ls":      complete.PredictOr(s3Completer, fsCompleter),
+	"/cp":      complete.PredictOr(s3Completer, fsCompleter),
+	"/rm":      complete.PredictOr(s3Completer, fsCompleter),
+	"/rb":      complete.PredictOr(s3Complete{deepLevel: 2}, fsCompleter),
+	"/cat":     complete.PredictOr(s3Completer, fsCompleter),
+	"/head":    complete.PredictOr(s3Completer, fsCompleter),
+	"/diff":    complete.PredictOr(s3Completer, fsCompleter),
+	"/find":    complete.PredictOr(s3Completer, fsCompleter),
+	"/mirror":  complete.PredictOr(s3Completer, fsCompleter),
+	"/pipe":    complete.PredictOr(s3Completer, fsCompleter),
+	"/extract": complete.PredictOr(s3Completer, fsCompleter),
+	"/stat":    complete.PredictOr(s3Completer, fsCompleter),
+	"/watch":   complete.PredictOr(s3Completer, fsCompleter),
+	"/mount":   complete.PredictOr(s3Completer, fsCompleter),
+	"/policy":  complete.PredictOr(s3Completer, fsCompleter),
+	"/tree":    complete.PredictOr(s3Complete{deepLevel: 2}, fsCompleter),
+	"/du":      complete.PredictOr(s3Complete{deepLevel: 2}, fsCompleter),
+	"/sample":  complete.PredictOr(s3Complete{deepLevel: 2}, fsCompleter),
+	"/browse":  complete.PredictOr(s3Completer, fsCompleter),
+	"/shell":   s3Completer,
+
+	"/mb":    aliasCompleter,
+	"/sql":   s3Completer,
+	"/ping":  aliasCompleter,
+	"/ready": aliasCompleter,
+
+	"/support/diag": aliasCompleter,
+
+	"/bench/put":   complete.PredictOr(s3Complete{deepLevel: 2}, fsCompleter),
+	"/bench/get":   complete.PredictOr(s3Complete{deepLevel: 2}, fsCompleter),
+	"/bench/mixed": complete.PredictOr(s3Complete{deepLevel: 2}, fsCompleter),
 
 	"/admin/info":       aliasCompleter,
 	"/admin/heal":       s3Completer,
 	"/admin/credential": aliasCompleter,
 
-	"/admin/config/get": aliasCompleter,
-	"/admin/config/set": aliasCompleter,
+	"/admin/info/cluster": aliasCompleter,
+
+	"/admin/config/get":     aliasCompleter,
+	"/admin/config/set":     aliasCompleter,
+	"/admin/config/export":  aliasCompleter,
+	"/admin/config/import":  complete.PredictOr(aliasCompleter, fsCompleter),
+	"/admin/config/history": aliasCompleter,
+	"/admin/config/restore": aliasCompleter,
+	"/admin/config/diff":    aliasCompleter,
 
 	"/admin/service/status":  aliasCompleter,
 	"/admin/service/restart": aliasCompleter,
@@ -175,8 +209,21 @@ var completeCmds = map[string]complete.Predictor{
 
 	"/admin/trace": aliasCompleter,
 
-	"/admin/profile/start": aliasCompleter,
-	"/admin/profile/stop":  aliasCompleter,
+	"/admin/top/locks": aliasCompleter,
+	"/admin/top/api":   aliasCompleter,
+	"/admin/top/net":   aliasCompleter,
+
+	"/admin/license/info": aliasCompleter,
+
+	"/admin/kms/key/status": aliasCompleter,
+
+	"/admin/speedtest": s3Completer,
+
+	"/admin/history": aliasCompleter,
+
+	"/admin/profile/start":    aliasCompleter,
+	"/admin/profile/stop":     aliasCompleter,
+	"/admin/profile/download": aliasCompleter,
 
 	"/admin/policy/info":   aliasCompleter,
 	"/admin/policy/set":    aliasCompleter,
@@ -198,23 +245,65 @@ var completeCmds = map[string]complete.Predictor{
 	"/admin/group/remove":  aliasCompleter,
 	"/admin/group/info":    aliasCompleter,
 
+	"/apply": aliasCompleter,
+
+	"/acl/get": aliasCompleter,
+	"/acl/set": aliasCompleter,
+
+	"/encrypt/set":    s3Complete{deepLevel: 2},
+	"/encrypt/info":   s3Complete{deepLevel: 2},
+	"/encrypt/clear":  s3Complete{deepLevel: 2},
+	"/encrypt/rotate": s3Completer,
+
+	"/encrypt/key/add":    s3Completer,
+	"/encrypt/key/remove": s3Completer,
+	"/encrypt/key/list":   aliasCompleter,
+
+	"/cache/clear":   aliasCompleter,
+	"/cache/disable": aliasCompleter,
+	"/cache/enable":  aliasCompleter,
+
 	"/event/add":    aliasCompleter,
 	"/event/list":   aliasCompleter,
 	"/event/remove": aliasCompleter,
 
 	"/session/clear":  nil,
+	"/session/export": nil,
+	"/session/import": nil,
+	"/session/info":   nil,
 	"/session/list":   nil,
+	"/session/repair": nil,
 	"/session/resume": nil,
 
 	"/share/download": nil,
 	"/share/list":     nil,
 	"/share/upload":   nil,
 
+	"/alias/set":    nil,
+	"/alias/list":   aliasCompleter,
+	"/alias/remove": aliasCompleter,
+	"/alias/export": nil,
+	"/alias/import": nil,
+
 	"/config/host/add":    nil,
 	"/config/host/list":   aliasCompleter,
 	"/config/host/remove": aliasCompleter,
 
+	"/config/encrypt": nil,
+	"/config/decrypt": nil,
+
+	"/config/profile/list":   nil,
+	"/config/profile/use":    profileCompleter,
+	"/config/profile/remove": profileCompleter,
+
+	"/job/schedule": nil,
+	"/job/list":     nil,
+	"/job/remove":   nil,
+	"/job/history":  nil,
+	"/job/run-due":  nil,
+
 	"/update":  nil,
+	"/agent":   nil,
 	"/version": nil,
 }
 
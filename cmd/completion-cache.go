@@ -0,0 +1,269 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/minio/mc/pkg/probe"
+)
+
+const (
+	// Folder inside the mc config folder holding cached listings.
+	globalCacheDir = "cache"
+
+	// Name of the file holding the aliases which are not cached.
+	cacheDisabledFile = "disabled.json"
+
+	// Time a cached listing is used for unless MC_CACHE_TTL is set.
+	defaultCacheTTL = 5 * time.Minute
+)
+
+// cachedListing is a listing of a single prefix saved in the cache.
+type cachedListing struct {
+	URL     string    `json:"url"`
+	Time    time.Time `json:"time"`
+	Entries []string  `json:"entries"`
+}
+
+// getCacheDir returns the folder holding cached listings.
+func getCacheDir() (string, *probe.Error) {
+	configDir, err := getMcConfigDir()
+	if err != nil {
+		return "", err.Trace()
+	}
+	return filepath.Join(configDir, globalCacheDir), nil
+}
+
+// getCacheTTL returns the time cached listings are valid for.
+func getCacheTTL() time.Duration {
+	if ttl, e := time.ParseDuration(os.Getenv("MC_CACHE_TTL")); e == nil {
+		return ttl
+	}
+	return defaultCacheTTL
+}
+
+// getCacheAliasDir returns the folder holding cached listings of an alias.
+func getCacheAliasDir(alias string) (string, *probe.Error) {
+	cacheDir, err := getCacheDir()
+	if err != nil {
+		return "", err.Trace(alias)
+	}
+	return filepath.Join(cacheDir, alias), nil
+}
+
+// getCacheFile returns the file holding the cached listing of urlStr.
+func getCacheFile(alias, urlStr string) (string, *probe.Error) {
+	aliasDir, err := getCacheAliasDir(alias)
+	if err != nil {
+		return "", err.Trace(urlStr)
+	}
+	sum := sha256.Sum256([]byte(urlStr))
+	return filepath.Join(aliasDir, hex.EncodeToString(sum[:])+".json"), nil
+}
+
+// loadCacheDisabled returns the aliases whose listings are never cached.
+func loadCacheDisabled() (map[string]bool, *probe.Error) {
+	cacheDir, err := getCacheDir()
+	if err != nil {
+		return nil, err.Trace()
+	}
+	disabled := make(map[string]bool)
+	disabledBytes, e := ioutil.ReadFile(filepath.Join(cacheDir, cacheDisabledFile))
+	if os.IsNotExist(e) {
+		return disabled, nil
+	}
+	if e != nil {
+		return nil, probe.NewError(e)
+	}
+	var aliases []string
+	if e = json.Unmarshal(disabledBytes, &aliases); e != nil {
+		return nil, probe.NewError(e)
+	}
+	for _, alias := range aliases {
+		disabled[alias] = true
+	}
+	return disabled, nil
+}
+
+// setCacheDisabled enables or disables caching of listings of an alias,
+// cached listings of a disabled alias are removed.
+func setCacheDisabled(alias string, isDisabled bool) *probe.Error {
+	disabled, err := loadCacheDisabled()
+	if err != nil {
+		return err.Trace(alias)
+	}
+	if isDisabled {
+		disabled[alias] = true
+		if err = clearCache(alias); err != nil {
+			return err.Trace(alias)
+		}
+	} else {
+		delete(disabled, alias)
+	}
+
+	aliases := []string{}
+	for alias := range disabled {
+		aliases = append(aliases, alias)
+	}
+	sort.Strings(aliases)
+	disabledBytes, e := json.Marshal(aliases)
+	if e != nil {
+		return probe.NewError(e)
+	}
+
+	cacheDir, err := getCacheDir()
+	if err != nil {
+		return err.Trace(alias)
+	}
+	if e = os.MkdirAll(cacheDir, 0700); e != nil {
+		return probe.NewError(e)
+	}
+	if e = ioutil.WriteFile(filepath.Join(cacheDir, cacheDisabledFile), disabledBytes, 0600); e != nil {
+		return probe.NewError(e)
+	}
+	return nil
+}
+
+// clearCache removes the cached listings of an alias, or of all aliases
+// if alias is empty.
+func clearCache(alias string) *probe.Error {
+	if alias == "" {
+		cacheDir, err := getCacheDir()
+		if err != nil {
+			return err.Trace()
+		}
+		dirs, e := ioutil.ReadDir(cacheDir)
+		if os.IsNotExist(e) {
+			return nil
+		}
+		if e != nil {
+			return probe.NewError(e)
+		}
+		for _, dir := range dirs {
+			if !dir.IsDir() {
+				continue
+			}
+			if e = os.RemoveAll(filepath.Join(cacheDir, dir.Name())); e != nil {
+				return probe.NewError(e)
+			}
+		}
+		return nil
+	}
+
+	aliasDir, err := getCacheAliasDir(alias)
+	if err != nil {
+		return err.Trace(alias)
+	}
+	if e := os.RemoveAll(aliasDir); e != nil {
+		return probe.NewError(e)
+	}
+	return nil
+}
+
+// loadCachedListing returns the cached listing of urlStr if it is not
+// older than ttl.
+func loadCachedListing(alias, urlStr string, ttl time.Duration) ([]string, bool) {
+	cacheFile, err := getCacheFile(alias, urlStr)
+	if err != nil {
+		return nil, false
+	}
+	listingBytes, e := ioutil.ReadFile(cacheFile)
+	if e != nil {
+		return nil, false
+	}
+	var listing cachedListing
+	if e = json.Unmarshal(listingBytes, &listing); e != nil {
+		return nil, false
+	}
+	if listing.URL != urlStr || time.Since(listing.Time) > ttl {
+		return nil, false
+	}
+	return listing.Entries, true
+}
+
+// saveCachedListing saves the listing of urlStr in the cache.
+func saveCachedListing(alias, urlStr string, entries []string) *probe.Error {
+	cacheFile, err := getCacheFile(alias, urlStr)
+	if err != nil {
+		return err.Trace(urlStr)
+	}
+	if e := os.MkdirAll(filepath.Dir(cacheFile), 0700); e != nil {
+		return probe.NewError(e)
+	}
+	listingBytes, e := json.Marshal(cachedListing{
+		URL:     urlStr,
+		Time:    UTCNow(),
+		Entries: entries,
+	})
+	if e != nil {
+		return probe.NewError(e)
+	}
+	// Write to a temporary file first, concurrent completions must
+	// never read a partially written listing.
+	tmpFile := cacheFile + "." + newRandomID(8)
+	if e = ioutil.WriteFile(tmpFile, listingBytes, 0600); e != nil {
+		return probe.NewError(e)
+	}
+	if e = os.Rename(tmpFile, cacheFile); e != nil {
+		os.Remove(tmpFile)
+		return probe.NewError(e)
+	}
+	return nil
+}
+
+// listPrefixEntries returns the aliased paths of the entries directly
+// under dirPath, folders end with a '/'. Listings are served from the
+// cache unless caching is disabled for the alias.
+func listPrefixEntries(alias, dirPath string) []string {
+	disabled, err := loadCacheDisabled()
+	isCached := err == nil && !disabled[alias] && isValidAlias(alias)
+	if isCached {
+		if entries, ok := loadCachedListing(alias, dirPath, getCacheTTL()); ok {
+			return entries
+		}
+	}
+
+	clnt, err := newClient(dirPath)
+	if err != nil {
+		return nil
+	}
+	var entries []string
+	for content := range clnt.List(false, false, DirFirst) {
+		if content.Err != nil {
+			// Never cache partial listings.
+			return entries
+		}
+		entry := alias + getKey(content)
+		if content.Type.IsDir() && !strings.HasSuffix(entry, "/") {
+			entry += "/"
+		}
+		entries = append(entries, entry)
+	}
+	if isCached {
+		saveCachedListing(alias, dirPath, entries)
+	}
+	return entries
+}
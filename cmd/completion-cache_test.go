@@ -0,0 +1,77 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"io/ioutil"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestCompletionCache(t *testing.T) {
+	configDir, e := ioutil.TempDir("", "mc-cache-")
+	if e != nil {
+		t.Fatal(e)
+	}
+	defer os.RemoveAll(configDir)
+	defer setMcConfigDir(mcCustomConfigDir)
+	setMcConfigDir(configDir)
+
+	entries := []string{"myminio/bucket/a", "myminio/bucket/dir/"}
+	if err := saveCachedListing("myminio", "myminio/bucket/", entries); err != nil {
+		t.Fatal(err)
+	}
+	cached, ok := loadCachedListing("myminio", "myminio/bucket/", time.Minute)
+	if !ok || !reflect.DeepEqual(cached, entries) {
+		t.Fatalf("expected cached entries %v, got %v", entries, cached)
+	}
+	if _, ok = loadCachedListing("myminio", "myminio/bucket/", 0); ok {
+		t.Fatal("expected expired listing not to be used")
+	}
+	if _, ok = loadCachedListing("myminio", "myminio/other/", time.Minute); ok {
+		t.Fatal("expected listing of another prefix not to be cached")
+	}
+
+	if err := setCacheDisabled("myminio", true); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok = loadCachedListing("myminio", "myminio/bucket/", time.Minute); ok {
+		t.Fatal("expected cached listings to be removed when caching is disabled")
+	}
+	disabled, err := loadCacheDisabled()
+	if err != nil || !disabled["myminio"] {
+		t.Fatalf("expected caching of myminio to be disabled, got %v %v", disabled, err)
+	}
+
+	if err = setCacheDisabled("myminio", false); err != nil {
+		t.Fatal(err)
+	}
+	if err = saveCachedListing("myminio", "myminio/bucket/", entries); err != nil {
+		t.Fatal(err)
+	}
+	if err = clearCache(""); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok = loadCachedListing("myminio", "myminio/bucket/", time.Minute); ok {
+		t.Fatal("expected cached listings to be removed")
+	}
+	if disabled, _ = loadCacheDisabled(); len(disabled) != 0 {
+		t.Fatalf("expected no disabled aliases, got %v", disabled)
+	}
+}
@@ -0,0 +1,106 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"errors"
+	"io"
+	"io/ioutil"
+	"runtime"
+	"strings"
+
+	"github.com/klauspost/pgzip"
+	"github.com/minio/mc/pkg/probe"
+)
+
+const (
+	// Block size handed to each compression worker.
+	compressBlockSize = 1 << 20
+
+	compressGzip = "gzip"
+)
+
+// compressExtensions maps a compression format to the extension
+// appended to the target object name.
+var compressExtensions = map[string]string{
+	compressGzip: ".gz",
+}
+
+// checkCompressFormat validates the value passed to --compress.
+func checkCompressFormat(format string) *probe.Error {
+	switch strings.ToLower(format) {
+	case "", compressGzip:
+		return nil
+	case "zstd":
+		return probe.NewError(errors.New("zstd compression is not supported by this build, please use gzip"))
+	}
+	return probe.NewError(errors.New("unknown compression format `" + format + "`, supported formats are [gzip]"))
+}
+
+// compressedName returns the name of an object once compressed
+// with the given format.
+func compressedName(name, format string) string {
+	ext := compressExtensions[strings.ToLower(format)]
+	if ext == "" || strings.HasSuffix(name, ext) {
+		return name
+	}
+	return name + ext
+}
+
+// decompressedName strips the extension added by compressedName.
+func decompressedName(name, encoding string) string {
+	return strings.TrimSuffix(name, compressExtensions[strings.ToLower(encoding)])
+}
+
+// isDecompressible returns true if objects stored with the given
+// Content-Encoding can be transparently decompressed.
+func isDecompressible(encoding string) bool {
+	return strings.ToLower(encoding) == compressGzip
+}
+
+// newCompressReader returns a reader streaming the compressed content
+// of r. Large inputs are compressed in parallel blocks by a pool of
+// workers, one per CPU.
+func newCompressReader(r io.Reader, format string) io.ReadCloser {
+	pr, pw := io.Pipe()
+	go func() {
+		zw := pgzip.NewWriter(pw)
+		zw.SetConcurrency(compressBlockSize, runtime.NumCPU())
+		if _, e := io.Copy(zw, r); e != nil {
+			zw.Close()
+			pw.CloseWithError(e)
+			return
+		}
+		pw.CloseWithError(zw.Close())
+	}()
+	return pr
+}
+
+// newDecompressReader wraps r to transparently decompress content
+// stored with the given Content-Encoding. Closing the returned reader
+// releases the decompressor, the caller remains responsible for
+// closing r.
+func newDecompressReader(r io.Reader, encoding string) (io.ReadCloser, *probe.Error) {
+	if !isDecompressible(encoding) {
+		return ioutil.NopCloser(r), nil
+	}
+	zr, e := pgzip.NewReader(r)
+	if e != nil {
+		return nil, probe.NewError(e)
+	}
+	return zr, nil
+}
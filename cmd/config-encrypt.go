@@ -0,0 +1,296 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/console"
+	"github.com/minio/mc/pkg/probe"
+)
+
+// Environment variable holding the password of an encrypted config.
+const mcEnvConfigPassword = "MC_CONFIG_PASSWORD"
+
+// Encrypted secret keys in the config file carry this prefix.
+const encryptedSecretPrefix = "encrypted:"
+
+var configEncryptCmd = cli.Command{
+	Name:            "encrypt",
+	Usage:           "encrypt secret keys in configuration file",
+	Action:          mainConfigEncrypt,
+	Before:          setGlobalsFromContext,
+	Flags:           globalFlags,
+	HideHelpCommand: true,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}}
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+DESCRIPTION:
+  Encrypt the secret keys of all aliases and profiles with a password. Secret keys
+  and encryption keys added later are encrypted as well. Commands using an alias
+  read the password from the MC_CONFIG_PASSWORD environment variable, or prompt
+  for it on the terminal.
+
+EXAMPLES:
+  1. Encrypt secret keys, prompting for a password.
+     $ {{.HelpName}}
+`,
+}
+
+var configDecryptCmd = cli.Command{
+	Name:            "decrypt",
+	Usage:           "decrypt secret keys in configuration file",
+	Action:          mainConfigDecrypt,
+	Before:          setGlobalsFromContext,
+	Flags:           globalFlags,
+	HideHelpCommand: true,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}}
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+  1. Save secret keys in plain text again, with the password in the environment.
+     $ MC_CONFIG_PASSWORD=mypassword {{.HelpName}}
+`,
+}
+
+// configEncryptMessage container for config encrypt and decrypt messages.
+type configEncryptMessage struct {
+	Status    string `json:"status"`
+	Encrypted bool   `json:"encrypted"`
+}
+
+// String colorized config encrypt message.
+func (c configEncryptMessage) String() string {
+	if c.Encrypted {
+		return console.Colorize("ConfigEncrypt", "Encrypted secret keys in `"+mustGetMcConfigPath()+"`.")
+	}
+	return console.Colorize("ConfigEncrypt", "Decrypted secret keys in `"+mustGetMcConfigPath()+"`.")
+}
+
+// JSON jsonified config encrypt message.
+func (c configEncryptMessage) JSON() string {
+	c.Status = "success"
+	jsonMessageBytes, e := json.MarshalIndent(c, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(jsonMessageBytes)
+}
+
+var (
+	configPasswordMutex sync.Mutex
+	// Password of the config, read once.
+	configPassword string
+	// Decrypted secret keys by their encrypted value, decrypting is slow
+	// on purpose and secrets are looked up for every new client.
+	decryptedSecrets = make(map[string]string)
+)
+
+// getConfigPassword - returns the config password, reading it on first use.
+func getConfigPassword() (string, *probe.Error) {
+	if configPassword != "" {
+		return configPassword, nil
+	}
+	password, err := readPassword(mcEnvConfigPassword, "Config password: ")
+	if err != nil {
+		return "", err.Trace()
+	}
+	if password == "" {
+		return "", probe.NewError(errors.New("config password cannot be empty"))
+	}
+	configPassword = password
+	return password, nil
+}
+
+// decryptConfigSecret - returns secret decrypted if it was saved encrypted.
+func decryptConfigSecret(secret string) (string, *probe.Error) {
+	if !strings.HasPrefix(secret, encryptedSecretPrefix) {
+		return secret, nil
+	}
+
+	configPasswordMutex.Lock()
+	defer configPasswordMutex.Unlock()
+	if plaintext, ok := decryptedSecrets[secret]; ok {
+		return plaintext, nil
+	}
+	password, err := getConfigPassword()
+	if err != nil {
+		return "", err.Trace()
+	}
+	plaintext, err := decryptSecret(password, strings.TrimPrefix(secret, encryptedSecretPrefix))
+	if err != nil {
+		return "", probe.NewError(errors.New("unable to decrypt secret key, check " + mcEnvConfigPassword))
+	}
+	decryptedSecrets[secret] = plaintext
+	return plaintext, nil
+}
+
+// encryptConfigSecret - returns secret encrypted unless it already is.
+func encryptConfigSecret(secret string) (string, *probe.Error) {
+	if secret == "" || strings.HasPrefix(secret, encryptedSecretPrefix) {
+		return secret, nil
+	}
+
+	configPasswordMutex.Lock()
+	defer configPasswordMutex.Unlock()
+	password, err := getConfigPassword()
+	if err != nil {
+		return "", err.Trace()
+	}
+	ciphertext, err := encryptSecret(password, secret)
+	if err != nil {
+		return "", err.Trace()
+	}
+	decryptedSecrets[encryptedSecretPrefix+ciphertext] = secret
+	return encryptedSecretPrefix + ciphertext, nil
+}
+
+// encryptConfig - returns a copy of cfg with all secret keys encrypted.
+func encryptConfig(cfg *configV9) (*configV9, *probe.Error) {
+	encCfg := *cfg
+	encCfg.Hosts = make(map[string]hostConfigV9, len(cfg.Hosts))
+	for alias, hostCfg := range cfg.Hosts {
+		var err *probe.Error
+		if hostCfg.SecretKey, err = encryptConfigSecret(hostCfg.SecretKey); err != nil {
+			return nil, err.Trace(alias)
+		}
+		if hostCfg.EncryptKeys != nil {
+			encryptKeys := make(map[string]string, len(hostCfg.EncryptKeys))
+			for prefix, key := range hostCfg.EncryptKeys {
+				if encryptKeys[prefix], err = encryptConfigSecret(key); err != nil {
+					return nil, err.Trace(alias, prefix)
+				}
+			}
+			hostCfg.EncryptKeys = encryptKeys
+		}
+		encCfg.Hosts[alias] = hostCfg
+	}
+	if cfg.Profiles != nil {
+		encCfg.Profiles = make(map[string]map[string]profileConfigV9, len(cfg.Profiles))
+	}
+	for profile, hosts := range cfg.Profiles {
+		encCfg.Profiles[profile] = make(map[string]profileConfigV9, len(hosts))
+		for alias, creds := range hosts {
+			var err *probe.Error
+			if creds.SecretKey, err = encryptConfigSecret(creds.SecretKey); err != nil {
+				return nil, err.Trace(profile, alias)
+			}
+			encCfg.Profiles[profile][alias] = creds
+		}
+	}
+	return &encCfg, nil
+}
+
+// decryptConfig - decrypts all secret keys of cfg in place.
+func decryptConfig(cfg *configV9) *probe.Error {
+	for alias, hostCfg := range cfg.Hosts {
+		var err *probe.Error
+		if hostCfg.SecretKey, err = decryptConfigSecret(hostCfg.SecretKey); err != nil {
+			return err.Trace(alias)
+		}
+		for prefix, key := range hostCfg.EncryptKeys {
+			if hostCfg.EncryptKeys[prefix], err = decryptConfigSecret(key); err != nil {
+				return err.Trace(alias, prefix)
+			}
+		}
+		cfg.Hosts[alias] = hostCfg
+	}
+	for profile, hosts := range cfg.Profiles {
+		for alias, creds := range hosts {
+			var err *probe.Error
+			if creds.SecretKey, err = decryptConfigSecret(creds.SecretKey); err != nil {
+				return err.Trace(profile, alias)
+			}
+			hosts[alias] = creds
+		}
+	}
+	return nil
+}
+
+// mainConfigEncrypt is the handle for "mc config encrypt" command.
+func mainConfigEncrypt(ctx *cli.Context) error {
+	if len(ctx.Args()) != 0 {
+		cli.ShowCommandHelpAndExit(ctx, "encrypt", 1) // last argument is exit code
+	}
+
+	console.SetColor("ConfigEncrypt", color.New(color.FgGreen))
+
+	conf, err := loadMcConfig()
+	fatalIf(err.Trace(globalMCConfigVersion), "Unable to load config version `"+globalMCConfigVersion+"`.")
+	if conf.Encrypted {
+		fatalIf(errInvalidArgument(), "Secret keys in `"+mustGetMcConfigPath()+"` are already encrypted.")
+	}
+
+	password, err := getConfigPassword()
+	fatalIf(err, "Unable to read password.")
+	if _, ok := os.LookupEnv(mcEnvConfigPassword); !ok {
+		confirm, err := readPassword("", "Confirm config password: ")
+		fatalIf(err, "Unable to read password.")
+		if confirm != password {
+			fatalIf(errInvalidArgument(), "Passwords do not match.")
+		}
+	}
+
+	conf.Encrypted = true
+	err = saveMcConfig(conf)
+	fatalIf(err.Trace(mustGetMcConfigPath()), "Unable to encrypt secret keys in `"+mustGetMcConfigPath()+"`.")
+
+	printMsg(configEncryptMessage{Encrypted: true})
+	return nil
+}
+
+// mainConfigDecrypt is the handle for "mc config decrypt" command.
+func mainConfigDecrypt(ctx *cli.Context) error {
+	if len(ctx.Args()) != 0 {
+		cli.ShowCommandHelpAndExit(ctx, "decrypt", 1) // last argument is exit code
+	}
+
+	console.SetColor("ConfigEncrypt", color.New(color.FgGreen))
+
+	conf, err := loadMcConfig()
+	fatalIf(err.Trace(globalMCConfigVersion), "Unable to load config version `"+globalMCConfigVersion+"`.")
+	if !conf.Encrypted {
+		fatalIf(errInvalidArgument(), "Secret keys in `"+mustGetMcConfigPath()+"` are not encrypted.")
+	}
+
+	err = decryptConfig(conf)
+	fatalIf(err, "Unable to decrypt secret keys.")
+
+	conf.Encrypted = false
+	err = saveMcConfig(conf)
+	fatalIf(err.Trace(mustGetMcConfigPath()), "Unable to decrypt secret keys in `"+mustGetMcConfigPath()+"`.")
+
+	printMsg(configEncryptMessage{Encrypted: false})
+	return nil
+}
@@ -18,6 +18,7 @@ package cmd
 
 import (
 	"math/rand"
+	"strings"
 	"time"
 
 	"github.com/fatih/color"
@@ -36,7 +37,52 @@ var hostAddFlags = []cli.Flag{
 		Name:  "api",
 		Usage: "API signature. Valid options are '[S3v4, S3v2]'",
 	},
+	cli.StringFlag{
+		Name:  "region",
+		Usage: "region of the host, skips the bucket location lookups",
+	},
+	cli.StringFlag{
+		Name:  "credential-process",
+		Usage: "command printing credentials as JSON, run again when they expire",
+	},
+	cli.StringFlag{
+		Name:  "role-arn",
+		Usage: "assume this role with the given keys to obtain temporary credentials",
+	},
+	cli.StringFlag{
+		Name:  "web-identity-token-file",
+		Usage: "obtain temporary credentials with the web identity token in this file",
+	},
+	cli.StringFlag{
+		Name:  "sts-endpoint",
+		Usage: "URL of the STS service, defaults to the host URL",
+	},
+	cli.StringFlag{
+		Name:  "ca-file",
+		Usage: "trust the CA certificates in this PEM file for the host",
+	},
+	cli.StringFlag{
+		Name:  "client-cert",
+		Usage: "client certificate PEM file for mutual TLS",
+	},
+	cli.StringFlag{
+		Name:  "client-key",
+		Usage: "private key PEM file of the client certificate",
+	},
+	cli.StringFlag{
+		Name:  "tls-min-version",
+		Usage: "minimum TLS version. Valid options are '[1.0, 1.1, 1.2, 1.3]'",
+	},
+	cli.StringFlag{
+		Name:  "tls-ciphers",
+		Usage: "comma separated TLS cipher suites in order of preference",
+	},
+	cli.BoolFlag{
+		Name:  "tls-skip-verify",
+		Usage: "disable SSL certificate verification for the host",
+	},
 }
+
 var configHostAddCmd = cli.Command{
 	Name:            "add",
 	ShortName:       "a",
@@ -49,11 +95,33 @@ var configHostAddCmd = cli.Command{
   {{.HelpName}} - {{.Usage}}
 
 USAGE:
-  {{.HelpName}} ALIAS URL ACCESSKEY SECRETKEY
+  {{.HelpName}} ALIAS URL [ACCESSKEY SECRETKEY]
 
 FLAGS:
   {{range .VisibleFlags}}{{.}}
   {{end}}
+CREDENTIAL PROCESS:
+  Instead of keys, an alias can name a command with --credential-process, such as a
+  Vault or SSO helper. The command prints credentials to stdout in the format of the
+  AWS CLI 'credential_process' setting:
+    {"Version": 1, "AccessKeyId": "...", "SecretAccessKey": "...",
+     "SessionToken": "...", "Expiration": "2019-10-01T10:00:00Z"}
+  It runs when mc first connects to the alias and again shortly before the credentials
+  expire. Keys are optional in this case, the API signature defaults to S3v4.
+
+TEMPORARY CREDENTIALS:
+  With --role-arn the keys are exchanged for temporary credentials of the role using the
+  STS AssumeRole API. With --web-identity-token-file the token in the file, for example
+  an OpenID Connect token kept current by another tool, is exchanged using the STS
+  AssumeRoleWithWebIdentity API and keys are optional. Temporary credentials are renewed
+  before they expire. STS requests go to the host URL unless --sts-endpoint is given.
+
+TLS OPTIONS:
+  TLS options apply to the host only. CA certificates in --ca-file are trusted in
+  addition to the system CAs and the CAs in the config folder. --client-cert and
+  --client-key authenticate mc to hosts which require mutual TLS. Without options,
+  TLS 1.2 is the minimum version and Go chooses the cipher suites.
+
 EXAMPLES:
   1. Add MinIO service under "myminio" alias. For security reasons turn off bash history momentarily.
      $ set +o history
@@ -71,6 +139,44 @@ EXAMPLES:
      $ {{.HelpName}} mys3 https://s3.amazonaws.com \
                  BKIKJAA5BMMU2RHO6IBB V8f1CwQqAcwo80UEIJEjc5gVQUSSx5ohQ9GSrr12
      $ set -o history
+
+  4. Add credentials for "myminio" to the "prod" profile. For security reasons turn off bash history momentarily.
+     $ set +o history
+     $ {{.HelpName}} --profile prod myminio http://localhost:9000 prodkey prodsecret123
+     $ set -o history
+
+  5. Add MinIO service under "myminio" alias, with credentials supplied by a helper command.
+     $ {{.HelpName}} myminio http://localhost:9000 --credential-process "vault-mc-creds myminio"
+
+  6. Add MinIO service under "myminio" alias, with temporary credentials for a web identity token.
+     $ {{.HelpName}} myminio http://localhost:9000 --web-identity-token-file /var/run/secrets/token
+
+  7. Add Amazon S3 storage service under "mys3" alias, assuming a role with the given keys. For security
+     reasons turn off bash history momentarily.
+     $ set +o history
+     $ {{.HelpName}} mys3 https://s3.amazonaws.com BKIKJAA5BMMU2RHO6IBB V8f1CwQqAcwo80UEIJEjc5gVQUSSx5ohQ9GSrr12 \
+                 --role-arn arn:aws:iam::123456789012:role/backup --sts-endpoint https://sts.amazonaws.com
+     $ set -o history
+
+  8. Add MinIO service under "myminio" alias, signed by a private CA and requiring a client certificate.
+     $ set +o history
+     $ {{.HelpName}} myminio https://minio.internal:9000 minio minio123 --ca-file /etc/pki/internal-ca.pem \
+                 --client-cert /etc/pki/mc.crt --client-key /etc/pki/mc.key --tls-min-version 1.3
+     $ set -o history
+
+  9. Add a Ceph RGW service under "myceph" alias, using signature v2, path style bucket lookup and
+     a fixed region. For security reasons turn off bash history momentarily.
+     $ set +o history
+     $ {{.HelpName}} myceph https://rgw.internal ACCESSKEY SECRETKEY --api S3v2 --lookup path --region default
+     $ set -o history
+
+  10. Add a SFTP server under "partner" alias, logging in as "drop" with the keys of the running ssh-agent
+      or in ~/.ssh. Host keys are verified against ~/.ssh/known_hosts.
+     $ {{.HelpName}} partner sftp://sftp.partner.com:2222 drop ""
+
+  11. Add the WebHDFS endpoint of a Hadoop cluster under "hdfs" alias, acting as user "etl". Use
+      swebhdfs:// for HTTPS endpoints.
+     $ {{.HelpName}} hdfs webhdfs://namenode.internal:9870 etl ""
 `,
 }
 
@@ -78,7 +184,10 @@ EXAMPLES:
 func checkConfigHostAddSyntax(ctx *cli.Context) {
 	args := ctx.Args()
 	argsNr := len(args)
-	if argsNr < 4 || argsNr > 5 {
+	// Keys are optional when a credential process or a web identity
+	// token supplies the credentials.
+	withKeys := argsNr != 2 || (ctx.String("credential-process") == "" && ctx.String("web-identity-token-file") == "")
+	if withKeys && (argsNr < 4 || argsNr > 5) {
 		fatalIf(errInvalidArgument().Trace(ctx.Args().Tail()...),
 			"Incorrect number of arguments for host add command.")
 	}
@@ -97,12 +206,15 @@ func checkConfigHostAddSyntax(ctx *cli.Context) {
 		fatalIf(errInvalidURL(url), "Invalid URL.")
 	}
 
-	if !isValidAccessKey(accessKey) {
+	// User names and passwords of SFTP and WebHDFS hosts have
+	// no S3 key length limits.
+	isS3 := isS3HostURL(url)
+	if withKeys && isS3 && !isValidAccessKey(accessKey) {
 		fatalIf(errInvalidArgument().Trace(accessKey),
 			"Invalid access key `"+accessKey+"`.")
 	}
 
-	if !isValidSecretKey(secretKey) {
+	if withKeys && isS3 && !isValidSecretKey(secretKey) {
 		fatalIf(errInvalidArgument().Trace(secretKey),
 			"Invalid secret key `"+secretKey+"`.")
 	}
@@ -112,19 +224,84 @@ func checkConfigHostAddSyntax(ctx *cli.Context) {
 			"Unrecognized API signature. Valid options are `[S3v4, S3v2]`.")
 	}
 
+	if ctx.String("role-arn") != "" && !withKeys {
+		fatalIf(errInvalidArgument().Trace(ctx.String("role-arn")),
+			"Assuming a role requires an access key and a secret key.")
+	}
+
+	if stsEndpoint := ctx.String("sts-endpoint"); stsEndpoint != "" && (!isValidHostURL(stsEndpoint) || !isS3HostURL(stsEndpoint)) {
+		fatalIf(errInvalidURL(stsEndpoint), "Invalid STS endpoint.")
+	}
+
+	if err := checkHostTLSConfig(hostTLSConfigFromContext(ctx)); err != nil {
+		fatalIf(err.Trace(), "Invalid TLS options.")
+	}
+
 	if !isValidLookup(bucketLookup) {
 		fatalIf(errInvalidArgument().Trace(bucketLookup),
 			"Unrecognized bucket lookup. Valid options are `[dns,auto, path]`.")
 	}
 }
 
+// hostTLSConfigFromContext - returns the TLS options given to
+// 'config host add', nil if there are none.
+func hostTLSConfigFromContext(ctx *cli.Context) *hostTLSConfigV9 {
+	tlsCfg := hostTLSConfigV9{
+		CAFile:     ctx.String("ca-file"),
+		CertFile:   ctx.String("client-cert"),
+		KeyFile:    ctx.String("client-key"),
+		MinVersion: ctx.String("tls-min-version"),
+		SkipVerify: ctx.Bool("tls-skip-verify"),
+	}
+	if ciphers := ctx.String("tls-ciphers"); ciphers != "" {
+		tlsCfg.CipherSuites = strings.Split(ciphers, ",")
+	}
+	if tlsCfg.CAFile == "" && tlsCfg.CertFile == "" && tlsCfg.KeyFile == "" &&
+		tlsCfg.MinVersion == "" && len(tlsCfg.CipherSuites) == 0 && !tlsCfg.SkipVerify {
+		return nil
+	}
+	return &tlsCfg
+}
+
 // addHost - add a host config.
 func addHost(alias string, hostCfgV9 hostConfigV9) {
 	mcCfgV9, err := loadMcConfig()
 	fatalIf(err.Trace(globalMCConfigVersion), "Unable to load config `"+mustGetMcConfigPath()+"`.")
 
-	// Add new host.
-	mcCfgV9.Hosts[alias] = hostCfgV9
+	profile := getActiveProfile(mcCfgV9)
+	if profile == "" {
+		// Add new host.
+		mcCfgV9.Hosts[alias] = hostCfgV9
+	} else {
+		if !isValidProfile(profile) {
+			fatalIf(errInvalidArgument().Trace(profile), "Invalid profile `"+profile+"`.")
+		}
+		// Hosts keep the endpoint, profiles only keep credentials. A new
+		// alias starts out with the same credentials in the default profile.
+		if hostCfg, ok := mcCfgV9.Hosts[alias]; ok {
+			hostCfg.URL = hostCfgV9.URL
+			hostCfg.API = hostCfgV9.API
+			hostCfg.Lookup = hostCfgV9.Lookup
+			hostCfg.RoleARN = hostCfgV9.RoleARN
+			hostCfg.WebIdentityTokenFile = hostCfgV9.WebIdentityTokenFile
+			hostCfg.STSEndpoint = hostCfgV9.STSEndpoint
+			hostCfg.TLS = hostCfgV9.TLS
+			mcCfgV9.Hosts[alias] = hostCfg
+		} else {
+			mcCfgV9.Hosts[alias] = hostCfgV9
+		}
+		if mcCfgV9.Profiles == nil {
+			mcCfgV9.Profiles = make(map[string]map[string]profileConfigV9)
+		}
+		if mcCfgV9.Profiles[profile] == nil {
+			mcCfgV9.Profiles[profile] = make(map[string]profileConfigV9)
+		}
+		mcCfgV9.Profiles[profile][alias] = profileConfigV9{
+			AccessKey:         hostCfgV9.AccessKey,
+			SecretKey:         hostCfgV9.SecretKey,
+			CredentialProcess: hostCfgV9.CredentialProcess,
+		}
+	}
 
 	err = saveMcConfig(mcCfgV9)
 	fatalIf(err.Trace(alias), "Unable to update hosts in config version `"+mustGetMcConfigPath()+"`.")
@@ -132,17 +309,23 @@ func addHost(alias string, hostCfgV9 hostConfigV9) {
 	printMsg(hostMessage{
 		op:        "add",
 		Alias:     alias,
+		Profile:   profile,
 		URL:       hostCfgV9.URL,
 		AccessKey: hostCfgV9.AccessKey,
 		SecretKey: hostCfgV9.SecretKey,
 		API:       hostCfgV9.API,
 		Lookup:    hostCfgV9.Lookup,
+		Region:    hostCfgV9.Region,
+
+		CredentialProcess:    hostCfgV9.CredentialProcess,
+		RoleARN:              hostCfgV9.RoleARN,
+		WebIdentityTokenFile: hostCfgV9.WebIdentityTokenFile,
 	})
 }
 
 // probeS3Signature - auto probe S3 server signature: issue a Stat call
 // using v4 signature then v2 in case of failure.
-func probeS3Signature(accessKey, secretKey, url string) (string, *probe.Error) {
+func probeS3Signature(accessKey, secretKey, url string, tlsCfg *hostTLSConfigV9) (string, *probe.Error) {
 	probeBucketName := randString(60, rand.NewSource(time.Now().UnixNano()), "probe-bucket-sign-")
 	// Test s3 connection for API auto probe
 	s3Config := &Config{
@@ -152,6 +335,7 @@ func probeS3Signature(accessKey, secretKey, url string) (string, *probe.Error) {
 		SecretKey: secretKey,
 		Signature: "s3v4",
 		HostURL:   urlJoinPath(url, probeBucketName),
+		TLS:       tlsCfg,
 	}
 
 	s3Client, err := s3New(s3Config)
@@ -186,13 +370,14 @@ func probeS3Signature(accessKey, secretKey, url string) (string, *probe.Error) {
 
 // buildS3Config constructs an S3 Config and does
 // signature auto-probe when needed.
-func buildS3Config(url, accessKey, secretKey, api, lookup string) (*Config, *probe.Error) {
+func buildS3Config(url, accessKey, secretKey, api, lookup string, tlsCfg *hostTLSConfigV9) (*Config, *probe.Error) {
 
 	s3Config := newS3Config(url, &hostConfigV9{
 		AccessKey: accessKey,
 		SecretKey: secretKey,
 		URL:       url,
 		Lookup:    lookup,
+		TLS:       tlsCfg,
 	})
 
 	// If api is provided we do not auto probe signature, this is
@@ -202,7 +387,7 @@ func buildS3Config(url, accessKey, secretKey, api, lookup string) (*Config, *pro
 		return s3Config, nil
 	}
 	// Probe S3 signature version
-	api, err := probeS3Signature(accessKey, secretKey, url)
+	api, err := probeS3Signature(accessKey, secretKey, url, tlsCfg)
 	if err != nil {
 		return nil, err.Trace(url, accessKey, secretKey, api, lookup)
 	}
@@ -223,9 +408,21 @@ func mainConfigHostAdd(ctx *cli.Context) error {
 		secretKey = args.Get(3)
 		api       = ctx.String("api")
 		lookup    = ctx.String("lookup")
+		process   = ctx.String("credential-process")
+		roleARN   = ctx.String("role-arn")
+		tokenFile = ctx.String("web-identity-token-file")
+		tlsCfg    = hostTLSConfigFromContext(ctx)
 	)
+	if (process != "" || roleARN != "" || tokenFile != "") && api == "" {
+		// The signature cannot be probed without the final credentials.
+		api = "S3v4"
+	}
+	if !isS3HostURL(url) && api == "" {
+		// SFTP and WebHDFS hosts have no S3 signature to probe.
+		api = "S3v4"
+	}
 
-	s3Config, err := buildS3Config(url, accessKey, secretKey, api, lookup)
+	s3Config, err := buildS3Config(url, accessKey, secretKey, api, lookup, tlsCfg)
 	fatalIf(err.Trace(ctx.Args()...), "Unable to initialize new config from the provided credentials.")
 
 	addHost(ctx.Args().Get(0), hostConfigV9{
@@ -234,6 +431,13 @@ func mainConfigHostAdd(ctx *cli.Context) error {
 		SecretKey: s3Config.SecretKey,
 		API:       s3Config.Signature,
 		Lookup:    lookup,
+		Region:    ctx.String("region"),
+
+		CredentialProcess:    process,
+		RoleARN:              roleARN,
+		WebIdentityTokenFile: tokenFile,
+		STSEndpoint:          trimTrailingSeparator(ctx.String("sts-endpoint")),
+		TLS:                  tlsCfg,
 	}) // Add a host with specified credentials.
 	return nil
 }
@@ -17,7 +17,6 @@
 package cmd
 
 import (
-	"fmt"
 	"sort"
 
 	"github.com/fatih/color"
@@ -78,6 +77,7 @@ func mainConfigHostList(ctx *cli.Context) error {
 	console.SetColor("SecretKey", color.New(color.FgCyan))
 	console.SetColor("API", color.New(color.FgBlue))
 	console.SetColor("Lookup", color.New(color.FgCyan))
+	console.SetColor("Region", color.New(color.FgCyan))
 
 	args := ctx.Args()
 	listHosts(args.Get(0)) // List all configured hosts.
@@ -88,14 +88,14 @@ func mainConfigHostList(ctx *cli.Context) error {
 func printHosts(hosts ...hostMessage) {
 	var maxAlias = 0
 	for _, host := range hosts {
-		if len(host.Alias) > maxAlias {
-			maxAlias = len(host.Alias)
+		if w := console.StringWidth(host.Alias); w > maxAlias {
+			maxAlias = w
 		}
 	}
 	for _, host := range hosts {
 		if !globalJSON {
 			// Format properly for alignment based on alias length only in non json mode.
-			host.Alias = fmt.Sprintf("%-*.*s", maxAlias, maxAlias, host.Alias)
+			host.Alias = console.PadRight(host.Alias, maxAlias)
 		}
 		if host.AccessKey == "" || host.SecretKey == "" {
 			host.AccessKey = ""
@@ -121,6 +121,10 @@ func listHosts(alias string) {
 	// If specific alias is requested, look for it and print.
 	if alias != "" {
 		if v, ok := conf.Hosts[alias]; ok {
+			err = applyProfile(conf, alias, &v)
+			fatalIf(err.Trace(alias), "Unable to list `"+alias+"`.")
+			v.SecretKey, err = decryptConfigSecret(v.SecretKey)
+			fatalIf(err.Trace(alias), "Unable to decrypt secret key of `"+alias+"`.")
 			printHosts(hostMessage{
 				op:          "list",
 				prettyPrint: false,
@@ -130,6 +134,7 @@ func listHosts(alias string) {
 				SecretKey:   v.SecretKey,
 				API:         v.API,
 				Lookup:      v.Lookup,
+				Region:      v.Region,
 			})
 			return
 		}
@@ -138,6 +143,10 @@ func listHosts(alias string) {
 
 	var hosts []hostMessage
 	for k, v := range conf.Hosts {
+		err = applyProfile(conf, k, &v)
+		fatalIf(err.Trace(k), "Unable to list hosts.")
+		v.SecretKey, err = decryptConfigSecret(v.SecretKey)
+		fatalIf(err.Trace(k), "Unable to decrypt secret key of `"+k+"`.")
 		hosts = append(hosts, hostMessage{
 			op:          "list",
 			prettyPrint: true,
@@ -147,6 +156,7 @@ func listHosts(alias string) {
 			SecretKey:   v.SecretKey,
 			API:         v.API,
 			Lookup:      v.Lookup,
+			Region:      v.Region,
 		})
 	}
 
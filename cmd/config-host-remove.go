@@ -43,6 +43,9 @@ EXAMPLES:
   1. Remove "goodisk" from config.
      $ {{.HelpName}} goodisk
 
+  2. Remove the credentials of "goodisk" from the "prod" profile, keeping the host.
+     $ {{.HelpName}} --profile prod goodisk
+
 `,
 }
 
@@ -78,11 +81,20 @@ func removeHost(alias string) {
 	conf, err := loadMcConfig()
 	fatalIf(err.Trace(globalMCConfigVersion), "Unable to load config version `"+globalMCConfigVersion+"`.")
 
-	// Remove host.
-	delete(conf.Hosts, alias)
+	profile := getActiveProfile(conf)
+	if profile == "" {
+		// Remove host.
+		delete(conf.Hosts, alias)
+	} else {
+		if _, ok := conf.Profiles[profile]; !ok {
+			fatalIf(errNoSuchProfile(profile), "Unable to remove `"+alias+"`.")
+		}
+		// Only drop the credentials saved in the profile.
+		delete(conf.Profiles[profile], alias)
+	}
 
 	err = saveMcConfig(conf)
 	fatalIf(err.Trace(alias), "Unable to save deleted hosts in config version `"+globalMCConfigVersion+"`.")
 
-	printMsg(hostMessage{op: "remove", Alias: alias})
+	printMsg(hostMessage{op: "remove", Alias: alias, Profile: profile})
 }
@@ -25,7 +25,7 @@ import (
 
 var configHostCmd = cli.Command{
 	Name:   "host",
-	Usage:  "add, remove and list hosts in configuration file",
+	Usage:  "add, remove and list hosts in configuration file, same as 'mc alias'",
 	Action: mainConfigHost,
 	Before: setGlobalsFromContext,
 	Flags:  globalFlags,
@@ -50,11 +50,17 @@ type hostMessage struct {
 	prettyPrint bool
 	Status      string `json:"status"`
 	Alias       string `json:"alias"`
+	Profile     string `json:"profile,omitempty"`
 	URL         string `json:"URL"`
 	AccessKey   string `json:"accessKey,omitempty"`
 	SecretKey   string `json:"secretKey,omitempty"`
 	API         string `json:"api,omitempty"`
 	Lookup      string `json:"lookup,omitempty"`
+	Region      string `json:"region,omitempty"`
+
+	CredentialProcess    string `json:"credentialProcess,omitempty"`
+	RoleARN              string `json:"roleArn,omitempty"`
+	WebIdentityTokenFile string `json:"webIdentityTokenFile,omitempty"`
 }
 
 // Print the config information of one alias, when prettyPrint flag
@@ -64,18 +70,31 @@ func (h hostMessage) String() string {
 	switch h.op {
 	case "list":
 		// Create a new pretty table with cols configuration
-		t := newPrettyRecord(2,
-			Row{"Alias", "Alias"},
-			Row{"URL", "URL"},
-			Row{"AccessKey", "AccessKey"},
-			Row{"SecretKey", "SecretKey"},
-			Row{"API", "API"},
-			Row{"Lookup", "Lookup"},
-		)
-		return t.buildRecord(h.Alias, h.URL, h.AccessKey, h.SecretKey, h.API, h.Lookup)
+		rows := []Row{
+			{"Alias", "Alias"},
+			{"URL", "URL"},
+			{"AccessKey", "AccessKey"},
+			{"SecretKey", "SecretKey"},
+			{"API", "API"},
+			{"Lookup", "Lookup"},
+		}
+		contents := []string{h.Alias, h.URL, h.AccessKey, h.SecretKey, h.API, h.Lookup}
+		// Region is only displayed for hosts which set it.
+		if h.Region != "" {
+			rows = append(rows, Row{"Region", "Region"})
+			contents = append(contents, h.Region)
+		}
+		t := newPrettyRecord(2, rows...)
+		return t.buildRecord(contents...)
 	case "remove":
+		if h.Profile != "" {
+			return console.Colorize("HostMessage", "Removed `"+h.Alias+"` from profile `"+h.Profile+"` successfully.")
+		}
 		return console.Colorize("HostMessage", "Removed `"+h.Alias+"` successfully.")
 	case "add":
+		if h.Profile != "" {
+			return console.Colorize("HostMessage", "Added `"+h.Alias+"` to profile `"+h.Profile+"` successfully.")
+		}
 		return console.Colorize("HostMessage", "Added `"+h.Alias+"` successfully.")
 	default:
 		return ""
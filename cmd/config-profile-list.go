@@ -0,0 +1,70 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/console"
+)
+
+var configProfileListCmd = cli.Command{
+	Name:            "list",
+	ShortName:       "ls",
+	Usage:           "list credential profiles in configuration file",
+	Action:          mainConfigProfileList,
+	Before:          setGlobalsFromContext,
+	Flags:           globalFlags,
+	HideHelpCommand: true,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}}
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+DESCRIPTION:
+  Profiles hold alternative credentials for configured aliases. The active
+  profile is marked with '*', aliases without credentials in the active
+  profile use the credentials of the 'default' profile.
+
+EXAMPLES:
+  1. List all profiles.
+     $ {{.HelpName}}
+`,
+}
+
+// mainConfigProfileList is the handle for "mc config profile list" command.
+func mainConfigProfileList(ctx *cli.Context) error {
+	if len(ctx.Args()) != 0 {
+		cli.ShowCommandHelpAndExit(ctx, "list", 1) // last argument is exit code
+	}
+
+	// Additional command speific theme customization.
+	console.SetColor("Profile", color.New(color.FgCyan, color.Bold))
+	console.SetColor("Aliases", color.New(color.FgYellow))
+
+	conf, err := loadMcConfig()
+	fatalIf(err.Trace(globalMCConfigVersion), "Unable to load config version `"+globalMCConfigVersion+"`.")
+
+	for _, profile := range listProfiles(conf) {
+		printMsg(profile)
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/console"
+)
+
+var configProfileRemoveCmd = cli.Command{
+	Name:            "remove",
+	ShortName:       "rm",
+	Usage:           "remove a credential profile from configuration file",
+	Action:          mainConfigProfileRemove,
+	Before:          setGlobalsFromContext,
+	Flags:           globalFlags,
+	HideHelpCommand: true,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} PROFILE
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+  1. Remove the "staging" profile.
+     $ {{.HelpName}} staging
+`,
+}
+
+// checkConfigProfileRemoveSyntax - verifies input arguments to 'config profile remove'.
+func checkConfigProfileRemoveSyntax(ctx *cli.Context) {
+	args := ctx.Args()
+	if len(args) != 1 {
+		fatalIf(errInvalidArgument().Trace(args...),
+			"Incorrect number of arguments to remove profile.")
+	}
+	if args.Get(0) == defaultProfile {
+		fatalIf(errInvalidArgument().Trace(args.Get(0)),
+			"The default profile cannot be removed, use `mc config host remove` instead.")
+	}
+	if !isValidProfile(args.Get(0)) {
+		fatalIf(errInvalidArgument().Trace(args.Get(0)),
+			"Invalid profile `"+args.Get(0)+"`.")
+	}
+}
+
+// mainConfigProfileRemove is the handle for "mc config profile remove" command.
+func mainConfigProfileRemove(ctx *cli.Context) error {
+	checkConfigProfileRemoveSyntax(ctx)
+
+	console.SetColor("ProfileMessage", color.New(color.FgGreen))
+
+	profile := ctx.Args().Get(0)
+	conf, err := loadMcConfig()
+	fatalIf(err.Trace(globalMCConfigVersion), "Unable to load config version `"+globalMCConfigVersion+"`.")
+
+	if _, ok := conf.Profiles[profile]; !ok {
+		fatalIf(errNoSuchProfile(profile), "Unable to remove profile `"+profile+"`.")
+	}
+	delete(conf.Profiles, profile)
+	if conf.Profile == profile {
+		conf.Profile = ""
+	}
+
+	err = saveMcConfig(conf)
+	fatalIf(err.Trace(profile), "Unable to save deleted profile in config version `"+globalMCConfigVersion+"`.")
+
+	printMsg(profileMessage{op: "remove", Profile: profile})
+	return nil
+}
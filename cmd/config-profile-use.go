@@ -0,0 +1,86 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/console"
+)
+
+var configProfileUseCmd = cli.Command{
+	Name:            "use",
+	Usage:           "select the profile used when --profile is not given",
+	Action:          mainConfigProfileUse,
+	Before:          setGlobalsFromContext,
+	Flags:           globalFlags,
+	HideHelpCommand: true,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} PROFILE
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+  1. Use credentials from the "prod" profile by default.
+     $ {{.HelpName}} prod
+
+  2. Go back to the credentials saved with the hosts.
+     $ {{.HelpName}} default
+`,
+}
+
+// checkConfigProfileUseSyntax - verifies input arguments to 'config profile use'.
+func checkConfigProfileUseSyntax(ctx *cli.Context) {
+	args := ctx.Args()
+	if len(args) != 1 {
+		fatalIf(errInvalidArgument().Trace(args...),
+			"Incorrect number of arguments to use profile.")
+	}
+	if !isValidProfile(args.Get(0)) {
+		fatalIf(errInvalidArgument().Trace(args.Get(0)),
+			"Invalid profile `"+args.Get(0)+"`.")
+	}
+}
+
+// mainConfigProfileUse is the handle for "mc config profile use" command.
+func mainConfigProfileUse(ctx *cli.Context) error {
+	checkConfigProfileUseSyntax(ctx)
+
+	console.SetColor("ProfileMessage", color.New(color.FgGreen))
+
+	profile := ctx.Args().Get(0)
+	conf, err := loadMcConfig()
+	fatalIf(err.Trace(globalMCConfigVersion), "Unable to load config version `"+globalMCConfigVersion+"`.")
+
+	conf.Profile = ""
+	if profile != defaultProfile {
+		if _, ok := conf.Profiles[profile]; !ok {
+			fatalIf(errNoSuchProfile(profile), "Unable to use profile `"+profile+"`.")
+		}
+		conf.Profile = profile
+	}
+
+	err = saveMcConfig(conf)
+	fatalIf(err.Trace(profile), "Unable to save profile in config version `"+globalMCConfigVersion+"`.")
+
+	printMsg(profileMessage{op: "use", Profile: profile})
+	return nil
+}
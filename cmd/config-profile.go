@@ -0,0 +1,167 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/minio/cli"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/console"
+	"github.com/minio/mc/pkg/probe"
+)
+
+// defaultProfile names the credentials kept with the hosts themselves,
+// which apply when no other profile is selected.
+const defaultProfile = "default"
+
+var configProfileCmd = cli.Command{
+	Name:   "profile",
+	Usage:  "list, use and remove credential profiles in configuration file",
+	Action: mainConfigProfile,
+	Before: setGlobalsFromContext,
+	Flags:  globalFlags,
+	Subcommands: []cli.Command{
+		configProfileListCmd,
+		configProfileUseCmd,
+		configProfileRemoveCmd,
+	},
+	HideHelpCommand: true,
+}
+
+// mainConfigProfile is the handle for "mc config profile" command.
+func mainConfigProfile(ctx *cli.Context) error {
+	cli.ShowCommandHelp(ctx, ctx.Args().First())
+	return nil
+	// Sub-commands like "list", "use" and "remove" have their own main.
+}
+
+// profileMessage container for profile messages.
+type profileMessage struct {
+	op      string
+	Status  string   `json:"status"`
+	Profile string   `json:"profile"`
+	Active  bool     `json:"active,omitempty"`
+	Aliases []string `json:"aliases,omitempty"`
+}
+
+// String colorized profile message.
+func (p profileMessage) String() string {
+	switch p.op {
+	case "list":
+		marker := "  "
+		if p.Active {
+			marker = "* "
+		}
+		return marker + console.Colorize("Profile", p.Profile) + "  " +
+			console.Colorize("Aliases", strings.Join(p.Aliases, ", "))
+	case "use":
+		return console.Colorize("ProfileMessage", "Using profile `"+p.Profile+"`.")
+	case "remove":
+		return console.Colorize("ProfileMessage", "Removed profile `"+p.Profile+"` successfully.")
+	default:
+		return ""
+	}
+}
+
+// JSON jsonified profile message.
+func (p profileMessage) JSON() string {
+	p.Status = "success"
+	jsonMessageBytes, e := json.MarshalIndent(p, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(jsonMessageBytes)
+}
+
+// isValidProfile - profile names follow the same rules as aliases.
+func isValidProfile(profile string) bool {
+	return regexp.MustCompile("^[a-zA-Z][a-zA-Z0-9-_]+$").MatchString(profile)
+}
+
+// getActiveProfile returns the profile selected with --profile or
+// MC_PROFILE, falling back to the one saved by 'mc config profile use'.
+// An empty value stands for the default profile.
+func getActiveProfile(cfg *configV9) string {
+	profile := cfg.Profile
+	if globalProfile != "" {
+		profile = globalProfile
+	}
+	if profile == defaultProfile {
+		return ""
+	}
+	return profile
+}
+
+// applyProfile replaces the credentials of alias with the ones saved in
+// the active profile. Aliases the profile has no credentials for keep
+// their default credentials.
+func applyProfile(cfg *configV9, alias string, hostCfg *hostConfigV9) *probe.Error {
+	profile := getActiveProfile(cfg)
+	if profile == "" {
+		return nil
+	}
+	hosts, ok := cfg.Profiles[profile]
+	if !ok {
+		return errNoSuchProfile(profile).Trace(profile)
+	}
+	if creds, ok := hosts[alias]; ok {
+		hostCfg.AccessKey = creds.AccessKey
+		hostCfg.SecretKey = creds.SecretKey
+		hostCfg.CredentialProcess = creds.CredentialProcess
+	}
+	return nil
+}
+
+// listProfiles - returns the default profile followed by all named
+// profiles sorted by name.
+func listProfiles(cfg *configV9) []profileMessage {
+	active := getActiveProfile(cfg)
+
+	var aliases []string
+	for alias := range cfg.Hosts {
+		aliases = append(aliases, alias)
+	}
+	sort.Strings(aliases)
+	profiles := []profileMessage{{
+		op:      "list",
+		Profile: defaultProfile,
+		Active:  active == "",
+		Aliases: aliases,
+	}}
+
+	var names []string
+	for name := range cfg.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		var aliases []string
+		for alias := range cfg.Profiles[name] {
+			aliases = append(aliases, alias)
+		}
+		sort.Strings(aliases)
+		profiles = append(profiles, profileMessage{
+			op:      "list",
+			Profile: name,
+			Active:  active == name,
+			Aliases: aliases,
+		})
+	}
+	return profiles
+}
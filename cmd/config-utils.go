@@ -51,7 +51,7 @@ func trimTrailingSeparator(hostURL string) string {
 func isValidHostURL(hostURL string) (ok bool) {
 	if strings.TrimSpace(hostURL) != "" {
 		url := newClientURL(hostURL)
-		if url.Scheme == "https" || url.Scheme == "http" {
+		if isValidScheme(url.Scheme) {
 			if url.Path == "/" {
 				ok = true
 			}
@@ -60,6 +60,13 @@ func isValidHostURL(hostURL string) (ok bool) {
 	return ok
 }
 
+// isS3HostURL - returns true if hostURL points to a S3 compatible
+// server, rather than a SFTP server or a WebHDFS endpoint.
+func isS3HostURL(hostURL string) bool {
+	scheme := newClientURL(hostURL).Scheme
+	return scheme == "https" || scheme == "http"
+}
+
 // isValidAPI - Validates if API signature string of supported type.
 func isValidAPI(api string) (ok bool) {
 	switch strings.ToLower(api) {
@@ -32,6 +32,18 @@ func TestValidHostURL(t *testing.T) {
 			hostURL: "/",
 			isHost:  false,
 		},
+		{
+			hostURL: "sftp://sftp.example.com:2222",
+			isHost:  true,
+		},
+		{
+			hostURL: "swebhdfs://namenode.example.com:9871",
+			isHost:  true,
+		},
+		{
+			hostURL: "ftp://ftp.example.com",
+			isHost:  false,
+		},
 	}
 
 	for _, testCase := range testCases {
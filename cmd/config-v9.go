@@ -42,12 +42,51 @@ type hostConfigV9 struct {
 	SecretKey string `json:"secretKey"`
 	API       string `json:"api"`
 	Lookup    string `json:"lookup"`
+	// Region of the host, bucket locations are not looked up when set.
+	Region string `json:"region,omitempty"`
+	// Command printing credentials in the AWS CLI 'credential_process' format.
+	CredentialProcess string `json:"credentialProcess,omitempty"`
+	// Temporary credentials are requested from the STS endpoint, which
+	// defaults to the host URL, by assuming RoleARN with the keys above
+	// or by presenting the token in WebIdentityTokenFile.
+	RoleARN              string `json:"roleArn,omitempty"`
+	WebIdentityTokenFile string `json:"webIdentityTokenFile,omitempty"`
+	STSEndpoint          string `json:"stsEndpoint,omitempty"`
+	// TLS options of the alias, in addition to the global ones.
+	TLS *hostTLSConfigV9 `json:"tls,omitempty"`
+	// SSE-C keys by bucket or prefix, saved encrypted with the config
+	// password and used automatically by commands reading and writing
+	// objects under the prefix.
+	EncryptKeys map[string]string `json:"encryptKeys,omitempty"`
+}
+
+// hostTLSConfigV9 TLS options of a host.
+type hostTLSConfigV9 struct {
+	CAFile       string   `json:"caFile,omitempty"`
+	CertFile     string   `json:"certFile,omitempty"`
+	KeyFile      string   `json:"keyFile,omitempty"`
+	MinVersion   string   `json:"minVersion,omitempty"`
+	CipherSuites []string `json:"cipherSuites,omitempty"`
+	SkipVerify   bool     `json:"skipVerify,omitempty"`
+}
+
+// profileConfigV9 credentials of a host in a named profile.
+type profileConfigV9 struct {
+	AccessKey         string `json:"accessKey"`
+	SecretKey         string `json:"secretKey"`
+	CredentialProcess string `json:"credentialProcess,omitempty"`
 }
 
 // configV8 config version.
 type configV9 struct {
 	Version string                  `json:"version"`
 	Hosts   map[string]hostConfigV9 `json:"hosts"`
+	// Encrypted is set by 'mc config encrypt', secret keys are saved
+	// encrypted with a password.
+	Encrypted bool `json:"encrypted,omitempty"`
+	// Profile selected by 'mc config profile use'.
+	Profile  string                                `json:"profile,omitempty"`
+	Profiles map[string]map[string]profileConfigV9 `json:"profiles,omitempty"`
 }
 
 // newConfigV9 - new config version.
@@ -144,7 +183,15 @@ func saveConfigV9(cfgV9 *configV9) *probe.Error {
 	cfgMutex.Lock()
 	defer cfgMutex.Unlock()
 
-	qs, e := quick.NewConfig(cfgV9, nil)
+	saveCfgV9 := cfgV9
+	if cfgV9.Encrypted {
+		var err *probe.Error
+		if saveCfgV9, err = encryptConfig(cfgV9); err != nil {
+			return err.Trace(mustGetMcConfigPath())
+		}
+	}
+
+	qs, e := quick.NewConfig(saveCfgV9, nil)
 	if e != nil {
 		return probe.NewError(e)
 	}
@@ -165,6 +165,12 @@ func getHostConfig(alias string) (*hostConfigV9, *probe.Error) {
 	// if host is exact return quickly.
 	if _, ok := mcCfg.Hosts[alias]; ok {
 		hostCfg := mcCfg.Hosts[alias]
+		if err = applyProfile(mcCfg, alias, &hostCfg); err != nil {
+			return nil, err.Trace(alias)
+		}
+		if hostCfg.SecretKey, err = decryptConfigSecret(hostCfg.SecretKey); err != nil {
+			return nil, err.Trace(alias)
+		}
 		return &hostCfg, nil
 	}
 
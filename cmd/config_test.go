@@ -16,7 +16,11 @@
 
 package cmd
 
-import "testing"
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
 
 // Tests valid host URL functionality.
 func TestParseEnvURLStr(t *testing.T) {
@@ -76,3 +80,91 @@ func TestParseEnvURLStrInvalid(t *testing.T) {
 		t.Fatalf("Expected failure")
 	}
 }
+
+// Tests credentials selection from profiles.
+func TestApplyProfile(t *testing.T) {
+	cfg := newConfigV9()
+	cfg.Hosts["myminio"] = hostConfigV9{URL: "http://localhost:9000", AccessKey: "devkey", SecretKey: "devsecret"}
+	cfg.Hosts["play"] = hostConfigV9{URL: "https://play.min.io", AccessKey: "playkey", SecretKey: "playsecret"}
+	cfg.Profiles = map[string]map[string]profileConfigV9{
+		"prod": {"myminio": {AccessKey: "prodkey", SecretKey: "prodsecret"}},
+	}
+	defer func() { globalProfile = "" }()
+
+	testCases := []struct {
+		saved     string
+		flag      string
+		alias     string
+		accessKey string
+		shouldErr bool
+	}{
+		{"", "", "myminio", "devkey", false},
+		{"", "prod", "myminio", "prodkey", false},
+		{"prod", "", "myminio", "prodkey", false},
+		{"prod", "default", "myminio", "devkey", false},
+		{"", "prod", "play", "playkey", false},
+		{"", "missing", "myminio", "", true},
+	}
+
+	for i, testCase := range testCases {
+		cfg.Profile = testCase.saved
+		globalProfile = testCase.flag
+		hostCfg := cfg.Hosts[testCase.alias]
+		err := applyProfile(cfg, testCase.alias, &hostCfg)
+		if testCase.shouldErr {
+			if err == nil {
+				t.Fatalf("Test %d: Expected error, got none", i+1)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("Test %d: Unexpected error %s", i+1, err)
+		}
+		if hostCfg.AccessKey != testCase.accessKey {
+			t.Fatalf("Test %d: Expected %s, got %s", i+1, testCase.accessKey, hostCfg.AccessKey)
+		}
+	}
+}
+
+// Tests encryption of secret keys.
+func TestEncryptConfig(t *testing.T) {
+	configPassword = "password"
+	defer func() { configPassword = "" }()
+
+	cfg := newConfigV9()
+	cfg.Hosts["myminio"] = hostConfigV9{URL: "http://localhost:9000", AccessKey: "minio", SecretKey: "minio123",
+		EncryptKeys: map[string]string{"mybucket": "MzJieXRlc2xvbmdzZWNyZXRrZXltdXN0YmVnaXZlbjE="}}
+	cfg.Hosts["anon"] = hostConfigV9{URL: "https://play.min.io"}
+	cfg.Profiles = map[string]map[string]profileConfigV9{
+		"prod": {"myminio": {AccessKey: "prodkey", SecretKey: "prodsecret"}},
+	}
+
+	encCfg, err := encryptConfig(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Hosts["myminio"].SecretKey != "minio123" {
+		t.Fatal("Expected config to be left unchanged")
+	}
+	if secretKey := encCfg.Hosts["myminio"].SecretKey; !strings.HasPrefix(secretKey, encryptedSecretPrefix) {
+		t.Fatalf("Expected encrypted secret key, got %s", secretKey)
+	}
+	if key := encCfg.Hosts["myminio"].EncryptKeys["mybucket"]; !strings.HasPrefix(key, encryptedSecretPrefix) {
+		t.Fatalf("Expected encrypted encryption key, got %s", key)
+	}
+	if cfg.Hosts["myminio"].EncryptKeys["mybucket"] != "MzJieXRlc2xvbmdzZWNyZXRrZXltdXN0YmVnaXZlbjE=" {
+		t.Fatal("Expected encryption keys to be left unchanged")
+	}
+	if secretKey := encCfg.Hosts["anon"].SecretKey; secretKey != "" {
+		t.Fatalf("Expected empty secret key, got %s", secretKey)
+	}
+
+	// Decrypt from scratch, as on the next run.
+	decryptedSecrets = make(map[string]string)
+	if err = decryptConfig(encCfg); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(encCfg, cfg) {
+		t.Fatalf("Expected %+v, got %+v", cfg, encCfg)
+	}
+}
@@ -19,6 +19,7 @@ package cmd
 import (
 	"bufio"
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -28,6 +29,7 @@ import (
 	"strings"
 	"syscall"
 
+	humanize "github.com/dustin/go-humanize"
 	"github.com/fatih/color"
 	"github.com/minio/cli"
 	"github.com/minio/mc/pkg/console"
@@ -61,6 +63,47 @@ var (
 			Name:  "attr",
 			Usage: "add custom metadata for the object",
 		},
+		cli.StringFlag{
+			Name:  "compress",
+			Usage: "compress object(s) on the fly before upload, supported formats are [gzip]",
+		},
+		cli.StringFlag{
+			Name:  "ledger",
+			Usage: "record every transferred object into a JSON lines file, gzip compressed if it ends with .gz",
+		},
+		cli.StringFlag{
+			Name:  "progress",
+			Usage: "progress renderer, one of [bar, dots, rate, none, json], defaults to a bar on terminals",
+		},
+		cli.StringFlag{
+			Name:  "notify-url",
+			Usage: "post a JSON event to this URL for every transferred object and once when done",
+		},
+		cli.StringFlag{
+			Name:  "exec-on-complete",
+			Usage: "run a command for every transferred object and once when done, details are passed as MC_HOOK_* environment variables",
+		},
+		cli.BoolFlag{
+			Name:  "pipeline",
+			Usage: "start copying while sources are still being scanned",
+		},
+		cli.BoolFlag{
+			Name:  "archive",
+			Usage: "stream SOURCE recursively into a single tar, tar.gz or zip archive, the format is guessed from TARGET",
+		},
+		cli.BoolFlag{
+			Name:  "auto-restore",
+			Usage: "restore archived objects (GLACIER, DEEP_ARCHIVE) and wait until they are available before copying",
+		},
+		cli.IntFlag{
+			Name:  "restore-days",
+			Value: 1,
+			Usage: "number of days restored copies stay available, used with --auto-restore",
+		},
+		cli.StringFlag{
+			Name:  "size",
+			Usage: "size of the data read from stdin with SOURCE '-', used to size multipart uploads",
+		},
 	}
 )
 
@@ -73,7 +116,7 @@ var cpCmd = cli.Command{
 	Usage:  "copy objects",
 	Action: mainCopy,
 	Before: setGlobalsFromContext,
-	Flags:  append(append(cpFlags, ioFlags...), globalFlags...),
+	Flags:  append(append(append(cpFlags, cseFlags...), ioFlags...), globalFlags...),
 	CustomHelpTemplate: `NAME:
   {{.HelpName}} - {{.Usage}}
 
@@ -86,6 +129,19 @@ FLAGS:
 ENVIRONMENT VARIABLES:
   MC_ENCRYPT:      list of comma delimited prefixes
   MC_ENCRYPT_KEY:  list of comma delimited prefix=secret values
+  MC_ENCRYPT_CLIENT_KEY:  key encrypting objects client-side
+  MC_CREDENTIALS_<alias>:  command printing fresh credentials for <alias> as JSON when they expire
+  MC_LIST_WORKERS:  number of prefixes listed concurrently by recursive listings (default 8)
+
+STREAMS:
+  A SOURCE of '-' copies stdin to TARGET, a TARGET of '-' copies SOURCE to stdout. Uploads from stdin
+  have an unknown size, if the exact size is known --size lets large uploads use smaller multipart parts.
+
+HOOKS:
+  --exec-on-complete runs its command with MC_HOOK_EVENT set to 'object' for every transfer,
+  along with MC_HOOK_SOURCE, MC_HOOK_TARGET, MC_HOOK_SIZE, MC_HOOK_RESULT and MC_HOOK_ERROR.
+  Once all transfers are done it runs again with MC_HOOK_EVENT set to 'session', along with
+  MC_HOOK_OBJECTS, MC_HOOK_SIZE and MC_HOOK_ERRORS. --notify-url receives the same events as JSON.
 
 EXAMPLES:
   01. Copy a list of objects from local file system to Amazon S3 cloud storage.
@@ -127,6 +183,45 @@ EXAMPLES:
 
   13. Copy a text file to an object storage and assign REDUCED_REDUNDANCY storage-class to the uploaded object.
       $ {{.HelpName}} --storage-class REDUCED_REDUNDANCY myobject.txt play/mybucket
+
+  14. Copy a log file to MinIO cloud storage compressed with gzip, the object is stored as 'app.log.gz'.
+      $ {{.HelpName}} --compress gzip app.log play/mybucket/
+
+  15. Copy a folder recursively to MinIO cloud storage and record every transfer in a compressed ledger.
+      $ {{.HelpName}} --recursive --ledger run1.ndjson.gz backup/ play/mybucket/
+
+  16. Copy a folder recursively from Amazon S3, restoring archived objects for 3 days before copying them.
+      $ {{.HelpName}} --recursive --auto-restore --restore-days 3 s3/archive/2015/ play/mybucket/2015/
+
+  17. Download a prefix from Amazon S3 as a single tar archive.
+      $ {{.HelpName}} --archive s3/mybucket/backups/ backup.tar
+
+  18. Stream a prefix from MinIO cloud storage as a tar archive to standard output.
+      $ {{.HelpName}} --archive play/mybucket/photos/ - | gzip > photos.tar.gz
+
+  19. Copy a folder recursively from a CI job, printing the transfer rate periodically instead of a progress bar.
+      $ {{.HelpName}} --recursive --progress rate build/ play/mybucket/artifacts/
+
+  20. Copy a folder recursively and notify a webhook about every uploaded object.
+      $ {{.HelpName}} --recursive --notify-url https://hooks.example.com/uploads build/ play/mybucket/artifacts/
+
+  21. Copy a large folder recursively, starting the uploads while the folder is still being scanned.
+      $ {{.HelpName}} --recursive --pipeline /mnt/dataset/ play/mybucket/dataset/
+
+  22. Copy a folder recursively to Amazon S3, encrypting the objects client-side with a key read from a file.
+      $ {{.HelpName}} --recursive --encrypt-client-key-file ~/.mc/backup.key backup/ s3/mybucket/backup/
+
+  23. Download a client-side encrypted object, the object is transparently decrypted.
+      $ {{.HelpName}} --encrypt-client-key-file ~/.mc/backup.key s3/mybucket/backup/db.sql db.sql
+
+  24. Stream a database dump from stdin into an object, compressed on the fly.
+      $ pg_dump mydb | {{.HelpName}} --compress gzip - play/backups/db.sql
+
+  25. Stream a 50GiB disk image from stdin with its size given, to size the multipart upload.
+      $ dd if=/dev/sdb bs=1M | {{.HelpName}} --size 50GiB - play/backups/sdb.img
+
+  26. Write an object to stdout.
+      $ {{.HelpName}} play/backups/db.sql - | psql mydb
  `,
 }
 
@@ -180,9 +275,12 @@ func doCopy(ctx context.Context, cpURLs URLs, pg ProgressReader, encKeyDB map[st
 	targetURL := cpURLs.TargetContent.URL
 	length := cpURLs.SourceContent.Size
 
-	if progressReader, ok := pg.(*progressBar); ok {
+	switch progressReader := pg.(type) {
+	case *progressBar:
 		progressReader.SetCaption(cpURLs.SourceContent.URL.String() + ": ")
-	} else {
+	case *lineProgress:
+		// Line renderers only report the overall progress.
+	default:
 		sourcePath := filepath.ToSlash(filepath.Join(sourceAlias, sourceURL.Path))
 		targetPath := filepath.ToSlash(filepath.Join(targetAlias, targetURL.Path))
 		printMsg(copyMessage{
@@ -193,19 +291,30 @@ func doCopy(ctx context.Context, cpURLs URLs, pg ProgressReader, encKeyDB map[st
 			TotalSize:  cpURLs.TotalSize,
 		})
 	}
-	return uploadSourceToTargetURL(ctx, cpURLs, pg, encKeyDB)
+	return retryOnExpiredCredentials(cpURLs, func() URLs {
+		return uploadSourceToTargetURL(ctx, cpURLs, pg, encKeyDB)
+	})
 }
 
 // doCopyFake - Perform a fake copy to update the progress bar appropriately.
 func doCopyFake(cpURLs URLs, pg Progress) URLs {
-	if progressReader, ok := pg.(*progressBar); ok {
+	switch progressReader := pg.(type) {
+	case *progressBar:
 		progressReader.ProgressBar.Add64(cpURLs.SourceContent.Size)
+	case *lineProgress:
+		progressReader.Add(cpURLs.SourceContent.Size)
 	}
 	return cpURLs
 }
 
+// Number of prepared URLs buffered ahead of the copy workers in
+// pipelined mode, scanning pauses when the buffer is full.
+const copyPipelineBuffer = 1000
+
 // doPrepareCopyURLs scans the source URL and prepares a list of objects for copying.
-func doPrepareCopyURLs(session *sessionV8, trapCh <-chan bool, cancelCopy context.CancelFunc) {
+// If prepared is set, it is called for every URL added to the session data, and
+// the scan bar is not shown since copying runs along with the scan.
+func doPrepareCopyURLs(session *sessionV8, trapCh <-chan bool, cancelCopy context.CancelFunc, prepared func(cpURLs URLs, totalBytes int64)) {
 	// Separate source and target. 'cp' can take only one target,
 	// but any number of sources.
 	sourceURLs := session.Header.CommandArgs[:len(session.Header.CommandArgs)-1]
@@ -227,8 +336,11 @@ func doPrepareCopyURLs(session *sessionV8, trapCh <-chan bool, cancelCopy contex
 	// Create a session data file to store the processed URLs.
 	dataFP := session.NewDataWriter()
 
+	// The scan bar is only shown along with the progress bar.
+	showScanBar := prepared == nil && isProgressBarMode(session.Header.CommandStringFlags["progress"])
+
 	var scanBar scanBarFunc
-	if !globalQuiet && !globalJSON { // set up progress bar
+	if showScanBar { // set up progress bar
 		scanBar = scanBarFactory()
 	}
 	URLsCh := prepareCopyURLs(sourceURLs, targetURL, isRecursive, encKeyDB)
@@ -242,7 +354,7 @@ func doPrepareCopyURLs(session *sessionV8, trapCh <-chan bool, cancelCopy contex
 			}
 			if cpURLs.Error != nil {
 				// Print in new line and adjust to top so that we don't print over the ongoing scan bar
-				if !globalQuiet && !globalJSON {
+				if showScanBar {
 					console.Eraseline()
 				}
 				if strings.Contains(cpURLs.Error.ToGoError().Error(), " is a folder.") {
@@ -270,22 +382,29 @@ func doPrepareCopyURLs(session *sessionV8, trapCh <-chan bool, cancelCopy contex
 			}
 
 			fmt.Fprintln(dataFP, string(jsonData))
-			if !globalQuiet && !globalJSON {
+			if showScanBar {
 				scanBar(cpURLs.SourceContent.URL.String())
 			}
 
 			totalBytes += cpURLs.SourceContent.Size
 			totalObjects++
+			if prepared != nil {
+				prepared(cpURLs, totalBytes)
+			}
 		case <-trapCh:
 			cancelCopy()
 			// Print in new line and adjust to top so that we don't print over the ongoing scan bar
-			if !globalQuiet && !globalJSON {
+			if showScanBar {
 				console.Eraseline()
 			}
 			session.Delete() // If we are interrupted during the URL scanning, we drop the session.
 			os.Exit(0)
 		}
 	}
+	if e := dataFP.Close(); e != nil {
+		session.Delete()
+		fatalIf(probe.NewError(e), "Unable to write session data.")
+	}
 	session.Header.TotalBytes = totalBytes
 	session.Header.TotalObjects = totalObjects
 	session.Save()
@@ -296,24 +415,85 @@ func doCopySession(session *sessionV8, encKeyDB map[string][]prefixSSEPair) erro
 
 	ctx, cancelCopy := context.WithCancel(context.Background())
 	defer cancelCopy()
-	if !session.HasData() {
-		doPrepareCopyURLs(session, trapCh, cancelCopy)
+
+	// In pipelined mode copying starts while the sources are scanned.
+	isPipelined := !session.HasData() && session.Header.CommandBoolFlags["pipeline"]
+	if !session.HasData() && !isPipelined {
+		doPrepareCopyURLs(session, trapCh, cancelCopy, nil)
+	} else if session.HasData() {
+		// Refuse to resume from damaged session data, entries would
+		// silently be skipped.
+		fatalIf(session.verifyData().Trace(session.SessionID),
+			"Unable to resume session, run `mc session repair "+session.SessionID+"` to recover it.")
 	}
 
-	// Prepare URL scanner from session data file.
-	urlScanner := bufio.NewScanner(session.NewDataReader())
 	// isCopied returns true if an object has been already copied
 	// or not. This is useful when we resume from a session.
 	isCopied := isLastFactory(session.Header.LastCopied)
 
-	// Store a progress bar or an accounter
-	var pg ProgressReader
+	// Open the transfer ledger if requested.
+	var ledger *transferLedger
+	if ledgerPath := session.Header.CommandStringFlags["ledger"]; ledgerPath != "" {
+		var err *probe.Error
+		ledger, err = newTransferLedger(ledgerPath)
+		fatalIf(err.Trace(ledgerPath), "Unable to open transfer ledger.")
+		defer ledger.Close()
+	}
 
-	// Enable progress bar reader only during default mode.
-	if !globalQuiet && !globalJSON { // set up progress bar
-		pg = newProgressBar(session.Header.TotalBytes)
+	// Notify downstream pipelines about completed transfers if requested.
+	hook, err := newTransferHook(session.Header.CommandStringFlags["notify-url"], session.Header.CommandStringFlags["exec-on-complete"])
+	fatalIf(err, "Unable to set up completion hooks.")
+
+	// Store a progress bar, a line renderer or an accounter
+	pg := newProgressReader(session.Header.CommandStringFlags["progress"], session.Header.TotalBytes)
+	_, isProgressBar := pg.(*progressBar)
+
+	// nextURLs returns the next prepared URLs, either read from the session
+	// data file or received from the ongoing scan in pipelined mode.
+	var nextURLs func() (URLs, bool)
+	var scanDoneCh chan struct{}
+	if isPipelined {
+		preparedCh := make(chan URLs, copyPipelineBuffer)
+		scanDoneCh = make(chan struct{})
+		go func() {
+			defer close(preparedCh)
+			// Interrupts are handled below, while copying.
+			doPrepareCopyURLs(session, nil, cancelCopy, func(cpURLs URLs, totalBytes int64) {
+				setProgressTotal(pg, totalBytes)
+				preparedCh <- cpURLs
+			})
+			close(scanDoneCh)
+		}()
+		nextURLs = func() (URLs, bool) {
+			cpURLs, ok := <-preparedCh
+			return cpURLs, ok
+		}
 	} else {
-		pg = newAccounter(session.Header.TotalBytes)
+		// Prepare URL scanner from session data file.
+		dataReader, err := session.NewDataReader()
+		fatalIf(err.Trace(session.SessionID), "Unable to read session data.")
+		urlScanner := bufio.NewScanner(dataReader)
+		nextURLs = func() (URLs, bool) {
+			for urlScanner.Scan() {
+				var cpURLs URLs
+				// Unmarshal copyURLs from each line. This expects each line to be
+				// an entire JSON object.
+				if e := json.Unmarshal([]byte(urlScanner.Text()), &cpURLs); e != nil {
+					errorIf(probe.NewError(e), "Unable to unmarshal %s", urlScanner.Text())
+					continue
+				}
+				return cpURLs, true
+			}
+			errorIf(probe.NewError(urlScanner.Err()), "Unable to read session data.")
+			return URLs{}, false
+		}
+	}
+
+	var cseKey []byte
+	if key := session.Header.CommandStringFlags["encrypt-client-key"]; key != "" {
+		var err *probe.Error
+		cseKey, err = parseCSEKey(key)
+		fatalIf(err, "Unable to parse client-side encryption key.")
 	}
 
 	var quitCh = make(chan struct{})
@@ -334,26 +514,13 @@ func doCopySession(session *sessionV8, encKeyDB map[string][]prefixSSEPair) erro
 				gracefulStop()
 				return
 			default:
-				if !urlScanner.Scan() {
+				cpURLs, ok := nextURLs()
+				if !ok {
 					// No more entries, quit immediately
 					gracefulStop()
 					return
 				}
 
-				if e := urlScanner.Err(); e != nil {
-					// Error while reading. quit immediately
-					gracefulStop()
-					return
-				}
-
-				var cpURLs URLs
-				// Unmarshal copyURLs from each line. This expects each line to be
-				// an entire JSON object.
-				if e := json.Unmarshal([]byte(urlScanner.Text()), &cpURLs); e != nil {
-					errorIf(probe.NewError(e), "Unable to unmarshal %s", urlScanner.Text())
-					continue
-				}
-
 				// Save total count.
 				cpURLs.TotalCount = session.Header.TotalObjects
 
@@ -371,6 +538,20 @@ func doCopySession(session *sessionV8, encKeyDB map[string][]prefixSSEPair) erro
 					cpURLs.TargetContent.Metadata["X-Amz-Storage-Class"] = session.Header.CommandStringFlags["storage-class"]
 				}
 
+				// Compress on the fly if requested, compressed objects
+				// are stored with an additional extension.
+				if compress := session.Header.CommandStringFlags["compress"]; compress != "" {
+					cpURLs.compress = compress
+					cpURLs.TargetContent.URL.Path = compressedName(cpURLs.TargetContent.URL.Path, compress)
+				}
+
+				// Encrypt uploads and decrypt downloads client-side
+				// if a key is provided.
+				cpURLs.cseKey = cseKey
+
+				// Restore archived objects before copying if requested.
+				cpURLs.restoreDays = session.Header.CommandIntFlags["restore-days"]
+
 				// Check and handle metadata if passed in command line args
 				if len(session.Header.UserMetaData) != 0 {
 					for metaDataKey, metaDataVal := range session.Header.UserMetaData {
@@ -385,7 +566,11 @@ func doCopySession(session *sessionV8, encKeyDB map[string][]prefixSSEPair) erro
 					}
 				} else {
 					queueCh <- func() URLs {
-						return doCopy(ctx, cpURLs, pg, encKeyDB)
+						startTime := UTCNow()
+						cpURLs = doCopy(ctx, cpURLs, pg, encKeyDB)
+						ledger.Record(cpURLs, startTime)
+						hook.Object(cpURLs, startTime)
+						return cpURLs
 					}
 				}
 			}
@@ -398,12 +583,24 @@ loop:
 	for {
 		select {
 		case <-trapCh:
+			if isPipelined && !isScanDone(scanDoneCh) {
+				// Session data is incomplete, the session cannot be
+				// resumed if the scan did not finish.
+				cancelCopy()
+				if isProgressBar {
+					console.Eraseline()
+				}
+				ledger.Close()
+				session.Delete()
+				console.Fatalln("Copy interrupted while scanning sources, the session cannot be resumed.")
+			}
 			quitCh <- struct{}{}
 			cancelCopy()
 			// Receive interrupt notification.
-			if !globalQuiet && !globalJSON {
+			if isProgressBar {
 				console.Eraseline()
 			}
+			ledger.Close()
 			session.CloseAndDie()
 		case cpURLs, ok := <-statusCh:
 			// Status channel is closed, we should return.
@@ -420,7 +617,7 @@ loop:
 
 				// Print in new line and adjust to top so that we
 				// don't print over the ongoing progress bar.
-				if !globalQuiet && !globalJSON {
+				if isProgressBar {
 					console.Eraseline()
 				}
 				errorIf(cpURLs.Error.Trace(cpURLs.SourceContent.URL.String()),
@@ -431,24 +628,37 @@ loop:
 				// For critical errors we should exit. Session
 				// can be resumed after the user figures out
 				// the  problem.
+				ledger.Close()
 				session.CloseAndDie()
 			}
 		}
 	}
 
-	if progressReader, ok := pg.(*progressBar); ok {
+	switch progressReader := pg.(type) {
+	case *progressBar:
 		if progressReader.ProgressBar.Get() > 0 {
 			progressReader.ProgressBar.Finish()
 		}
-	} else {
-		if accntReader, ok := pg.(*accounter); ok {
-			printMsg(accntReader.Stat())
-		}
+	case *lineProgress:
+		progressReader.Finish()
+	case *accounter:
+		printMsg(progressReader.Stat())
 	}
 
+	hook.Session()
 	return retErr
 }
 
+// isScanDone returns true once scanDoneCh is closed.
+func isScanDone(scanDoneCh <-chan struct{}) bool {
+	select {
+	case <-scanDoneCh:
+		return true
+	default:
+		return false
+	}
+}
+
 // validate the passed metadataString and populate the map
 func getMetaDataEntry(metadataString string) (map[string]string, *probe.Error) {
 	metaDataMap := make(map[string]string)
@@ -476,6 +686,24 @@ func mainCopy(ctx *cli.Context) error {
 		fatalIf(err, "Unable to parse attribute %v", ctx.String("attr"))
 	}
 
+	// Stream the source into a single archive, this is not a regular
+	// copy and does not need a session.
+	if ctx.Bool("archive") {
+		args := ctx.Args()
+		if len(args) != 2 {
+			cli.ShowCommandHelpAndExit(ctx, "cp", 1)
+		}
+		fatalIf(archiveURL(args.Get(0), args.Get(1), encKeyDB).Trace(args...), "Unable to archive `"+args.Get(0)+"`.")
+		return nil
+	}
+
+	// Stream from stdin or to stdout, this is not a regular
+	// copy and does not need a session either.
+	if args := ctx.Args(); len(args) == 2 && (args.Get(0) == "-" || args.Get(1) == "-") {
+		fatalIf(copyStream(ctx, args.Get(0), args.Get(1), encKeyDB, userMetaMap).Trace(args...), "Unable to copy `"+args.Get(0)+"` to `"+args.Get(1)+"`.")
+		return nil
+	}
+
 	// check 'copy' cli arguments.
 	checkCopySyntax(ctx, encKeyDB)
 
@@ -497,14 +725,41 @@ func mainCopy(ctx *cli.Context) error {
 	}
 	sse := ctx.String("encrypt")
 
+	compress := ctx.String("compress")
+	fatalIf(checkCompressFormat(compress).Trace(compress), "Unable to use compression.")
+
+	cseKey, err := getCSEKey(ctx)
+	fatalIf(err, "Unable to parse client-side encryption key.")
+
+	progress := ctx.String("progress")
+	fatalIf(checkProgressMode(progress).Trace(progress), "Unable to use progress renderer.")
+
+	var restoreDays int
+	if ctx.Bool("auto-restore") {
+		restoreDays = ctx.Int("restore-days")
+		if restoreDays < 1 {
+			fatalIf(errInvalidArgument().Trace(ctx.String("restore-days")), "Restore days should be at least 1.")
+		}
+	}
+
 	session := newSessionV8()
 	session.Header.CommandType = "cp"
 	session.Header.CommandBoolFlags["recursive"] = recursive
+	session.Header.CommandBoolFlags["pipeline"] = ctx.Bool("pipeline")
 	session.Header.CommandStringFlags["older-than"] = olderThan
 	session.Header.CommandStringFlags["newer-than"] = newerThan
 	session.Header.CommandStringFlags["storage-class"] = storageClass
 	session.Header.CommandStringFlags["encrypt-key"] = sseKeys
 	session.Header.CommandStringFlags["encrypt"] = sse
+	session.Header.CommandStringFlags["compress"] = compress
+	if cseKey != nil {
+		session.Header.CommandStringFlags["encrypt-client-key"] = base64.StdEncoding.EncodeToString(cseKey)
+	}
+	session.Header.CommandStringFlags["ledger"] = ctx.String("ledger")
+	session.Header.CommandStringFlags["progress"] = progress
+	session.Header.CommandStringFlags["notify-url"] = ctx.String("notify-url")
+	session.Header.CommandStringFlags["exec-on-complete"] = ctx.String("exec-on-complete")
+	session.Header.CommandIntFlags["restore-days"] = restoreDays
 	session.Header.UserMetaData = userMetaMap
 
 	var e error
@@ -520,3 +775,54 @@ func mainCopy(ctx *cli.Context) error {
 
 	return e
 }
+
+// copyStream - copies stdin to targetURL or sourceURL to stdout, as
+// given by the special URL '-'.
+func copyStream(ctx *cli.Context, sourceURL, targetURL string, encKeyDB map[string][]prefixSSEPair, userMetaMap map[string]string) *probe.Error {
+	if ctx.Bool("recursive") {
+		return errInvalidArgument().Trace("--recursive")
+	}
+	cseKey, err := getCSEKey(ctx)
+	if err != nil {
+		return err.Trace(sourceURL, targetURL)
+	}
+	if targetURL == "-" {
+		if sourceURL == "-" {
+			return catOut(os.Stdin, -1).Trace(sourceURL)
+		}
+		return catURL(sourceURL, encKeyDB, cseKey).Trace(sourceURL)
+	}
+
+	compress := ctx.String("compress")
+	if err = checkCompressFormat(compress); err != nil {
+		return err.Trace(compress)
+	}
+	size := int64(-1)
+	if sizeStr := ctx.String("size"); sizeStr != "" {
+		sizeHint, e := humanize.ParseBytes(sizeStr)
+		if e != nil {
+			return probe.NewError(e).Trace(sizeStr)
+		}
+		// A size of zero stands for an unknown size.
+		if sizeHint > 0 {
+			size = int64(sizeHint)
+		}
+	}
+	metadata := map[string]string{}
+	for k, v := range userMetaMap {
+		metadata[k] = v
+	}
+	if storageClass := ctx.String("storage-class"); storageClass != "" {
+		metadata["X-Amz-Storage-Class"] = storageClass
+	}
+	n, err := pipe(targetURL, size, metadata, encKeyDB, compress, cseKey)
+	if err != nil {
+		return err.Trace(targetURL)
+	}
+	printMsg(copyMessage{
+		Source: sourceURL,
+		Target: targetURL,
+		Size:   n,
+	})
+	return nil
+}
@@ -0,0 +1,300 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/minio/mc/pkg/console"
+	"github.com/minio/mc/pkg/probe"
+	minio "github.com/minio/minio-go/v6"
+	"github.com/minio/minio-go/v6/pkg/credentials"
+	"golang.org/x/crypto/ssh/terminal"
+)
+
+// Environment variable holding a command which prints fresh
+// credentials for an alias, i.e. MC_CREDENTIALS_myminio. It takes
+// precedence over the credential process saved for the alias.
+const mcEnvCredentialsPrefix = "MC_CREDENTIALS_"
+
+// Credentials returned by a command are refreshed this long before
+// they expire.
+const credentialsExpiryWindow = time.Minute
+
+// processCredentials - credentials printed by a credentials command,
+// in the format used by the AWS CLI 'credential_process' setting.
+type processCredentials struct {
+	Version         int       `json:"Version"`
+	AccessKeyID     string    `json:"AccessKeyId"`
+	SecretAccessKey string    `json:"SecretAccessKey"`
+	SessionToken    string    `json:"SessionToken"`
+	Expiration      time.Time `json:"Expiration"`
+}
+
+// parseProcessCredentials parses the output of a credentials command.
+func parseProcessCredentials(output []byte) (processCredentials, *probe.Error) {
+	var creds processCredentials
+	if e := json.Unmarshal(output, &creds); e != nil {
+		return creds, probe.NewError(e)
+	}
+	if creds.AccessKeyID == "" || creds.SecretAccessKey == "" {
+		return creds, probe.NewError(errors.New("credentials command returned empty credentials"))
+	}
+	return creds, nil
+}
+
+// refreshableCredentials - a credentials provider for an alias which can
+// be refreshed when the server rejects expired credentials, either by
+// running the credentials command of the alias or by prompting the user.
+type refreshableCredentials struct {
+	mutex       sync.Mutex
+	alias       string
+	command     string
+	signerType  credentials.SignatureType
+	value       credentials.Value
+	expiration  time.Time
+	refreshedAt time.Time
+	creds       *credentials.Credentials
+}
+
+// Retrieve returns the current credentials, running the credentials
+// command if they are about to expire.
+func (r *refreshableCredentials) Retrieve() (credentials.Value, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if r.command != "" && r.isExpired() {
+		if err := r.runCommand(); err != nil {
+			return credentials.Value{}, err.ToGoError()
+		}
+	}
+	return r.value, nil
+}
+
+// IsExpired returns true if the credentials have to be retrieved again.
+func (r *refreshableCredentials) IsExpired() bool {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return r.command != "" && r.isExpired()
+}
+
+func (r *refreshableCredentials) isExpired() bool {
+	if r.value.AccessKeyID == "" {
+		return true
+	}
+	return !r.expiration.IsZero() && time.Now().Add(credentialsExpiryWindow).After(r.expiration)
+}
+
+// runCommand obtains new credentials from the credentials command.
+func (r *refreshableCredentials) runCommand() *probe.Error {
+	args := strings.Fields(r.command)
+	output, e := exec.Command(args[0], args[1:]...).Output()
+	if e != nil {
+		return probe.NewError(e).Trace(r.command)
+	}
+	creds, err := parseProcessCredentials(output)
+	if err != nil {
+		return err.Trace(r.command)
+	}
+	r.value = credentials.Value{
+		AccessKeyID:     creds.AccessKeyID,
+		SecretAccessKey: creds.SecretAccessKey,
+		SessionToken:    creds.SessionToken,
+		SignerType:      r.signerType,
+	}
+	r.expiration = creds.Expiration
+	return nil
+}
+
+// prompt asks the user for new credentials on the terminal.
+func (r *refreshableCredentials) prompt() *probe.Error {
+	console.Eraseline()
+	console.Println("Credentials for `" + r.alias + "` have expired.")
+	console.Print("Access Key: ")
+	accessKey, e := bufio.NewReader(os.Stdin).ReadString('\n')
+	if e != nil {
+		return probe.NewError(e)
+	}
+	console.Print("Secret Key: ")
+	secretKey, e := terminal.ReadPassword(int(os.Stdin.Fd()))
+	console.Println()
+	if e != nil {
+		return probe.NewError(e)
+	}
+	console.Print("Session Token (optional): ")
+	sessionToken, e := terminal.ReadPassword(int(os.Stdin.Fd()))
+	console.Println()
+	if e != nil {
+		return probe.NewError(e)
+	}
+	r.value = credentials.Value{
+		AccessKeyID:     strings.TrimSpace(accessKey),
+		SecretAccessKey: strings.TrimSpace(string(secretKey)),
+		SessionToken:    strings.TrimSpace(string(sessionToken)),
+		SignerType:      r.signerType,
+	}
+	return nil
+}
+
+// refresh replaces expired credentials unless they were already refreshed
+// after since, which happens when concurrent transfers fail at once.
+func (r *refreshableCredentials) refresh(since time.Time) *probe.Error {
+	r.mutex.Lock()
+	if r.refreshedAt.After(since) {
+		r.mutex.Unlock()
+		return nil
+	}
+
+	var err *probe.Error
+	switch {
+	case r.command != "":
+		err = r.runCommand()
+	case isTerminal() && !globalJSON && !globalQuiet:
+		err = r.prompt()
+	default:
+		err = probe.NewError(fmt.Errorf("credentials for `%s` have expired, set %s%s or add the alias with --credential-process to a command printing fresh credentials",
+			r.alias, mcEnvCredentialsPrefix, r.alias))
+	}
+	if err == nil {
+		r.refreshedAt = time.Now()
+	}
+	r.mutex.Unlock()
+	if err != nil {
+		return err.Trace(r.alias)
+	}
+
+	// Expire outside of the provider lock, retrieving credentials
+	// locks in the opposite order.
+	r.creds.Expire()
+	return nil
+}
+
+var (
+	credentialsMutex sync.Mutex
+	// Credentials of all aliases used by this command.
+	aliasCredentials = make(map[string]*refreshableCredentials)
+)
+
+// newAliasCredentials returns refreshable credentials for the alias of config.
+func newAliasCredentials(config *Config) *credentials.Credentials {
+	signerType := credentials.SignatureV4
+	if strings.ToUpper(config.Signature) == "S3V2" {
+		signerType = credentials.SignatureV2
+	}
+	command, ok := os.LookupEnv(mcEnvCredentialsPrefix + config.Alias)
+	if !ok {
+		command = config.CredentialProcess
+	}
+	provider := &refreshableCredentials{
+		alias:      config.Alias,
+		command:    command,
+		signerType: signerType,
+		value: credentials.Value{
+			AccessKeyID:     config.AccessKey,
+			SecretAccessKey: config.SecretKey,
+			SignerType:      signerType,
+		},
+	}
+	if config.AccessKey == "" || config.SecretKey == "" {
+		provider.value.SignerType = credentials.SignatureAnonymous
+	}
+	provider.creds = credentials.New(provider)
+
+	credentialsMutex.Lock()
+	aliasCredentials[config.Alias] = provider
+	credentialsMutex.Unlock()
+
+	return provider.creds
+}
+
+// newSTSCredentials returns temporary credentials from the STS endpoint
+// of the alias, which are requested again shortly before they expire.
+func newSTSCredentials(config *Config) (*credentials.Credentials, *probe.Error) {
+	if config.WebIdentityToken != "" {
+		creds, e := credentials.NewSTSWebIdentity(config.STSEndpoint, func() (*credentials.WebIdentityToken, error) {
+			// Read the token on every renewal, it is rotated by its issuer.
+			token, e := ioutil.ReadFile(config.WebIdentityToken)
+			if e != nil {
+				return nil, e
+			}
+			return &credentials.WebIdentityToken{Token: strings.TrimSpace(string(token))}, nil
+		})
+		return creds, probe.NewError(e).Trace(config.STSEndpoint)
+	}
+	creds, e := credentials.NewSTSAssumeRole(config.STSEndpoint, credentials.STSAssumeRoleOptions{
+		AccessKey:       config.AccessKey,
+		SecretKey:       config.SecretKey,
+		RoleARN:         config.RoleARN,
+		RoleSessionName: "mc-" + config.Alias,
+	})
+	return creds, probe.NewError(e).Trace(config.STSEndpoint, config.RoleARN)
+}
+
+// isCredentialsExpired returns true if the server rejected a request
+// because of expired credentials.
+func isCredentialsExpired(err *probe.Error) bool {
+	if err == nil {
+		return false
+	}
+	switch minio.ToErrorResponse(err.ToGoError()).Code {
+	case "ExpiredToken", "TokenRefreshRequired", "InvalidTokenId":
+		return true
+	}
+	return false
+}
+
+// refreshCredentials refreshes the credentials of the given aliases,
+// transfers started before since are retried with the new credentials.
+func refreshCredentials(since time.Time, aliases ...string) *probe.Error {
+	refreshed := make(map[string]bool)
+	for _, alias := range aliases {
+		credentialsMutex.Lock()
+		provider, ok := aliasCredentials[alias]
+		credentialsMutex.Unlock()
+		if !ok || refreshed[alias] {
+			continue
+		}
+		if err := provider.refresh(since); err != nil {
+			return err.Trace(aliases...)
+		}
+		refreshed[alias] = true
+	}
+	return nil
+}
+
+// retryOnExpiredCredentials runs transfer once more if it failed because
+// the credentials of the source or target alias expired, other transfers
+// wait while the credentials are refreshed. It stops the command if the
+// credentials cannot be refreshed rather than failing every transfer.
+func retryOnExpiredCredentials(urls URLs, transfer func() URLs) URLs {
+	startTime := time.Now()
+	result := transfer()
+	if !isCredentialsExpired(result.Error) {
+		return result
+	}
+	err := refreshCredentials(startTime, urls.SourceAlias, urls.TargetAlias)
+	fatalIf(err, "Unable to refresh expired credentials.")
+	return transfer()
+}
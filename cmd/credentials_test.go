@@ -0,0 +1,92 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/minio/mc/pkg/probe"
+	minio "github.com/minio/minio-go/v6"
+)
+
+func TestParseProcessCredentials(t *testing.T) {
+	testCases := []struct {
+		output      string
+		expected    processCredentials
+		expectedErr bool
+	}{
+		{
+			output: `{"Version": 1, "AccessKeyId": "access", "SecretAccessKey": "secret", "SessionToken": "token", "Expiration": "2019-10-01T10:00:00Z"}`,
+			expected: processCredentials{
+				Version:         1,
+				AccessKeyID:     "access",
+				SecretAccessKey: "secret",
+				SessionToken:    "token",
+				Expiration:      time.Date(2019, 10, 1, 10, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			output:   `{"AccessKeyId": "access", "SecretAccessKey": "secret"}`,
+			expected: processCredentials{AccessKeyID: "access", SecretAccessKey: "secret"},
+		},
+		{output: `{"AccessKeyId": "access"}`, expectedErr: true},
+		{output: `not json`, expectedErr: true},
+	}
+	for i, testCase := range testCases {
+		creds, err := parseProcessCredentials([]byte(testCase.output))
+		if testCase.expectedErr != (err != nil) {
+			t.Fatalf("Test %d: Expected error %t, got %v", i+1, testCase.expectedErr, err)
+		}
+		if err == nil && creds != testCase.expected {
+			t.Errorf("Test %d: Expected %+v, got %+v", i+1, testCase.expected, creds)
+		}
+	}
+}
+
+func TestIsCredentialsExpired(t *testing.T) {
+	testCases := []struct {
+		err     *probe.Error
+		expired bool
+	}{
+		{nil, false},
+		{probe.NewError(errors.New("connection reset")), false},
+		{probe.NewError(minio.ErrorResponse{Code: "AccessDenied"}), false},
+		{probe.NewError(minio.ErrorResponse{Code: "ExpiredToken"}), true},
+		{probe.NewError(minio.ErrorResponse{Code: "InvalidTokenId"}), true},
+	}
+	for i, testCase := range testCases {
+		if expired := isCredentialsExpired(testCase.err); expired != testCase.expired {
+			t.Errorf("Test %d: Expected %t, got %t", i+1, testCase.expired, expired)
+		}
+	}
+}
+
+func TestAliasCredentialProcess(t *testing.T) {
+	creds := newAliasCredentials(&Config{
+		Alias:             "processtest",
+		CredentialProcess: `echo {"Version":1,"AccessKeyId":"access","SecretAccessKey":"secret","SessionToken":"token"}`,
+	})
+	value, e := creds.Get()
+	if e != nil {
+		t.Fatal(e)
+	}
+	if value.AccessKeyID != "access" || value.SecretAccessKey != "secret" || value.SessionToken != "token" {
+		t.Fatalf("Unexpected credentials %+v", value)
+	}
+}
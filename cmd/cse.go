@@ -0,0 +1,237 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/sio"
+)
+
+const (
+	// Metadata holding the data key of a client-side encrypted
+	// object, sealed with the user provided key.
+	cseSealedKeyMeta = "X-Amz-Meta-Mc-Cse-Sealed-Key"
+	// Metadata holding the algorithm used to encrypt the object.
+	cseAlgorithmMeta = "X-Amz-Meta-Mc-Cse-Algorithm"
+
+	// Objects are encrypted in the DARE format using AES-256-GCM,
+	// see https://github.com/minio/sio.
+	cseAlgorithm = "DAREv2-AES256-GCM"
+
+	cseKeySize = 32
+)
+
+// errCSEKeyRequired is returned when reading a client-side encrypted
+// object without a key.
+var errCSEKeyRequired = errors.New("object is client-side encrypted, please provide the key with --encrypt-client-key or --encrypt-client-key-file")
+
+// parseCSEKey validates a client-side encryption key.
+func parseCSEKey(key string) ([]byte, *probe.Error) {
+	return decodeEncryptionKey(key)
+}
+
+// loadCSEKey returns the client-side encryption key passed directly
+// or read from a key file, nil if neither is set.
+func loadCSEKey(key, keyFile string) ([]byte, *probe.Error) {
+	if key != "" && keyFile != "" {
+		return nil, probe.NewError(errors.New("--encrypt-client-key and --encrypt-client-key-file cannot be used together"))
+	}
+	if keyFile != "" {
+		data, e := ioutil.ReadFile(keyFile)
+		if e != nil {
+			return nil, probe.NewError(e).Trace(keyFile)
+		}
+		// Raw keys are used as is, text keys may end with a newline.
+		if len(data) == cseKeySize {
+			return data, nil
+		}
+		key = strings.TrimSpace(string(data))
+	}
+	if key == "" {
+		return nil, nil
+	}
+	return parseCSEKey(key)
+}
+
+// getCSEKey returns the client-side encryption key set on the
+// command line, nil if client-side encryption is not requested.
+func getCSEKey(ctx *cli.Context) ([]byte, *probe.Error) {
+	return loadCSEKey(ctx.String("encrypt-client-key"), ctx.String("encrypt-client-key-file"))
+}
+
+// sealCSEKey encrypts the data key of an object with the user key.
+func sealCSEKey(key, dataKey []byte) (string, *probe.Error) {
+	aead, err := newCSEKeyCipher(key)
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, e := io.ReadFull(rand.Reader, nonce); e != nil {
+		return "", probe.NewError(e)
+	}
+	sealedKey := aead.Seal(nonce, nonce, dataKey, []byte(cseAlgorithm))
+	return base64.StdEncoding.EncodeToString(sealedKey), nil
+}
+
+// unsealCSEKey decrypts the data key of an object with the user key.
+func unsealCSEKey(key []byte, sealedKey string) ([]byte, *probe.Error) {
+	aead, err := newCSEKeyCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	data, e := base64.StdEncoding.DecodeString(sealedKey)
+	if e != nil || len(data) < aead.NonceSize() {
+		return nil, probe.NewError(errors.New("malformed client-side encryption key in object metadata"))
+	}
+	nonce, data := data[:aead.NonceSize()], data[aead.NonceSize():]
+	dataKey, e := aead.Open(nil, nonce, data, []byte(cseAlgorithm))
+	if e != nil {
+		return nil, probe.NewError(errors.New("unable to decrypt object, the client-side encryption key does not match"))
+	}
+	return dataKey, nil
+}
+
+func newCSEKeyCipher(key []byte) (cipher.AEAD, *probe.Error) {
+	block, e := aes.NewCipher(key)
+	if e != nil {
+		return nil, probe.NewError(e)
+	}
+	aead, e := cipher.NewGCM(block)
+	if e != nil {
+		return nil, probe.NewError(e)
+	}
+	return aead, nil
+}
+
+// isCSEEncrypted returns true if the metadata belongs to a client-side
+// encrypted object.
+func isCSEEncrypted(metadata map[string]string) bool {
+	_, ok := getCSEMetadata(metadata, cseSealedKeyMeta)
+	return ok
+}
+
+// getCSEMetadata looks up a metadata entry regardless of the case
+// used by the backend to return it.
+func getCSEMetadata(metadata map[string]string, key string) (string, bool) {
+	for k, v := range metadata {
+		if http.CanonicalHeaderKey(k) == key {
+			return v, true
+		}
+	}
+	return "", false
+}
+
+// removeCSEMetadata drops the client-side encryption metadata, used
+// once an object is decrypted.
+func removeCSEMetadata(metadata map[string]string) {
+	for k := range metadata {
+		switch http.CanonicalHeaderKey(k) {
+		case cseSealedKeyMeta, cseAlgorithmMeta:
+			delete(metadata, k)
+		}
+	}
+}
+
+func cseConfig(dataKey []byte) sio.Config {
+	return sio.Config{
+		MinVersion:   sio.Version20,
+		MaxVersion:   sio.Version20,
+		CipherSuites: []byte{sio.AES_256_GCM},
+		Key:          dataKey,
+	}
+}
+
+// newCSEEncryptReader returns a reader encrypting r with a random data
+// key, the sealed data key is recorded in metadata. The caller
+// remains responsible for closing r.
+func newCSEEncryptReader(r io.Reader, key []byte, metadata map[string]string) (io.ReadCloser, *probe.Error) {
+	dataKey := make([]byte, cseKeySize)
+	if _, e := io.ReadFull(rand.Reader, dataKey); e != nil {
+		return nil, probe.NewError(e)
+	}
+	sealedKey, err := sealCSEKey(key, dataKey)
+	if err != nil {
+		return nil, err
+	}
+	encReader, e := sio.EncryptReader(r, cseConfig(dataKey))
+	if e != nil {
+		return nil, probe.NewError(e)
+	}
+	removeCSEMetadata(metadata)
+	metadata[cseSealedKeyMeta] = sealedKey
+	metadata[cseAlgorithmMeta] = cseAlgorithm
+	return ioutil.NopCloser(encReader), nil
+}
+
+// newCSEDecryptReader returns a reader decrypting r, the content of a
+// client-side encrypted object with the given metadata. The caller
+// remains responsible for closing r.
+func newCSEDecryptReader(r io.Reader, key []byte, metadata map[string]string) (io.ReadCloser, *probe.Error) {
+	if key == nil {
+		return nil, probe.NewError(errCSEKeyRequired)
+	}
+	if algorithm, _ := getCSEMetadata(metadata, cseAlgorithmMeta); algorithm != cseAlgorithm {
+		return nil, probe.NewError(errors.New("unsupported client-side encryption algorithm `" + algorithm + "`"))
+	}
+	sealedKey, _ := getCSEMetadata(metadata, cseSealedKeyMeta)
+	dataKey, err := unsealCSEKey(key, sealedKey)
+	if err != nil {
+		return nil, err
+	}
+	decReader, e := sio.DecryptReader(r, cseConfig(dataKey))
+	if e != nil {
+		return nil, probe.NewError(e)
+	}
+	return ioutil.NopCloser(decReader), nil
+}
+
+// cseEncryptedSize returns the size of an object once encrypted, -1
+// if the size is not known.
+func cseEncryptedSize(size int64) int64 {
+	if size < 0 {
+		return -1
+	}
+	encSize, e := sio.EncryptedSize(uint64(size))
+	if e != nil {
+		return -1
+	}
+	return int64(encSize)
+}
+
+// cseDecryptedSize returns the size of the plain text of an encrypted
+// object, -1 if the size is not known.
+func cseDecryptedSize(size int64) int64 {
+	if size < 0 {
+		return -1
+	}
+	decSize, e := sio.DecryptedSize(uint64(size))
+	if e != nil {
+		return -1
+	}
+	return int64(decSize)
+}
@@ -0,0 +1,121 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestParseCSEKey(t *testing.T) {
+	testCases := []struct {
+		key string
+		ok  bool
+	}{
+		{"32byteslongsecretkeymustbegiven1", true},
+		{"MzJieXRlc2xvbmdzZWNyZXRrZXltdXN0YmVnaXZlbjE=", true},
+		{"shortkey", false},
+		{"MzJieXRlc2xvbmdzZWNyZXRrZXltdXN0YmVnaXZlbg==", false},
+	}
+	for i, testCase := range testCases {
+		key, err := parseCSEKey(testCase.key)
+		if (err == nil) != testCase.ok {
+			t.Errorf("Test %d: expected ok %v, got %v", i+1, testCase.ok, err)
+			continue
+		}
+		if err == nil && string(key) != "32byteslongsecretkeymustbegiven1" {
+			t.Errorf("Test %d: unexpected key %q", i+1, key)
+		}
+	}
+}
+
+func TestLoadCSEKeyFile(t *testing.T) {
+	dir, e := ioutil.TempDir("", "mc-cse-")
+	if e != nil {
+		t.Fatal(e)
+	}
+	defer os.RemoveAll(dir)
+
+	keyFile := filepath.Join(dir, "key")
+	if e = ioutil.WriteFile(keyFile, []byte("MzJieXRlc2xvbmdzZWNyZXRrZXltdXN0YmVnaXZlbjE=\n"), 0600); e != nil {
+		t.Fatal(e)
+	}
+	key, err := loadCSEKey("", keyFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(key) != "32byteslongsecretkeymustbegiven1" {
+		t.Errorf("unexpected key %q", key)
+	}
+	if _, err = loadCSEKey("32byteslongsecretkeymustbegiven1", keyFile); err == nil {
+		t.Error("expected an error when both a key and a key file are given")
+	}
+	if key, err = loadCSEKey("", ""); key != nil || err != nil {
+		t.Errorf("expected no key, got %q, %v", key, err)
+	}
+}
+
+func TestCSERoundTrip(t *testing.T) {
+	key := []byte("32byteslongsecretkeymustbegiven1")
+	data := bytes.Repeat([]byte("client-side encryption "), 10000)
+
+	metadata := map[string]string{"Content-Type": "text/plain"}
+	encReader, err := newCSEEncryptReader(bytes.NewReader(data), key, metadata)
+	if err != nil {
+		t.Fatal(err)
+	}
+	encData, e := ioutil.ReadAll(encReader)
+	if e != nil {
+		t.Fatal(e)
+	}
+	if !isCSEEncrypted(metadata) {
+		t.Fatalf("expected encryption metadata, got %v", metadata)
+	}
+	if int64(len(encData)) != cseEncryptedSize(int64(len(data))) {
+		t.Errorf("expected encrypted size %d, got %d", cseEncryptedSize(int64(len(data))), len(encData))
+	}
+	if cseDecryptedSize(int64(len(encData))) != int64(len(data)) {
+		t.Errorf("expected decrypted size %d, got %d", len(data), cseDecryptedSize(int64(len(encData))))
+	}
+
+	decReader, err := newCSEDecryptReader(bytes.NewReader(encData), key, metadata)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decData, e := ioutil.ReadAll(decReader)
+	if e != nil {
+		t.Fatal(e)
+	}
+	if !bytes.Equal(decData, data) {
+		t.Error("decrypted data does not match")
+	}
+
+	if _, err = newCSEDecryptReader(bytes.NewReader(encData), []byte("32byteslongsecretkeymustbegiven2"), metadata); err == nil {
+		t.Error("expected an error decrypting with the wrong key")
+	}
+	if _, err = newCSEDecryptReader(bytes.NewReader(encData), nil, metadata); err == nil {
+		t.Error("expected an error decrypting without a key")
+	}
+
+	removeCSEMetadata(metadata)
+	if isCSEEncrypted(metadata) || metadata["Content-Type"] != "text/plain" {
+		t.Errorf("unexpected metadata %v", metadata)
+	}
+}
@@ -17,6 +17,7 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"net/url"
 	"strings"
@@ -36,6 +37,15 @@ var (
 			Name:  "depth, d",
 			Usage: "print the total for a folder prefix only if it is N or fewer levels below the command line argument",
 		},
+		cli.BoolFlag{
+			Name:  "versions",
+			Usage: "include the size of noncurrent object versions",
+		},
+		cli.IntFlag{
+			Name:  "parallel",
+			Value: 8,
+			Usage: "number of folder prefixes listed in parallel",
+		},
 	}
 )
 
@@ -64,19 +74,27 @@ EXAMPLES:
 
    2. Summarize disk usage of 'louis' prefix in 'jazz-songs' bucket upto two levels.
       $ {{.HelpName}} --depth=2 s3/jazz-songs/louis/
+
+   3. Summarize disk usage of a versioned bucket including all noncurrent versions.
+      $ {{.HelpName}} --versions s3/backups
+
+   4. Summarize disk usage of a large bucket listing 32 prefixes in parallel, in JSON.
+      $ {{.HelpName}} --parallel 32 --json s3/datalake
 `,
 }
 
 // Structured message depending on the type of console.
 type duMessage struct {
-	Prefix string `json:"prefix"`
-	Size   string `json:"size"`
-	Status string `json:"status"`
+	Prefix   string `json:"prefix"`
+	Size     int64  `json:"size"`
+	Objects  int64  `json:"objects"`
+	Versions int64  `json:"versions,omitempty"`
+	Status   string `json:"status"`
 }
 
 // Colorized message for console printing.
 func (r duMessage) String() string {
-	return fmt.Sprintf("%s\t%s", console.Colorize("Size", r.Size),
+	return fmt.Sprintf("%s\t%s", console.Colorize("Size", strings.Join(strings.Fields(humanize.IBytes(uint64(r.Size))), "")),
 		console.Colorize("Prefix", r.Prefix))
 }
 
@@ -87,26 +105,89 @@ func (r duMessage) JSON() string {
 	return string(msgBytes)
 }
 
-func du(urlStr string, depth int, encKeyDB map[string][]prefixSSEPair) (int64, error) {
+// duUsage is the usage of a prefix and all its nested prefixes.
+type duUsage struct {
+	size     int64
+	objects  int64
+	versions int64
+
+	// messages of nested prefixes in listing order.
+	msgs []duMessage
+}
+
+// add accumulates the usage of a nested prefix.
+func (u *duUsage) add(v duUsage) {
+	u.size += v.size
+	u.objects += v.objects
+	u.versions += v.versions
+	u.msgs = append(u.msgs, v.msgs...)
+}
+
+// noncurrentUsage returns the size and the number of noncurrent versions.
+func noncurrentUsage(versions []objectVersion) (size, count int64) {
+	for _, version := range versions {
+		if version.IsLatest {
+			continue
+		}
+		size += version.Size
+		count++
+	}
+	return size, count
+}
+
+// duPending is a nested prefix being summarized.
+type duPending struct {
+	done  chan struct{}
+	usage duUsage
+	err   *probe.Error
+}
+
+// duLister summarizes prefixes, listing up to a fixed number of
+// nested prefixes in parallel.
+type duLister struct {
+	versions bool
+	sem      chan struct{}
+}
+
+// start summarizes urlStr in the background if a listing slot is
+// available, otherwise inline.
+func (d *duLister) start(urlStr string, depth int) *duPending {
+	p := &duPending{done: make(chan struct{})}
+	select {
+	case d.sem <- struct{}{}:
+		go func() {
+			defer func() { <-d.sem }()
+			p.usage, p.err = d.du(urlStr, depth)
+			close(p.done)
+		}()
+	default:
+		p.usage, p.err = d.du(urlStr, depth)
+		close(p.done)
+	}
+	return p
+}
+
+// du summarizes the usage of urlStr, prefixes up to depth levels below
+// are reported in the returned usage messages.
+func (d *duLister) du(urlStr string, depth int) (duUsage, *probe.Error) {
+	var usage duUsage
+
 	targetAlias, targetURL, _ := mustExpandAlias(urlStr)
 	if !strings.HasSuffix(targetURL, "/") {
 		targetURL += "/"
 	}
 
-	clnt, pErr := newClientFromAlias(targetAlias, targetURL)
-	if pErr != nil {
-		errorIf(pErr.Trace(urlStr), "Failed to summarize disk usage `"+urlStr+"`.")
-		return 0, exitStatus(globalErrorExitStatus) // End of journey.
+	clnt, err := newClientFromAlias(targetAlias, targetURL)
+	if err != nil {
+		return usage, err.Trace(urlStr)
 	}
 
+	var pending []*duPending
 	isRecursive := false
 	isIncomplete := false
-	contentCh := clnt.List(isRecursive, isIncomplete, DirFirst)
-	size := int64(0)
-	for content := range contentCh {
+	for content := range clnt.List(isRecursive, isIncomplete, DirFirst) {
 		if content.Err != nil {
-			errorIf(content.Err.Trace(urlStr), "Failed to find disk usage of `"+urlStr+"` recursively.")
-			return 0, exitStatus(globalErrorExitStatus)
+			return usage, content.Err.Trace(urlStr)
 		}
 
 		if content.URL.String() == targetURL {
@@ -123,30 +204,55 @@ func du(urlStr string, depth int, encKeyDB map[string][]prefixSSEPair) (int64, e
 			if targetAlias != "" {
 				subDirAlias = targetAlias + "/" + content.URL.Path
 			}
-			used, err := du(subDirAlias, depth, encKeyDB)
+			pending = append(pending, d.start(subDirAlias, depth))
+		} else {
+			usage.size += content.Size
+			usage.objects++
+		}
+	}
+
+	// Noncurrent versions of the objects at this level, nested
+	// prefixes account for their own versions.
+	if d.versions {
+		s3Clnt, ok := clnt.(*s3Client)
+		if !ok {
+			return usage, probe.NewError(errors.New("object versions are only available on S3 servers")).Trace(urlStr)
+		}
+		if bucket, _ := s3Clnt.url2BucketAndObject(); bucket != "" {
+			versions, err := s3Clnt.ListVersions(false)
 			if err != nil {
-				return 0, err
+				return usage, err.Trace(urlStr)
 			}
-			size += used
-		} else {
-			size += content.Size
+			size, count := noncurrentUsage(versions)
+			usage.size += size
+			usage.versions += count
 		}
 	}
 
+	for _, p := range pending {
+		<-p.done
+		if p.err != nil {
+			return usage, p.err
+		}
+		usage.add(p.usage)
+	}
+
 	if depth != 0 {
-		u, err := url.Parse(targetURL)
-		if err != nil {
-			panic(err)
+		u, e := url.Parse(targetURL)
+		if e != nil {
+			return usage, probe.NewError(e).Trace(targetURL)
 		}
 
-		printMsg(duMessage{
-			Prefix: strings.Trim(u.Path, "/"),
-			Size:   strings.Join(strings.Fields(humanize.IBytes(uint64(size))), ""),
-			Status: "success",
+		usage.msgs = append(usage.msgs, duMessage{
+			Prefix:   strings.Trim(u.Path, "/"),
+			Size:     usage.size,
+			Objects:  usage.objects,
+			Versions: usage.versions,
+			Status:   "success",
 		})
 	}
 
-	return size, nil
+	return usage, nil
 }
 
 // main for du command.
@@ -155,7 +261,7 @@ func mainDu(ctx *cli.Context) error {
 	console.SetColor("Size", color.New(color.FgYellow))
 
 	// Parse encryption keys per command.
-	encKeyDB, err := getEncKeys(ctx)
+	_, err := getEncKeys(ctx)
 	fatalIf(err, "Unable to parse encryption keys.")
 
 	// du specific flags.
@@ -163,14 +269,28 @@ func mainDu(ctx *cli.Context) error {
 	if depth == 0 {
 		depth = -1
 	}
+	if ctx.Int("parallel") < 1 {
+		fatalIf(errInvalidArgument().Trace(ctx.String("parallel")), "--parallel should be at least 1.")
+	}
 
-	// Set color.
-	console.SetColor("Remove", color.New(color.FgGreen, color.Bold))
+	lister := &duLister{
+		versions: ctx.Bool("versions"),
+		// One slot is held by the caller.
+		sem: make(chan struct{}, ctx.Int("parallel")-1),
+	}
 
 	var duErr error
 	for _, urlStr := range ctx.Args() {
-		if _, err := du(urlStr, depth, encKeyDB); duErr == nil {
-			duErr = err
+		usage, err := lister.du(urlStr, depth)
+		if err != nil {
+			errorIf(err.Trace(urlStr), "Failed to summarize disk usage `"+urlStr+"`.")
+			if duErr == nil {
+				duErr = exitStatus(globalErrorExitStatus)
+			}
+			continue
+		}
+		for _, msg := range usage.msgs {
+			printMsg(msg)
 		}
 	}
 
@@ -0,0 +1,53 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import "testing"
+
+func TestNoncurrentUsage(t *testing.T) {
+	testCases := []struct {
+		versions []objectVersion
+		size     int64
+		count    int64
+	}{
+		{nil, 0, 0},
+		{[]objectVersion{{Key: "a", IsLatest: true, Size: 10}}, 0, 0},
+		{[]objectVersion{
+			{Key: "a", IsLatest: true, Size: 10},
+			{Key: "a", Size: 20},
+			{Key: "b", Size: 5},
+			{Key: "b", IsLatest: true, Size: 1},
+		}, 25, 2},
+	}
+	for i, testCase := range testCases {
+		size, count := noncurrentUsage(testCase.versions)
+		if size != testCase.size || count != testCase.count {
+			t.Errorf("Test %d: Expected %d/%d, got %d/%d", i+1, testCase.size, testCase.count, size, count)
+		}
+	}
+}
+
+func TestDuUsageAdd(t *testing.T) {
+	usage := duUsage{size: 1, objects: 1, msgs: []duMessage{{Prefix: "a"}}}
+	usage.add(duUsage{size: 2, objects: 3, versions: 4, msgs: []duMessage{{Prefix: "b"}}})
+	if usage.size != 3 || usage.objects != 4 || usage.versions != 4 {
+		t.Fatalf("Unexpected usage %+v", usage)
+	}
+	if len(usage.msgs) != 2 || usage.msgs[0].Prefix != "a" || usage.msgs[1].Prefix != "b" {
+		t.Fatalf("Unexpected messages %+v", usage.msgs)
+	}
+}
@@ -0,0 +1,77 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/console"
+)
+
+var encryptInfoCmd = cli.Command{
+	Name:   "info",
+	Usage:  "show the default encryption of a bucket",
+	Action: mainEncryptInfo,
+	Before: setGlobalsFromContext,
+	Flags:  globalFlags,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} TARGET
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+  1. Show the default encryption of the bucket 'mybucket'.
+     $ {{.HelpName}} myminio/mybucket
+
+  2. Show the default encryption of the bucket 'mybucket' in JSON.
+     $ {{.HelpName}} --json s3/mybucket
+`,
+}
+
+// checkEncryptInfoSyntax - validate all the passed arguments
+func checkEncryptInfoSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) != 1 {
+		cli.ShowCommandHelpAndExit(ctx, "info", 1) // last argument is exit code
+	}
+}
+
+// mainEncryptInfo is the handle for "mc encrypt info" command.
+func mainEncryptInfo(ctx *cli.Context) error {
+	checkEncryptInfoSyntax(ctx)
+
+	console.SetColor("Encrypt", color.New(color.FgGreen, color.Bold))
+
+	targetURL := ctx.Args().First()
+	clnt, err := newEncryptClient(targetURL)
+	fatalIf(err, "Unable to initialize target `"+targetURL+"`.")
+
+	algorithm, keyID, err := clnt.GetBucketEncryption()
+	fatalIf(err.Trace(targetURL), "Unable to get the default encryption of `"+targetURL+"`.")
+
+	printMsg(encryptMessage{
+		op:        "info",
+		URL:       targetURL,
+		Type:      encryptType(algorithm),
+		Algorithm: algorithm,
+		KeyID:     keyID,
+	})
+	return nil
+}
@@ -0,0 +1,103 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"encoding/base64"
+	"errors"
+
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/console"
+	"github.com/minio/mc/pkg/probe"
+)
+
+var encryptKeyAddCmd = cli.Command{
+	Name:   "add",
+	Usage:  "save an SSE-C key for a bucket or prefix",
+	Action: mainEncryptKeyAdd,
+	Before: setGlobalsFromContext,
+	Flags:  globalFlags,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} TARGET KEY
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+DESCRIPTION:
+  KEY is 32 bytes of plain text or 44 bytes of base64 encoded text. Keys are saved
+  encrypted with the config password, the secret keys of the config have to be
+  encrypted first with 'mc config encrypt'. Commands such as cp, cat, stat and mirror
+  use the key of the longest matching prefix, keys passed with --encrypt-key or
+  MC_ENCRYPT_KEY take precedence.
+
+EXAMPLES:
+  1. Save a key for all objects of the bucket 'mybucket'.
+     $ {{.HelpName}} myminio/mybucket 32byteslongsecretkeymustbegiven1
+
+  2. Save a base64 encoded key for the prefix 'mybucket/backups'.
+     $ {{.HelpName}} myminio/mybucket/backups MzJieXRlc2xvbmdzZWNyZXRrZXltdXN0YmVnaXZlbjE=
+`,
+}
+
+// checkEncryptKeyAddSyntax - validate all the passed arguments
+func checkEncryptKeyAddSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) != 2 {
+		cli.ShowCommandHelpAndExit(ctx, "add", 1) // last argument is exit code
+	}
+}
+
+// mainEncryptKeyAdd is the handle for "mc encrypt key add" command.
+func mainEncryptKeyAdd(ctx *cli.Context) error {
+	checkEncryptKeyAddSyntax(ctx)
+
+	console.SetColor("EncryptKey", color.New(color.FgGreen))
+
+	args := ctx.Args()
+	alias, prefix, err := splitEncryptKeyTarget(args.Get(0))
+	fatalIf(err, "Expected ALIAS/BUCKET[/PREFIX], got `"+args.Get(0)+"`.")
+	key, err := decodeEncryptionKey(args.Get(1))
+	fatalIf(err, "Unable to parse encryption key.")
+
+	conf, err := loadMcConfig()
+	fatalIf(err.Trace(globalMCConfigVersion), "Unable to load config version `"+globalMCConfigVersion+"`.")
+	if !conf.Encrypted {
+		fatalIf(probe.NewError(errors.New("secret keys are not encrypted")), "Encryption keys are saved encrypted, please run `mc config encrypt` first.")
+	}
+	hostCfg, ok := conf.Hosts[alias]
+	if !ok {
+		fatalIf(errNoMatchingHost(alias).Trace(alias), "No such alias `"+alias+"` found.")
+	}
+
+	// Keys are saved base64 encoded, saveMcConfig encrypts them.
+	encryptKeys := make(map[string]string, len(hostCfg.EncryptKeys)+1)
+	for k, v := range hostCfg.EncryptKeys {
+		encryptKeys[k] = v
+	}
+	encryptKeys[prefix] = base64.StdEncoding.EncodeToString(key)
+	hostCfg.EncryptKeys = encryptKeys
+	conf.Hosts[alias] = hostCfg
+
+	err = saveMcConfig(conf)
+	fatalIf(err.Trace(alias), "Unable to save encryption keys in config version `"+globalMCConfigVersion+"`.")
+
+	printMsg(encryptKeyMessage{op: "add", Alias: alias, Prefix: prefix})
+	return nil
+}
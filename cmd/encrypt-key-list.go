@@ -0,0 +1,94 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"sort"
+
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/console"
+)
+
+var encryptKeyListCmd = cli.Command{
+	Name:   "list",
+	Usage:  "list buckets and prefixes with a saved SSE-C key",
+	Action: mainEncryptKeyList,
+	Before: setGlobalsFromContext,
+	Flags:  globalFlags,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [ALIAS]
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+DESCRIPTION:
+  Only the buckets and prefixes are listed, keys are never displayed.
+
+EXAMPLES:
+  1. List the prefixes with a saved key for all aliases.
+     $ {{.HelpName}}
+
+  2. List the prefixes with a saved key for the alias 'myminio'.
+     $ {{.HelpName}} myminio
+`,
+}
+
+// checkEncryptKeyListSyntax - validate all the passed arguments
+func checkEncryptKeyListSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) > 1 {
+		cli.ShowCommandHelpAndExit(ctx, "list", 1) // last argument is exit code
+	}
+}
+
+// mainEncryptKeyList is the handle for "mc encrypt key list" command.
+func mainEncryptKeyList(ctx *cli.Context) error {
+	checkEncryptKeyListSyntax(ctx)
+
+	console.SetColor("EncryptKey", color.New(color.FgCyan, color.Bold))
+
+	conf, err := loadMcConfig()
+	fatalIf(err.Trace(globalMCConfigVersion), "Unable to load config version `"+globalMCConfigVersion+"`.")
+
+	var aliases []string
+	if alias := ctx.Args().First(); alias != "" {
+		if _, ok := conf.Hosts[alias]; !ok {
+			fatalIf(errNoMatchingHost(alias).Trace(alias), "No such alias `"+alias+"` found.")
+		}
+		aliases = append(aliases, alias)
+	} else {
+		for alias := range conf.Hosts {
+			aliases = append(aliases, alias)
+		}
+		sort.Strings(aliases)
+	}
+
+	for _, alias := range aliases {
+		prefixes := make([]string, 0, len(conf.Hosts[alias].EncryptKeys))
+		for prefix := range conf.Hosts[alias].EncryptKeys {
+			prefixes = append(prefixes, prefix)
+		}
+		sort.Strings(prefixes)
+		for _, prefix := range prefixes {
+			printMsg(encryptKeyMessage{op: "list", Alias: alias, Prefix: prefix})
+		}
+	}
+	return nil
+}
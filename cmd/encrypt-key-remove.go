@@ -0,0 +1,90 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/console"
+)
+
+var encryptKeyRemoveCmd = cli.Command{
+	Name:   "remove",
+	Usage:  "remove a saved SSE-C key",
+	Action: mainEncryptKeyRemove,
+	Before: setGlobalsFromContext,
+	Flags:  globalFlags,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} TARGET
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+  1. Remove the key saved for the prefix 'mybucket/backups'.
+     $ {{.HelpName}} myminio/mybucket/backups
+`,
+}
+
+// checkEncryptKeyRemoveSyntax - validate all the passed arguments
+func checkEncryptKeyRemoveSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) != 1 {
+		cli.ShowCommandHelpAndExit(ctx, "remove", 1) // last argument is exit code
+	}
+}
+
+// mainEncryptKeyRemove is the handle for "mc encrypt key remove" command.
+func mainEncryptKeyRemove(ctx *cli.Context) error {
+	checkEncryptKeyRemoveSyntax(ctx)
+
+	console.SetColor("EncryptKey", color.New(color.FgGreen))
+
+	target := ctx.Args().First()
+	alias, prefix, err := splitEncryptKeyTarget(target)
+	fatalIf(err, "Expected ALIAS/BUCKET[/PREFIX], got `"+target+"`.")
+
+	conf, err := loadMcConfig()
+	fatalIf(err.Trace(globalMCConfigVersion), "Unable to load config version `"+globalMCConfigVersion+"`.")
+	hostCfg, ok := conf.Hosts[alias]
+	if !ok {
+		fatalIf(errNoMatchingHost(alias).Trace(alias), "No such alias `"+alias+"` found.")
+	}
+	if _, ok = hostCfg.EncryptKeys[prefix]; !ok {
+		fatalIf(errInvalidArgument().Trace(target), "No encryption key saved for `"+target+"`.")
+	}
+
+	encryptKeys := make(map[string]string, len(hostCfg.EncryptKeys))
+	for k, v := range hostCfg.EncryptKeys {
+		if k != prefix {
+			encryptKeys[k] = v
+		}
+	}
+	if len(encryptKeys) == 0 {
+		encryptKeys = nil
+	}
+	hostCfg.EncryptKeys = encryptKeys
+	conf.Hosts[alias] = hostCfg
+
+	err = saveMcConfig(conf)
+	fatalIf(err.Trace(alias), "Unable to save encryption keys in config version `"+globalMCConfigVersion+"`.")
+
+	printMsg(encryptKeyMessage{op: "remove", Alias: alias, Prefix: prefix})
+	return nil
+}
@@ -0,0 +1,138 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"encoding/base64"
+	"sort"
+	"strings"
+
+	"github.com/minio/cli"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/console"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio-go/v6/pkg/encrypt"
+)
+
+var encryptKeyCmd = cli.Command{
+	Name:   "key",
+	Usage:  "manage SSE-C keys saved in configuration file",
+	Action: mainEncryptKey,
+	Before: setGlobalsFromContext,
+	Flags:  globalFlags,
+	Subcommands: []cli.Command{
+		encryptKeyAddCmd,
+		encryptKeyRemoveCmd,
+		encryptKeyListCmd,
+	},
+	HideHelpCommand: true,
+}
+
+// mainEncryptKey is the handle for "mc encrypt key" command.
+func mainEncryptKey(ctx *cli.Context) error {
+	cli.ShowCommandHelp(ctx, ctx.Args().First())
+	return nil
+	// Sub-commands like "add", "remove", "list" have their own main.
+}
+
+// encryptKeyMessage container for a saved SSE-C key, the key itself
+// is never displayed.
+type encryptKeyMessage struct {
+	op     string
+	Status string `json:"status"`
+	Alias  string `json:"alias"`
+	Prefix string `json:"prefix"`
+}
+
+// String colorized encrypt key message.
+func (e encryptKeyMessage) String() string {
+	target := e.Alias + "/" + e.Prefix
+	switch e.op {
+	case "add":
+		return console.Colorize("EncryptKey", "Added encryption key for `"+target+"`.")
+	case "remove":
+		return console.Colorize("EncryptKey", "Removed encryption key for `"+target+"`.")
+	}
+	return console.Colorize("EncryptKey", target)
+}
+
+// JSON jsonified encrypt key message.
+func (e encryptKeyMessage) JSON() string {
+	e.Status = "success"
+	encryptKeyMessageBytes, err := json.MarshalIndent(e, "", " ")
+	fatalIf(probe.NewError(err), "Unable to marshal into JSON.")
+
+	return string(encryptKeyMessageBytes)
+}
+
+// splitEncryptKeyTarget returns the alias and the bucket or prefix of
+// an ALIAS/BUCKET[/PREFIX] target.
+func splitEncryptKeyTarget(target string) (alias, prefix string, err *probe.Error) {
+	alias, prefix = url2Alias(target)
+	prefix = strings.Trim(prefix, "/")
+	if !isValidAlias(alias) || prefix == "" {
+		return "", "", errInvalidArgument().Trace(target)
+	}
+	return alias, prefix, nil
+}
+
+// sortedEncryptKeyPrefixes returns the prefixes of keys, longest first
+// so that the most specific key of a resource is found first.
+func sortedEncryptKeyPrefixes(keys map[string]string) []string {
+	prefixes := make([]string, 0, len(keys))
+	for prefix := range keys {
+		prefixes = append(prefixes, prefix)
+	}
+	sort.Slice(prefixes, func(i, j int) bool {
+		if len(prefixes[i]) != len(prefixes[j]) {
+			return len(prefixes[i]) > len(prefixes[j])
+		}
+		return prefixes[i] < prefixes[j]
+	})
+	return prefixes
+}
+
+// getConfigEncKeys returns the SSE-C keys saved in the config for an
+// alias, decrypting them with the config password.
+func getConfigEncKeys(alias string) ([]prefixSSEPair, *probe.Error) {
+	mcCfg, err := loadMcConfig()
+	if err != nil {
+		// Config errors are reported once the alias is used.
+		return nil, nil
+	}
+	keys := mcCfg.Hosts[alias].EncryptKeys
+	pairs := make([]prefixSSEPair, 0, len(keys))
+	for _, prefix := range sortedEncryptKeyPrefixes(keys) {
+		key, err := decryptConfigSecret(keys[prefix])
+		if err != nil {
+			return nil, err.Trace(alias, prefix)
+		}
+		decodedKey, e := base64.StdEncoding.DecodeString(key)
+		if e != nil {
+			return nil, probe.NewError(e).Trace(alias, prefix)
+		}
+		sse, e := encrypt.NewSSEC(decodedKey)
+		if e != nil {
+			return nil, probe.NewError(e).Trace(alias, prefix)
+		}
+		pairs = append(pairs, prefixSSEPair{
+			Prefix: alias + "/" + prefix,
+			SSE:    sse,
+		})
+	}
+	return pairs, nil
+}
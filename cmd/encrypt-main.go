@@ -0,0 +1,135 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"errors"
+
+	"github.com/minio/cli"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/console"
+	"github.com/minio/mc/pkg/probe"
+)
+
+var (
+	encryptFlags = []cli.Flag{}
+)
+
+var encryptCmd = cli.Command{
+	Name:            "encrypt",
+	Usage:           "manage the encryption of buckets and objects",
+	HideHelpCommand: true,
+	Action:          mainEncrypt,
+	Before:          setGlobalsFromContext,
+	Flags:           append(encryptFlags, globalFlags...),
+	Subcommands: []cli.Command{
+		encryptSetCmd,
+		encryptInfoCmd,
+		encryptClearCmd,
+		encryptRotateCmd,
+		encryptKeyCmd,
+	},
+}
+
+// mainEncrypt is the handle for "mc encrypt" command.
+func mainEncrypt(ctx *cli.Context) error {
+	cli.ShowCommandHelp(ctx, ctx.Args().First())
+	return nil
+	// Sub-commands like "set", "info", "clear", "rotate", "key" have their own main.
+}
+
+// Default bucket encryption types supported by encrypt commands.
+const (
+	encryptSSES3  = "sse-s3"
+	encryptSSEKMS = "sse-kms"
+	encryptNone   = "none"
+)
+
+// encryptAlgorithm returns the S3 algorithm of an encryption type.
+func encryptAlgorithm(sseType string) (string, bool) {
+	switch sseType {
+	case encryptSSES3:
+		return sseAlgorithmAES256, true
+	case encryptSSEKMS:
+		return sseAlgorithmKMS, true
+	}
+	return "", false
+}
+
+// encryptType returns the encryption type of an S3 algorithm, unknown
+// algorithms are returned as is.
+func encryptType(algorithm string) string {
+	switch algorithm {
+	case "":
+		return encryptNone
+	case sseAlgorithmAES256:
+		return encryptSSES3
+	case sseAlgorithmKMS:
+		return encryptSSEKMS
+	}
+	return algorithm
+}
+
+// encryptMessage container for the default encryption of a bucket.
+type encryptMessage struct {
+	op        string
+	Status    string `json:"status"`
+	URL       string `json:"url"`
+	Type      string `json:"type"`
+	Algorithm string `json:"algorithm,omitempty"`
+	KeyID     string `json:"keyId,omitempty"`
+}
+
+// String colorized encrypt message.
+func (e encryptMessage) String() string {
+	encryption := e.Type
+	if e.KeyID != "" {
+		encryption += " with key `" + e.KeyID + "`"
+	}
+	switch e.op {
+	case "set":
+		return console.Colorize("Encrypt", "Default encryption of `"+e.URL+"` is set to "+encryption+".")
+	case "clear":
+		return console.Colorize("Encrypt", "Default encryption of `"+e.URL+"` is removed.")
+	}
+	return console.Colorize("Encrypt", "Default encryption of `"+e.URL+"`: "+encryption)
+}
+
+// JSON jsonified encrypt message.
+func (e encryptMessage) JSON() string {
+	e.Status = "success"
+	encryptMessageBytes, err := json.MarshalIndent(e, "", " ")
+	fatalIf(probe.NewError(err), "Unable to marshal into JSON.")
+
+	return string(encryptMessageBytes)
+}
+
+// newEncryptClient returns the client of the bucket at targetURL.
+func newEncryptClient(targetURL string) (*s3Client, *probe.Error) {
+	clnt, err := newClient(targetURL)
+	if err != nil {
+		return nil, err.Trace(targetURL)
+	}
+	s3Clnt, ok := clnt.(*s3Client)
+	if !ok {
+		return nil, probe.NewError(APINotImplemented{API: "BucketEncryption", APIType: "filesystem"}).Trace(targetURL)
+	}
+	if _, object := s3Clnt.url2BucketAndObject(); object != "" {
+		return nil, probe.NewError(errors.New("default encryption is set on buckets, not on objects")).Trace(targetURL)
+	}
+	return s3Clnt, nil
+}
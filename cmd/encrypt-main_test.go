@@ -0,0 +1,148 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"encoding/xml"
+	"reflect"
+	"testing"
+
+	"github.com/minio/minio-go/v6/pkg/encrypt"
+)
+
+func TestEncryptAlgorithm(t *testing.T) {
+	testCases := []struct {
+		sseType   string
+		algorithm string
+		ok        bool
+	}{
+		{encryptSSES3, sseAlgorithmAES256, true},
+		{encryptSSEKMS, sseAlgorithmKMS, true},
+		{encryptNone, "", false},
+		{"SSE-C", "", false},
+	}
+	for i, testCase := range testCases {
+		algorithm, ok := encryptAlgorithm(testCase.sseType)
+		if algorithm != testCase.algorithm || ok != testCase.ok {
+			t.Errorf("Test %d: expected (%s, %v), got (%s, %v)", i+1, testCase.algorithm, testCase.ok, algorithm, ok)
+		}
+		if ok && encryptType(algorithm) != testCase.sseType {
+			t.Errorf("Test %d: expected type %s, got %s", i+1, testCase.sseType, encryptType(algorithm))
+		}
+	}
+	if encryptType("") != encryptNone {
+		t.Errorf("expected type %s for no algorithm, got %s", encryptNone, encryptType(""))
+	}
+}
+
+func TestBucketEncryptionXML(t *testing.T) {
+	data := `<ServerSideEncryptionConfiguration xmlns="http://s3.amazonaws.com/doc/2006-03-01/"><Rule><ApplyServerSideEncryptionByDefault><SSEAlgorithm>aws:kms</SSEAlgorithm><KMSMasterKeyID>my-minio-key</KMSMasterKeyID></ApplyServerSideEncryptionByDefault></Rule></ServerSideEncryptionConfiguration>`
+	var config bucketEncryption
+	if err := xml.Unmarshal([]byte(data), &config); err != nil {
+		t.Fatal(err)
+	}
+	if len(config.Rules) != 1 || config.Rules[0].SSEAlgorithm != sseAlgorithmKMS || config.Rules[0].KMSMasterKeyID != "my-minio-key" {
+		t.Fatalf("unexpected rules %v", config.Rules)
+	}
+	out, err := xml.Marshal(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(out) != data {
+		t.Errorf("expected %s, got %s", data, out)
+	}
+}
+
+func TestParseRotateKey(t *testing.T) {
+	testCases := []struct {
+		key string
+		ok  bool
+	}{
+		{"32byteslongsecretkeymustbegiven1", true},
+		{"MzJieXRlc2xvbmdzZWNyZXRrZXltdXN0YmVnaXZlbjE=", true},
+		{"32byteslongsecretkey", false},
+		{"", false},
+	}
+	for i, testCase := range testCases {
+		sse, err := parseRotateKey(testCase.key)
+		if (err == nil) != testCase.ok {
+			t.Errorf("Test %d: expected ok %v, got %v", i+1, testCase.ok, err)
+			continue
+		}
+		if err == nil && sse.Type() != encrypt.SSEC {
+			t.Errorf("Test %d: expected SSE-C, got %v", i+1, sse.Type())
+		}
+	}
+}
+
+func TestRotateMetadata(t *testing.T) {
+	metadata := map[string]string{
+		"Content-Type":        "text/plain",
+		"Cache-Control":       "max-age=60",
+		"X-Amz-Meta-Owner":    "backup",
+		"Last-Modified":       "Mon, 02 Dec 2019 10:00:00 GMT",
+		"Content-Length":      "42",
+		"Etag":                "\"d41d8cd98f00b204e9800998ecf8427e\"",
+		"X-Amz-Storage-Class": "REDUCED_REDUNDANCY",
+	}
+	expected := map[string]string{
+		"Content-Type":        "text/plain",
+		"Cache-Control":       "max-age=60",
+		"X-Amz-Meta-Owner":    "backup",
+		"X-Amz-Storage-Class": "REDUCED_REDUNDANCY",
+	}
+	if preserved := rotateMetadata(metadata); !reflect.DeepEqual(preserved, expected) {
+		t.Errorf("expected %v, got %v", expected, preserved)
+	}
+}
+
+func TestSplitEncryptKeyTarget(t *testing.T) {
+	testCases := []struct {
+		target string
+		alias  string
+		prefix string
+		ok     bool
+	}{
+		{"myminio/mybucket", "myminio", "mybucket", true},
+		{"myminio/mybucket/backups/", "myminio", "mybucket/backups", true},
+		{"myminio", "", "", false},
+		{"myminio/", "", "", false},
+	}
+	for i, testCase := range testCases {
+		alias, prefix, err := splitEncryptKeyTarget(testCase.target)
+		if (err == nil) != testCase.ok {
+			t.Errorf("Test %d: expected ok %v, got %v", i+1, testCase.ok, err)
+			continue
+		}
+		if alias != testCase.alias || prefix != testCase.prefix {
+			t.Errorf("Test %d: expected (%s, %s), got (%s, %s)", i+1, testCase.alias, testCase.prefix, alias, prefix)
+		}
+	}
+}
+
+func TestSortedEncryptKeyPrefixes(t *testing.T) {
+	keys := map[string]string{
+		"mybucket":             "",
+		"mybucket/backups/db":  "",
+		"mybucket/backups":     "",
+		"otherbucket/archives": "",
+	}
+	expected := []string{"otherbucket/archives", "mybucket/backups/db", "mybucket/backups", "mybucket"}
+	if prefixes := sortedEncryptKeyPrefixes(keys); !reflect.DeepEqual(prefixes, expected) {
+		t.Errorf("expected %v, got %v", expected, prefixes)
+	}
+}
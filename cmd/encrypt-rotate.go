@@ -0,0 +1,260 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/dustin/go-humanize"
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/console"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio-go/v6/pkg/encrypt"
+)
+
+var (
+	encryptRotateFlags = []cli.Flag{
+		cli.StringFlag{
+			Name:  "old-key",
+			Usage: "current SSE-C key of the objects, 32 bytes plain text or 44 bytes base64 encoded",
+		},
+		cli.StringFlag{
+			Name:  "new-key",
+			Usage: "new SSE-C key of the objects, 32 bytes plain text or 44 bytes base64 encoded",
+		},
+		cli.BoolFlag{
+			Name:  "dry-run",
+			Usage: "only report the objects that would be re-encrypted",
+		},
+	}
+)
+
+var encryptRotateCmd = cli.Command{
+	Name:   "rotate",
+	Usage:  "re-encrypt SSE-C objects with a new key",
+	Action: mainEncryptRotate,
+	Before: setGlobalsFromContext,
+	Flags:  append(encryptRotateFlags, globalFlags...),
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} --old-key KEY --new-key KEY TARGET
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+DESCRIPTION:
+  All objects under TARGET are re-encrypted by the server, the data is not
+  downloaded. Objects already encrypted with the new key are skipped, an
+  interrupted rotation is resumed by running the same command again.
+
+EXAMPLES:
+  1. Re-encrypt all objects under 'mybucket/backups/' with a new key.
+     $ {{.HelpName}} --old-key 32byteslongsecretkeymustbegiven1 --new-key 32byteslongsecretkeymustbegiven2 myminio/mybucket/backups/
+
+  2. Report the objects of 'mybucket' that would be re-encrypted, with base64 encoded keys.
+     $ {{.HelpName}} --dry-run --old-key MzJieXRlc2xvbmdzZWNyZXRrZXltdXN0YmVnaXZlbjE= \
+         --new-key MzJieXRlc2xvbmdzZWNyZXRrZXltdXN0YmVnaXZlbjI= myminio/mybucket
+`,
+}
+
+// Status of an object after a key rotation.
+const (
+	rotateStatusRotated        = "rotated"
+	rotateStatusWouldRotate    = "would rotate"
+	rotateStatusAlreadyRotated = "already rotated"
+)
+
+// encryptRotateObjectMessage container for the key rotation of an object.
+type encryptRotateObjectMessage struct {
+	Status string `json:"status"`
+	Key    string `json:"key"`
+	Size   int64  `json:"size"`
+	Result string `json:"result"`
+}
+
+// String colorized key rotation message of an object.
+func (r encryptRotateObjectMessage) String() string {
+	return console.Colorize("EncryptRotate", "`"+r.Key+"` ("+humanize.IBytes(uint64(r.Size))+") "+r.Result+".")
+}
+
+// JSON jsonified key rotation message of an object.
+func (r encryptRotateObjectMessage) JSON() string {
+	r.Status = "success"
+	rotateMessageBytes, e := json.MarshalIndent(r, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(rotateMessageBytes)
+}
+
+// encryptRotateMessage container for the summary of a key rotation.
+type encryptRotateMessage struct {
+	Status         string `json:"status"`
+	URL            string `json:"url"`
+	DryRun         bool   `json:"dryRun,omitempty"`
+	Rotated        int    `json:"rotated"`
+	AlreadyRotated int    `json:"alreadyRotated"`
+	Failed         int    `json:"failed"`
+	Size           int64  `json:"size"`
+}
+
+// String colorized summary of a key rotation.
+func (r encryptRotateMessage) String() string {
+	rotated := "Re-encrypted "
+	if r.DryRun {
+		rotated = "Would re-encrypt "
+	}
+	msg := rotated + strconv.Itoa(r.Rotated) + " object(s) (" + humanize.IBytes(uint64(r.Size)) + ") under `" + r.URL + "`"
+	msg += ", " + strconv.Itoa(r.AlreadyRotated) + " already encrypted with the new key"
+	if r.Failed > 0 {
+		msg += ", " + strconv.Itoa(r.Failed) + " failed"
+	}
+	return console.Colorize("EncryptRotate", msg+".")
+}
+
+// JSON jsonified summary of a key rotation.
+func (r encryptRotateMessage) JSON() string {
+	r.Status = "success"
+	rotateMessageBytes, e := json.MarshalIndent(r, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(rotateMessageBytes)
+}
+
+// parseRotateKey returns the SSE-C key of a 32 bytes plain text or
+// 44 bytes base64 encoded key.
+func parseRotateKey(key string) (encrypt.ServerSide, *probe.Error) {
+	decodedKey, err := decodeEncryptionKey(key)
+	if err != nil {
+		return nil, err
+	}
+	sse, e := encrypt.NewSSEC(decodedKey)
+	if e != nil {
+		return nil, probe.NewError(e)
+	}
+	return sse, nil
+}
+
+// rotateMetadata returns the metadata of an object to preserve when
+// copying it onto itself, response headers such as Last-Modified are
+// dropped.
+func rotateMetadata(metadata map[string]string) map[string]string {
+	preserved := make(map[string]string)
+	for k, v := range metadata {
+		key := http.CanonicalHeaderKey(k)
+		switch {
+		case strings.HasPrefix(key, "X-Amz-Meta-"):
+		case key == "Content-Type", key == "Content-Encoding", key == "Content-Disposition",
+			key == "Content-Language", key == "Cache-Control", key == "X-Amz-Storage-Class":
+		default:
+			continue
+		}
+		preserved[k] = v
+	}
+	return preserved
+}
+
+// rotateObject re-encrypts an object with the new key, objects already
+// encrypted with the new key are left untouched.
+func rotateObject(alias string, content *clientContent, oldKey, newKey encrypt.ServerSide, dryRun bool) (string, *probe.Error) {
+	urlStr := content.URL.String()
+	clnt, err := newClientFromAlias(alias, urlStr)
+	if err != nil {
+		return "", err.Trace(urlStr)
+	}
+	if _, err = clnt.Stat(false, false, newKey); err == nil {
+		return rotateStatusAlreadyRotated, nil
+	}
+	st, err := clnt.Stat(false, true, oldKey)
+	if err != nil {
+		return "", err.Trace(urlStr)
+	}
+	if dryRun {
+		return rotateStatusWouldRotate, nil
+	}
+	// Copy the object onto itself, the server decrypts it with the
+	// old key and encrypts it again with the new one.
+	if err = clnt.Copy(content.URL.Path, st.Size, nil, oldKey, newKey, rotateMetadata(st.Metadata)); err != nil {
+		return "", err.Trace(urlStr)
+	}
+	return rotateStatusRotated, nil
+}
+
+// checkEncryptRotateSyntax - validate all the passed arguments
+func checkEncryptRotateSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) != 1 || ctx.String("old-key") == "" || ctx.String("new-key") == "" {
+		cli.ShowCommandHelpAndExit(ctx, "rotate", 1) // last argument is exit code
+	}
+}
+
+// mainEncryptRotate is the handle for "mc encrypt rotate" command.
+func mainEncryptRotate(ctx *cli.Context) error {
+	checkEncryptRotateSyntax(ctx)
+
+	console.SetColor("EncryptRotate", color.New(color.FgGreen, color.Bold))
+
+	oldKey, err := parseRotateKey(ctx.String("old-key"))
+	fatalIf(err, "Unable to parse the old key.")
+	newKey, err := parseRotateKey(ctx.String("new-key"))
+	fatalIf(err, "Unable to parse the new key.")
+	if ctx.String("old-key") == ctx.String("new-key") {
+		fatalIf(errInvalidArgument(), "The old and the new key are the same.")
+	}
+
+	targetURL := ctx.Args().First()
+	alias, _ := url2Alias(targetURL)
+	clnt, err := newClient(targetURL)
+	fatalIf(err, "Unable to initialize target `"+targetURL+"`.")
+	if _, ok := clnt.(*s3Client); !ok {
+		fatalIf(probe.NewError(APINotImplemented{API: "SSE-C", APIType: "filesystem"}).Trace(targetURL), "Unable to rotate keys of `"+targetURL+"`.")
+	}
+
+	summary := encryptRotateMessage{URL: targetURL, DryRun: ctx.Bool("dry-run")}
+	for content := range clnt.List(true, false, DirNone) {
+		if content.Err != nil {
+			errorIf(content.Err.Trace(targetURL), "Unable to list `"+targetURL+"`.")
+			summary.Failed++
+			continue
+		}
+		key := strings.TrimPrefix(content.URL.Path, string(content.URL.Separator))
+		result, err := rotateObject(alias, content, oldKey, newKey, summary.DryRun)
+		if err != nil {
+			errorIf(err, "Unable to re-encrypt `"+key+"`.")
+			summary.Failed++
+			continue
+		}
+		switch result {
+		case rotateStatusAlreadyRotated:
+			summary.AlreadyRotated++
+		default:
+			summary.Rotated++
+			summary.Size += content.Size
+		}
+		printMsg(encryptRotateObjectMessage{Key: key, Size: content.Size, Result: result})
+	}
+	printMsg(summary)
+
+	if summary.Failed > 0 {
+		return exitStatus(globalErrorExitStatus)
+	}
+	return nil
+}
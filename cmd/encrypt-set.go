@@ -0,0 +1,99 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/console"
+)
+
+var encryptSetCmd = cli.Command{
+	Name:   "set",
+	Usage:  "set the default encryption of a bucket",
+	Action: mainEncryptSet,
+	Before: setGlobalsFromContext,
+	Flags:  globalFlags,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} TYPE [KEYID] TARGET
+
+TYPE:
+  sse-s3   encrypt objects with keys managed by the server
+  sse-kms  encrypt objects with the KMS key KEYID
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+DESCRIPTION:
+  Objects uploaded to the bucket without encryption headers are encrypted with
+  the default encryption, objects uploaded earlier are left as they are.
+
+EXAMPLES:
+  1. Encrypt new objects of the bucket 'mybucket' with keys managed by the server.
+     $ {{.HelpName}} sse-s3 myminio/mybucket
+
+  2. Encrypt new objects of the bucket 'mybucket' with the KMS key 'my-minio-key'.
+     $ {{.HelpName}} sse-kms my-minio-key s3/mybucket
+`,
+}
+
+// checkEncryptSetSyntax - validate all the passed arguments
+func checkEncryptSetSyntax(ctx *cli.Context) {
+	args := ctx.Args()
+	if len(args) < 2 || len(args) > 3 {
+		cli.ShowCommandHelpAndExit(ctx, "set", 1) // last argument is exit code
+	}
+	if _, ok := encryptAlgorithm(args.First()); !ok {
+		fatalIf(errInvalidArgument().Trace(args.First()), "Unknown encryption type `"+args.First()+"`, use sse-s3 or sse-kms.")
+	}
+	if (args.First() == encryptSSEKMS) != (len(args) == 3) {
+		cli.ShowCommandHelpAndExit(ctx, "set", 1) // last argument is exit code
+	}
+}
+
+// mainEncryptSet is the handle for "mc encrypt set" command.
+func mainEncryptSet(ctx *cli.Context) error {
+	checkEncryptSetSyntax(ctx)
+
+	console.SetColor("Encrypt", color.New(color.FgGreen, color.Bold))
+
+	args := ctx.Args()
+	sseType := args.First()
+	algorithm, _ := encryptAlgorithm(sseType)
+	var keyID string
+	if sseType == encryptSSEKMS {
+		keyID = args.Get(1)
+	}
+	targetURL := args.Get(len(args) - 1)
+
+	clnt, err := newEncryptClient(targetURL)
+	fatalIf(err, "Unable to initialize target `"+targetURL+"`.")
+
+	fatalIf(clnt.SetBucketEncryption(algorithm, keyID).Trace(targetURL), "Unable to set the default encryption of `"+targetURL+"`.")
+
+	printMsg(encryptMessage{
+		op:        "set",
+		URL:       targetURL,
+		Type:      sseType,
+		Algorithm: algorithm,
+		KeyID:     keyID,
+	})
+	return nil
+}
@@ -51,6 +51,8 @@ func fatalIf(err *probe.Error, msg string, data ...interface{}) {
 }
 
 func fatal(err *probe.Error, msg string, data ...interface{}) {
+	logError("fatal", err, fmt.Sprintf(msg, data...))
+	globalSummary.addError(err, fmt.Sprintf(msg, data...), true)
 	if globalJSON {
 		errorMsg := errorMessage{
 			Message: msg,
@@ -122,6 +124,8 @@ func errorIf(err *probe.Error, msg string, data ...interface{}) {
 	if err == nil {
 		return
 	}
+	logError("error", err, fmt.Sprintf(msg, data...))
+	globalSummary.addError(err, fmt.Sprintf(msg, data...), false)
 	if globalJSON {
 		errorMsg := errorMessage{
 			Message: fmt.Sprintf(msg, data...),
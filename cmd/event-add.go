@@ -31,7 +31,7 @@ var (
 		cli.StringFlag{
 			Name:  "event",
 			Value: "put,delete,get",
-			Usage: "filter specific type of event. Defaults to all event",
+			Usage: "filter specific type of event, comma separated list of put, delete and get",
 		},
 		cli.StringFlag{
 			Name:  "prefix",
@@ -80,6 +80,8 @@ func checkEventAddSyntax(ctx *cli.Context) {
 	if len(ctx.Args()) != 2 {
 		cli.ShowCommandHelpAndExit(ctx, "add", 1) // last argument is exit code
 	}
+	events := strings.Split(ctx.String("event"), ",")
+	fatalIf(checkWatchEvents(events), "Invalid event types, supported types are [put, delete, get].")
 }
 
 // eventAddMessage container
@@ -18,6 +18,7 @@ package cmd
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/fatih/color"
 	"github.com/minio/cli"
@@ -40,7 +41,7 @@ var eventListCmd = cli.Command{
   {{.HelpName}} - {{.Usage}}
 
 USAGE:
-  {{.HelpName}} TARGET ARN [FLAGS]
+  {{.HelpName}} TARGET [ARN] [FLAGS]
 
 FLAGS:
   {{range .VisibleFlags}}{{.}}
@@ -51,6 +52,9 @@ EXAMPLES:
 
   2. List all notification configurations
     $ {{.HelpName}} s3/mybucket
+
+  3. List all notification configurations in JSON format
+    $ {{.HelpName}} --json s3/mybucket
 `,
 }
 
@@ -69,6 +73,10 @@ type eventListMessage struct {
 	Prefix string   `json:"prefix"`
 	Suffix string   `json:"suffix"`
 	Arn    string   `json:"arn"`
+
+	// Column widths used to align the configurations of a bucket.
+	arnWidth   int
+	eventWidth int
 }
 
 func (u eventListMessage) JSON() string {
@@ -79,23 +87,48 @@ func (u eventListMessage) JSON() string {
 }
 
 func (u eventListMessage) String() string {
-	msg := console.Colorize("ARN", fmt.Sprintf("%s   ", u.Arn))
-	for i, event := range u.Event {
-		msg += console.Colorize("Event", event)
-		if i != len(u.Event)-1 {
-			msg += ","
-		}
-	}
-	msg += console.Colorize("Filter", fmt.Sprintf("   Filter: "))
+	msg := console.Colorize("ARN", fmt.Sprintf("%-*s   ", u.arnWidth, u.Arn))
+	msg += console.Colorize("Event", fmt.Sprintf("%-*s", u.eventWidth, strings.Join(u.Event, ",")))
+	var filters []string
 	if u.Prefix != "" {
-		msg += console.Colorize("Filter", fmt.Sprintf("prefix=\"%s\"", u.Prefix))
+		filters = append(filters, fmt.Sprintf("prefix=\"%s\"", u.Prefix))
 	}
 	if u.Suffix != "" {
-		msg += console.Colorize("Filter", fmt.Sprintf("suffix=\"%s\"", u.Suffix))
+		filters = append(filters, fmt.Sprintf("suffix=\"%s\"", u.Suffix))
+	}
+	if len(filters) > 0 {
+		msg += console.Colorize("Filter", "   Filter: "+strings.Join(filters, ", "))
 	}
 	return msg
 }
 
+// eventListMessages converts notification configurations into messages
+// sharing the same column widths.
+func eventListMessages(configs []notificationConfig) []eventListMessage {
+	var arnWidth, eventWidth int
+	for _, config := range configs {
+		if len(config.Arn) > arnWidth {
+			arnWidth = len(config.Arn)
+		}
+		if n := len(strings.Join(config.Events, ",")); n > eventWidth {
+			eventWidth = n
+		}
+	}
+	var msgs []eventListMessage
+	for _, config := range configs {
+		msgs = append(msgs, eventListMessage{
+			Event:      config.Events,
+			Prefix:     config.Prefix,
+			Suffix:     config.Suffix,
+			Arn:        config.Arn,
+			ID:         config.ID,
+			arnWidth:   arnWidth,
+			eventWidth: eventWidth,
+		})
+	}
+	return msgs
+}
+
 func mainEventList(ctx *cli.Context) error {
 	console.SetColor("ARN", color.New(color.FgGreen, color.Bold))
 	console.SetColor("Event", color.New(color.FgCyan, color.Bold))
@@ -123,13 +156,8 @@ func mainEventList(ctx *cli.Context) error {
 	configs, err := s3Client.ListNotificationConfigs(arn)
 	fatalIf(err, "Cannot list notifications on the specified bucket.")
 
-	for _, config := range configs {
-		printMsg(eventListMessage{
-			Event:  config.Events,
-			Prefix: config.Prefix,
-			Suffix: config.Suffix,
-			Arn:    config.Arn,
-			ID:     config.ID})
+	for _, msg := range eventListMessages(configs) {
+		printMsg(msg)
 	}
 
 	return nil
@@ -0,0 +1,41 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import "testing"
+
+func TestEventListMessages(t *testing.T) {
+	configs := []notificationConfig{
+		{Arn: "arn:minio:sqs::1:webhook", Events: []string{"s3:ObjectCreated:*"}, Prefix: "photos/"},
+		{Arn: "arn:minio:sqs::2:nats", Events: []string{"s3:ObjectCreated:*", "s3:ObjectRemoved:*"}},
+	}
+	msgs := eventListMessages(configs)
+	if len(msgs) != len(configs) {
+		t.Fatalf("expected %d messages, got %d", len(configs), len(msgs))
+	}
+	for i, msg := range msgs {
+		if msg.arnWidth != len("arn:minio:sqs::1:webhook") {
+			t.Errorf("Test %d: unexpected ARN width %d", i+1, msg.arnWidth)
+		}
+		if msg.eventWidth != len("s3:ObjectCreated:*,s3:ObjectRemoved:*") {
+			t.Errorf("Test %d: unexpected event width %d", i+1, msg.eventWidth)
+		}
+		if msg.Arn != configs[i].Arn || msg.Prefix != configs[i].Prefix {
+			t.Errorf("Test %d: configuration not preserved, got %#v", i+1, msg)
+		}
+	}
+}
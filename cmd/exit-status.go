@@ -0,0 +1,198 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"os"
+	"sync"
+	"sync/atomic"
+
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/console"
+	"github.com/minio/mc/pkg/probe"
+	minio "github.com/minio/minio-go/v6"
+)
+
+// Exit statuses of mc, besides 0 for success and 1 for other errors.
+const (
+	// Some operations failed but the command ran to completion.
+	exitStatusPartialFailure = 3
+	// All errors were due to missing or rejected credentials.
+	exitStatusAuthError = 4
+	// All errors were due to missing buckets, objects or files.
+	exitStatusNotFound = 5
+	// All errors were due to an exceeded quota.
+	exitStatusQuotaExceeded = 6
+	// The command was interrupted by a signal.
+	exitStatusInterrupted = 130
+)
+
+// Error categories of the command summary.
+const (
+	errorCategoryAuth        = "auth"
+	errorCategoryNotFound    = "not-found"
+	errorCategoryQuota       = "quota"
+	errorCategoryInterrupted = "interrupted"
+	errorCategoryOther       = "other"
+)
+
+// Only the first errors are listed in the summary, all are counted.
+const maxSummaryErrors = 100
+
+// errorCategory - returns the category of err.
+func errorCategory(err *probe.Error) string {
+	e := err.ToGoError()
+	switch e.(type) {
+	case BucketDoesNotExist, ObjectMissing, PathNotFound:
+		return errorCategoryNotFound
+	case PathInsufficientPermission:
+		return errorCategoryAuth
+	}
+	if e == context.Canceled {
+		return errorCategoryInterrupted
+	}
+	switch minio.ToErrorResponse(e).Code {
+	case "AccessDenied", "InvalidAccessKeyId", "SignatureDoesNotMatch", "ExpiredToken",
+		"InvalidToken", "InvalidTokenId", "XMinioAdminInvalidAccessKey", "XMinioAdminInvalidSecretKey":
+		return errorCategoryAuth
+	case "NoSuchBucket", "NoSuchKey", "NoSuchUpload", "NoSuchVersion", "XMinioAdminNoSuchUser",
+		"XMinioAdminNoSuchPolicy", "XMinioAdminNoSuchGroup":
+		return errorCategoryNotFound
+	case "XMinioAdminBucketQuotaExceeded", "QuotaExceeded":
+		return errorCategoryQuota
+	}
+	return errorCategoryOther
+}
+
+// summaryError - an error listed in the command summary.
+type summaryError struct {
+	Category string `json:"category"`
+	Message  string `json:"message"`
+	Cause    string `json:"cause"`
+	Fatal    bool   `json:"fatal,omitempty"`
+}
+
+// commandSummary - results and errors of the command, printed at exit
+// in JSON mode.
+type commandSummary struct {
+	mutex      sync.Mutex
+	Status     string         `json:"status"`
+	Type       string         `json:"type"`
+	ExitStatus int            `json:"exitStatus"`
+	Results    int            `json:"results"`
+	Errors     int            `json:"errors"`
+	Categories map[string]int `json:"categories,omitempty"`
+	ErrorList  []summaryError `json:"errorList,omitempty"`
+	fatal      bool
+}
+
+var globalSummary = &commandSummary{Categories: make(map[string]int)}
+
+// Set once an interrupt or termination signal is received.
+var globalInterrupted int32
+
+// addResult - counts a result printed by the command.
+func (s *commandSummary) addResult() {
+	s.mutex.Lock()
+	s.Results++
+	s.mutex.Unlock()
+}
+
+// addError - records an error of the command.
+func (s *commandSummary) addError(err *probe.Error, msg string, fatal bool) {
+	category := errorCategory(err)
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.Errors++
+	s.Categories[category]++
+	s.fatal = s.fatal || fatal
+	if len(s.ErrorList) < maxSummaryErrors {
+		s.ErrorList = append(s.ErrorList, summaryError{
+			Category: category,
+			Message:  msg,
+			Cause:    err.ToGoError().Error(),
+			Fatal:    fatal,
+		})
+	}
+}
+
+// exitStatus - returns the exit status replacing status, the exit
+// status chosen by the command. Successful exits are left alone.
+func (s *commandSummary) exitStatus(status int) int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if status == 0 {
+		return 0
+	}
+	if atomic.LoadInt32(&globalInterrupted) == 1 || s.Categories[errorCategoryInterrupted] > 0 {
+		return exitStatusInterrupted
+	}
+	if s.Errors == 0 {
+		return status
+	}
+	// Errors of a single kind have their own exit status.
+	for category, exitStatus := range map[string]int{
+		errorCategoryAuth:     exitStatusAuthError,
+		errorCategoryNotFound: exitStatusNotFound,
+		errorCategoryQuota:    exitStatusQuotaExceeded,
+	} {
+		if s.Categories[category] == s.Errors {
+			return exitStatus
+		}
+	}
+	if !s.fatal {
+		return exitStatusPartialFailure
+	}
+	return status
+}
+
+// JSON - jsonified command summary.
+func (s *commandSummary) JSON() string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.Status = "success"
+	if s.ExitStatus != 0 {
+		s.Status = "error"
+	}
+	s.Type = "summary"
+	summaryJSONBytes, e := json.MarshalIndent(s, "", " ")
+	if e != nil {
+		return ""
+	}
+	return string(summaryJSONBytes)
+}
+
+// exitWithSummary - exits with the exit status for status, printing
+// the command summary first in JSON mode.
+func exitWithSummary(status int) {
+	status = globalSummary.exitStatus(status)
+	printSummary(status)
+	os.Exit(status)
+}
+
+// printSummary - prints the command summary in JSON mode.
+func printSummary(status int) {
+	if !globalJSON {
+		return
+	}
+	globalSummary.mutex.Lock()
+	globalSummary.ExitStatus = status
+	globalSummary.mutex.Unlock()
+	console.Println(globalSummary.JSON())
+}
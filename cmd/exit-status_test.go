@@ -0,0 +1,58 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/minio/mc/pkg/probe"
+	minio "github.com/minio/minio-go/v6"
+)
+
+func TestCommandSummaryExitStatus(t *testing.T) {
+	accessDenied := probe.NewError(minio.ErrorResponse{Code: "AccessDenied"})
+	noSuchKey := probe.NewError(minio.ErrorResponse{Code: "NoSuchKey"})
+	quota := probe.NewError(minio.ErrorResponse{Code: "XMinioAdminBucketQuotaExceeded"})
+	other := probe.NewError(errors.New("connection reset"))
+
+	testCases := []struct {
+		status   int
+		errs     []*probe.Error
+		fatal    bool
+		expected int
+	}{
+		{0, []*probe.Error{other}, false, 0},
+		{1, nil, false, 1},
+		{1, []*probe.Error{accessDenied, accessDenied}, false, exitStatusAuthError},
+		{1, []*probe.Error{noSuchKey}, true, exitStatusNotFound},
+		{1, []*probe.Error{quota}, false, exitStatusQuotaExceeded},
+		{1, []*probe.Error{accessDenied, noSuchKey}, false, exitStatusPartialFailure},
+		{1, []*probe.Error{other}, false, exitStatusPartialFailure},
+		{1, []*probe.Error{other}, true, 1},
+		{1, []*probe.Error{probe.NewError(PathNotFound{Path: "/tmp/missing"})}, true, exitStatusNotFound},
+	}
+	for i, testCase := range testCases {
+		s := &commandSummary{Categories: make(map[string]int)}
+		for _, err := range testCase.errs {
+			s.addError(err, "Failed.", testCase.fatal)
+		}
+		if status := s.exitStatus(testCase.status); status != testCase.expected {
+			t.Errorf("Test %d: Expected %d, got %d", i+1, testCase.expected, status)
+		}
+	}
+}
@@ -0,0 +1,201 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bufio"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/console"
+	"github.com/minio/mc/pkg/probe"
+)
+
+var (
+	extractFlags = []cli.Flag{}
+)
+
+// Extract an archive into object storage.
+var extractCmd = cli.Command{
+	Name:   "extract",
+	Usage:  "upload the entries of a tar or zip archive",
+	Action: mainExtract,
+	Before: setGlobalsFromContext,
+	Flags:  append(append(extractFlags, ioFlags...), globalFlags...),
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [FLAGS] ARCHIVE TARGET
+{{if .VisibleFlags}}
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}{{end}}
+ENVIRONMENT VARIABLES:
+  MC_ENCRYPT_KEY:  list of comma delimited prefix=secret values
+
+ARCHIVE:
+  Tar archives, optionally gzip compressed, are streamed from a local file, an object or standard input ('-').
+  Zip archives can only be extracted from a local file.
+
+EXAMPLES:
+  1. Upload the content of a local tar archive to a prefix on MinIO cloud storage.
+     $ {{.HelpName}} backup.tar play/mybucket/backup/
+
+  2. Stream a compressed tar archive from standard input into Amazon S3 cloud storage.
+     $ curl -s https://example.com/dataset.tar.gz | {{.HelpName}} - s3/datasets/2019/
+
+  3. Upload the content of a local zip archive to a bucket on MinIO cloud storage.
+     $ {{.HelpName}} photos.zip play/photos/
+`,
+}
+
+// extractMessage container for extracted archive entries.
+type extractMessage struct {
+	Status string `json:"status"`
+	Source string `json:"source"`
+	Target string `json:"target"`
+	Size   int64  `json:"size"`
+}
+
+// String colorized extract message.
+func (e extractMessage) String() string {
+	return console.Colorize("Extract", fmt.Sprintf("`%s` -> `%s`", e.Source, e.Target))
+}
+
+// JSON jsonified extract message.
+func (e extractMessage) JSON() string {
+	e.Status = "success"
+	extractMessageBytes, err := json.MarshalIndent(e, "", " ")
+	fatalIf(probe.NewError(err), "Unable to marshal into JSON.")
+
+	return string(extractMessageBytes)
+}
+
+// extractEntry uploads a single archive entry below targetURL.
+func extractEntry(archiveName, name, targetURL string, reader io.Reader, size int64, encKeyDB map[string][]prefixSSEPair) *probe.Error {
+	entryName, ok := archiveEntryName(name)
+	if !ok {
+		return nil
+	}
+	entryURL := urlJoinPath(targetURL, entryName)
+	alias, _ := url2Alias(entryURL)
+	sse := getSSE(entryURL, encKeyDB[alias])
+	if _, err := putTargetStreamWithURL(entryURL, reader, size, sse, "", nil); err != nil {
+		return err.Trace(entryURL)
+	}
+	printMsg(extractMessage{
+		Source: archiveName + ":" + entryName,
+		Target: entryURL,
+		Size:   size,
+	})
+	return nil
+}
+
+// extractTar streams a tar archive, gzip compressed or not, into targetURL.
+func extractTar(archiveName string, reader io.Reader, targetURL string, encKeyDB map[string][]prefixSSEPair) *probe.Error {
+	br := bufio.NewReader(reader)
+	if magic, e := br.Peek(2); e == nil && magic[0] == 0x1f && magic[1] == 0x8b {
+		gzr, e := gzip.NewReader(br)
+		if e != nil {
+			return probe.NewError(e).Trace(archiveName)
+		}
+		defer gzr.Close()
+		reader = gzr
+	} else {
+		reader = br
+	}
+
+	tr := tar.NewReader(reader)
+	for {
+		hdr, e := tr.Next()
+		if e == io.EOF {
+			return nil
+		}
+		if e != nil {
+			return probe.NewError(e).Trace(archiveName)
+		}
+		if hdr.Typeflag != tar.TypeReg && hdr.Typeflag != tar.TypeRegA {
+			continue
+		}
+		if err := extractEntry(archiveName, hdr.Name, targetURL, tr, hdr.Size, encKeyDB); err != nil {
+			return err.Trace(hdr.Name)
+		}
+	}
+}
+
+// extractZip uploads all the entries of a local zip archive into targetURL.
+func extractZip(archivePath string, targetURL string, encKeyDB map[string][]prefixSSEPair) *probe.Error {
+	zr, e := zip.OpenReader(archivePath)
+	if e != nil {
+		return probe.NewError(e).Trace(archivePath)
+	}
+	defer zr.Close()
+
+	for _, f := range zr.File {
+		if f.FileInfo().IsDir() {
+			continue
+		}
+		rc, e := f.Open()
+		if e != nil {
+			return probe.NewError(e).Trace(archivePath, f.Name)
+		}
+		err := extractEntry(archivePath, f.Name, targetURL, rc, int64(f.UncompressedSize64), encKeyDB)
+		rc.Close()
+		if err != nil {
+			return err.Trace(f.Name)
+		}
+	}
+	return nil
+}
+
+// mainExtract is the entry point for extract command.
+func mainExtract(ctx *cli.Context) error {
+	// Parse encryption keys per command.
+	encKeyDB, err := getEncKeys(ctx)
+	fatalIf(err, "Unable to parse encryption keys.")
+
+	args := ctx.Args()
+	if len(args) != 2 {
+		cli.ShowCommandHelpAndExit(ctx, "extract", 1)
+	}
+	archiveName, targetURL := args.Get(0), args.Get(1)
+
+	// Additional command specific theme customization.
+	console.SetColor("Extract", color.New(color.FgGreen, color.Bold))
+
+	if archiveFormat(archiveName) == archiveZip {
+		fatalIf(extractZip(archiveName, targetURL, encKeyDB), "Unable to extract `"+archiveName+"`.")
+		return nil
+	}
+
+	var reader io.ReadCloser = os.Stdin
+	if archiveName != "-" {
+		reader, err = getSourceStreamFromURL(archiveName, encKeyDB)
+		fatalIf(err.Trace(archiveName), "Unable to read from `"+archiveName+"`.")
+		defer reader.Close()
+	}
+	fatalIf(extractTar(archiveName, reader, targetURL, encKeyDB), "Unable to extract `"+archiveName+"`.")
+	return nil
+}
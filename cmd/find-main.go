@@ -69,6 +69,18 @@ var (
 			Name:  "smaller",
 			Usage: "match all objects smaller than specified size in units (see UNITS)",
 		},
+		cli.StringFlag{
+			Name:  "size",
+			Usage: "match all objects larger (+N) or smaller (-N) than specified size in units (see UNITS)",
+		},
+		cli.StringSliceFlag{
+			Name:  "metadata",
+			Usage: "match all objects with metadata KEY=VALUE, VALUE accepts wildcard patterns",
+		},
+		cli.StringSliceFlag{
+			Name:  "tags",
+			Usage: "match all objects with tag KEY=VALUE, VALUE accepts wildcard patterns",
+		},
 		cli.UintFlag{
 			Name:  "maxdepth",
 			Usage: "limit directory navigation to specified depth",
@@ -96,11 +108,12 @@ FLAGS:
   {{range .VisibleFlags}}{{.}}
   {{end}}
 UNITS
-  --smaller, --larger flags accept human-readable case-insensitive number
+  --smaller, --larger, --size flags accept human-readable case-insensitive number
   suffixes such as "k", "m", "g" and "t" referring to the metric units KB,
   MB, GB and TB respectively. Adding an "i" to these prefixes, uses the IEC
   units, so that "gi" refers to "gibibyte" or "GiB". A "b" at the end is
-  also accepted. Without suffixes the unit is bytes.
+  also accepted. Without suffixes the unit is bytes. --size requires a "+"
+  or "-" prefix, i.e. +10MiB matches objects larger than 10MiB.
 
   --older-than, --newer-than flags accept the string for days, hours and minutes 
   i.e. 1d2h30m states 1 day, 2 hours and 30 minutes.
@@ -117,7 +130,14 @@ FORMAT
 
   Keywords supported if target is object storage:
 
-     {url} --> Substitutes to a shareable URL of the path.
+     {url}  --> Substitutes to a shareable URL of the path.
+     {etag} --> Substitutes to object ETag of the path.
+
+METADATA
+  --metadata matches user defined metadata with or without the "X-Amz-Meta-" prefix
+  and standard headers such as "Content-Type". --metadata and --tags may be repeated,
+  an object matches only if all of them match. Both require an additional request
+  per listed object.
 
 EXAMPLES:
   01. Find all "foo.jpg" in all buckets under "s3" account.
@@ -150,6 +170,15 @@ EXAMPLES:
 
   10. List all objects up to 3 levels sub-directory deep under "s3/bucket".
       $ {{.HelpName}} s3/bucket --maxdepth 3
+
+  11. Find all objects larger than 10MiB and older than 30 days under "s3/logs" and remove them.
+      $ {{.HelpName}} s3/logs --size +10MiB --older-than 30d --exec "mc rm {}"
+
+  12. Find all objects tagged with "project=archive" and a "Content-Type" of images under "s3/bucket".
+      $ {{.HelpName}} s3/bucket --tags "project=archive" --metadata "Content-Type=image/*"
+
+  13. Print the size and ETag of all objects with user metadata "owner" set to "alice" under "s3/bucket".
+      $ {{.HelpName}} s3/bucket --metadata "owner=alice" --print "{url} {size} {etag}"
 `,
 }
 
@@ -197,6 +226,8 @@ type findContext struct {
 	newerThan     string
 	largerSize    uint64
 	smallerSize   uint64
+	metadata      map[string]string
+	tags          map[string]string
 	watch         bool
 
 	// Internal values
@@ -252,6 +283,17 @@ func mainFind(ctx *cli.Context) error {
 		fatalIf(probe.NewError(e).Trace(ctx.String("smaller")), "Unable to parse input bytes.")
 	}
 
+	if ctx.String("size") != "" {
+		largerSize, smallerSize, err = parseFindSize(ctx.String("size"), largerSize, smallerSize)
+		fatalIf(err.Trace(ctx.String("size")), "Unable to parse input size.")
+	}
+
+	metadata, err := parseFindKeyValues(ctx.StringSlice("metadata"))
+	fatalIf(err.Trace(ctx.StringSlice("metadata")...), "Unable to parse metadata matchers.")
+
+	tags, err := parseFindKeyValues(ctx.StringSlice("tags"))
+	fatalIf(err.Trace(ctx.StringSlice("tags")...), "Unable to parse tag matchers.")
+
 	targetAlias, _, hostCfg, err := expandAlias(args[0])
 	fatalIf(err.Trace(args[0]), "Unable to expand alias.")
 
@@ -273,6 +315,8 @@ func mainFind(ctx *cli.Context) error {
 		newerThan:     newerThan,
 		largerSize:    largerSize,
 		smallerSize:   smallerSize,
+		metadata:      metadata,
+		tags:          tags,
 		watch:         ctx.Bool("watch"),
 		targetAlias:   targetAlias,
 		targetURL:     args[0],
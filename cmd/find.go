@@ -18,6 +18,7 @@ package cmd
 
 import (
 	"bytes"
+	"errors"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -224,7 +225,7 @@ func getAliasedPath(ctx *findContext, path string) string {
 
 func find(ctx *findContext, fileContent contentMessage) {
 	// Match the incoming content, didn't match return.
-	if !matchFind(ctx, fileContent) {
+	if !matchFind(ctx, fileContent) || !matchFindAttributes(ctx, fileContent) {
 		return
 	} // For all matching content
 
@@ -279,6 +280,7 @@ func doFind(ctx *findContext) error {
 			Key:  fileKeyName,
 			Time: content.Time.Local(),
 			Size: content.Size,
+			ETag: content.ETag,
 		}
 
 		// Match the incoming content, didn't match return.
@@ -286,6 +288,11 @@ func doFind(ctx *findContext) error {
 			continue
 		} // For all matching content
 
+		// Metadata and tags need additional requests, match them last.
+		if !matchFindAttributes(ctx, fileContent) {
+			continue
+		}
+
 		prevKeyName = fileKeyName
 
 		// proceed to either exec, format the output string.
@@ -369,6 +376,16 @@ func stringsReplace(args string, fileContent contentMessage) string {
 		str = strings.Replace(str, `{"url"}`, strconv.Quote(getShareURL(fileContent.Key)), -1)
 	}
 
+	// replace all instances of {etag}
+	if strings.Contains(str, "{etag}") {
+		str = strings.Replace(str, "{etag}", fileContent.ETag, -1)
+	}
+
+	// replace all instances of {"etag"}
+	if strings.Contains(str, `{"etag"}`) {
+		str = strings.Replace(str, `{"etag"}`, strconv.Quote(fileContent.ETag), -1)
+	}
+
 	return str
 }
 
@@ -411,6 +428,110 @@ func matchFind(ctx *findContext, fileContent contentMessage) (match bool) {
 	return match
 }
 
+// parseFindSize parses --size values, a "+" prefix updates the larger
+// than bound and a "-" prefix updates the smaller than bound.
+func parseFindSize(size string, largerSize, smallerSize uint64) (uint64, uint64, *probe.Error) {
+	if len(size) < 2 || (size[0] != '+' && size[0] != '-') {
+		return largerSize, smallerSize, probe.NewError(errors.New("size should be prefixed with '+' or '-'"))
+	}
+	n, e := humanize.ParseBytes(size[1:])
+	if e != nil {
+		return largerSize, smallerSize, probe.NewError(e)
+	}
+	if size[0] == '+' {
+		return n, smallerSize, nil
+	}
+	return largerSize, n, nil
+}
+
+// parseFindKeyValues parses a list of KEY=VALUE matchers.
+func parseFindKeyValues(pairs []string) (map[string]string, *probe.Error) {
+	if len(pairs) == 0 {
+		return nil, nil
+	}
+	kvs := make(map[string]string, len(pairs))
+	for _, pair := range pairs {
+		kv := strings.SplitN(pair, "=", 2)
+		if len(kv) != 2 || kv[0] == "" {
+			return nil, probe.NewError(errors.New("`" + pair + "` should be of the form KEY=VALUE"))
+		}
+		kvs[kv[0]] = kv[1]
+	}
+	return kvs, nil
+}
+
+// metadataMatch reports whether all the patterns match the metadata,
+// keys are matched case-insensitively with or without the user
+// metadata prefix and values are matched as wildcard patterns.
+func metadataMatch(patterns, metadata map[string]string) bool {
+	for key, pattern := range patterns {
+		found := false
+		for k, v := range metadata {
+			if !strings.EqualFold(k, key) && !strings.EqualFold(k, "X-Amz-Meta-"+key) {
+				continue
+			}
+			if wildcard.Match(pattern, v) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// tagsMatch reports whether all the patterns match the object tags.
+func tagsMatch(patterns, tags map[string]string) bool {
+	for key, pattern := range patterns {
+		value, ok := tags[key]
+		if !ok || !wildcard.Match(pattern, value) {
+			return false
+		}
+	}
+	return true
+}
+
+// matchFindAttributes matches the metadata and tags of fileContent, these
+// are not part of a listing and are fetched only when requested.
+func matchFindAttributes(ctx *findContext, fileContent contentMessage) bool {
+	if len(ctx.metadata) == 0 && len(ctx.tags) == 0 {
+		return true
+	}
+	targetAlias, targetURLFull, _, err := expandAlias(fileContent.Key)
+	if err != nil {
+		errorIf(err.Trace(fileContent.Key), "Unable to expand alias.")
+		return false
+	}
+	clnt, err := newClientFromAlias(targetAlias, targetURLFull)
+	if err != nil {
+		errorIf(err.Trace(fileContent.Key), "Unable to initialize client instance from alias.")
+		return false
+	}
+	if len(ctx.metadata) > 0 {
+		content, err := clnt.Stat(false, true, nil)
+		if err != nil {
+			errorIf(err.Trace(fileContent.Key), "Unable to lookup file/object.")
+			return false
+		}
+		if content.Type.IsDir() || !metadataMatch(ctx.metadata, content.Metadata) {
+			return false
+		}
+	}
+	if len(ctx.tags) > 0 {
+		tags, err := clnt.GetTags()
+		if err != nil {
+			errorIf(err.Trace(fileContent.Key), "Unable to fetch object tags.")
+			return false
+		}
+		if !tagsMatch(ctx.tags, tags) {
+			return false
+		}
+	}
+	return true
+}
+
 // 7 days in seconds.
 var defaultSevenDays = time.Duration(604800) * time.Second
 
@@ -408,6 +408,18 @@ func TestStringReplace(t *testing.T) {
 				Time: time.Unix(2147483647, 0).UTC(),
 			},
 		},
+		// Tests string replace {etag}
+		{
+			str:         `{etag}`,
+			expectedStr: `d41d8cd98f00b204e9800998ecf8427e`,
+			content:     contentMessage{ETag: "d41d8cd98f00b204e9800998ecf8427e"},
+		},
+		// Tests string replace {"etag"} with quotes.
+		{
+			str:         `{"etag"}`,
+			expectedStr: `"d41d8cd98f00b204e9800998ecf8427e"`,
+			content:     contentMessage{ETag: "d41d8cd98f00b204e9800998ecf8427e"},
+		},
 	}
 	for i, testCase := range testCases {
 		gotStr := stringsReplace(testCase.str, testCase.content)
@@ -417,6 +429,67 @@ func TestStringReplace(t *testing.T) {
 	}
 }
 
+// Tests parsing --size values.
+func TestParseFindSize(t *testing.T) {
+	testCases := []struct {
+		size        string
+		larger      uint64
+		smaller     uint64
+		expectedErr bool
+	}{
+		{"+10MiB", 10 * 1024 * 1024, 0, false},
+		{"-1KB", 0, 1000, false},
+		{"+0", 0, 0, false},
+		{"10MiB", 0, 0, true},
+		{"+", 0, 0, true},
+		{"-1XB", 0, 0, true},
+	}
+	for i, testCase := range testCases {
+		larger, smaller, err := parseFindSize(testCase.size, 0, 0)
+		if testCase.expectedErr != (err != nil) {
+			t.Fatalf("Test %d: Expected error %t, got %v", i+1, testCase.expectedErr, err)
+		}
+		if err != nil {
+			continue
+		}
+		if larger != testCase.larger || smaller != testCase.smaller {
+			t.Errorf("Test %d: Expected %d/%d, got %d/%d", i+1, testCase.larger, testCase.smaller, larger, smaller)
+		}
+	}
+}
+
+// Tests matching object metadata and tags.
+func TestMetadataMatch(t *testing.T) {
+	metadata := map[string]string{
+		"Content-Type":     "image/png",
+		"X-Amz-Meta-Owner": "alice",
+	}
+	testCases := []struct {
+		patterns map[string]string
+		match    bool
+	}{
+		{nil, true},
+		{map[string]string{"content-type": "image/*"}, true},
+		{map[string]string{"owner": "alice"}, true},
+		{map[string]string{"X-Amz-Meta-Owner": "a*"}, true},
+		{map[string]string{"owner": "alice", "Content-Type": "text/*"}, false},
+		{map[string]string{"project": "*"}, false},
+	}
+	for i, testCase := range testCases {
+		if match := metadataMatch(testCase.patterns, metadata); match != testCase.match {
+			t.Errorf("Test %d: Expected metadata match %t, got %t", i+1, testCase.match, match)
+		}
+	}
+
+	tags := map[string]string{"project": "archive"}
+	if !tagsMatch(map[string]string{"project": "arch*"}, tags) {
+		t.Errorf("Expected tags to match")
+	}
+	if tagsMatch(map[string]string{"Project": "archive"}, tags) {
+		t.Errorf("Expected tag keys to be case sensitive")
+	}
+}
+
 // Tests exit status, getExitStatus() function
 func TestGetExitStatus(t *testing.T) {
 	testCases := []struct {
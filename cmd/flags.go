@@ -34,13 +34,18 @@ var globalFlags = []cli.Flag{
 		Value: mustGetMcConfigDir(),
 		Usage: "path to configuration folder",
 	},
+	cli.StringFlag{
+		Name:   "profile",
+		Usage:  "use host credentials from the named profile",
+		EnvVar: "MC_PROFILE",
+	},
 	cli.BoolFlag{
 		Name:  "quiet, q",
 		Usage: "disable progress bar display",
 	},
 	cli.BoolFlag{
 		Name:  "no-color",
-		Usage: "disable color theme",
+		Usage: "disable color theme, also disabled by a non-empty NO_COLOR",
 	},
 	cli.BoolFlag{
 		Name:  "json",
@@ -54,6 +59,19 @@ var globalFlags = []cli.Flag{
 		Name:  "insecure",
 		Usage: "disable SSL certificate verification",
 	},
+	cli.BoolFlag{
+		Name:  "debug-http",
+		Usage: "log HTTP requests and responses to stderr, with credentials redacted",
+	},
+	cli.StringFlag{
+		Name:   "log-file",
+		Usage:  "also write errors and results as JSON lines to this file, rotated at 100MiB",
+		EnvVar: "MC_LOG_FILE",
+	},
+	cli.StringFlag{
+		Name:  "debug-http-har",
+		Usage: "write HTTP requests and responses to a HAR file, with credentials redacted",
+	},
 }
 
 // Flags common across all I/O commands such as cp, mirror, stat, pipe etc.
@@ -64,6 +82,19 @@ var ioFlags = []cli.Flag{
 	},
 }
 
+// Flags for client-side encryption, used by cp, cat and pipe.
+var cseFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:   "encrypt-client-key",
+		Usage:  "encrypt/decrypt objects client-side with a 32 bytes plain text or 44 bytes base64 encoded key",
+		EnvVar: "MC_ENCRYPT_CLIENT_KEY",
+	},
+	cli.StringFlag{
+		Name:  "encrypt-client-key-file",
+		Usage: "read the client-side encryption key from a file",
+	},
+}
+
 // registerCmd registers a cli command
 func registerCmd(cmd cli.Command) {
 	commands = append(commands, cmd)
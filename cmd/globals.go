@@ -58,6 +58,11 @@ var (
 	globalDebug    = false // Debug flag set via command line
 	globalNoColor  = false // No Color flag set via command line
 	globalInsecure = false // Insecure flag set via command line
+	globalProfile  = ""    // Profile flag set via command line or MC_PROFILE
+
+	// HTTP request logging set via command line, not saved in sessions.
+	globalDebugHTTP    = false
+	globalDebugHTTPHAR = ""
 
 	// WHEN YOU ADD NEXT GLOBAL FLAG, MAKE SURE TO ALSO UPDATE SESSION CODE AND CODE BELOW.
 )
@@ -71,12 +76,15 @@ var (
 )
 
 // Set global states. NOTE: It is deliberately kept monolithic to ensure we dont miss out any flags.
-func setGlobals(quiet, debug, json, noColor, insecure bool) {
+func setGlobals(quiet, debug, json, noColor, insecure bool, profile string) {
 	globalQuiet = globalQuiet || quiet
 	globalDebug = globalDebug || debug
 	globalJSON = globalJSON || json
 	globalNoColor = globalNoColor || noColor
 	globalInsecure = globalInsecure || insecure
+	if profile != "" {
+		globalProfile = profile
+	}
 
 	// Enable debug messages if requested.
 	if globalDebug {
@@ -94,8 +102,34 @@ func setGlobalsFromContext(ctx *cli.Context) error {
 	quiet := ctx.IsSet("quiet")
 	debug := ctx.IsSet("debug")
 	json := ctx.IsSet("json")
-	noColor := ctx.IsSet("no-color")
+	// Honor the NO_COLOR convention, see https://no-color.org
+	noColor := ctx.IsSet("no-color") || os.Getenv("NO_COLOR") != ""
 	insecure := ctx.IsSet("insecure")
-	setGlobals(quiet, debug, json, noColor, insecure)
+	profile := ctx.String("profile")
+	if profile == "" {
+		profile = ctx.GlobalString("profile")
+	}
+	setGlobals(quiet, debug, json, noColor, insecure, profile)
+
+	logPath := ctx.String("log-file")
+	if logPath == "" {
+		logPath = ctx.GlobalString("log-file")
+	}
+	switch {
+	case globalLogFile != nil:
+		// Sub-commands log under their own name.
+		globalLogFile.setCommand(ctx.Command.Name)
+	case logPath != "":
+		logFile, err := openLogFile(logPath, ctx.Command.Name)
+		fatalIf(err, "Unable to open log file `"+logPath+"`.")
+		globalLogFile = logFile
+	}
+
+	globalDebugHTTP = globalDebugHTTP || ctx.IsSet("debug-http") || ctx.GlobalIsSet("debug-http")
+	if har := ctx.String("debug-http-har"); har != "" {
+		globalDebugHTTPHAR = har
+	} else if har = ctx.GlobalString("debug-http-har"); har != "" {
+		globalDebugHTTPHAR = har
+	}
 	return nil
 }
@@ -0,0 +1,197 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/minio/mc/pkg/probe"
+)
+
+// Hook events sent by transferHook.
+const (
+	hookEventObject  = "object"
+	hookEventSession = "session"
+)
+
+// hookObjectEvent is sent for every completed transfer.
+type hookObjectEvent struct {
+	Event string `json:"event"`
+	ledgerRecord
+}
+
+// hookSessionEvent is sent once when all transfers are done.
+type hookSessionEvent struct {
+	Event    string        `json:"event"`
+	Time     time.Time     `json:"time"`
+	Objects  int64         `json:"objects"`
+	Size     int64         `json:"size"`
+	Errors   int64         `json:"errors"`
+	Duration time.Duration `json:"duration"`
+}
+
+// transferHook notifies downstream pipelines about completed transfers,
+// either by posting JSON events to a URL or by running a command with
+// the event details in its environment.
+type transferHook struct {
+	notifyURL string
+	command   string
+	client    *http.Client
+
+	mutex     sync.Mutex
+	startTime time.Time
+	objects   int64
+	size      int64
+	errors    int64
+}
+
+// newTransferHook returns a hook for the given URL and command, a nil
+// hook is returned when both are empty.
+func newTransferHook(notifyURL, command string) (*transferHook, *probe.Error) {
+	if notifyURL == "" && command == "" {
+		return nil, nil
+	}
+	if notifyURL != "" {
+		u, e := url.Parse(notifyURL)
+		if e != nil {
+			return nil, probe.NewError(e).Trace(notifyURL)
+		}
+		if u.Scheme != "http" && u.Scheme != "https" {
+			return nil, probe.NewError(errors.New("notify URL should be an http or https URL")).Trace(notifyURL)
+		}
+	}
+	if command != "" && len(strings.Fields(command)) == 0 {
+		return nil, errInvalidArgument().Trace(command)
+	}
+	return &transferHook{
+		notifyURL: notifyURL,
+		command:   command,
+		client:    &http.Client{Timeout: 30 * time.Second},
+		startTime: UTCNow(),
+	}, nil
+}
+
+// hookObjectEnv returns the environment describing a completed transfer.
+func hookObjectEnv(event hookObjectEvent) []string {
+	return []string{
+		"MC_HOOK_EVENT=" + event.Event,
+		"MC_HOOK_SOURCE=" + event.Source,
+		"MC_HOOK_TARGET=" + event.Target,
+		"MC_HOOK_SIZE=" + strconv.FormatInt(event.Size, 10),
+		"MC_HOOK_RESULT=" + event.Result,
+		"MC_HOOK_ERROR=" + event.Error,
+	}
+}
+
+// hookSessionEnv returns the environment describing a finished session.
+func hookSessionEnv(event hookSessionEvent) []string {
+	return []string{
+		"MC_HOOK_EVENT=" + event.Event,
+		"MC_HOOK_OBJECTS=" + strconv.FormatInt(event.Objects, 10),
+		"MC_HOOK_SIZE=" + strconv.FormatInt(event.Size, 10),
+		"MC_HOOK_ERRORS=" + strconv.FormatInt(event.Errors, 10),
+	}
+}
+
+// Object notifies about a single completed transfer.
+func (h *transferHook) Object(urls URLs, startTime time.Time) {
+	if h == nil || urls.SourceContent == nil {
+		return
+	}
+	event := hookObjectEvent{Event: hookEventObject, ledgerRecord: newLedgerRecord(urls, startTime)}
+
+	h.mutex.Lock()
+	if urls.Error != nil {
+		h.errors++
+	} else {
+		h.objects++
+		h.size += event.Size
+	}
+	h.mutex.Unlock()
+
+	errorIf(h.notify(event, hookObjectEnv(event)), "Unable to run completion hook for `"+event.Source+"`.")
+}
+
+// Session notifies once that all transfers are done.
+func (h *transferHook) Session() {
+	if h == nil {
+		return
+	}
+	h.mutex.Lock()
+	event := hookSessionEvent{
+		Event:    hookEventSession,
+		Time:     h.startTime,
+		Objects:  h.objects,
+		Size:     h.size,
+		Errors:   h.errors,
+		Duration: time.Since(h.startTime),
+	}
+	h.mutex.Unlock()
+
+	errorIf(h.notify(event, hookSessionEnv(event)), "Unable to run completion hook.")
+}
+
+// notify posts the event and runs the command, if configured.
+func (h *transferHook) notify(event interface{}, env []string) *probe.Error {
+	if h.notifyURL != "" {
+		if err := h.post(event); err != nil {
+			return err.Trace(h.notifyURL)
+		}
+	}
+	if h.command != "" {
+		args := strings.Fields(h.command)
+		cmd := exec.Command(args[0], args[1:]...)
+		cmd.Env = append(os.Environ(), env...)
+		if output, e := cmd.CombinedOutput(); e != nil {
+			return probe.NewError(e).Trace(h.command, string(output))
+		}
+	}
+	return nil
+}
+
+// post sends the event as JSON to the notify URL.
+func (h *transferHook) post(event interface{}) *probe.Error {
+	eventBytes, e := json.Marshal(event)
+	if e != nil {
+		return probe.NewError(e)
+	}
+	req, e := http.NewRequest(http.MethodPost, h.notifyURL, bytes.NewReader(eventBytes))
+	if e != nil {
+		return probe.NewError(e)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", getUserAgent())
+	resp, e := h.client.Do(req)
+	if e != nil {
+		return probe.NewError(e)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return probe.NewError(errors.New("notify URL responded with `" + resp.Status + "`"))
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/minio/mc/pkg/probe"
+)
+
+func TestNewTransferHook(t *testing.T) {
+	testCases := []struct {
+		notifyURL string
+		command   string
+		isNil     bool
+		success   bool
+	}{
+		{"", "", true, true},
+		{"https://hooks.example.com/uploads", "", false, true},
+		{"", "/usr/local/bin/index.sh", false, true},
+		{"ftp://hooks.example.com", "", true, false},
+		{"", "   ", true, false},
+	}
+	for i, testCase := range testCases {
+		hook, err := newTransferHook(testCase.notifyURL, testCase.command)
+		if (err == nil) != testCase.success {
+			t.Errorf("Test %d: expected success %v, got %v", i+1, testCase.success, err)
+		}
+		if (hook == nil) != testCase.isNil {
+			t.Errorf("Test %d: expected nil hook %v, got %v", i+1, testCase.isNil, hook)
+		}
+	}
+}
+
+func TestTransferHookNotify(t *testing.T) {
+	var mutex sync.Mutex
+	var events []map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		event := map[string]interface{}{}
+		if e := json.Unmarshal(body, &event); e != nil {
+			t.Error(e)
+		}
+		mutex.Lock()
+		events = append(events, event)
+		mutex.Unlock()
+	}))
+	defer server.Close()
+
+	hook, err := newTransferHook(server.URL, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	startTime := UTCNow()
+	hook.Object(URLs{
+		SourceContent: &clientContent{URL: *newClientURL("/data/a.txt"), Size: 10},
+		TargetContent: &clientContent{URL: *newClientURL("/bucket/a.txt")},
+		TargetAlias:   "play",
+	}, startTime)
+	hook.Object(URLs{
+		SourceContent: &clientContent{URL: *newClientURL("/data/b.txt"), Size: 20},
+		TargetContent: &clientContent{URL: *newClientURL("/bucket/b.txt")},
+		TargetAlias:   "play",
+		Error:         probe.NewError(errors.New("access denied")),
+	}, startTime)
+	hook.Session()
+
+	if len(events) != 3 {
+		t.Fatalf("expected 3 events, got %d", len(events))
+	}
+	if events[0]["event"] != hookEventObject || events[0]["target"] != "play/bucket/a.txt" || events[0]["result"] != "success" {
+		t.Errorf("unexpected object event %v", events[0])
+	}
+	if events[1]["result"] != "error" {
+		t.Errorf("expected failed transfer, got %v", events[1])
+	}
+	session := events[2]
+	if session["event"] != hookEventSession || session["objects"] != float64(1) || session["size"] != float64(10) || session["errors"] != float64(1) {
+		t.Errorf("unexpected session event %v", session)
+	}
+}
@@ -0,0 +1,188 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/minio/mc/pkg/probe"
+)
+
+// cronSchedule is a parsed cron expression, each field is a bitset of
+// the matching values.
+type cronSchedule struct {
+	minute, hour, dom, month, dow uint64
+	// A restricted day of month or week matches if either one matches.
+	domStar, dowStar bool
+}
+
+var cronMacros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+var cronMonthNames = map[string]int{
+	"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+	"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+}
+
+var cronDayNames = map[string]int{
+	"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+}
+
+// parseCronSchedule parses a standard five field cron expression
+// 'MINUTE HOUR DAY-OF-MONTH MONTH DAY-OF-WEEK' or one of the @hourly,
+// @daily, @weekly, @monthly and @yearly macros.
+func parseCronSchedule(expr string) (cronSchedule, *probe.Error) {
+	expr = strings.TrimSpace(expr)
+	if macro, ok := cronMacros[strings.ToLower(expr)]; ok {
+		expr = macro
+	}
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return cronSchedule{}, probe.NewError(errors.New("cron expression `" + expr + "` must have 5 fields"))
+	}
+
+	var s cronSchedule
+	var err *probe.Error
+	if s.minute, err = parseCronField(fields[0], 0, 59, nil); err != nil {
+		return cronSchedule{}, err.Trace(expr)
+	}
+	if s.hour, err = parseCronField(fields[1], 0, 23, nil); err != nil {
+		return cronSchedule{}, err.Trace(expr)
+	}
+	if s.dom, err = parseCronField(fields[2], 1, 31, nil); err != nil {
+		return cronSchedule{}, err.Trace(expr)
+	}
+	if s.month, err = parseCronField(fields[3], 1, 12, cronMonthNames); err != nil {
+		return cronSchedule{}, err.Trace(expr)
+	}
+	if s.dow, err = parseCronField(fields[4], 0, 7, cronDayNames); err != nil {
+		return cronSchedule{}, err.Trace(expr)
+	}
+	// Both 0 and 7 are Sunday.
+	if s.dow&(1<<7) != 0 {
+		s.dow |= 1
+	}
+	s.domStar = strings.HasPrefix(fields[2], "*")
+	s.dowStar = strings.HasPrefix(fields[4], "*")
+	return s, nil
+}
+
+// parseCronValue parses a number or, when names is set, a name.
+func parseCronValue(value string, min, max int, names map[string]int) (int, *probe.Error) {
+	if n, ok := names[strings.ToLower(value)]; ok {
+		return n, nil
+	}
+	n, e := strconv.Atoi(value)
+	if e != nil || n < min || n > max {
+		return 0, probe.NewError(errors.New("invalid cron value `" + value + "`, expected " + strconv.Itoa(min) + "-" + strconv.Itoa(max)))
+	}
+	return n, nil
+}
+
+// parseCronField parses a comma separated list of '*', values and
+// ranges, each optionally followed by a '/STEP'.
+func parseCronField(field string, min, max int, names map[string]int) (uint64, *probe.Error) {
+	var bits uint64
+	for _, part := range strings.Split(field, ",") {
+		step := 1
+		if i := strings.Index(part, "/"); i >= 0 {
+			var e error
+			if step, e = strconv.Atoi(part[i+1:]); e != nil || step <= 0 {
+				return 0, probe.NewError(errors.New("invalid cron step `" + part + "`"))
+			}
+			part = part[:i]
+		}
+
+		low, high := min, max
+		switch {
+		case part == "*":
+		case strings.Contains(part, "-"):
+			bounds := strings.SplitN(part, "-", 2)
+			var err *probe.Error
+			if low, err = parseCronValue(bounds[0], min, max, names); err != nil {
+				return 0, err
+			}
+			if high, err = parseCronValue(bounds[1], min, max, names); err != nil {
+				return 0, err
+			}
+			if low > high {
+				return 0, probe.NewError(errors.New("invalid cron range `" + part + "`"))
+			}
+		default:
+			var err *probe.Error
+			if low, err = parseCronValue(part, min, max, names); err != nil {
+				return 0, err
+			}
+			// A single value with a step runs from the value to the maximum.
+			if step == 1 {
+				high = low
+			}
+		}
+		for i := low; i <= high; i += step {
+			bits |= 1 << uint(i)
+		}
+	}
+	return bits, nil
+}
+
+// matchDay returns true if the day of t matches the schedule.
+func (s cronSchedule) matchDay(t time.Time) bool {
+	domMatch := s.dom&(1<<uint(t.Day())) != 0
+	dowMatch := s.dow&(1<<uint(t.Weekday())) != 0
+	if s.domStar || s.dowStar {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
+
+// next returns the first time after t matching the schedule, or the
+// zero time if there is none within five years.
+func (s cronSchedule) next(t time.Time) time.Time {
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	loc := t.Location()
+	limit := t.AddDate(5, 0, 0)
+	for t.Before(limit) {
+		if s.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if !s.matchDay(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if s.hour&(1<<uint(t.Hour())) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+			continue
+		}
+		if s.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
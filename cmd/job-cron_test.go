@@ -0,0 +1,110 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseCronSchedule(t *testing.T) {
+	testCases := []struct {
+		expr    string
+		success bool
+	}{
+		{"* * * * *", true},
+		{"0 2 * * *", true},
+		{"*/15 9-17 * * mon-fri", true},
+		{"0,30 */2 1,15 jan-jun 0", true},
+		{"5 4 * * 7", true},
+		{"@daily", true},
+		{"@HOURLY", true},
+		{"* * * *", false},
+		{"60 * * * *", false},
+		{"* 24 * * *", false},
+		{"* * 0 * *", false},
+		{"* * * 13 *", false},
+		{"* * * * 8", false},
+		{"*/0 * * * *", false},
+		{"10-5 * * * *", false},
+		{"* * * foo *", false},
+		{"@reboot", false},
+	}
+	for i, testCase := range testCases {
+		_, err := parseCronSchedule(testCase.expr)
+		if testCase.success && err != nil {
+			t.Errorf("Test %d: unexpected error for %q: %v", i+1, testCase.expr, err.ToGoError())
+		}
+		if !testCase.success && err == nil {
+			t.Errorf("Test %d: expected an error for %q", i+1, testCase.expr)
+		}
+	}
+}
+
+func TestCronScheduleNext(t *testing.T) {
+	// Wednesday.
+	now := time.Date(2019, time.November, 13, 10, 17, 42, 0, time.UTC)
+	testCases := []struct {
+		expr string
+		next time.Time
+	}{
+		{"* * * * *", time.Date(2019, time.November, 13, 10, 18, 0, 0, time.UTC)},
+		{"0 2 * * *", time.Date(2019, time.November, 14, 2, 0, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2019, time.November, 13, 10, 30, 0, 0, time.UTC)},
+		{"0 9 * * mon", time.Date(2019, time.November, 18, 9, 0, 0, 0, time.UTC)},
+		{"0 0 * * 7", time.Date(2019, time.November, 17, 0, 0, 0, 0, time.UTC)},
+		{"@monthly", time.Date(2019, time.December, 1, 0, 0, 0, 0, time.UTC)},
+		{"@yearly", time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 12 29 feb *", time.Date(2020, time.February, 29, 12, 0, 0, 0, time.UTC)},
+		// Day of month or day of week when both are restricted.
+		{"0 0 1 * fri", time.Date(2019, time.November, 15, 0, 0, 0, 0, time.UTC)},
+		{"0 0 31 feb *", time.Time{}},
+	}
+	for i, testCase := range testCases {
+		schedule, err := parseCronSchedule(testCase.expr)
+		if err != nil {
+			t.Fatalf("Test %d: unexpected error for %q: %v", i+1, testCase.expr, err.ToGoError())
+		}
+		if next := schedule.next(now); !next.Equal(testCase.next) {
+			t.Errorf("Test %d: expected next run of %q at %v, got %v", i+1, testCase.expr, testCase.next, next)
+		}
+	}
+}
+
+func TestValidateScheduledJobArgs(t *testing.T) {
+	testCases := []struct {
+		args    []string
+		success bool
+	}{
+		{[]string{"mirror", "--overwrite", "play/bucket", "/backup"}, true},
+		{[]string{"cp", "--recursive", "play/bucket", "/backup"}, true},
+		{[]string{"rm", "--recursive", "--force", "play/bucket"}, true},
+		{[]string{"mirror"}, false},
+		{[]string{"admin", "info", "play"}, false},
+		{[]string{"mirror", "--watch", "play/bucket", "/backup"}, false},
+		{[]string{"cp", "-", "play/bucket/object"}, false},
+	}
+	for i, testCase := range testCases {
+		err := validateScheduledJobArgs(testCase.args)
+		if testCase.success && err != nil {
+			t.Errorf("Test %d: unexpected error %v", i+1, err.ToGoError())
+		}
+		if !testCase.success && err == nil {
+			t.Errorf("Test %d: expected an error", i+1)
+		}
+	}
+}
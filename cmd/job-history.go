@@ -0,0 +1,80 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/console"
+)
+
+var jobHistoryCmd = cli.Command{
+	Name:   "history",
+	Usage:  "show the recent runs of a scheduled job",
+	Action: mainJobHistory,
+	Before: setGlobalsFromContext,
+	Flags:  globalFlags,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} NAME
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+DESCRIPTION:
+  The last 20 runs of a job are kept, with the end of their output.
+
+EXAMPLES:
+  1. Show the recent runs of the job 'nightly-backup', with the output of failed runs.
+     $ {{.HelpName}} nightly-backup
+
+  2. Show the recent runs of the job 'nightly-backup' in JSON, including the output of all runs.
+     $ {{.HelpName}} --json nightly-backup
+`,
+}
+
+// checkJobHistorySyntax - validate all the passed arguments
+func checkJobHistorySyntax(ctx *cli.Context) {
+	if len(ctx.Args()) != 1 {
+		cli.ShowCommandHelpAndExit(ctx, "history", 1) // last argument is exit code
+	}
+}
+
+// mainJobHistory is the handle for "mc job history" command.
+func mainJobHistory(ctx *cli.Context) error {
+	checkJobHistorySyntax(ctx)
+
+	console.SetColor("Time", color.New(color.FgGreen))
+	console.SetColor("Job", color.New(color.FgCyan, color.Bold))
+	console.SetColor("JobSuccess", color.New(color.FgGreen))
+	console.SetColor("JobFailure", color.New(color.FgRed))
+
+	name := ctx.Args().First()
+	jobs, err := loadScheduledJobs()
+	fatalIf(err, "Unable to load scheduled jobs.")
+	job, ok := jobs[name]
+	if !ok {
+		fatalIf(errInvalidArgument().Trace(name), "No job `"+name+"` found.")
+	}
+
+	for _, run := range job.History {
+		printMsg(jobRunMessage{Name: name, scheduledJobRun: run})
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/console"
+)
+
+var jobListCmd = cli.Command{
+	Name:   "list",
+	Usage:  "list scheduled jobs",
+	Action: mainJobList,
+	Before: setGlobalsFromContext,
+	Flags:  globalFlags,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}}
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+  1. List all scheduled jobs with their next and last run.
+     $ {{.HelpName}}
+
+  2. List all scheduled jobs in JSON, including the status of their last run.
+     $ {{.HelpName}} --json
+`,
+}
+
+// checkJobListSyntax - validate all the passed arguments
+func checkJobListSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) != 0 {
+		cli.ShowCommandHelpAndExit(ctx, "list", 1) // last argument is exit code
+	}
+}
+
+// mainJobList is the handle for "mc job list" command.
+func mainJobList(ctx *cli.Context) error {
+	checkJobListSyntax(ctx)
+
+	console.SetColor("Job", color.New(color.FgCyan, color.Bold))
+	console.SetColor("JobSchedule", color.New(color.FgYellow))
+	console.SetColor("JobSuccess", color.New(color.FgGreen))
+	console.SetColor("JobFailure", color.New(color.FgRed))
+
+	jobs, err := loadScheduledJobs()
+	fatalIf(err, "Unable to load scheduled jobs.")
+
+	for _, job := range sortedScheduledJobs(jobs) {
+		printMsg(newJobMessage("list", job))
+	}
+	return nil
+}
@@ -0,0 +1,375 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/minio/cli"
+	jsoncolor "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/console"
+	"github.com/minio/mc/pkg/probe"
+)
+
+const (
+	// scheduledJobsFile holds all scheduled jobs and their run history.
+	scheduledJobsFile = "jobs.json"
+
+	// Number of runs kept in the history of each job.
+	scheduledJobHistorySize = 20
+
+	// Number of bytes kept from the end of the output of each run.
+	scheduledJobOutputSize = 4 * 1024
+)
+
+// Commands which can be scheduled.
+var scheduledJobCommands = []string{"cp", "mirror", "rm"}
+
+var jobCmd = cli.Command{
+	Name:   "job",
+	Usage:  "schedule cp, mirror and rm runs",
+	Action: mainJob,
+	Before: setGlobalsFromContext,
+	Flags:  globalFlags,
+	Subcommands: []cli.Command{
+		jobScheduleCmd,
+		jobListCmd,
+		jobRemoveCmd,
+		jobHistoryCmd,
+		jobRunDueCmd,
+	},
+	HideHelpCommand: true,
+}
+
+// mainJob is the handle for "mc job" command.
+func mainJob(ctx *cli.Context) error {
+	cli.ShowCommandHelp(ctx, ctx.Args().First())
+	return nil
+	// Sub-commands like "schedule", "list", "run-due" have their own main.
+}
+
+// scheduledJobRun is one run of a scheduled job.
+type scheduledJobRun struct {
+	StartTime time.Time `json:"startTime"`
+	EndTime   time.Time `json:"endTime"`
+	ExitCode  int       `json:"exitCode"`
+	Output    string    `json:"output,omitempty"`
+}
+
+// scheduledJob is an mc command run on a cron schedule.
+type scheduledJob struct {
+	Name     string            `json:"name"`
+	Schedule string            `json:"schedule"`
+	Args     []string          `json:"args"`
+	NextRun  time.Time         `json:"nextRun"`
+	History  []scheduledJobRun `json:"history,omitempty"`
+}
+
+// validateScheduledJobArgs checks that args are an mc command which can be scheduled.
+func validateScheduledJobArgs(args []string) *probe.Error {
+	if len(args) < 2 {
+		return probe.NewError(errors.New("a job needs a command and its arguments"))
+	}
+	isScheduled := false
+	for _, command := range scheduledJobCommands {
+		if args[0] == command {
+			isScheduled = true
+		}
+	}
+	if !isScheduled {
+		return probe.NewError(errors.New("command `" + args[0] + "` cannot be scheduled, supported commands are [" + strings.Join(scheduledJobCommands, ", ") + "]"))
+	}
+	for _, arg := range args[1:] {
+		switch arg {
+		case "-", "--watch", "-w", "--continue", "-c":
+			return probe.NewError(errors.New("`" + arg + "` cannot be used in a scheduled job"))
+		}
+	}
+	return nil
+}
+
+// scheduledJobsMutex serializes updates of the jobs file within a process.
+var scheduledJobsMutex sync.Mutex
+
+// getScheduledJobsPath returns the path of the scheduled jobs file.
+func getScheduledJobsPath() (string, *probe.Error) {
+	configDir, err := getMcConfigDir()
+	if err != nil {
+		return "", err.Trace()
+	}
+	return filepath.Join(configDir, scheduledJobsFile), nil
+}
+
+// loadScheduledJobs reads all scheduled jobs, by name.
+func loadScheduledJobs() (map[string]scheduledJob, *probe.Error) {
+	jobsPath, err := getScheduledJobsPath()
+	if err != nil {
+		return nil, err.Trace()
+	}
+	jobs := map[string]scheduledJob{}
+	jobsBytes, e := ioutil.ReadFile(jobsPath)
+	if os.IsNotExist(e) {
+		return jobs, nil
+	}
+	if e != nil {
+		return nil, probe.NewError(e).Trace(jobsPath)
+	}
+	if e = json.Unmarshal(jobsBytes, &jobs); e != nil {
+		return nil, probe.NewError(e).Trace(jobsPath)
+	}
+	return jobs, nil
+}
+
+// saveScheduledJobs writes all scheduled jobs.
+func saveScheduledJobs(jobs map[string]scheduledJob) *probe.Error {
+	jobsPath, err := getScheduledJobsPath()
+	if err != nil {
+		return err.Trace()
+	}
+	jobsBytes, e := json.MarshalIndent(jobs, "", " ")
+	if e != nil {
+		return probe.NewError(e)
+	}
+	// Write to a temporary file first so that a concurrent run never reads a partial file.
+	tmpPath := jobsPath + "." + newRandomID(8)
+	if e = ioutil.WriteFile(tmpPath, jobsBytes, 0600); e != nil {
+		return probe.NewError(e).Trace(tmpPath)
+	}
+	if e = os.Rename(tmpPath, jobsPath); e != nil {
+		os.Remove(tmpPath)
+		return probe.NewError(e).Trace(jobsPath)
+	}
+	return nil
+}
+
+// updateScheduledJob loads the jobs, applies update to the named job
+// and saves them. The job is not saved if update returns false.
+func updateScheduledJob(name string, update func(job *scheduledJob) bool) *probe.Error {
+	scheduledJobsMutex.Lock()
+	defer scheduledJobsMutex.Unlock()
+
+	jobs, err := loadScheduledJobs()
+	if err != nil {
+		return err.Trace(name)
+	}
+	job, ok := jobs[name]
+	if !ok {
+		return probe.NewError(errors.New("no job `" + name + "`"))
+	}
+	if !update(&job) {
+		return nil
+	}
+	jobs[name] = job
+	return saveScheduledJobs(jobs)
+}
+
+// runScheduledJob runs the mc command of a job and returns the run.
+func runScheduledJob(job scheduledJob) scheduledJobRun {
+	run := scheduledJobRun{StartTime: UTCNow()}
+
+	var output bytes.Buffer
+	executable, e := os.Executable()
+	if e == nil {
+		args := append([]string{job.Args[0], "--config-dir", mustGetMcConfigDir(), "--quiet", "--no-color"}, job.Args[1:]...)
+		cmd := exec.Command(executable, args...)
+		cmd.Stdout = &output
+		cmd.Stderr = &output
+		e = cmd.Run()
+	}
+	switch err := e.(type) {
+	case nil:
+	case *exec.ExitError:
+		run.ExitCode = err.ExitCode()
+	default:
+		run.ExitCode = -1
+		output.WriteString(e.Error())
+	}
+
+	run.EndTime = UTCNow()
+	run.Output = output.String()
+	if len(run.Output) > scheduledJobOutputSize {
+		run.Output = run.Output[len(run.Output)-scheduledJobOutputSize:]
+	}
+	return run
+}
+
+// runDueScheduledJobs runs all jobs which are due at now in parallel
+// and returns their runs once all have finished. The next run of each
+// job is saved before it starts so it is not started twice.
+func runDueScheduledJobs(now time.Time) (map[string]scheduledJobRun, *probe.Error) {
+	scheduledJobsMutex.Lock()
+	jobs, err := loadScheduledJobs()
+	if err != nil {
+		scheduledJobsMutex.Unlock()
+		return nil, err.Trace()
+	}
+	var due []scheduledJob
+	for name, job := range jobs {
+		if job.NextRun.IsZero() || job.NextRun.After(now) {
+			continue
+		}
+		schedule, err := parseCronSchedule(job.Schedule)
+		if err != nil {
+			continue
+		}
+		due = append(due, job)
+		job.NextRun = schedule.next(now)
+		jobs[name] = job
+	}
+	if len(due) > 0 {
+		err = saveScheduledJobs(jobs)
+	}
+	scheduledJobsMutex.Unlock()
+	if err != nil {
+		return nil, err.Trace()
+	}
+
+	var mutex sync.Mutex
+	var wg sync.WaitGroup
+	runs := make(map[string]scheduledJobRun, len(due))
+	for _, job := range due {
+		wg.Add(1)
+		go func(job scheduledJob) {
+			defer wg.Done()
+			run := runScheduledJob(job)
+			errorIf(updateScheduledJob(job.Name, func(job *scheduledJob) bool {
+				job.History = append(job.History, run)
+				if len(job.History) > scheduledJobHistorySize {
+					job.History = job.History[len(job.History)-scheduledJobHistorySize:]
+				}
+				return true
+			}), "Unable to save the run of job `"+job.Name+"`.")
+			mutex.Lock()
+			runs[job.Name] = run
+			mutex.Unlock()
+		}(job)
+	}
+	wg.Wait()
+	return runs, nil
+}
+
+// sortedScheduledJobs returns the jobs sorted by name.
+func sortedScheduledJobs(jobs map[string]scheduledJob) []scheduledJob {
+	sorted := make([]scheduledJob, 0, len(jobs))
+	for _, job := range jobs {
+		sorted = append(sorted, job)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Name < sorted[j].Name
+	})
+	return sorted
+}
+
+// jobMessage container for a scheduled job.
+type jobMessage struct {
+	op       string
+	Status   string           `json:"status"`
+	Name     string           `json:"name"`
+	Schedule string           `json:"schedule"`
+	Args     []string         `json:"args"`
+	NextRun  time.Time        `json:"nextRun"`
+	LastRun  *scheduledJobRun `json:"lastRun,omitempty"`
+}
+
+func newJobMessage(op string, job scheduledJob) jobMessage {
+	msg := jobMessage{
+		op:       op,
+		Name:     job.Name,
+		Schedule: job.Schedule,
+		Args:     job.Args,
+		NextRun:  job.NextRun,
+	}
+	if len(job.History) > 0 {
+		msg.LastRun = &job.History[len(job.History)-1]
+	}
+	return msg
+}
+
+// String colorized scheduled job message.
+func (j jobMessage) String() string {
+	switch j.op {
+	case "schedule":
+		return console.Colorize("Job", "Scheduled job `"+j.Name+"` to run `mc "+strings.Join(j.Args, " ")+"` at `"+j.Schedule+"`, next run at "+j.NextRun.Local().Format(printDate)+".")
+	case "remove":
+		return console.Colorize("Job", "Removed job `"+j.Name+"`.")
+	}
+	lastRun := "never run"
+	if j.LastRun != nil {
+		lastRun = "last run " + j.LastRun.StartTime.Local().Format(printDate) + " " + jobExitStatus(j.LastRun.ExitCode)
+	}
+	return console.Colorize("Job", j.Name) + " " +
+		console.Colorize("JobSchedule", "["+j.Schedule+"]") + " " +
+		"mc " + strings.Join(j.Args, " ") + ", next run " + j.NextRun.Local().Format(printDate) + ", " + lastRun
+}
+
+// JSON jsonified scheduled job message.
+func (j jobMessage) JSON() string {
+	j.Status = "success"
+	jobMessageBytes, e := jsoncolor.MarshalIndent(j, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(jobMessageBytes)
+}
+
+// jobRunMessage container for a run of a scheduled job.
+type jobRunMessage struct {
+	Status string `json:"status"`
+	Name   string `json:"name"`
+	scheduledJobRun
+}
+
+// jobExitStatus describes the exit code of a run.
+func jobExitStatus(exitCode int) string {
+	if exitCode == 0 {
+		return console.Colorize("JobSuccess", "succeeded")
+	}
+	return console.Colorize("JobFailure", "failed with exit code "+strconv.Itoa(exitCode))
+}
+
+// String colorized scheduled job run message.
+func (j jobRunMessage) String() string {
+	msg := console.Colorize("Time", "["+j.StartTime.Local().Format(printDate)+"] ") +
+		console.Colorize("Job", j.Name) + " " + jobExitStatus(j.ExitCode) +
+		" after " + timeDurationToHumanizedDuration(j.EndTime.Sub(j.StartTime)).StringShort()
+	if j.ExitCode != 0 && j.Output != "" {
+		msg += "\n" + strings.TrimRight(j.Output, "\n")
+	}
+	return msg
+}
+
+// JSON jsonified scheduled job run message.
+func (j jobRunMessage) JSON() string {
+	j.Status = "success"
+	if j.ExitCode != 0 {
+		j.Status = "error"
+	}
+	jobRunMessageBytes, e := jsoncolor.MarshalIndent(j, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(jobRunMessageBytes)
+}
@@ -0,0 +1,75 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/console"
+)
+
+var jobRemoveCmd = cli.Command{
+	Name:   "remove",
+	Usage:  "remove a scheduled job",
+	Action: mainJobRemove,
+	Before: setGlobalsFromContext,
+	Flags:  globalFlags,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} NAME
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+  1. Remove the job 'nightly-backup' and its history.
+     $ {{.HelpName}} nightly-backup
+`,
+}
+
+// checkJobRemoveSyntax - validate all the passed arguments
+func checkJobRemoveSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) != 1 {
+		cli.ShowCommandHelpAndExit(ctx, "remove", 1) // last argument is exit code
+	}
+}
+
+// mainJobRemove is the handle for "mc job remove" command.
+func mainJobRemove(ctx *cli.Context) error {
+	checkJobRemoveSyntax(ctx)
+
+	console.SetColor("Job", color.New(color.FgGreen))
+
+	name := ctx.Args().First()
+
+	scheduledJobsMutex.Lock()
+	defer scheduledJobsMutex.Unlock()
+
+	jobs, err := loadScheduledJobs()
+	fatalIf(err, "Unable to load scheduled jobs.")
+	job, ok := jobs[name]
+	if !ok {
+		fatalIf(errInvalidArgument().Trace(name), "No job `"+name+"` found.")
+	}
+	delete(jobs, name)
+	fatalIf(saveScheduledJobs(jobs).Trace(name), "Unable to remove job `"+name+"`.")
+
+	printMsg(newJobMessage("remove", job))
+	return nil
+}
@@ -0,0 +1,117 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"sort"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/console"
+)
+
+var jobRunDueCmd = cli.Command{
+	Name:   "run-due",
+	Usage:  "run all scheduled jobs which are due",
+	Action: mainJobRunDue,
+	Before: setGlobalsFromContext,
+	Flags:  globalFlags,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}}
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+DESCRIPTION:
+  Due jobs run in parallel, the command returns when all of them have finished and
+  fails if any of them failed. A job which is still running when it is due again is
+  not started twice. Runs missed while neither 'mc agent' nor this command ran are
+  not caught up, only the next run of the job is done.
+
+EXAMPLES:
+  1. Run all due jobs every minute from a single crontab entry.
+     $ crontab -l
+     * * * * * /usr/local/bin/mc --quiet job run-due
+`,
+}
+
+// checkJobRunDueSyntax - validate all the passed arguments
+func checkJobRunDueSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) != 0 {
+		cli.ShowCommandHelpAndExit(ctx, "run-due", 1) // last argument is exit code
+	}
+}
+
+// mainJobRunDue is the handle for "mc job run-due" command.
+func mainJobRunDue(ctx *cli.Context) error {
+	checkJobRunDueSyntax(ctx)
+
+	console.SetColor("Time", color.New(color.FgGreen))
+	console.SetColor("Job", color.New(color.FgCyan, color.Bold))
+	console.SetColor("JobSuccess", color.New(color.FgGreen))
+	console.SetColor("JobFailure", color.New(color.FgRed))
+
+	runs, err := runDueScheduledJobs(time.Now())
+	fatalIf(err, "Unable to run scheduled jobs.")
+
+	names := make([]string, 0, len(runs))
+	for name := range runs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	failed := false
+	for _, name := range names {
+		run := runs[name]
+		printMsg(jobRunMessage{Name: name, scheduledJobRun: run})
+		if run.ExitCode != 0 {
+			failed = true
+		}
+	}
+	if failed {
+		return exitStatus(globalErrorExitStatus)
+	}
+	return nil
+}
+
+// runScheduledJobsEvery runs the due scheduled jobs on every tick of
+// interval until doneCh is closed, used by 'mc agent'.
+func runScheduledJobsEvery(interval time.Duration, doneCh <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-doneCh:
+			return
+		case now := <-ticker.C:
+			// Jobs may run longer than the interval, they are
+			// not started twice while running.
+			go func() {
+				runs, err := runDueScheduledJobs(now)
+				errorIf(err, "Unable to run scheduled jobs.")
+				for name, run := range runs {
+					printMsg(jobRunMessage{Name: name, scheduledJobRun: run})
+				}
+			}()
+		}
+	}
+}
@@ -0,0 +1,121 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"regexp"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/console"
+)
+
+var jobScheduleFlags = []cli.Flag{
+	cli.BoolFlag{
+		Name:  "replace",
+		Usage: "replace an existing job with the same name, keeping its history",
+	},
+}
+
+var jobScheduleCmd = cli.Command{
+	Name:   "schedule",
+	Usage:  "schedule a cp, mirror or rm command",
+	Action: mainJobSchedule,
+	Before: setGlobalsFromContext,
+	Flags:  append(jobScheduleFlags, globalFlags...),
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [FLAGS] NAME SCHEDULE COMMAND [ARGS...]
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+DESCRIPTION:
+  SCHEDULE is a cron expression 'MINUTE HOUR DAY-OF-MONTH MONTH DAY-OF-WEEK' in local time,
+  or one of @hourly, @daily, @weekly, @monthly and @yearly. COMMAND is 'cp', 'mirror' or 'rm'
+  followed by its flags and arguments, as they would be passed to mc.
+
+  Jobs are stored in the mc config directory and run by 'mc agent', or by 'mc job run-due'
+  which can be started every minute by a single crontab entry or timer.
+
+EXAMPLES:
+  1. Mirror a bucket to a local directory every night at 2am.
+     $ {{.HelpName}} nightly-backup "0 2 * * *" mirror --overwrite --remove play/mybucket /backup/mybucket
+
+  2. Remove objects older than 30 days from a bucket every hour.
+     $ {{.HelpName}} expire-logs @hourly rm --recursive --force --older-than 30d play/logs
+
+  3. Change the schedule of an existing job to every 15 minutes on weekdays.
+     $ {{.HelpName}} --replace nightly-backup "*/15 * * * mon-fri" mirror --overwrite play/mybucket /backup/mybucket
+`,
+}
+
+var jobNameRegexp = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.-]*$`)
+
+// checkJobScheduleSyntax - validate all the passed arguments
+func checkJobScheduleSyntax(ctx *cli.Context) {
+	args := ctx.Args()
+	if len(args) < 4 {
+		cli.ShowCommandHelpAndExit(ctx, "schedule", 1) // last argument is exit code
+	}
+	if !jobNameRegexp.MatchString(args[0]) {
+		fatalIf(errInvalidArgument().Trace(args[0]), "Invalid job name `"+args[0]+"`, only letters, digits, '.', '_' and '-' are allowed.")
+	}
+	_, err := parseCronSchedule(args[1])
+	fatalIf(err, "Invalid schedule `"+args[1]+"`.")
+	fatalIf(validateScheduledJobArgs(args[2:]), "Invalid job command.")
+}
+
+// mainJobSchedule is the handle for "mc job schedule" command.
+func mainJobSchedule(ctx *cli.Context) error {
+	checkJobScheduleSyntax(ctx)
+
+	console.SetColor("Job", color.New(color.FgGreen))
+
+	args := ctx.Args()
+	name := args[0]
+	schedule, _ := parseCronSchedule(args[1])
+	job := scheduledJob{
+		Name:     name,
+		Schedule: args[1],
+		Args:     args[2:],
+		NextRun:  schedule.next(time.Now()),
+	}
+	if job.NextRun.IsZero() {
+		fatalIf(errInvalidArgument().Trace(args[1]), "Schedule `"+args[1]+"` never matches.")
+	}
+
+	scheduledJobsMutex.Lock()
+	defer scheduledJobsMutex.Unlock()
+
+	jobs, err := loadScheduledJobs()
+	fatalIf(err, "Unable to load scheduled jobs.")
+	if existing, ok := jobs[name]; ok {
+		if !ctx.Bool("replace") {
+			fatalIf(errInvalidArgument().Trace(name), "Job `"+name+"` already exists, use --replace to replace it.")
+		}
+		job.History = existing.History
+	}
+	jobs[name] = job
+	fatalIf(saveScheduledJobs(jobs).Trace(name), "Unable to save job `"+name+"`.")
+
+	printMsg(newJobMessage("schedule", job))
+	return nil
+}
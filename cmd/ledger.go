@@ -0,0 +1,120 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/minio/mc/pkg/probe"
+)
+
+// ledgerRecord is a single line in a transfer ledger.
+type ledgerRecord struct {
+	Time     time.Time     `json:"time"`
+	Source   string        `json:"source"`
+	Target   string        `json:"target"`
+	Size     int64         `json:"size"`
+	Duration time.Duration `json:"duration"`
+	Result   string        `json:"result"`
+	Error    string        `json:"error,omitempty"`
+}
+
+// transferLedger appends one JSON record per transferred object to a
+// local file. If the file name ends with `.gz` the ledger is gzip
+// compressed, such that it can be shipped directly to billing systems.
+type transferLedger struct {
+	mutex sync.Mutex
+	file  *os.File
+	gzw   *gzip.Writer
+	w     io.Writer
+}
+
+// newTransferLedger opens the ledger file at path for appending.
+func newTransferLedger(path string) (*transferLedger, *probe.Error) {
+	file, e := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if e != nil {
+		return nil, probe.NewError(e).Trace(path)
+	}
+	l := &transferLedger{file: file, w: file}
+	if strings.HasSuffix(filepath.Base(path), ".gz") {
+		// Concatenated gzip members are valid gzip streams, appending
+		// to an existing ledger keeps it readable with zcat.
+		l.gzw = gzip.NewWriter(file)
+		l.w = l.gzw
+	}
+	return l, nil
+}
+
+// newLedgerRecord describes the outcome of a single transfer.
+func newLedgerRecord(urls URLs, startTime time.Time) ledgerRecord {
+	record := ledgerRecord{
+		Time:     startTime,
+		Source:   filepath.ToSlash(filepath.Join(urls.SourceAlias, urls.SourceContent.URL.Path)),
+		Size:     urls.SourceContent.Size,
+		Duration: time.Since(startTime),
+		Result:   "success",
+	}
+	if urls.TargetContent != nil {
+		record.Target = filepath.ToSlash(filepath.Join(urls.TargetAlias, urls.TargetContent.URL.Path))
+	}
+	if urls.Error != nil {
+		record.Result = "error"
+		record.Error = urls.Error.ToGoError().Error()
+	}
+	return record
+}
+
+// Record writes the outcome of a single transfer to the ledger.
+func (l *transferLedger) Record(urls URLs, startTime time.Time) {
+	if l == nil || urls.SourceContent == nil {
+		return
+	}
+	recordBytes, e := json.Marshal(newLedgerRecord(urls, startTime))
+	if e != nil {
+		return
+	}
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.w.Write(append(recordBytes, '\n'))
+}
+
+// Close flushes pending records and closes the ledger file.
+func (l *transferLedger) Close() *probe.Error {
+	if l == nil {
+		return nil
+	}
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	if l.gzw != nil {
+		if e := l.gzw.Close(); e != nil {
+			l.file.Close()
+			return probe.NewError(e)
+		}
+	}
+	if e := l.file.Close(); e != nil {
+		return probe.NewError(e)
+	}
+	return nil
+}
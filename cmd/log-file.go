@@ -0,0 +1,115 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/minio/mc/pkg/probe"
+)
+
+// Log files are rotated once they grow beyond this size.
+const logFileMaxSize = 100 * 1024 * 1024
+
+// logEntry - a line of the log file.
+type logEntry struct {
+	Time    time.Time       `json:"time"`
+	Level   string          `json:"level"`
+	Command string          `json:"command,omitempty"`
+	Message string          `json:"message,omitempty"`
+	Error   string          `json:"error,omitempty"`
+	Result  json.RawMessage `json:"result,omitempty"`
+}
+
+// logFile - writes errors and results of commands as JSON lines,
+// independently of the console output.
+type logFile struct {
+	mutex   sync.Mutex
+	out     *rotatingFile
+	command string
+	failed  bool
+}
+
+// openLogFile - opens the log file at path for appending.
+func openLogFile(path, command string) (*logFile, *probe.Error) {
+	out, err := openRotatingFile(path, logFileMaxSize)
+	if err != nil {
+		return nil, err.Trace(path)
+	}
+	return &logFile{out: out, command: command}, nil
+}
+
+// setCommand - sets the command name of entries.
+func (l *logFile) setCommand(command string) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	if command != "" {
+		l.command = command
+	}
+}
+
+// write - appends entry to the log file.
+func (l *logFile) write(entry logEntry) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	entry.Time = UTCNow()
+	entry.Command = l.command
+	entryBytes, e := json.Marshal(entry)
+	if e != nil {
+		return
+	}
+	entryBytes = append(entryBytes, '\n')
+
+	if l.failed {
+		return
+	}
+	if _, e = l.out.Write(entryBytes); e != nil {
+		// Keep console output going, only logging stops.
+		fmt.Fprintf(os.Stderr, "Unable to write log file `%s`: %v\n", l.out.path, e)
+		l.failed = true
+	}
+}
+
+// Log file set via --log-file or MC_LOG_FILE.
+var globalLogFile *logFile
+
+// logResult - logs the result printed by a command.
+func logResult(msg message) {
+	if globalLogFile == nil {
+		return
+	}
+	var result bytes.Buffer
+	if e := json.Compact(&result, []byte(msg.JSON())); e != nil {
+		globalLogFile.write(logEntry{Level: "info", Message: msg.String()})
+		return
+	}
+	globalLogFile.write(logEntry{Level: "info", Result: result.Bytes()})
+}
+
+// logError - logs an error of a command at level "error" or "fatal".
+func logError(level string, err *probe.Error, msg string) {
+	if globalLogFile == nil {
+		return
+	}
+	globalLogFile.write(logEntry{Level: level, Message: msg, Error: err.ToGoError().Error()})
+}
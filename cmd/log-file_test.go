@@ -0,0 +1,74 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bufio"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLogFileRotate(t *testing.T) {
+	dir, e := ioutil.TempDir("", "mc-log-")
+	if e != nil {
+		t.Fatal(e)
+	}
+	defer os.RemoveAll(dir)
+
+	logPath := filepath.Join(dir, "mc.log")
+	l, err := openLogFile(logPath, "mirror")
+	if err != nil {
+		t.Fatal(err)
+	}
+	l.write(logEntry{Level: "info", Result: json.RawMessage(`{"status":"success"}`)})
+
+	// Pretend the log is full, the next entry starts a new file.
+	l.out.size = logFileMaxSize
+	l.write(logEntry{Level: "error", Message: "Failed to copy", Error: "Access Denied."})
+
+	for _, testCase := range []struct {
+		path  string
+		level string
+	}{
+		{logPath + ".1", "info"},
+		{logPath, "error"},
+	} {
+		f, e := os.Open(testCase.path)
+		if e != nil {
+			t.Fatal(e)
+		}
+		scanner := bufio.NewScanner(f)
+		var lines []string
+		for scanner.Scan() {
+			lines = append(lines, scanner.Text())
+		}
+		f.Close()
+		if len(lines) != 1 {
+			t.Fatalf("%s: Expected 1 entry, got %d", testCase.path, len(lines))
+		}
+		var entry logEntry
+		if e = json.Unmarshal([]byte(lines[0]), &entry); e != nil {
+			t.Fatal(e)
+		}
+		if entry.Level != testCase.level || entry.Command != "mirror" || entry.Time.IsZero() {
+			t.Fatalf("%s: Unexpected entry %s", testCase.path, lines[0])
+		}
+	}
+}
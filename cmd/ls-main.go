@@ -18,10 +18,12 @@ package cmd
 
 import (
 	"strings"
+	"text/template"
 
 	"github.com/fatih/color"
 	"github.com/minio/cli"
 	"github.com/minio/mc/pkg/console"
+	"github.com/minio/mc/pkg/probe"
 )
 
 // ls specific flags.
@@ -35,6 +37,34 @@ var (
 			Name:  "incomplete, I",
 			Usage: "list incomplete uploads",
 		},
+		cli.BoolFlag{
+			Name:  "metadata",
+			Usage: "include user metadata of objects, requires an additional request per object",
+		},
+		cli.StringFlag{
+			Name:  "sort",
+			Usage: "sort entries by 'name', 'size' or 'time'",
+		},
+		cli.BoolFlag{
+			Name:  "reverse",
+			Usage: "reverse the sort order",
+		},
+		cli.BoolFlag{
+			Name:  "summarize",
+			Usage: "print the total number and size of objects",
+		},
+		cli.StringFlag{
+			Name:  "format",
+			Usage: "print entries using a Go template",
+		},
+		cli.IntFlag{
+			Name:  "max-keys",
+			Usage: "list at most N entries and print the key to continue from",
+		},
+		cli.StringFlag{
+			Name:  "start-after",
+			Usage: "list entries after this object key",
+		},
 	}
 )
 
@@ -54,6 +84,20 @@ USAGE:
 FLAGS:
   {{range .VisibleFlags}}{{.}}
   {{end}}
+ENVIRONMENT VARIABLES:
+  MC_LIST_WORKERS:  number of prefixes listed concurrently by recursive listings (default 8)
+
+FORMAT:
+  --format templates are executed for every entry, available fields are
+  .Key, .Size, .Time, .ETag, .Filetype and .Metadata. Sorting needs all
+  entries in memory before anything is printed.
+
+PAGINATION:
+  --max-keys and --start-after are supported on S3 compatible storage only.
+  --start-after takes a key relative to the bucket. When more entries are
+  available, the key to pass to --start-after to list the next page is printed,
+  with --json it is the 'nextStartAfter' field of the last message.
+
 EXAMPLES:
   1. List buckets on Amazon S3 cloud storage.
      $ {{.HelpName}} s3
@@ -72,6 +116,18 @@ EXAMPLES:
 
   6. List incomplete (previously failed) uploads of objects on Amazon S3.
      $ {{.HelpName}} --incomplete s3/mybucket
+
+  7. List all objects of mybucket along with their user metadata as JSON.
+     $ {{.HelpName}} --metadata --json s3/mybucket
+
+  8. List the largest objects of mybucket first, with their total count and size.
+     $ {{.HelpName}} --recursive --sort size --reverse --summarize s3/mybucket
+
+  9. List the key and size in bytes of all objects of mybucket, separated by a comma.
+     $ {{.HelpName}} --recursive --format '{{"{{"}}.Key{{"}}"}},{{"{{"}}.Size{{"}}"}}' s3/mybucket
+
+  10. List the next 1000 objects of mybucket after 'photos/2019/kitten.png' as JSON.
+      $ {{.HelpName}} --recursive --json --max-keys 1000 --start-after photos/2019/kitten.png s3/mybucket
 `,
 }
 
@@ -86,6 +142,21 @@ func checkListSyntax(ctx *cli.Context) {
 			fatalIf(errInvalidArgument().Trace(args...), "Unable to validate empty argument.")
 		}
 	}
+	switch ctx.String("sort") {
+	case "", lsSortName, lsSortSize, lsSortTime:
+	default:
+		fatalIf(errInvalidArgument().Trace(ctx.String("sort")), "Invalid --sort value, supported values are [name, size, time].")
+	}
+	if ctx.Int("max-keys") < 0 {
+		fatalIf(errInvalidArgument().Trace(ctx.String("max-keys")), "--max-keys cannot be negative.")
+	}
+	if (ctx.Int("max-keys") > 0 || ctx.String("start-after") != "") && ctx.Bool("incomplete") {
+		fatalIf(errInvalidArgument(), "--max-keys and --start-after cannot be used with --incomplete.")
+	}
+	if format := ctx.String("format"); format != "" {
+		_, e := template.New("ls").Parse(format)
+		fatalIf(probe.NewError(e).Trace(format), "Unable to parse --format template.")
+	}
 	// extract URLs.
 	URLs := ctx.Args()
 	isIncomplete := ctx.Bool("incomplete")
@@ -110,6 +181,8 @@ func mainList(ctx *cli.Context) error {
 	console.SetColor("Dir", color.New(color.FgCyan, color.Bold))
 	console.SetColor("Size", color.New(color.FgYellow))
 	console.SetColor("Time", color.New(color.FgGreen))
+	console.SetColor("Metadata", color.New(color.FgWhite))
+	console.SetColor("Summary", color.New(color.Bold))
 
 	// check 'ls' cli arguments.
 	checkListSyntax(ctx)
@@ -117,6 +190,17 @@ func mainList(ctx *cli.Context) error {
 	// Set command flags from context.
 	isRecursive := ctx.Bool("recursive")
 	isIncomplete := ctx.Bool("incomplete")
+	opts := lsOptions{
+		isMetadata:  ctx.Bool("metadata"),
+		isSummarize: ctx.Bool("summarize"),
+		isReverse:   ctx.Bool("reverse"),
+		sortBy:      ctx.String("sort"),
+		startAfter:  ctx.String("start-after"),
+		maxKeys:     ctx.Int("max-keys"),
+	}
+	if format := ctx.String("format"); format != "" {
+		opts.format = template.Must(template.New("ls").Parse(format))
+	}
 
 	args := ctx.Args()
 	// mimic operating system tool behavior.
@@ -139,7 +223,8 @@ func mainList(ctx *cli.Context) error {
 			}
 		}
 
-		if e := doList(clnt, isRecursive, isIncomplete); e != nil {
+		targetAlias, _, _ := mustExpandAlias(targetURL)
+		if e := doList(clnt, targetAlias, isRecursive, isIncomplete, opts); e != nil {
 			cErr = e
 		}
 	}
@@ -20,7 +20,9 @@ import (
 	"fmt"
 	"path/filepath"
 	"runtime"
+	"sort"
 	"strings"
+	"text/template"
 	"time"
 
 	humanize "github.com/dustin/go-humanize"
@@ -36,12 +38,13 @@ const (
 
 // contentMessage container for content message structure.
 type contentMessage struct {
-	Status   string    `json:"status"`
-	Filetype string    `json:"type"`
-	Time     time.Time `json:"lastModified"`
-	Size     int64     `json:"size"`
-	Key      string    `json:"key"`
-	ETag     string    `json:"etag"`
+	Status   string            `json:"status"`
+	Filetype string            `json:"type"`
+	Time     time.Time         `json:"lastModified"`
+	Size     int64             `json:"size"`
+	Key      string            `json:"key"`
+	ETag     string            `json:"etag"`
+	Metadata map[string]string `json:"metadata,omitempty"`
 }
 
 // String colorized string message.
@@ -54,6 +57,14 @@ func (c contentMessage) String() string {
 		}
 		return message + console.Colorize("File", c.Key)
 	}()
+	if len(c.Metadata) > 0 {
+		var metadata []string
+		for k, v := range c.Metadata {
+			metadata = append(metadata, k+"="+v)
+		}
+		sort.Strings(metadata)
+		message = message + console.Colorize("Metadata", " "+strings.Join(metadata, ","))
+	}
 	return message
 }
 
@@ -66,6 +77,149 @@ func (c contentMessage) JSON() string {
 	return string(jsonMessageBytes)
 }
 
+// lsFormatMessage is a content message printed with a user defined
+// template.
+type lsFormatMessage struct {
+	contentMessage
+	format *template.Template
+}
+
+// String content message formatted with the user defined template.
+func (l lsFormatMessage) String() string {
+	var b strings.Builder
+	if e := l.format.Execute(&b, l.contentMessage); e != nil {
+		fatalIf(probe.NewError(e), "Unable to format `"+l.Key+"`.")
+	}
+	return b.String()
+}
+
+// lsSummaryMessage container for the totals of a listing.
+type lsSummaryMessage struct {
+	Status  string `json:"status"`
+	Objects int64  `json:"totalObjects"`
+	Size    int64  `json:"totalSize"`
+}
+
+// String colorized summary message.
+func (l lsSummaryMessage) String() string {
+	return console.Colorize("Summary", fmt.Sprintf("Total: %d objects, %s", l.Objects,
+		strings.Join(strings.Fields(humanize.IBytes(uint64(l.Size))), "")))
+}
+
+// JSON jsonified summary message.
+func (l lsSummaryMessage) JSON() string {
+	l.Status = "success"
+	summaryMessageBytes, e := json.MarshalIndent(l, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(summaryMessageBytes)
+}
+
+// Sort orders supported by ls --sort.
+const (
+	lsSortName = "name"
+	lsSortSize = "size"
+	lsSortTime = "time"
+)
+
+// lsPageMessage container for the continuation point of a paged listing.
+type lsPageMessage struct {
+	Status         string `json:"status"`
+	IsTruncated    bool   `json:"isTruncated"`
+	NextStartAfter string `json:"nextStartAfter,omitempty"`
+}
+
+// String colorized page message.
+func (l lsPageMessage) String() string {
+	if !l.IsTruncated {
+		return ""
+	}
+	return console.Colorize("Summary", "More entries available, continue with --start-after '"+l.NextStartAfter+"'")
+}
+
+// JSON jsonified page message.
+func (l lsPageMessage) JSON() string {
+	l.Status = "success"
+	pageMessageBytes, e := json.MarshalIndent(l, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(pageMessageBytes)
+}
+
+// lsOptions - options which change what ls prints and how.
+type lsOptions struct {
+	isMetadata  bool
+	isSummarize bool
+	isReverse   bool
+	sortBy      string
+	format      *template.Template
+	startAfter  string
+	maxKeys     int
+}
+
+// isPaged returns true if only a page of the listing is requested.
+func (o lsOptions) isPaged() bool {
+	return o.startAfter != "" || o.maxKeys > 0
+}
+
+// listPage lists a single page of entries, the returned message holds
+// the key to resume listing from.
+func listPage(clnt Client, isRecursive bool, opts lsOptions) (<-chan *clientContent, lsPageMessage, *probe.Error) {
+	s3Clnt, ok := clnt.(*s3Client)
+	if !ok {
+		return nil, lsPageMessage{}, probe.NewError(APINotImplemented{API: "ListPage", APIType: "filesystem"})
+	}
+	contents, next, err := s3Clnt.ListPage(isRecursive, opts.startAfter, opts.maxKeys)
+	if err != nil {
+		return nil, lsPageMessage{}, err
+	}
+	contentCh := make(chan *clientContent, len(contents))
+	for _, content := range contents {
+		contentCh <- content
+	}
+	close(contentCh)
+	return contentCh, lsPageMessage{IsTruncated: next != "", NextStartAfter: next}, nil
+}
+
+// isSorted returns true if contents need to be collected and sorted
+// before printing.
+func (o lsOptions) isSorted() bool {
+	return o.sortBy != "" || o.isReverse
+}
+
+// sortContents sorts content messages by name, size or modification
+// time, entries of equal size or time are ordered by name.
+func sortContents(contents []contentMessage, sortBy string, isReverse bool) {
+	less := func(i, j int) bool {
+		switch sortBy {
+		case lsSortSize:
+			if contents[i].Size != contents[j].Size {
+				return contents[i].Size < contents[j].Size
+			}
+		case lsSortTime:
+			if !contents[i].Time.Equal(contents[j].Time) {
+				return contents[i].Time.Before(contents[j].Time)
+			}
+		}
+		return contents[i].Key < contents[j].Key
+	}
+	if isReverse {
+		sort.SliceStable(contents, func(i, j int) bool { return less(j, i) })
+		return
+	}
+	sort.SliceStable(contents, less)
+}
+
+// printContent prints a content message, with the user defined template
+// if any.
+func printContent(content contentMessage, format *template.Template) {
+	if format != nil {
+		printMsg(lsFormatMessage{content, format})
+		return
+	}
+	printMsg(content)
+}
+
 // parseContent parse client Content container into printer struct.
 func parseContent(c *clientContent) contentMessage {
 	content := contentMessage{}
@@ -104,15 +258,50 @@ func getKey(c *clientContent) string {
 	return c.URL.Path
 }
 
-// doList - list all entities inside a folder.
-func doList(clnt Client, isRecursive, isIncomplete bool) error {
+// getUserMetadata returns the user defined metadata of an object.
+func getUserMetadata(alias string, content *clientContent) (map[string]string, *probe.Error) {
+	clnt, err := newClientFromAlias(alias, content.URL.String())
+	if err != nil {
+		return nil, err.Trace(alias, content.URL.String())
+	}
+	st, err := clnt.Stat(false, true, nil)
+	if err != nil {
+		return nil, err.Trace(alias, content.URL.String())
+	}
+	metadata := make(map[string]string)
+	for k, v := range st.Metadata {
+		if strings.HasPrefix(strings.ToLower(k), "x-amz-meta-") {
+			metadata[k] = v
+		}
+	}
+	return metadata, nil
+}
+
+// doList - list all entities inside a folder, user metadata of objects
+// is fetched with an additional request per object if opts.isMetadata is
+// set.
+func doList(clnt Client, alias string, isRecursive, isIncomplete bool, opts lsOptions) error {
 	prefixPath := clnt.GetURL().Path
 	separator := string(clnt.GetURL().Separator)
 	if !strings.HasSuffix(prefixPath, separator) {
 		prefixPath = prefixPath[:strings.LastIndex(prefixPath, separator)+1]
 	}
 	var cErr error
-	for content := range clnt.List(isRecursive, isIncomplete, DirNone) {
+	var contents []contentMessage
+	var summary lsSummaryMessage
+
+	var contentCh <-chan *clientContent
+	var page lsPageMessage
+	if opts.isPaged() {
+		var err *probe.Error
+		if contentCh, page, err = listPage(clnt, isRecursive, opts); err != nil {
+			errorIf(err.Trace(clnt.GetURL().String()), "Unable to list folder.")
+			return exitStatus(globalErrorExitStatus)
+		}
+	} else {
+		contentCh = clnt.List(isRecursive, isIncomplete, DirNone)
+	}
+	for content := range contentCh {
 		if content.Err != nil {
 			switch content.Err.ToGoError().(type) {
 			// handle this specifically for filesystem related errors.
@@ -136,6 +325,14 @@ func doList(clnt Client, isRecursive, isIncomplete bool) error {
 			cErr = exitStatus(globalErrorExitStatus) // Set the exit status.
 			continue
 		}
+		var metadata map[string]string
+		if opts.isMetadata && !isIncomplete && !content.Type.IsDir() {
+			var err *probe.Error
+			if metadata, err = getUserMetadata(alias, content); err != nil {
+				errorIf(err.Trace(clnt.GetURL().String()), "Unable to fetch metadata.")
+				cErr = exitStatus(globalErrorExitStatus) // Set the exit status.
+			}
+		}
 		// Convert any os specific delimiters to "/".
 		contentURL := filepath.ToSlash(content.URL.Path)
 		prefixPath = filepath.ToSlash(prefixPath)
@@ -146,8 +343,30 @@ func doList(clnt Client, isRecursive, isIncomplete bool) error {
 		contentURL = strings.TrimPrefix(contentURL, prefixPath)
 		content.URL.Path = contentURL
 		parsedContent := parseContent(content)
+		parsedContent.Metadata = metadata
+		if !content.Type.IsDir() {
+			summary.Objects++
+			summary.Size += content.Size
+		}
+		if opts.isSorted() {
+			contents = append(contents, parsedContent)
+			continue
+		}
 		// Print colorized or jsonized content info.
-		printMsg(parsedContent)
+		printContent(parsedContent, opts.format)
+	}
+
+	if opts.isSorted() {
+		sortContents(contents, opts.sortBy, opts.isReverse)
+		for _, content := range contents {
+			printContent(content, opts.format)
+		}
+	}
+	if opts.isSummarize {
+		printMsg(summary)
+	}
+	if opts.isPaged() && (page.IsTruncated || globalJSON) {
+		printMsg(page)
 	}
 	return cErr
 }
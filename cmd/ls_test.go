@@ -15,3 +15,39 @@
  */
 
 package cmd
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSortContents(t *testing.T) {
+	now := time.Now()
+	contents := []contentMessage{
+		{Key: "b", Size: 10, Time: now.Add(-time.Hour)},
+		{Key: "a", Size: 30, Time: now},
+		{Key: "c", Size: 10, Time: now.Add(-2 * time.Hour)},
+	}
+	testCases := []struct {
+		sortBy    string
+		isReverse bool
+		expected  []string
+	}{
+		{lsSortName, false, []string{"a", "b", "c"}},
+		{lsSortName, true, []string{"c", "b", "a"}},
+		{"", true, []string{"c", "b", "a"}},
+		{lsSortSize, false, []string{"b", "c", "a"}},
+		{lsSortSize, true, []string{"a", "c", "b"}},
+		{lsSortTime, false, []string{"c", "b", "a"}},
+		{lsSortTime, true, []string{"a", "b", "c"}},
+	}
+	for i, testCase := range testCases {
+		sorted := append([]contentMessage{}, contents...)
+		sortContents(sorted, testCase.sortBy, testCase.isReverse)
+		for j, content := range sorted {
+			if content.Key != testCase.expected[j] {
+				t.Fatalf("Test %d: expected %v at %d, got %s", i+1, testCase.expected, j, content.Key)
+			}
+		}
+	}
+}
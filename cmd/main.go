@@ -60,6 +60,10 @@ COMMANDS:
 GLOBAL FLAGS:
   {{range .VisibleFlags}}{{.}}
   {{end}}{{end}}
+EXIT STATUS:
+  0 success, 1 error, 3 partial failure, 4 authentication error, 5 not found,
+  6 quota exceeded, 130 interrupted. With --json a summary of errors is printed last.
+
 TIP:
   Use '{{.Name}} --autocompletion' to enable shell autocompletion
 
@@ -106,10 +110,16 @@ func Main(args []string) {
 	// Set the mc app name.
 	appName := filepath.Base(args[0])
 
+	// Exit statuses tell the kind of errors apart, a summary of the
+	// errors is printed at exit in JSON mode.
+	cli.OsExiter = exitWithSummary
+	console.Exit = exitWithSummary
+
 	// Run the app - exit on error.
 	if err := registerApp(appName).Run(args); err != nil {
-		os.Exit(1)
+		exitWithSummary(1)
 	}
+	printSummary(0)
 }
 
 // Function invoked when invalid command is passed.
@@ -313,19 +323,35 @@ var appCmds = []cli.Command{
 	catCmd,
 	headCmd,
 	pipeCmd,
+	extractCmd,
 	shareCmd,
 	findCmd,
 	sqlCmd,
 	statCmd,
 	treeCmd,
+	browseCmd,
 	duCmd,
+	sampleCmd,
+	benchCmd,
 	diffCmd,
 	rmCmd,
 	eventCmd,
 	watchCmd,
+	mountCmd,
+	agentCmd,
+	jobCmd,
 	policyCmd,
+	aclCmd,
+	encryptCmd,
+	applyCmd,
 	adminCmd,
+	supportCmd,
+	aliasCmd,
+	shellCmd,
+	pingCmd,
+	readyCmd,
 	sessionCmd,
+	cacheCmd,
 	configCmd,
 	updateCmd,
 	versionCmd,
@@ -29,7 +29,7 @@ var (
 		cli.StringFlag{
 			Name:  "region",
 			Value: "us-east-1",
-			Usage: "specify bucket region; defaults to 'us-east-1'",
+			Usage: "specify bucket region; defaults to the region of the alias or 'us-east-1'",
 		},
 		cli.BoolFlag{
 			Name:  "ignore-existing, p",
@@ -125,8 +125,18 @@ func mainMakeBucket(ctx *cli.Context) error {
 			continue
 		}
 
+		// Buckets are made in the region of the alias, unless
+		// another one is requested.
+		bucketRegion := region
+		if !ctx.IsSet("region") {
+			alias, _ := url2Alias(targetURL)
+			if hostCfg := mustGetHostConfig(alias); hostCfg != nil && hostCfg.Region != "" {
+				bucketRegion = hostCfg.Region
+			}
+		}
+
 		// Make bucket.
-		err = clnt.MakeBucket(region, ignoreExisting)
+		err = clnt.MakeBucket(bucketRegion, ignoreExisting)
 		if err != nil {
 			switch err.ToGoError().(type) {
 			case BucketNameEmpty:
@@ -91,6 +91,35 @@ var (
 			Name:  "attr",
 			Usage: "add custom metadata for all objects",
 		},
+		cli.StringFlag{
+			Name:  "ledger",
+			Usage: "record every transferred object into a JSON lines file, gzip compressed if it ends with .gz",
+		},
+		cli.StringFlag{
+			Name:  "progress",
+			Usage: "progress renderer, one of [bar, dots, rate, none, json], defaults to a bar on terminals",
+		},
+		cli.StringFlag{
+			Name:  "notify-url",
+			Usage: "post a JSON event to this URL for every transferred object and once when done",
+		},
+		cli.StringFlag{
+			Name:  "exec-on-complete",
+			Usage: "run a command for every transferred object and once when done, details are passed as MC_HOOK_* environment variables",
+		},
+		cli.BoolFlag{
+			Name:  "auto-restore",
+			Usage: "restore archived objects (GLACIER, DEEP_ARCHIVE) and wait until they are available before mirroring",
+		},
+		cli.IntFlag{
+			Name:  "restore-days",
+			Value: 1,
+			Usage: "number of days restored copies stay available, used with --auto-restore",
+		},
+		cli.BoolFlag{
+			Name:  "server-side",
+			Usage: "copy objects on the server between aliases of the same MinIO deployment, requires admin credentials",
+		},
 	}
 )
 
@@ -113,6 +142,14 @@ FLAGS:
 ENVIRONMENT VARIABLES:
    MC_ENCRYPT:      list of comma delimited prefixes
    MC_ENCRYPT_KEY:  list of comma delimited prefix=secret values
+   MC_CREDENTIALS_<alias>:  command printing fresh credentials for <alias> as JSON when they expire
+   MC_LIST_WORKERS:  number of prefixes listed concurrently by recursive listings (default 8)
+
+HOOKS:
+   --exec-on-complete runs its command with MC_HOOK_EVENT set to 'object' for every transfer,
+   along with MC_HOOK_SOURCE, MC_HOOK_TARGET, MC_HOOK_SIZE, MC_HOOK_RESULT and MC_HOOK_ERROR.
+   Once all transfers are done it runs again with MC_HOOK_EVENT set to 'session', along with
+   MC_HOOK_OBJECTS, MC_HOOK_SIZE and MC_HOOK_ERRORS. --notify-url receives the same events as JSON.
 
 EXAMPLES:
   01. Mirror a bucket recursively from MinIO cloud storage to a bucket on Amazon S3 cloud storage.
@@ -157,6 +194,21 @@ EXAMPLES:
 
   13. Update 'Cache-Control' header on existing objects.
       $ {{.HelpName}} --attr Cache-Control=max-age=90000,min-fresh=9000 myminio/video-files myminio/video-files
+
+  14. Mirror a local folder to MinIO cloud storage and record every transfer in a compressed ledger.
+      $ {{.HelpName}} --ledger run1.ndjson.gz backup/ play/archive
+
+  15. Mirror a bucket from Amazon S3, restoring archived objects for 3 days before mirroring them.
+      $ {{.HelpName}} --auto-restore --restore-days 3 s3/archive play/archive
+
+  16. Mirror a bucket from a CI job, printing a dot every second of activity instead of a progress bar.
+      $ {{.HelpName}} --progress dots s3/builds play/builds
+
+  17. Migrate a bucket between two tenants of the same MinIO deployment without streaming the data through mc.
+      $ {{.HelpName}} --server-side tenant1/data tenant2/data
+
+  18. Continuously mirror a local folder and run a script for every uploaded file.
+      $ {{.HelpName}} --watch --exec-on-complete /usr/local/bin/index-upload.sh /var/lib/uploads play/uploads
 `,
 }
 
@@ -195,6 +247,18 @@ type mirrorJob struct {
 
 	excludeOptions []string
 	encKeyDB       map[string][]prefixSSEPair
+
+	// optional ledger of all transferred objects
+	ledger *transferLedger
+
+	// optional notifications about completed transfers
+	hook *transferHook
+
+	// restore archived objects for this many days, zero disables restore
+	restoreDays int
+
+	// copy objects on the server between aliases of the same deployment
+	serverSide bool
 }
 
 // mirrorMessage container for file mirror messages
@@ -226,7 +290,11 @@ func (mj *mirrorJob) doRemove(sURLs URLs) URLs {
 	if mj.isFake {
 		return sURLs.WithError(nil)
 	}
+	return removeMirrorTarget(sURLs)
+}
 
+// removeMirrorTarget - removes a file on target which is not on source.
+func removeMirrorTarget(sURLs URLs) URLs {
 	// Construct proper path with alias.
 	targetWithAlias := filepath.Join(sURLs.TargetAlias, sURLs.TargetContent.URL.Path)
 	clnt, pErr := newClient(targetWithAlias)
@@ -285,6 +353,10 @@ func (mj *mirrorJob) doMirror(ctx context.Context, cancelMirror context.CancelFu
 	// Initialize additional target user metadata.
 	sURLs.TargetContent.UserMetadata = mj.userMetadata
 
+	// Restore archived objects before mirroring if requested.
+	sURLs.restoreDays = mj.restoreDays
+	sURLs.serverSide = mj.serverSide
+
 	sourcePath := filepath.ToSlash(filepath.Join(sourceAlias, sourceURL.Path))
 	targetPath := filepath.ToSlash(filepath.Join(targetAlias, targetURL.Path))
 	mj.status.PrintMsg(mirrorMessage{
@@ -294,7 +366,13 @@ func (mj *mirrorJob) doMirror(ctx context.Context, cancelMirror context.CancelFu
 		TotalCount: sURLs.TotalCount,
 		TotalSize:  sURLs.TotalSize,
 	})
-	return uploadSourceToTargetURL(ctx, sURLs, mj.status, mj.encKeyDB)
+	startTime := UTCNow()
+	sURLs = retryOnExpiredCredentials(sURLs, func() URLs {
+		return uploadSourceToTargetURL(ctx, sURLs, mj.status, mj.encKeyDB)
+	})
+	mj.ledger.Record(sURLs, startTime)
+	mj.hook.Object(sURLs, startTime)
+	return sURLs
 }
 
 // Update progress status
@@ -671,6 +749,39 @@ func runMirror(srcURL, dstURL string, ctx *cli.Context, encKeyDB map[string][]pr
 		userMetaMap,
 		encKeyDB)
 
+	if ledgerPath := ctx.String("ledger"); ledgerPath != "" {
+		var err *probe.Error
+		mj.ledger, err = newTransferLedger(ledgerPath)
+		fatalIf(err.Trace(ledgerPath), "Unable to open transfer ledger.")
+		defer mj.ledger.Close()
+	}
+
+	var err *probe.Error
+	mj.hook, err = newTransferHook(ctx.String("notify-url"), ctx.String("exec-on-complete"))
+	fatalIf(err, "Unable to set up completion hooks.")
+
+	// Replace the default status with the requested progress renderer.
+	if progress := ctx.String("progress"); progress != "" {
+		fatalIf(checkProgressMode(progress).Trace(progress), "Unable to use progress renderer.")
+		if isProgressBarMode(progress) {
+			mj.status = NewProgressStatus(mj.parallel)
+		} else {
+			mj.status = NewLineStatus(mj.parallel, progress)
+		}
+	}
+
+	if ctx.Bool("auto-restore") {
+		mj.restoreDays = ctx.Int("restore-days")
+		if mj.restoreDays < 1 {
+			fatalIf(errInvalidArgument().Trace(ctx.String("restore-days")), "Restore days should be at least 1.")
+		}
+	}
+
+	if ctx.Bool("server-side") {
+		fatalIf(checkServerSideMirror(srcURL, dstURL), "Unable to mirror on the server.")
+		mj.serverSide = true
+	}
+
 	srcClt, err := newClient(srcURL)
 	fatalIf(err, "Unable to initialize `"+srcURL+"`.")
 
@@ -738,7 +849,9 @@ func runMirror(srcURL, dstURL string, ctx *cli.Context, encKeyDB map[string][]pr
 	defer cancelMirror()
 
 	// Start mirroring job
-	return mj.mirror(ctxt, cancelMirror)
+	errorDetected := mj.mirror(ctxt, cancelMirror)
+	mj.hook.Session()
+	return errorDetected
 }
 
 // Main entry point for mirror command.
@@ -758,9 +871,34 @@ func mainMirror(ctx *cli.Context) error {
 	srcURL := args[0]
 	tgtURL := args[1]
 
-	if errorDetected := runMirror(srcURL, tgtURL, ctx, encKeyDB); errorDetected {
+	// Continuous and fake mirrors are not recorded in a session, running
+	// them again is all it takes to resume them.
+	if ctx.Bool("watch") || ctx.Bool("fake") {
+		if errorDetected := runMirror(srcURL, tgtURL, ctx, encKeyDB); errorDetected {
+			return exitStatus(globalErrorExitStatus)
+		}
+		return nil
+	}
+
+	session := newCommandSession(ctx, append(mirrorFlags, ioFlags...))
+	errorDetected := doMirrorSession(session, ctx, encKeyDB)
+	session.Delete()
+	if errorDetected {
 		return exitStatus(globalErrorExitStatus)
 	}
 
 	return nil
 }
+
+// doMirrorSession mirrors the source and target recorded in a session,
+// the session is saved for 'mc session resume' if interrupted. Objects
+// already mirrored are skipped when resuming since they no longer differ.
+func doMirrorSession(session *sessionV8, ctx *cli.Context, encKeyDB map[string][]prefixSSEPair) bool {
+	trapCh := signalTrap(os.Interrupt, syscall.SIGTERM, syscall.SIGKILL)
+	args := ctx.Args()
+	errorDetected := runMirror(args.Get(0), args.Get(1), ctx, encKeyDB)
+	if isSessionInterrupted(trapCh) {
+		session.CloseAndDie()
+	}
+	return errorDetected
+}
@@ -0,0 +1,124 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+
+	"github.com/minio/mc/pkg/probe"
+)
+
+// serverHost returns the normalized host of an alias endpoint.
+func serverHost(endpoint string) (string, *probe.Error) {
+	u, e := url.Parse(endpoint)
+	if e != nil {
+		return "", probe.NewError(e).Trace(endpoint)
+	}
+	host := strings.ToLower(u.Host)
+	if u.Port() == "" {
+		switch u.Scheme {
+		case "https":
+			host += ":443"
+		case "http":
+			host += ":80"
+		}
+	}
+	return host, nil
+}
+
+// checkSameVersion verifies that all the servers of both deployments
+// run the same MinIO release.
+func checkSameVersion(srcVersions, dstVersions []string) *probe.Error {
+	var version string
+	for _, v := range append(srcVersions, dstVersions...) {
+		if version == "" {
+			version = v
+			continue
+		}
+		if v != version {
+			return probe.NewError(errors.New("servers run different MinIO versions `" + version + "` and `" + v + "`"))
+		}
+	}
+	if version == "" {
+		return probe.NewError(errors.New("unable to find the MinIO server version"))
+	}
+	return nil
+}
+
+// serverVersions returns the MinIO release of every server of the
+// deployment behind aliasedURL, admin credentials are required.
+func serverVersions(aliasedURL string) ([]string, *probe.Error) {
+	client, err := newAdminClient(aliasedURL)
+	if err != nil {
+		return nil, err.Trace(aliasedURL)
+	}
+	serversInfo, e := client.ServerInfo()
+	if e != nil {
+		return nil, probe.NewError(e).Trace(aliasedURL)
+	}
+	var versions []string
+	for _, serverInfo := range serversInfo {
+		if serverInfo.Error != "" {
+			return nil, probe.NewError(errors.New(serverInfo.Error)).Trace(serverInfo.Addr)
+		}
+		versions = append(versions, serverInfo.Data.Properties.Version)
+	}
+	return versions, nil
+}
+
+// checkServerSideMirror verifies that objects can be copied from srcURL to
+// dstURL entirely on the server, without streaming them through mc. This
+// requires both aliases to be the same MinIO deployment, possibly with
+// different credentials, since MinIO servers cannot stream objects to
+// another deployment.
+func checkServerSideMirror(srcURL, dstURL string) *probe.Error {
+	_, _, srcCfg, err := expandAlias(srcURL)
+	if err != nil {
+		return err.Trace(srcURL)
+	}
+	_, _, dstCfg, err := expandAlias(dstURL)
+	if err != nil {
+		return err.Trace(dstURL)
+	}
+	if srcCfg == nil || dstCfg == nil {
+		return probe.NewError(errors.New("server side mirroring requires both source and target to be MinIO aliases"))
+	}
+
+	srcHost, err := serverHost(srcCfg.URL)
+	if err != nil {
+		return err.Trace(srcURL)
+	}
+	dstHost, err := serverHost(dstCfg.URL)
+	if err != nil {
+		return err.Trace(dstURL)
+	}
+	if srcHost != dstHost {
+		return probe.NewError(errors.New("server side mirroring between different MinIO deployments is not supported"))
+	}
+
+	srcVersions, err := serverVersions(srcURL)
+	if err != nil {
+		return err.Trace(srcURL)
+	}
+	dstVersions, err := serverVersions(dstURL)
+	if err != nil {
+		return err.Trace(dstURL)
+	}
+	return checkSameVersion(srcVersions, dstVersions)
+}
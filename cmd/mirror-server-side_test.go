@@ -0,0 +1,59 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import "testing"
+
+func TestServerHost(t *testing.T) {
+	testCases := []struct {
+		endpoint string
+		host     string
+	}{
+		{"https://play.min.io", "play.min.io:443"},
+		{"http://localhost:9000", "localhost:9000"},
+		{"http://LOCALHOST", "localhost:80"},
+		{"https://play.min.io:443", "play.min.io:443"},
+	}
+	for i, testCase := range testCases {
+		host, err := serverHost(testCase.endpoint)
+		if err != nil {
+			t.Fatalf("Test %d: Unexpected error %v", i+1, err)
+		}
+		if host != testCase.host {
+			t.Errorf("Test %d: Expected %s, got %s", i+1, testCase.host, host)
+		}
+	}
+}
+
+func TestCheckSameVersion(t *testing.T) {
+	testCases := []struct {
+		src, dst    []string
+		expectedErr bool
+	}{
+		{[]string{"2019-09-18T21-55-05Z"}, []string{"2019-09-18T21-55-05Z"}, false},
+		{[]string{"2019-09-18T21-55-05Z", "2019-09-18T21-55-05Z"}, []string{"2019-09-18T21-55-05Z"}, false},
+		{[]string{"2019-09-18T21-55-05Z"}, []string{"2019-08-29T01-25-07Z"}, true},
+		{[]string{"2019-09-18T21-55-05Z", "2019-08-29T01-25-07Z"}, []string{"2019-09-18T21-55-05Z"}, true},
+		{nil, nil, true},
+	}
+	for i, testCase := range testCases {
+		err := checkSameVersion(testCase.src, testCase.dst)
+		if testCase.expectedErr != (err != nil) {
+			t.Errorf("Test %d: Expected error %t, got %v", i+1, testCase.expectedErr, err)
+		}
+	}
+}
@@ -0,0 +1,214 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio-go/v6/pkg/encrypt"
+)
+
+// mountCacheEntry is one cached directory listing.
+type mountCacheEntry struct {
+	contents []*clientContent
+	expiry   time.Time
+}
+
+// mountCache caches directory listings of a mount, keyed by the
+// directory path relative to the mount root. Lookups and stats of
+// files are served from the listing of their parent directory.
+type mountCache struct {
+	mutex   sync.Mutex
+	ttl     time.Duration
+	size    int
+	entries map[string]mountCacheEntry
+}
+
+// newMountCache returns a cache keeping up to size listings for
+// ttl, a zero ttl or size disables caching.
+func newMountCache(ttl time.Duration, size int) *mountCache {
+	return &mountCache{
+		ttl:     ttl,
+		size:    size,
+		entries: make(map[string]mountCacheEntry),
+	}
+}
+
+func (c *mountCache) get(dir string) ([]*clientContent, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	entry, ok := c.entries[dir]
+	if !ok {
+		return nil, false
+	}
+	if time.Now().After(entry.expiry) {
+		delete(c.entries, dir)
+		return nil, false
+	}
+	return entry.contents, true
+}
+
+func (c *mountCache) set(dir string, contents []*clientContent) {
+	if c.ttl <= 0 || c.size <= 0 {
+		return
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	now := time.Now()
+	if _, ok := c.entries[dir]; !ok && len(c.entries) >= c.size {
+		// Drop expired listings first, then the one closest to expiry.
+		var oldest string
+		var oldestExpiry time.Time
+		found := false
+		for k, entry := range c.entries {
+			if now.After(entry.expiry) {
+				delete(c.entries, k)
+				continue
+			}
+			if !found || entry.expiry.Before(oldestExpiry) {
+				oldest, oldestExpiry, found = k, entry.expiry, true
+			}
+		}
+		if len(c.entries) >= c.size {
+			delete(c.entries, oldest)
+		}
+	}
+	c.entries[dir] = mountCacheEntry{contents: contents, expiry: now.Add(c.ttl)}
+}
+
+func (c *mountCache) invalidate(dir string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	delete(c.entries, dir)
+}
+
+// mountFS holds the state shared by all files and directories of a
+// mounted bucket or prefix. Paths are relative to the mount root and
+// use '/' as separator, the root itself is "".
+type mountFS struct {
+	target   string
+	alias    string
+	readOnly bool
+	encKeyDB map[string][]prefixSSEPair
+	cache    *mountCache
+}
+
+func newMountFS(target string, readOnly bool, encKeyDB map[string][]prefixSSEPair, cache *mountCache) *mountFS {
+	alias, _ := url2Alias(target)
+	return &mountFS{
+		target:   target,
+		alias:    alias,
+		readOnly: readOnly,
+		encKeyDB: encKeyDB,
+		cache:    cache,
+	}
+}
+
+// mountJoin joins a name to a directory path relative to the mount root.
+func mountJoin(dir, name string) string {
+	if dir == "" {
+		return name
+	}
+	return dir + "/" + name
+}
+
+// mountParent returns the parent directory of a path relative to the mount root.
+func mountParent(p string) string {
+	dir := path.Dir(p)
+	if dir == "." {
+		return ""
+	}
+	return dir
+}
+
+// mountName returns the file or directory name of a listed content.
+func mountName(content *clientContent) string {
+	return path.Base(strings.TrimSuffix(filepath.ToSlash(content.URL.Path), "/"))
+}
+
+// urlOf returns the aliased URL of a path relative to the mount root.
+func (m *mountFS) urlOf(p string) string {
+	if p == "" {
+		return m.target
+	}
+	return m.target + "/" + p
+}
+
+// sse returns the encryption key configured for a path, if any.
+func (m *mountFS) sse(p string) encrypt.ServerSide {
+	return getSSE(m.urlOf(p), m.encKeyDB[m.alias])
+}
+
+// readDir lists the directory p, from the cache when possible.
+func (m *mountFS) readDir(p string) ([]*clientContent, *probe.Error) {
+	if contents, ok := m.cache.get(p); ok {
+		return contents, nil
+	}
+
+	dirURL := m.urlOf(p) + "/"
+	clnt, err := newClient(dirURL)
+	if err != nil {
+		return nil, err.Trace(dirURL)
+	}
+	dirPath := strings.TrimSuffix(filepath.ToSlash(clnt.GetURL().Path), "/")
+
+	var contents []*clientContent
+	// Drain the listing even after an error so the lister is not left blocked.
+	for content := range clnt.List(false, false, DirNone) {
+		if err != nil {
+			continue
+		}
+		if content.Err != nil {
+			err = content.Err.Trace(dirURL)
+			continue
+		}
+		// Object stores list the directory marker of a prefix along with its contents.
+		if strings.TrimSuffix(filepath.ToSlash(content.URL.Path), "/") == dirPath {
+			continue
+		}
+		contents = append(contents, content)
+	}
+	if err != nil {
+		return nil, err
+	}
+	m.cache.set(p, contents)
+	return contents, nil
+}
+
+// lookup returns the listed content of path p, or nil if p does not exist.
+func (m *mountFS) lookup(p string) (*clientContent, *probe.Error) {
+	contents, err := m.readDir(mountParent(p))
+	if err != nil {
+		return nil, err
+	}
+	name := path.Base(p)
+	for _, content := range contents {
+		if mountName(content) == name {
+			return content, nil
+		}
+	}
+	return nil, nil
+}
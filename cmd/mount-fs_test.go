@@ -0,0 +1,90 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMountCache(t *testing.T) {
+	contents := []*clientContent{{Size: 1}}
+
+	cache := newMountCache(time.Minute, 2)
+	cache.set("", contents)
+	cache.set("a", contents)
+	if _, ok := cache.get("a"); !ok {
+		t.Fatal("expected listing of `a` to be cached")
+	}
+
+	// A third listing evicts the one closest to expiry.
+	cache.set("b", contents)
+	if len(cache.entries) != 2 {
+		t.Fatalf("expected 2 cached listings, got %d", len(cache.entries))
+	}
+	if _, ok := cache.get(""); ok {
+		t.Fatal("expected listing of the root to be evicted")
+	}
+
+	cache.invalidate("b")
+	if _, ok := cache.get("b"); ok {
+		t.Fatal("expected listing of `b` to be invalidated")
+	}
+
+	expired := newMountCache(time.Nanosecond, 2)
+	expired.set("a", contents)
+	time.Sleep(time.Millisecond)
+	if _, ok := expired.get("a"); ok {
+		t.Fatal("expected listing of `a` to be expired")
+	}
+
+	disabled := newMountCache(0, 2)
+	disabled.set("a", contents)
+	if _, ok := disabled.get("a"); ok {
+		t.Fatal("expected no listing to be cached with a zero ttl")
+	}
+}
+
+func TestMountPaths(t *testing.T) {
+	testCases := []struct {
+		dir, name, path, parent string
+	}{
+		{"", "a", "a", ""},
+		{"a", "b", "a/b", "a"},
+		{"a/b", "c.txt", "a/b/c.txt", "a/b"},
+	}
+	for i, testCase := range testCases {
+		p := mountJoin(testCase.dir, testCase.name)
+		if p != testCase.path {
+			t.Errorf("Test %d: expected path %q, got %q", i+1, testCase.path, p)
+		}
+		if parent := mountParent(p); parent != testCase.parent {
+			t.Errorf("Test %d: expected parent %q, got %q", i+1, testCase.parent, parent)
+		}
+	}
+
+	fsys := newMountFS("play/bucket/prefix", false, nil, newMountCache(0, 0))
+	if fsys.alias != "play" {
+		t.Errorf("expected alias `play`, got %q", fsys.alias)
+	}
+	if u := fsys.urlOf(""); u != "play/bucket/prefix" {
+		t.Errorf("expected root url `play/bucket/prefix`, got %q", u)
+	}
+	if u := fsys.urlOf("a/b"); u != "play/bucket/prefix/a/b" {
+		t.Errorf("expected url `play/bucket/prefix/a/b`, got %q", u)
+	}
+}
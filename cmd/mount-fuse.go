@@ -0,0 +1,598 @@
+//go:build linux || darwin || freebsd
+// +build linux darwin freebsd
+
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"syscall"
+	"time"
+
+	"bazil.org/fuse"
+	"bazil.org/fuse/fs"
+	"github.com/minio/mc/pkg/probe"
+)
+
+// mountServe mounts fsys at mountPoint and serves it until it is
+// unmounted or trapCh fires, ready is called once the mount is live.
+func mountServe(fsys *mountFS, mountPoint string, trapCh <-chan bool, ready func()) *probe.Error {
+	options := []fuse.MountOption{
+		fuse.FSName(fsys.target),
+		fuse.Subtype("mc"),
+	}
+	if fsys.readOnly {
+		options = append(options, fuse.ReadOnly())
+	}
+
+	conn, e := fuse.Mount(mountPoint, options...)
+	if e != nil {
+		return probe.NewError(e)
+	}
+	defer conn.Close()
+
+	serveCh := make(chan error, 1)
+	go func() {
+		serveCh <- fs.Serve(conn, fsys)
+	}()
+
+	<-conn.Ready
+	if conn.MountError != nil {
+		return probe.NewError(conn.MountError)
+	}
+	ready()
+
+	go func() {
+		if <-trapCh {
+			errorIf(probe.NewError(fuse.Unmount(mountPoint)), "Unable to unmount `"+mountPoint+"`.")
+		}
+	}()
+
+	if e = <-serveCh; e != nil {
+		return probe.NewError(e)
+	}
+	return nil
+}
+
+// mountErrno converts a client error to the errno returned to the kernel.
+func mountErrno(err *probe.Error) error {
+	switch err.ToGoError().(type) {
+	case PathNotFound, ObjectMissing, BucketDoesNotExist:
+		return fuse.ENOENT
+	case PathInsufficientPermission:
+		return fuse.EPERM
+	case APINotImplemented:
+		return fuse.ENOTSUP
+	}
+	errorIf(err, "Unable to complete the filesystem request.")
+	return fuse.EIO
+}
+
+// Root - implements fs.FS.
+func (m *mountFS) Root() (fs.Node, error) {
+	return &mountDir{fsys: m, mtime: time.Now()}, nil
+}
+
+// mountNode returns the node for a listed content.
+func (m *mountFS) mountNode(p string, content *clientContent) fs.Node {
+	if content.Type.IsDir() {
+		return &mountDir{fsys: m, path: p, mtime: content.Time}
+	}
+	return &mountFile{fsys: m, path: p, size: content.Size, mtime: content.Time}
+}
+
+// remove deletes the object at the aliased URL.
+func (m *mountFS) remove(urlStr string) *probe.Error {
+	clnt, err := newClient(urlStr)
+	if err != nil {
+		return err.Trace(urlStr)
+	}
+	contentCh := make(chan *clientContent, 1)
+	contentCh <- &clientContent{URL: clnt.GetURL()}
+	close(contentCh)
+	for err = range clnt.Remove(false, false, contentCh) {
+		if err != nil {
+			return err.Trace(urlStr)
+		}
+	}
+	return nil
+}
+
+// mountDir is a directory, a prefix on object storage.
+type mountDir struct {
+	fsys  *mountFS
+	path  string
+	mtime time.Time
+}
+
+// Attr - implements fs.Node.
+func (d *mountDir) Attr(ctx context.Context, a *fuse.Attr) error {
+	a.Mode = os.ModeDir | 0755
+	if d.fsys.readOnly {
+		a.Mode = os.ModeDir | 0555
+	}
+	a.Mtime = d.mtime
+	return nil
+}
+
+// Lookup - implements fs.NodeStringLookuper.
+func (d *mountDir) Lookup(ctx context.Context, name string) (fs.Node, error) {
+	p := mountJoin(d.path, name)
+	content, err := d.fsys.lookup(p)
+	if err != nil {
+		return nil, mountErrno(err)
+	}
+	if content == nil {
+		return nil, fuse.ENOENT
+	}
+	return d.fsys.mountNode(p, content), nil
+}
+
+// ReadDirAll - implements fs.HandleReadDirAller.
+func (d *mountDir) ReadDirAll(ctx context.Context) ([]fuse.Dirent, error) {
+	contents, err := d.fsys.readDir(d.path)
+	if err != nil {
+		return nil, mountErrno(err)
+	}
+	dirents := make([]fuse.Dirent, 0, len(contents))
+	for _, content := range contents {
+		dirent := fuse.Dirent{Name: mountName(content), Type: fuse.DT_File}
+		if content.Type.IsDir() {
+			dirent.Type = fuse.DT_Dir
+		}
+		dirents = append(dirents, dirent)
+	}
+	return dirents, nil
+}
+
+// Mkdir - implements fs.NodeMkdirer.
+func (d *mountDir) Mkdir(ctx context.Context, req *fuse.MkdirRequest) (fs.Node, error) {
+	if d.fsys.readOnly {
+		return nil, fuse.Errno(syscall.EROFS)
+	}
+	p := mountJoin(d.path, req.Name)
+	dirURL := d.fsys.urlOf(p) + "/"
+	clnt, err := newClient(dirURL)
+	if err != nil {
+		return nil, mountErrno(err.Trace(dirURL))
+	}
+	if err = clnt.MakeBucket("", false); err != nil {
+		return nil, mountErrno(err.Trace(dirURL))
+	}
+	d.fsys.cache.invalidate(d.path)
+	return &mountDir{fsys: d.fsys, path: p, mtime: time.Now()}, nil
+}
+
+// Create - implements fs.NodeCreater.
+func (d *mountDir) Create(ctx context.Context, req *fuse.CreateRequest, resp *fuse.CreateResponse) (fs.Node, fs.Handle, error) {
+	if d.fsys.readOnly {
+		return nil, nil, fuse.Errno(syscall.EROFS)
+	}
+	tmp, e := ioutil.TempFile("", "mc-mount-")
+	if e != nil {
+		return nil, nil, mountErrno(probe.NewError(e))
+	}
+	f := &mountFile{fsys: d.fsys, path: mountJoin(d.path, req.Name), mtime: time.Now()}
+	h := &mountHandle{file: f, tmp: tmp, dirty: true}
+	f.writer = h
+	d.fsys.cache.invalidate(d.path)
+	return f, h, nil
+}
+
+// Remove - implements fs.NodeRemover.
+func (d *mountDir) Remove(ctx context.Context, req *fuse.RemoveRequest) error {
+	if d.fsys.readOnly {
+		return fuse.Errno(syscall.EROFS)
+	}
+	p := mountJoin(d.path, req.Name)
+	urlStr := d.fsys.urlOf(p)
+	if req.Dir {
+		d.fsys.cache.invalidate(p)
+		contents, err := d.fsys.readDir(p)
+		if err != nil {
+			return mountErrno(err)
+		}
+		if len(contents) > 0 {
+			return fuse.Errno(syscall.ENOTEMPTY)
+		}
+		urlStr += "/"
+	}
+	if err := d.fsys.remove(urlStr); err != nil {
+		return mountErrno(err)
+	}
+	d.fsys.cache.invalidate(p)
+	d.fsys.cache.invalidate(d.path)
+	return nil
+}
+
+// Rename - implements fs.NodeRenamer. Only files are renamed, as a
+// server side copy followed by a delete. Renaming a directory fails
+// with EXDEV which makes tools like mv fall back to copying.
+func (d *mountDir) Rename(ctx context.Context, req *fuse.RenameRequest, newDir fs.Node) error {
+	if d.fsys.readOnly {
+		return fuse.Errno(syscall.EROFS)
+	}
+	target, ok := newDir.(*mountDir)
+	if !ok {
+		return fuse.Errno(syscall.EXDEV)
+	}
+	oldPath := mountJoin(d.path, req.OldName)
+	newPath := mountJoin(target.path, req.NewName)
+
+	content, err := d.fsys.lookup(oldPath)
+	if err != nil {
+		return mountErrno(err)
+	}
+	if content == nil {
+		return fuse.ENOENT
+	}
+	if content.Type.IsDir() {
+		return fuse.Errno(syscall.EXDEV)
+	}
+
+	newURL := d.fsys.urlOf(newPath)
+	clnt, err := newClient(newURL)
+	if err != nil {
+		return mountErrno(err.Trace(newURL))
+	}
+	sourcePath := filepath.ToSlash(content.URL.Path)
+	if err = clnt.Copy(sourcePath, content.Size, nil, d.fsys.sse(oldPath), d.fsys.sse(newPath), nil); err != nil {
+		return mountErrno(err.Trace(sourcePath, newURL))
+	}
+	if err = d.fsys.remove(d.fsys.urlOf(oldPath)); err != nil {
+		return mountErrno(err)
+	}
+	d.fsys.cache.invalidate(d.path)
+	d.fsys.cache.invalidate(target.path)
+	return nil
+}
+
+// mountFile is a regular file, an object on object storage.
+type mountFile struct {
+	fsys *mountFS
+	path string
+
+	mutex sync.Mutex
+	size  int64
+	mtime time.Time
+	// writer is the last handle opened for writing, if any.
+	writer *mountHandle
+}
+
+// Attr - implements fs.Node.
+func (f *mountFile) Attr(ctx context.Context, a *fuse.Attr) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	a.Mode = 0644
+	if f.fsys.readOnly {
+		a.Mode = 0444
+	}
+	a.Size = uint64(f.size)
+	a.Mtime = f.mtime
+	return nil
+}
+
+// Open - implements fs.NodeOpener. Files opened for writing are staged
+// in a temporary file which is uploaded when the file is flushed.
+func (f *mountFile) Open(ctx context.Context, req *fuse.OpenRequest, resp *fuse.OpenResponse) (fs.Handle, error) {
+	if req.Flags.IsReadOnly() {
+		return &mountHandle{file: f}, nil
+	}
+	if f.fsys.readOnly {
+		return nil, fuse.Errno(syscall.EROFS)
+	}
+
+	tmp, e := ioutil.TempFile("", "mc-mount-")
+	if e != nil {
+		return nil, mountErrno(probe.NewError(e))
+	}
+	h := &mountHandle{file: f, tmp: tmp}
+	if req.Flags&fuse.OpenTruncate != 0 {
+		h.dirty = true
+	} else if err := h.download(); err != nil {
+		h.close()
+		return nil, mountErrno(err)
+	}
+
+	f.mutex.Lock()
+	if h.dirty {
+		f.size = 0
+	}
+	f.writer = h
+	f.mutex.Unlock()
+	return h, nil
+}
+
+// Setattr - implements fs.NodeSetattrer. Only changing the size is
+// supported, other attributes are silently ignored.
+func (f *mountFile) Setattr(ctx context.Context, req *fuse.SetattrRequest, resp *fuse.SetattrResponse) error {
+	if req.Valid.Size() {
+		if f.fsys.readOnly {
+			return fuse.Errno(syscall.EROFS)
+		}
+		f.mutex.Lock()
+		writer := f.writer
+		f.mutex.Unlock()
+
+		switch {
+		case writer != nil:
+			if err := writer.truncate(int64(req.Size)); err != nil {
+				return mountErrno(err)
+			}
+		case req.Size == 0:
+			if err := f.put(ctx, bytes.NewReader(nil), 0); err != nil {
+				return mountErrno(err)
+			}
+		default:
+			return fuse.ENOTSUP
+		}
+		f.mutex.Lock()
+		f.size = int64(req.Size)
+		f.mutex.Unlock()
+	}
+	return f.Attr(ctx, &resp.Attr)
+}
+
+// Fsync - implements fs.NodeFsyncer.
+func (f *mountFile) Fsync(ctx context.Context, req *fuse.FsyncRequest) error {
+	f.mutex.Lock()
+	writer := f.writer
+	f.mutex.Unlock()
+
+	if writer != nil {
+		if err := writer.flush(ctx); err != nil {
+			return mountErrno(err)
+		}
+	}
+	return nil
+}
+
+// put uploads the file contents.
+func (f *mountFile) put(ctx context.Context, reader io.Reader, size int64) *probe.Error {
+	urlStr := f.fsys.urlOf(f.path)
+	clnt, err := newClient(urlStr)
+	if err != nil {
+		return err.Trace(urlStr)
+	}
+	metadata := map[string]string{
+		"Content-Type": guessURLContentType(urlStr),
+	}
+	if _, err = clnt.Put(ctx, reader, size, metadata, nil, f.fsys.sse(f.path)); err != nil {
+		return err.Trace(urlStr)
+	}
+
+	f.mutex.Lock()
+	f.mtime = time.Now()
+	f.mutex.Unlock()
+	f.fsys.cache.invalidate(mountParent(f.path))
+	return nil
+}
+
+// mountHandle is an open file. Read only handles read the object with
+// ranged GETs when the client supports it, writable handles work on
+// a temporary copy of the object.
+type mountHandle struct {
+	file *mountFile
+
+	mutex  sync.Mutex
+	reader io.ReadCloser
+	offset int64
+	tmp    *os.File
+	dirty  bool
+}
+
+// open starts reading the object from the beginning.
+func (h *mountHandle) open() *probe.Error {
+	if h.reader != nil {
+		h.reader.Close()
+		h.reader = nil
+	}
+	urlStr := h.file.fsys.urlOf(h.file.path)
+	clnt, err := newClient(urlStr)
+	if err != nil {
+		return err.Trace(urlStr)
+	}
+	reader, err := clnt.Get(h.file.fsys.sse(h.file.path))
+	if err != nil {
+		return err.Trace(urlStr)
+	}
+	h.reader = reader
+	h.offset = 0
+	return nil
+}
+
+// download copies the object into the temporary file.
+func (h *mountHandle) download() *probe.Error {
+	if err := h.open(); err != nil {
+		return err
+	}
+	defer func() {
+		h.reader.Close()
+		h.reader = nil
+	}()
+	if _, e := io.Copy(h.tmp, h.reader); e != nil {
+		return probe.NewError(e)
+	}
+	return nil
+}
+
+// readAt reads the object at offset, seeking by reopening and
+// skipping when the reader has no ReadAt.
+func (h *mountHandle) readAt(buf []byte, offset int64) (int, *probe.Error) {
+	if h.reader == nil {
+		if err := h.open(); err != nil {
+			return 0, err
+		}
+	}
+	if readerAt, ok := h.reader.(io.ReaderAt); ok {
+		n, e := readerAt.ReadAt(buf, offset)
+		if e != nil && e != io.EOF {
+			return n, probe.NewError(e)
+		}
+		return n, nil
+	}
+
+	if offset < h.offset {
+		if err := h.open(); err != nil {
+			return 0, err
+		}
+	}
+	if offset > h.offset {
+		n, e := io.CopyN(ioutil.Discard, h.reader, offset-h.offset)
+		h.offset += n
+		if e == io.EOF {
+			return 0, nil
+		}
+		if e != nil {
+			return 0, probe.NewError(e)
+		}
+	}
+	n, e := io.ReadFull(h.reader, buf)
+	h.offset += int64(n)
+	if e != nil && e != io.EOF && e != io.ErrUnexpectedEOF {
+		return n, probe.NewError(e)
+	}
+	return n, nil
+}
+
+// truncate changes the size of the temporary file.
+func (h *mountHandle) truncate(size int64) *probe.Error {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	if h.tmp == nil {
+		return probe.NewError(os.ErrClosed)
+	}
+	if e := h.tmp.Truncate(size); e != nil {
+		return probe.NewError(e)
+	}
+	h.dirty = true
+	return nil
+}
+
+// flush uploads the temporary file if it was modified.
+func (h *mountHandle) flush(ctx context.Context) *probe.Error {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	if h.tmp == nil || !h.dirty {
+		return nil
+	}
+	st, e := h.tmp.Stat()
+	if e != nil {
+		return probe.NewError(e)
+	}
+	if err := h.file.put(ctx, io.NewSectionReader(h.tmp, 0, st.Size()), st.Size()); err != nil {
+		return err
+	}
+	h.dirty = false
+	return nil
+}
+
+// close releases the reader and removes the temporary file.
+func (h *mountHandle) close() {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	if h.reader != nil {
+		h.reader.Close()
+		h.reader = nil
+	}
+	if h.tmp != nil {
+		h.tmp.Close()
+		os.Remove(h.tmp.Name())
+		h.tmp = nil
+	}
+}
+
+// Read - implements fs.HandleReader.
+func (h *mountHandle) Read(ctx context.Context, req *fuse.ReadRequest, resp *fuse.ReadResponse) error {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	buf := make([]byte, req.Size)
+	if h.tmp != nil {
+		n, e := h.tmp.ReadAt(buf, req.Offset)
+		if e != nil && e != io.EOF {
+			return mountErrno(probe.NewError(e))
+		}
+		resp.Data = buf[:n]
+		return nil
+	}
+	n, err := h.readAt(buf, req.Offset)
+	if err != nil {
+		return mountErrno(err)
+	}
+	resp.Data = buf[:n]
+	return nil
+}
+
+// Write - implements fs.HandleWriter.
+func (h *mountHandle) Write(ctx context.Context, req *fuse.WriteRequest, resp *fuse.WriteResponse) error {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	if h.tmp == nil {
+		return fuse.Errno(syscall.EBADF)
+	}
+	n, e := h.tmp.WriteAt(req.Data, req.Offset)
+	if e != nil {
+		return mountErrno(probe.NewError(e))
+	}
+	h.dirty = true
+	resp.Size = n
+
+	h.file.mutex.Lock()
+	if end := req.Offset + int64(n); end > h.file.size {
+		h.file.size = end
+	}
+	h.file.mutex.Unlock()
+	return nil
+}
+
+// Flush - implements fs.HandleFlusher, called on every close of the file.
+func (h *mountHandle) Flush(ctx context.Context, req *fuse.FlushRequest) error {
+	if err := h.flush(ctx); err != nil {
+		return mountErrno(err)
+	}
+	return nil
+}
+
+// Release - implements fs.HandleReleaser.
+func (h *mountHandle) Release(ctx context.Context, req *fuse.ReleaseRequest) error {
+	err := h.flush(ctx)
+	h.close()
+
+	h.file.mutex.Lock()
+	if h.file.writer == h {
+		h.file.writer = nil
+	}
+	h.file.mutex.Unlock()
+
+	if err != nil {
+		return mountErrno(err)
+	}
+	return nil
+}
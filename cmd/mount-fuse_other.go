@@ -0,0 +1,32 @@
+//go:build !linux && !darwin && !freebsd
+// +build !linux,!darwin,!freebsd
+
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"errors"
+	"runtime"
+
+	"github.com/minio/mc/pkg/probe"
+)
+
+// mountServe - mounting needs FUSE, which is not available on this platform.
+func mountServe(fsys *mountFS, mountPoint string, trapCh <-chan bool, ready func()) *probe.Error {
+	return probe.NewError(errors.New("mount is not supported on " + runtime.GOOS))
+}
@@ -0,0 +1,183 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"os"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/console"
+	"github.com/minio/mc/pkg/probe"
+)
+
+var (
+	mountFlags = []cli.Flag{
+		cli.BoolFlag{
+			Name:  "read-only",
+			Usage: "mount read-only, all writes fail with EROFS",
+		},
+		cli.StringFlag{
+			Name:  "cache-ttl",
+			Value: "30s",
+			Usage: "how long directory listings are cached, 0 disables the metadata cache",
+		},
+		cli.IntFlag{
+			Name:  "cache-size",
+			Value: 1024,
+			Usage: "maximum number of directory listings kept in the metadata cache",
+		},
+	}
+)
+
+// Mount a bucket as a local filesystem.
+var mountCmd = cli.Command{
+	Name:   "mount",
+	Usage:  "mount a bucket or prefix as a local filesystem",
+	Action: mainMount,
+	Before: setGlobalsFromContext,
+	Flags:  append(append(mountFlags, ioFlags...), globalFlags...),
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [FLAGS] ALIAS/BUCKET[/PREFIX] MOUNTPOINT
+{{if .VisibleFlags}}
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}{{end}}
+NOTES:
+  The mount runs in the foreground until it is interrupted or unmounted with 'umount'
+  ('fusermount -u' on Linux). Mounting needs FUSE and is supported on Linux, macOS
+  (with osxfuse) and FreeBSD.
+
+  Files are read with ranged GETs. Files opened for writing are staged in a temporary
+  local file and uploaded when they are closed, large files use multipart uploads.
+  Renaming a directory is not supported by object storage, 'mv' falls back to copying.
+
+ENVIRONMENT VARIABLES:
+  MC_ENCRYPT_KEY:  list of comma delimited prefix=secret values
+
+EXAMPLES:
+  1. Mount a bucket on MinIO play server at /mnt/mybucket.
+     $ {{.HelpName}} play/mybucket /mnt/mybucket
+
+  2. Mount the prefix 'photos/2019/' of a bucket read-only.
+     $ {{.HelpName}} --read-only s3/mybucket/photos/2019 /mnt/photos
+
+  3. Mount a bucket which changes often, caching directory listings for 5 seconds.
+     $ {{.HelpName}} --cache-ttl 5s play/mybucket /mnt/mybucket
+
+  4. Mount a bucket with objects encrypted using server side encryption with customer provided keys.
+     $ {{.HelpName}} --encrypt-key "play/mybucket=32byteslongsecretkeymustbegiven1" play/mybucket /mnt/mybucket
+`,
+}
+
+// mountMessage is printed when the mount is ready and when it ends.
+type mountMessage struct {
+	Status     string `json:"status"`
+	Target     string `json:"target"`
+	MountPoint string `json:"mountPoint"`
+	ReadOnly   bool   `json:"readOnly"`
+	Mounted    bool   `json:"mounted"`
+}
+
+func (m mountMessage) String() string {
+	if !m.Mounted {
+		return console.Colorize("Mount", "Unmounted `"+m.MountPoint+"`.")
+	}
+	msg := "Mounted `" + m.Target + "` at `" + m.MountPoint + "`"
+	if m.ReadOnly {
+		msg += " (read-only)"
+	}
+	return console.Colorize("Mount", msg+".")
+}
+
+func (m mountMessage) JSON() string {
+	m.Status = "success"
+	jsonMessageBytes, e := json.MarshalIndent(m, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(jsonMessageBytes)
+}
+
+// checkMountSyntax - validate all the passed arguments
+func checkMountSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) != 2 {
+		cli.ShowCommandHelpAndExit(ctx, "mount", 1) // last argument is exit code
+	}
+	if _, e := time.ParseDuration(ctx.String("cache-ttl")); e != nil {
+		fatalIf(probe.NewError(e), "Unable to parse cache-ttl=`"+ctx.String("cache-ttl")+"`.")
+	}
+	if ctx.Int("cache-size") < 0 {
+		fatalIf(errInvalidArgument().Trace(ctx.String("cache-size")), "Cache size cannot be negative.")
+	}
+
+	target := strings.TrimSuffix(ctx.Args().Get(0), "/")
+	client, err := newClient(target)
+	fatalIf(err.Trace(target), "Unable to initialize target `"+target+"`.")
+	if client.GetURL().Type != objectStorage {
+		fatalIf(errInvalidArgument().Trace(target), "Mount target `"+target+"` is not an alias.")
+	}
+	if _, path := url2Alias(target); strings.Trim(path, "/") == "" {
+		fatalIf(errInvalidArgument().Trace(target), "Mount target `"+target+"` must name a bucket.")
+	}
+
+	mountPoint := ctx.Args().Get(1)
+	st, e := os.Stat(mountPoint)
+	fatalIf(probe.NewError(e).Trace(mountPoint), "Unable to access mount point `"+mountPoint+"`.")
+	if !st.IsDir() {
+		fatalIf(errInvalidArgument().Trace(mountPoint), "Mount point `"+mountPoint+"` is not a directory.")
+	}
+}
+
+// mainMount is the handle for "mc mount" command.
+func mainMount(ctx *cli.Context) error {
+	console.SetColor("Mount", color.New(color.FgGreen, color.Bold))
+
+	checkMountSyntax(ctx)
+
+	// Parse encryption keys per command.
+	encKeyDB, err := getEncKeys(ctx)
+	fatalIf(err, "Unable to parse encryption keys.")
+
+	target := strings.TrimSuffix(ctx.Args().Get(0), "/")
+	mountPoint := ctx.Args().Get(1)
+	readOnly := ctx.Bool("read-only")
+	cacheTTL, _ := time.ParseDuration(ctx.String("cache-ttl"))
+
+	fsys := newMountFS(target, readOnly, encKeyDB, newMountCache(cacheTTL, ctx.Int("cache-size")))
+	msg := mountMessage{
+		Target:     target,
+		MountPoint: mountPoint,
+		ReadOnly:   readOnly,
+	}
+
+	trapCh := signalTrap(os.Interrupt, syscall.SIGTERM)
+	err = mountServe(fsys, mountPoint, trapCh, func() {
+		msg.Mounted = true
+		printMsg(msg)
+	})
+	fatalIf(err.Trace(target, mountPoint), "Unable to mount `"+target+"` at `"+mountPoint+"`.")
+
+	msg.Mounted = false
+	printMsg(msg)
+	return nil
+}
@@ -0,0 +1,245 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/console"
+	"github.com/minio/mc/pkg/probe"
+)
+
+// MinIO health endpoints.
+const (
+	healthLivePath  = "/minio/health/live"
+	healthReadyPath = "/minio/health/ready"
+)
+
+// ping specific flags.
+var (
+	pingFlags = []cli.Flag{
+		cli.IntFlag{
+			Name:  "count, c",
+			Value: 4,
+			Usage: "number of attempts",
+		},
+		cli.StringFlag{
+			Name:  "interval",
+			Value: "1s",
+			Usage: "wait between attempts",
+		},
+		cli.StringFlag{
+			Name:  "timeout",
+			Value: "5s",
+			Usage: "timeout of each attempt",
+		},
+	}
+)
+
+// Check whether a server is alive.
+var pingCmd = cli.Command{
+	Name:   "ping",
+	Usage:  "check whether a server is alive and measure its latency",
+	Action: mainPing,
+	Before: setGlobalsFromContext,
+	Flags:  append(pingFlags, globalFlags...),
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [FLAGS] ALIAS
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+DESCRIPTION:
+  The liveness endpoint of MinIO servers is requested --count times, other
+  S3 services are sent a HEAD request. The exit status is non-zero when any
+  attempt fails.
+
+EXAMPLES:
+  1. Check whether the server of alias 'myminio' is alive.
+     $ {{.HelpName}} myminio
+
+  2. Check 10 times with a 2 seconds timeout, e.g. in a liveness probe.
+     $ {{.HelpName}} --count 10 --interval 500ms --timeout 2s myminio
+`,
+}
+
+// pingMessage is the result of a single attempt.
+type pingMessage struct {
+	Status  string  `json:"status"`
+	Target  string  `json:"target"`
+	Attempt int     `json:"attempt"`
+	Latency float64 `json:"latencyMs"`
+	Err     string  `json:"error,omitempty"`
+}
+
+// String colorized ping message.
+func (p pingMessage) String() string {
+	if p.Err != "" {
+		return console.Colorize("PingFailure", fmt.Sprintf("%s: attempt=%d error=%s", p.Target, p.Attempt, p.Err))
+	}
+	return console.Colorize("PingSuccess", fmt.Sprintf("%s: attempt=%d time=%.1fms", p.Target, p.Attempt, p.Latency))
+}
+
+// JSON jsonified ping message.
+func (p pingMessage) JSON() string {
+	p.Status = "success"
+	if p.Err != "" {
+		p.Status = "error"
+	}
+	pingMessageBytes, e := json.MarshalIndent(p, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(pingMessageBytes)
+}
+
+// pingSummaryMessage summarizes all attempts.
+type pingSummaryMessage struct {
+	Status   string  `json:"status"`
+	Target   string  `json:"target"`
+	Attempts int     `json:"attempts"`
+	Failures int     `json:"failures"`
+	Min      float64 `json:"minMs"`
+	Avg      float64 `json:"avgMs"`
+	Max      float64 `json:"maxMs"`
+	Jitter   float64 `json:"jitterMs"`
+}
+
+// String colorized ping summary message.
+func (p pingSummaryMessage) String() string {
+	return fmt.Sprintf("%s %d attempts, %d failed, min/avg/max/jitter = %.1f/%.1f/%.1f/%.1f ms",
+		console.Colorize("Key", p.Target+":"), p.Attempts, p.Failures, p.Min, p.Avg, p.Max, p.Jitter)
+}
+
+// JSON jsonified ping summary message.
+func (p pingSummaryMessage) JSON() string {
+	p.Status = "success"
+	if p.Failures > 0 {
+		p.Status = "error"
+	}
+	pingSummaryBytes, e := json.MarshalIndent(p, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(pingSummaryBytes)
+}
+
+// newPingSummary summarizes attempts, the jitter is the mean difference
+// between the latencies of consecutive successful attempts.
+func newPingSummary(target string, attempts []pingMessage) pingSummaryMessage {
+	summary := pingSummaryMessage{Target: target, Attempts: len(attempts)}
+	var latencies []float64
+	for _, attempt := range attempts {
+		if attempt.Err != "" {
+			summary.Failures++
+			continue
+		}
+		latencies = append(latencies, attempt.Latency)
+	}
+	if len(latencies) == 0 {
+		return summary
+	}
+	summary.Min, summary.Max = latencies[0], latencies[0]
+	var total, deltas float64
+	for i, latency := range latencies {
+		total += latency
+		summary.Min = math.Min(summary.Min, latency)
+		summary.Max = math.Max(summary.Max, latency)
+		if i > 0 {
+			deltas += math.Abs(latency - latencies[i-1])
+		}
+	}
+	summary.Avg = total / float64(len(latencies))
+	if len(latencies) > 1 {
+		summary.Jitter = deltas / float64(len(latencies)-1)
+	}
+	return summary
+}
+
+// checkPingSyntax - validate all the passed arguments
+func checkPingSyntax(ctx *cli.Context, name string) {
+	if len(ctx.Args()) != 1 {
+		cli.ShowCommandHelpAndExit(ctx, name, 1) // last argument is exit code
+	}
+	if ctx.Int("count") <= 0 {
+		fatalIf(errInvalidArgument().Trace(ctx.Args()...), "Number of attempts must be positive.")
+	}
+	for _, flag := range []string{"interval", "timeout"} {
+		if d, e := time.ParseDuration(ctx.String(flag)); e != nil || d < 0 {
+			fatalIf(errInvalidArgument().Trace(ctx.String(flag)), "Invalid "+flag+" `"+ctx.String(flag)+"`.")
+		}
+	}
+}
+
+// pingHealth requests a health endpoint of the server of aliasedURL
+// --count times and prints every attempt followed by a summary.
+func pingHealth(ctx *cli.Context, endpoint string) error {
+	console.SetColor("PingSuccess", color.New(color.FgGreen, color.Bold))
+	console.SetColor("PingFailure", color.New(color.FgRed, color.Bold))
+
+	aliasedURL := ctx.Args().Get(0)
+	count := ctx.Int("count")
+	interval, _ := time.ParseDuration(ctx.String("interval"))
+	timeout, _ := time.ParseDuration(ctx.String("timeout"))
+
+	clnt, err := newClient(aliasedURL)
+	fatalIf(err.Trace(aliasedURL), "Unable to initialize target `"+aliasedURL+"`.")
+	s3Clnt, ok := clnt.(*s3Client)
+	if !ok {
+		fatalIf(probe.NewError(errors.New("`"+aliasedURL+"` is not an S3 alias")), "Unable to check `"+aliasedURL+"`.")
+	}
+
+	var attempts []pingMessage
+	for i := 1; i <= count; i++ {
+		if i > 1 {
+			time.Sleep(interval)
+		}
+		healthCtx, cancel := context.WithTimeout(context.Background(), timeout)
+		start := time.Now()
+		err = s3Clnt.healthCheck(healthCtx, endpoint)
+		latency := time.Since(start)
+		cancel()
+
+		attempt := pingMessage{Target: aliasedURL, Attempt: i, Latency: milliseconds(latency)}
+		if err != nil {
+			attempt.Err = err.ToGoError().Error()
+		}
+		printMsg(attempt)
+		attempts = append(attempts, attempt)
+	}
+
+	summary := newPingSummary(aliasedURL, attempts)
+	printMsg(summary)
+	if summary.Failures > 0 {
+		return exitStatus(globalErrorExitStatus)
+	}
+	return nil
+}
+
+// mainPing is the handle for "mc ping" command.
+func mainPing(ctx *cli.Context) error {
+	checkPingSyntax(ctx, "ping")
+	return pingHealth(ctx, healthLivePath)
+}
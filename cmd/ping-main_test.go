@@ -0,0 +1,45 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import "testing"
+
+func TestPingSummary(t *testing.T) {
+	testCases := []struct {
+		attempts []pingMessage
+		expected pingSummaryMessage
+	}{
+		{nil, pingSummaryMessage{Target: "myminio"}},
+		{
+			[]pingMessage{{Latency: 10}, {Latency: 14}, {Latency: 12}},
+			pingSummaryMessage{Target: "myminio", Attempts: 3, Min: 10, Avg: 12, Max: 14, Jitter: 3},
+		},
+		{
+			[]pingMessage{{Latency: 10}, {Err: "connection refused"}, {Latency: 20}},
+			pingSummaryMessage{Target: "myminio", Attempts: 3, Failures: 1, Min: 10, Avg: 15, Max: 20, Jitter: 10},
+		},
+		{
+			[]pingMessage{{Err: "Service Unavailable"}},
+			pingSummaryMessage{Target: "myminio", Attempts: 1, Failures: 1},
+		},
+	}
+	for i, testCase := range testCases {
+		if got := newPingSummary("myminio", testCase.attempts); got != testCase.expected {
+			t.Errorf("Test %d: expected %+v, got %+v", i+1, testCase.expected, got)
+		}
+	}
+}
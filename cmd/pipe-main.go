@@ -17,6 +17,7 @@
 package cmd
 
 import (
+	"io"
 	"os"
 	"syscall"
 
@@ -30,6 +31,10 @@ var (
 			Name:  "encrypt",
 			Usage: "encrypt objects (using server-side encryption with server managed keys)",
 		},
+		cli.StringFlag{
+			Name:  "compress",
+			Usage: "compress the stream on the fly before upload, supported formats are [gzip]",
+		},
 	}
 )
 
@@ -39,7 +44,7 @@ var pipeCmd = cli.Command{
 	Usage:  "stream STDIN to an object",
 	Action: mainPipe,
 	Before: setGlobalsFromContext,
-	Flags:  append(append(pipeFlags, ioFlags...), globalFlags...),
+	Flags:  append(append(append(pipeFlags, cseFlags...), ioFlags...), globalFlags...),
 	CustomHelpTemplate: `NAME:
   {{.HelpName}} - {{.Usage}}
 
@@ -52,6 +57,7 @@ FLAGS:
 ENVIRONMENT VARIABLES:
   MC_ENCRYPT:      list of comma delimited prefix values
   MC_ENCRYPT_KEY:  list of comma delimited prefix=secret values
+  MC_ENCRYPT_CLIENT_KEY:  key encrypting objects client-side
 
 EXAMPLES:
   1. Write contents of stdin to a file on local filesystem.
@@ -65,30 +71,65 @@ EXAMPLES:
 
   4. Stream MySQL database dump to Amazon S3 directly.
      $ mysqldump -u root -p ******* accountsdb | {{.HelpName}} s3/sql-backups/backups/accountsdb-oct-9-2015.sql
+
+  5. Stream MySQL database dump to Amazon S3 compressed with gzip, the object is stored as 'accountsdb.sql.gz'.
+     $ mysqldump -u root -p ******* accountsdb | {{.HelpName}} --compress gzip s3/sql-backups/backups/accountsdb.sql
+
+  6. Stream MySQL database dump to Amazon S3 encrypted client-side, with a key read from a file.
+     $ mysqldump -u root -p ******* accountsdb | {{.HelpName}} --encrypt-client-key-file ~/.mc/backup.key s3/sql-backups/backups/accountsdb.sql
 `,
 }
 
-func pipe(targetURL string, encKeyDB map[string][]prefixSSEPair) *probe.Error {
+func pipe(targetURL string, size int64, metadata map[string]string, encKeyDB map[string][]prefixSSEPair, compress string, cseKey []byte) (int64, *probe.Error) {
 	if targetURL == "" {
 		// When no target is specified, pipe cat's stdin to stdout.
-		return catOut(os.Stdin, -1).Trace()
+		return 0, catOut(os.Stdin, -1).Trace()
 	}
 	alias, _ := url2Alias(targetURL)
 	sseKey := getSSE(targetURL, encKeyDB[alias])
 
-	// Stream from stdin to multiple objects until EOF.
-	// Ignore size, since os.Stat() would not return proper size all the time
-	// for local filesystem for example /proc files.
-	_, err := putTargetStreamWithURL(targetURL, os.Stdin, -1, sseKey)
+	var reader io.Reader = os.Stdin
+	if compress != "" {
+		compressReader := newCompressReader(os.Stdin, compress)
+		defer compressReader.Close()
+		reader = compressReader
+		size = -1
+	}
+
+	// Encrypt the stream client-side if a key is provided.
+	if metadata == nil {
+		metadata = map[string]string{}
+	}
+	if cseKey != nil {
+		encReader, err := newCSEEncryptReader(reader, cseKey, metadata)
+		if err != nil {
+			return 0, err.Trace(targetURL)
+		}
+		reader = encReader
+		size = cseEncryptedSize(size)
+	}
+
+	// Stream from stdin to multiple objects until EOF. The size is
+	// unknown unless given by the caller, since os.Stat() would not
+	// return proper size all the time for local filesystem for
+	// example /proc files.
+	n, err := putTargetStreamWithURL(targetURL, reader, size, sseKey, compress, metadata)
+	if err == nil && size > 0 {
+		// Uploads with a known size stop reading there, data
+		// left on stdin would be silently dropped.
+		if extra, _ := os.Stdin.Read(make([]byte, 1)); extra > 0 {
+			return n, errInvalidArgument().Trace(targetURL, "input is larger than the given size")
+		}
+	}
 	// TODO: See if this check is necessary.
 	switch e := err.ToGoError().(type) {
 	case *os.PathError:
 		if e.Err == syscall.EPIPE {
 			// stdin closed by the user. Gracefully exit.
-			return nil
+			return n, nil
 		}
 	}
-	return err.Trace(targetURL)
+	return n, err.Trace(targetURL)
 }
 
 // check pipe input arguments.
@@ -107,13 +148,19 @@ func mainPipe(ctx *cli.Context) error {
 	// validate pipe input arguments.
 	checkPipeSyntax(ctx)
 
+	compress := ctx.String("compress")
+	fatalIf(checkCompressFormat(compress).Trace(compress), "Unable to use compression.")
+
+	cseKey, err := getCSEKey(ctx)
+	fatalIf(err, "Unable to parse client-side encryption key.")
+
 	if len(ctx.Args()) == 0 {
-		err = pipe("", nil)
+		_, err = pipe("", -1, nil, nil, "", nil)
 		fatalIf(err.Trace("stdout"), "Unable to write to one or more targets.")
 	} else {
 		// extract URLs.
 		URLs := ctx.Args()
-		err = pipe(URLs[0], encKeyDB)
+		_, err = pipe(URLs[0], -1, nil, encKeyDB, compress, cseKey)
 		fatalIf(err.Trace(URLs[0]), "Unable to write to one or more targets.")
 	}
 
@@ -37,6 +37,16 @@ var (
 			Name:  "recursive, r",
 			Usage: "list recursively",
 		},
+		cli.StringFlag{
+			Name:  "from",
+			Usage: "source dialect of the policy document to translate",
+			Value: "aws",
+		},
+		cli.StringFlag{
+			Name:  "to",
+			Usage: "target dialect of the translated policy document",
+			Value: "minio",
+		},
 	}
 )
 
@@ -55,6 +65,7 @@ USAGE:
   {{.HelpName}} [FLAGS] FILE TARGET
   {{.HelpName}} [FLAGS] TARGET
   {{.HelpName}} list [FLAGS] TARGET
+  {{.HelpName}} translate [--from aws] [--to minio] FILE
 {{if .VisibleFlags}}
 FLAGS:
   {{range .VisibleFlags}}{{.}}
@@ -92,6 +103,9 @@ EXAMPLES:
 
    9. List public object URLs recursively.
       $ {{.HelpName}} --recursive links s3/shared/
+
+  10. Translate an AWS policy document into a MinIO compatible policy document.
+      $ {{.HelpName}} translate --from aws --to minio /path/to/policy.json
 `,
 }
 
@@ -175,6 +189,13 @@ func (s policyLinksMessage) JSON() string {
 // checkPolicySyntax check for incoming syntax.
 func checkPolicySyntax(ctx *cli.Context) {
 	argsLength := len(ctx.Args())
+	// Translate accepts its own flags after the keyword.
+	if ctx.Args().First() == "translate" {
+		if argsLength < 2 {
+			cli.ShowCommandHelpAndExit(ctx, "policy", 1)
+		}
+		return
+	}
 	// Always print a help message when we have extra arguments
 	if argsLength > 3 {
 		cli.ShowCommandHelpAndExit(ctx, "policy", 1) // last argument is exit code.
@@ -470,6 +491,7 @@ func mainPolicy(ctx *cli.Context) error {
 
 	// Additional command speific theme customization.
 	console.SetColor("Policy", color.New(color.FgGreen, color.Bold))
+	console.SetColor("PolicyWarning", color.New(color.FgYellow, color.Bold))
 
 	switch ctx.Args().First() {
 	case "set", "set-json", "get", "get-json":
@@ -484,6 +506,9 @@ func mainPolicy(ctx *cli.Context) error {
 	case "links":
 		// policy links alias/bucket/prefix
 		runPolicyLinksCmd(ctx.Args().Tail(), ctx.Bool("recursive"))
+	case "translate":
+		// policy translate --from aws --to minio path-to-policy-json-file
+		runPolicyTranslateCmd(ctx)
 	default:
 		// Shows command example and exit
 		cli.ShowCommandHelpAndExit(ctx, "policy", 1)
@@ -0,0 +1,329 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strings"
+
+	"github.com/minio/cli"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/console"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio/pkg/wildcard"
+)
+
+// Condition keys understood by MinIO policy evaluation.
+var minioConditionKeys = map[string]bool{
+	"aws:currenttime":                 true,
+	"aws:epochtime":                   true,
+	"aws:principaltype":               true,
+	"aws:referer":                     true,
+	"aws:securetransport":             true,
+	"aws:sourceip":                    true,
+	"aws:useragent":                   true,
+	"aws:userid":                      true,
+	"aws:username":                    true,
+	"s3:delimiter":                    true,
+	"s3:locationconstraint":           true,
+	"s3:max-keys":                     true,
+	"s3:prefix":                       true,
+	"s3:x-amz-acl":                    true,
+	"s3:x-amz-content-sha256":         true,
+	"s3:x-amz-copy-source":            true,
+	"s3:x-amz-metadata-directive":     true,
+	"s3:x-amz-server-side-encryption": true,
+	"s3:x-amz-server-side-encryption-aws-kms-key-id": true,
+	"s3:x-amz-storage-class":                         true,
+}
+
+// Actions understood by MinIO policy evaluation.
+var minioPolicyActions = []string{
+	"s3:AbortMultipartUpload",
+	"s3:CreateBucket",
+	"s3:DeleteBucket",
+	"s3:DeleteBucketPolicy",
+	"s3:DeleteObject",
+	"s3:GetBucketLifecycle",
+	"s3:GetBucketLocation",
+	"s3:GetBucketNotification",
+	"s3:GetBucketPolicy",
+	"s3:GetObject",
+	"s3:HeadBucket",
+	"s3:ListAllMyBuckets",
+	"s3:ListBucket",
+	"s3:ListBucketMultipartUploads",
+	"s3:ListenBucketNotification",
+	"s3:ListMultipartUploadParts",
+	"s3:PutBucketLifecycle",
+	"s3:PutBucketNotification",
+	"s3:PutBucketPolicy",
+	"s3:PutObject",
+}
+
+// policyTranslateMessage is container for translated policy documents.
+type policyTranslateMessage struct {
+	Status   string                 `json:"status"`
+	From     string                 `json:"from"`
+	To       string                 `json:"to"`
+	Policy   map[string]interface{} `json:"policy"`
+	Warnings []string               `json:"warnings,omitempty"`
+}
+
+// String colorized translated policy message.
+func (p policyTranslateMessage) String() string {
+	var msg string
+	for _, warning := range p.Warnings {
+		msg += console.Colorize("PolicyWarning", "Warning: "+warning) + "\n"
+	}
+	policyBytes, e := json.MarshalIndent(p.Policy, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return msg + string(policyBytes)
+}
+
+// JSON jsonified translated policy message.
+func (p policyTranslateMessage) JSON() string {
+	p.Status = "success"
+	policyJSONBytes, e := json.MarshalIndent(p, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(policyJSONBytes)
+}
+
+// asStringSlice normalizes a policy element which may either be a
+// single string or a list of strings.
+func asStringSlice(v interface{}) []string {
+	switch val := v.(type) {
+	case string:
+		return []string{val}
+	case []interface{}:
+		var s []string
+		for _, e := range val {
+			if str, ok := e.(string); ok {
+				s = append(s, str)
+			}
+		}
+		return s
+	}
+	return nil
+}
+
+// isMinioPolicyAction returns true if the given action, possibly with
+// wildcards, matches at least one action supported by MinIO.
+func isMinioPolicyAction(action string) bool {
+	if action == "*" || action == "s3:*" {
+		return true
+	}
+	for _, a := range minioPolicyActions {
+		if wildcard.Match(strings.ToLower(action), strings.ToLower(a)) {
+			return true
+		}
+	}
+	return false
+}
+
+// translatePrincipal converts an AWS principal into the MinIO form,
+// only anonymous principals are meaningful to MinIO.
+func translatePrincipal(principal interface{}) (interface{}, bool) {
+	switch p := principal.(type) {
+	case string:
+		if p == "*" {
+			return map[string]interface{}{"AWS": []interface{}{"*"}}, true
+		}
+	case map[string]interface{}:
+		if len(p) != 1 {
+			return nil, false
+		}
+		for _, v := range asStringSlice(p["AWS"]) {
+			if v != "*" {
+				return nil, false
+			}
+		}
+		if _, ok := p["AWS"]; ok {
+			return map[string]interface{}{"AWS": []interface{}{"*"}}, true
+		}
+	}
+	return nil, false
+}
+
+// translateAWSPolicy rewrites an AWS policy document into a MinIO
+// compatible policy document. Statements which cannot be represented
+// are dropped, and every modification is reported as a warning.
+func translateAWSPolicy(policy map[string]interface{}) (map[string]interface{}, []string) {
+	var warnings []string
+	translated := map[string]interface{}{
+		"Version": "2012-10-17",
+	}
+	if version, ok := policy["Version"].(string); ok && version != "2012-10-17" {
+		warnings = append(warnings, fmt.Sprintf("policy version `%s` replaced with `2012-10-17`", version))
+	}
+
+	var statements []interface{}
+	switch st := policy["Statement"].(type) {
+	case []interface{}:
+		statements = st
+	case map[string]interface{}:
+		statements = []interface{}{st}
+	}
+
+	var result []interface{}
+	for i, s := range statements {
+		statement, ok := s.(map[string]interface{})
+		if !ok {
+			warnings = append(warnings, fmt.Sprintf("statement %d is malformed and was dropped", i))
+			continue
+		}
+		sid := fmt.Sprintf("%d", i)
+		if v, ok := statement["Sid"].(string); ok && v != "" {
+			sid = v
+		}
+
+		unsupported := ""
+		for _, key := range []string{"NotAction", "NotPrincipal", "NotResource"} {
+			if _, ok := statement[key]; ok {
+				unsupported = key + " is not supported"
+			}
+		}
+		if unsupported != "" {
+			warnings = append(warnings, fmt.Sprintf("statement `%s` dropped: %s", sid, unsupported))
+			continue
+		}
+
+		newStatement := map[string]interface{}{}
+		if v, ok := statement["Sid"]; ok {
+			newStatement["Sid"] = v
+		}
+		newStatement["Effect"] = statement["Effect"]
+
+		if principal, ok := statement["Principal"]; ok {
+			p, ok := translatePrincipal(principal)
+			if !ok {
+				warnings = append(warnings, fmt.Sprintf("statement `%s` dropped: only anonymous principals are supported", sid))
+				continue
+			}
+			newStatement["Principal"] = p
+		}
+
+		var actions []interface{}
+		for _, action := range asStringSlice(statement["Action"]) {
+			if !isMinioPolicyAction(action) {
+				warnings = append(warnings, fmt.Sprintf("statement `%s`: action `%s` is not supported and was removed", sid, action))
+				continue
+			}
+			actions = append(actions, action)
+		}
+		if len(actions) == 0 {
+			warnings = append(warnings, fmt.Sprintf("statement `%s` dropped: no supported actions", sid))
+			continue
+		}
+		newStatement["Action"] = actions
+
+		var resources []interface{}
+		for _, resource := range asStringSlice(statement["Resource"]) {
+			if resource != "*" && !strings.HasPrefix(resource, "arn:aws:s3:::") {
+				warnings = append(warnings, fmt.Sprintf("statement `%s`: resource `%s` is not an S3 resource and was removed", sid, resource))
+				continue
+			}
+			resources = append(resources, resource)
+		}
+		if len(resources) == 0 {
+			warnings = append(warnings, fmt.Sprintf("statement `%s` dropped: no supported resources", sid))
+			continue
+		}
+		newStatement["Resource"] = resources
+
+		if conditions, ok := statement["Condition"].(map[string]interface{}); ok {
+			newConditions := map[string]interface{}{}
+			for operator, value := range conditions {
+				kv, ok := value.(map[string]interface{})
+				if !ok {
+					continue
+				}
+				newKV := map[string]interface{}{}
+				for key, v := range kv {
+					if !minioConditionKeys[strings.ToLower(key)] {
+						warnings = append(warnings, fmt.Sprintf("statement `%s`: condition key `%s` is not supported and was removed", sid, key))
+						continue
+					}
+					newKV[key] = v
+				}
+				if len(newKV) > 0 {
+					newConditions[operator] = newKV
+				}
+			}
+			if len(newConditions) > 0 {
+				newStatement["Condition"] = newConditions
+			}
+		}
+		result = append(result, newStatement)
+	}
+	translated["Statement"] = result
+	sort.Strings(warnings)
+	return translated, warnings
+}
+
+// parsePolicyTranslateArgs extracts the --from and --to values which
+// may be passed after the 'translate' keyword.
+func parsePolicyTranslateArgs(args cli.Args, from, to string) (string, string, string) {
+	var file string
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		switch {
+		case arg == "--from" && i+1 < len(args):
+			i++
+			from = args[i]
+		case arg == "--to" && i+1 < len(args):
+			i++
+			to = args[i]
+		case strings.HasPrefix(arg, "--from="):
+			from = strings.TrimPrefix(arg, "--from=")
+		case strings.HasPrefix(arg, "--to="):
+			to = strings.TrimPrefix(arg, "--to=")
+		default:
+			file = arg
+		}
+	}
+	return from, to, file
+}
+
+// Run policy translate command
+func runPolicyTranslateCmd(ctx *cli.Context) {
+	from, to, file := parsePolicyTranslateArgs(ctx.Args().Tail(), ctx.String("from"), ctx.String("to"))
+	if file == "" {
+		cli.ShowCommandHelpAndExit(ctx, "policy", 1)
+	}
+	if strings.ToLower(from) != "aws" || strings.ToLower(to) != "minio" {
+		fatalIf(errDummy().Trace(from, to), "Unsupported policy translation from `"+from+"` to `"+to+"`. Only `aws` to `minio` is supported.")
+	}
+
+	policyBytes, e := ioutil.ReadFile(file)
+	fatalIf(probe.NewError(e).Trace(file), "Unable to read policy file `"+file+"`.")
+
+	policy := map[string]interface{}{}
+	e = json.Unmarshal(policyBytes, &policy)
+	fatalIf(probe.NewError(e).Trace(file), "Unable to parse policy file `"+file+"`.")
+
+	translated, warnings := translateAWSPolicy(policy)
+	printMsg(policyTranslateMessage{
+		Status:   "success",
+		From:     from,
+		To:       to,
+		Policy:   translated,
+		Warnings: warnings,
+	})
+}
@@ -0,0 +1,70 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestTranslateAWSPolicy(t *testing.T) {
+	testCases := []struct {
+		policy             string
+		expectedStatements int
+		expectedWarnings   int
+	}{
+		// Anonymous read-only policy is kept as is.
+		{
+			policy:             `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Principal":"*","Action":["s3:GetObject"],"Resource":["arn:aws:s3:::bucket/*"]}]}`,
+			expectedStatements: 1,
+			expectedWarnings:   0,
+		},
+		// Account principals are not supported.
+		{
+			policy:             `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Principal":{"AWS":"arn:aws:iam::123456789012:root"},"Action":"s3:GetObject","Resource":"arn:aws:s3:::bucket/*"}]}`,
+			expectedStatements: 0,
+			expectedWarnings:   1,
+		},
+		// Unsupported actions, resources and condition keys are removed.
+		{
+			policy:             `{"Version":"2012-10-17","Statement":[{"Sid":"s1","Effect":"Allow","Action":["s3:Get*","kms:Decrypt"],"Resource":["arn:aws:s3:::bucket/*","arn:aws:kms:us-east-1:123:key/abc"],"Condition":{"StringEquals":{"aws:SourceVpc":"vpc-1","aws:SourceIp":"10.0.0.0/8"}}}]}`,
+			expectedStatements: 1,
+			expectedWarnings:   3,
+		},
+		// NotAction statements are dropped.
+		{
+			policy:             `{"Version":"2008-10-17","Statement":{"Effect":"Deny","NotAction":"s3:GetObject","Resource":"*"}}`,
+			expectedStatements: 0,
+			expectedWarnings:   2,
+		},
+	}
+
+	for i, testCase := range testCases {
+		policy := map[string]interface{}{}
+		if e := json.Unmarshal([]byte(testCase.policy), &policy); e != nil {
+			t.Fatalf("Test %d: unexpected error %v", i+1, e)
+		}
+		translated, warnings := translateAWSPolicy(policy)
+		statements, _ := translated["Statement"].([]interface{})
+		if len(statements) != testCase.expectedStatements {
+			t.Errorf("Test %d: expected %d statements, got %d", i+1, testCase.expectedStatements, len(statements))
+		}
+		if len(warnings) != testCase.expectedWarnings {
+			t.Errorf("Test %d: expected %d warnings, got %v", i+1, testCase.expectedWarnings, warnings)
+		}
+	}
+}
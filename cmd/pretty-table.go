@@ -17,8 +17,6 @@
 package cmd
 
 import (
-	"fmt"
-
 	"github.com/minio/mc/pkg/console"
 )
 
@@ -56,29 +54,21 @@ func (t PrettyTable) buildRow(contents ...string) (line string) {
 
 	// Format fields and construct message
 	for i := 0; i < totalColumns; i++ {
-		// Default field format without pretty effect
-		fieldContent := ""
-		fieldFormat := "%s"
+		// Default field without pretty effect
+		fieldContent := contents[i]
 		if t.cols[i].maxLen >= 0 {
-			// Override field format
-			fieldFormat = fmt.Sprintf("%%-%d.%ds", t.cols[i].maxLen, t.cols[i].maxLen)
-			// Cut field string and add '...' if length is greater than maxLen
-			if len(contents[i]) > t.cols[i].maxLen {
-				fieldContent = contents[i][:t.cols[i].maxLen-len(dots)] + dots
-			} else {
-				fieldContent = contents[i]
-			}
-		} else {
-			fieldContent = contents[i]
+			// Cut field string and add '...' if wider than maxLen,
+			// then pad it to maxLen columns.
+			fieldContent = console.PadRight(console.Truncate(fieldContent, t.cols[i].maxLen, dots), t.cols[i].maxLen)
 		}
 
 		// Add separator if this is not the last column
 		if i < totalColumns-1 {
-			fieldFormat += t.separator
+			fieldContent += t.separator
 		}
 
 		// Add the field to the resulted message
-		line += console.Colorize(t.cols[i].colorTheme, fmt.Sprintf(fieldFormat, fieldContent))
+		line += console.Colorize(t.cols[i].colorTheme, fieldContent)
 	}
 	return
 }
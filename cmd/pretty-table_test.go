@@ -39,6 +39,8 @@ func TestPrettyTable(t *testing.T) {
 		{" | ", []Field{{"", -1}, {"", -1}, {"", -1}}, []string{"column1", "column2", "column3"}, "column1 | column2 | column3"},
 		// Test 6: multiple fields
 		{" | ", []Field{{"", 5}, {"", -1}}, []string{"144550032", "my long content that should not be cut"}, "14... | my long content that should not be cut"},
+		// Test 7: wide characters are cut and padded by columns
+		{" | ", []Field{{"", 7}, {"", 6}, {"", -1}}, []string{"写真写真写真", "写真", "end"}, "写真... | 写真   | end"},
 	}
 
 	for idx, testCase := range testCases {
@@ -26,6 +26,8 @@ type message interface {
 
 // printMsg prints message string or JSON structure depending on the type of output console.
 func printMsg(msg message) {
+	logResult(msg)
+	globalSummary.addResult()
 	if !globalJSON {
 		console.Println(msg.String())
 	} else {
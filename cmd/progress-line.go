@@ -0,0 +1,201 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/minio/mc/pkg/console"
+	"github.com/minio/mc/pkg/probe"
+)
+
+// Progress renderers selectable with --progress.
+const (
+	progressModeBar  = "bar"
+	progressModeDots = "dots"
+	progressModeRate = "rate"
+	progressModeNone = "none"
+	progressModeJSON = "json"
+)
+
+// Number of dots printed on a single line by the dots renderer.
+const progressDotsPerLine = 60
+
+// checkProgressMode validates the value passed to --progress.
+func checkProgressMode(mode string) *probe.Error {
+	switch mode {
+	case "", progressModeBar, progressModeDots, progressModeRate, progressModeNone, progressModeJSON:
+		return nil
+	}
+	return probe.NewError(errors.New("unknown progress renderer `" + mode + "`, supported renderers are [bar, dots, rate, none, json]"))
+}
+
+// isProgressBarMode returns true if the given renderer draws a progress
+// bar, an empty mode picks a progress bar unless quiet or json output is
+// requested.
+func isProgressBarMode(mode string) bool {
+	if mode == "" {
+		return !globalQuiet && !globalJSON
+	}
+	return mode == progressModeBar
+}
+
+// newProgressReader returns the progress reader for the given renderer.
+func newProgressReader(mode string, total int64) ProgressReader {
+	switch {
+	case isProgressBarMode(mode):
+		return newProgressBar(total)
+	case mode == "":
+		return newAccounter(total)
+	}
+	return newLineProgress(mode, total)
+}
+
+// setProgressTotal updates the total of a progress reader whose total
+// is only known while transferring.
+func setProgressTotal(pg ProgressReader, total int64) {
+	switch progressReader := pg.(type) {
+	case *progressBar:
+		// A progress bar without a total is not started yet.
+		isStarted := progressReader.ProgressBar.Total > 0
+		progressReader.SetTotal(total)
+		if !isStarted && total > 0 {
+			progressReader.ProgressBar.Start()
+		}
+	case *lineProgress:
+		progressReader.accounter.Total = total
+	case *accounter:
+		progressReader.Total = total
+	}
+}
+
+// lineProgress renders progress without any terminal control
+// characters, suitable for CI logs and machine consumption.
+type lineProgress struct {
+	*accounter
+
+	mode     string
+	interval time.Duration
+	dots     int
+	last     int64
+
+	stopOnce sync.Once
+	stopCh   chan struct{}
+	doneCh   chan struct{}
+}
+
+// newLineProgress instantiates a line oriented progress renderer.
+func newLineProgress(mode string, total int64) *lineProgress {
+	lp := &lineProgress{
+		accounter: newAccounter(total),
+		mode:      mode,
+		interval:  5 * time.Second,
+		stopCh:    make(chan struct{}),
+		doneCh:    make(chan struct{}),
+	}
+	if mode == progressModeDots {
+		lp.interval = time.Second
+	}
+	go lp.render()
+	return lp
+}
+
+// current returns the current accounting stats.
+func (lp *lineProgress) current() accountStat {
+	transferred := lp.accounter.Get()
+	return accountStat{
+		Status:      "success",
+		Total:       lp.accounter.Total,
+		Transferred: transferred,
+		Speed:       lp.accounter.write(transferred),
+	}
+}
+
+// render prints the progress at every interval until stopped.
+func (lp *lineProgress) render() {
+	defer close(lp.doneCh)
+	ticker := time.NewTicker(lp.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-lp.stopCh:
+			return
+		case <-ticker.C:
+			lp.print()
+		}
+	}
+}
+
+// print renders a single progress update.
+func (lp *lineProgress) print() {
+	stat := lp.current()
+	if stat.Transferred == lp.last {
+		return
+	}
+	lp.last = stat.Transferred
+
+	switch lp.mode {
+	case progressModeDots:
+		console.Print(".")
+		lp.dots++
+		if lp.dots == progressDotsPerLine {
+			lp.dots = 0
+			if stat.Total > 0 {
+				console.Print(fmt.Sprintf(" %3d%%", stat.Transferred*100/stat.Total))
+			}
+			console.Print("\n")
+		}
+	case progressModeRate:
+		console.Println(stat.String())
+	case progressModeJSON:
+		console.Println(lp.jsonLine(stat))
+	}
+}
+
+// jsonLine returns the stats as a single JSON line.
+func (lp *lineProgress) jsonLine(stat accountStat) string {
+	statBytes, e := json.Marshal(stat)
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(statBytes)
+}
+
+// Finish stops rendering and prints the final summary.
+func (lp *lineProgress) Finish() {
+	lp.stopOnce.Do(func() {
+		close(lp.stopCh)
+		<-lp.doneCh
+
+		// Stat also stops the accounter.
+		stat := lp.accounter.Stat()
+		stat.Status = "success"
+		switch lp.mode {
+		case progressModeDots:
+			if lp.dots > 0 {
+				console.Print("\n")
+			}
+			console.Println(stat.String())
+		case progressModeRate:
+			console.Println(stat.String())
+		case progressModeJSON:
+			console.Println(lp.jsonLine(stat))
+		}
+	})
+}
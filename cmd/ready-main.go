@@ -0,0 +1,54 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"github.com/minio/cli"
+)
+
+// Check whether a server is ready to serve requests.
+var readyCmd = cli.Command{
+	Name:   "ready",
+	Usage:  "check whether a server is ready to serve requests",
+	Action: mainReady,
+	Before: setGlobalsFromContext,
+	Flags:  append(pingFlags, globalFlags...),
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [FLAGS] ALIAS
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+DESCRIPTION:
+  The readiness endpoint of MinIO servers is requested --count times, other
+  S3 services are sent a HEAD request. The exit status is non-zero when any
+  attempt fails.
+
+EXAMPLES:
+  1. Check whether the server of alias 'myminio' is ready, e.g. in a readiness probe.
+     $ {{.HelpName}} --count 1 myminio
+`,
+}
+
+// mainReady is the handle for "mc ready" command.
+func mainReady(ctx *cli.Context) error {
+	checkPingSyntax(ctx, "ready")
+	return pingHealth(ctx, healthReadyPath)
+}
@@ -0,0 +1,96 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio-go/v6/pkg/encrypt"
+)
+
+// Interval between two checks of a pending restore.
+const restorePollInterval = time.Minute
+
+// isArchivedStorageClass returns true if objects of the given storage
+// class have to be restored before they can be read.
+func isArchivedStorageClass(storageClass string) bool {
+	switch strings.ToUpper(storageClass) {
+	case s3StorageClassGlacier, s3StorageClassDeepArchive:
+		return true
+	}
+	return false
+}
+
+// parseRestoreStatus parses the value of the x-amz-restore header,
+// e.g. `ongoing-request="false", expiry-date="Fri, 23 Dec 2012 00:00:00 GMT"`.
+func parseRestoreStatus(status string) (restored, ongoing bool) {
+	status = strings.ToLower(status)
+	switch {
+	case strings.Contains(status, `ongoing-request="true"`):
+		return false, true
+	case strings.Contains(status, `ongoing-request="false"`):
+		return true, false
+	}
+	return false, false
+}
+
+// waitForRestore makes sure an archived source object is readable. When
+// days is positive a restore request is issued if needed, and the object
+// is polled until the restored copy is available, otherwise ObjectOnGlacier
+// is returned for objects which are not restored yet.
+func waitForRestore(ctx context.Context, alias, urlStr string, sse encrypt.ServerSide, days int) *probe.Error {
+	clnt, err := newClientFromAlias(alias, urlStr)
+	if err != nil {
+		return err.Trace(alias, urlStr)
+	}
+
+	requested := false
+	for {
+		content, err := clnt.Stat(false, true, sse)
+		if err != nil {
+			return err.Trace(alias, urlStr)
+		}
+		storageClass := content.StorageClass
+		if storageClass == "" {
+			storageClass = content.Metadata["X-Amz-Storage-Class"]
+		}
+		if !isArchivedStorageClass(storageClass) {
+			return nil
+		}
+		restored, ongoing := parseRestoreStatus(content.Metadata["X-Amz-Restore"])
+		if restored {
+			return nil
+		}
+		if days <= 0 {
+			return probe.NewError(ObjectOnGlacier{urlStr})
+		}
+		if !ongoing && !requested {
+			if err = clnt.Restore(days); err != nil {
+				return err.Trace(alias, urlStr)
+			}
+			requested = true
+		}
+		select {
+		case <-ctx.Done():
+			return probe.NewError(ctx.Err())
+		case <-time.After(restorePollInterval):
+		}
+	}
+}
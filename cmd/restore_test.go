@@ -0,0 +1,39 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import "testing"
+
+func TestParseRestoreStatus(t *testing.T) {
+	testCases := []struct {
+		status   string
+		restored bool
+		ongoing  bool
+	}{
+		{"", false, false},
+		{`ongoing-request="true"`, false, true},
+		{`ongoing-request="false", expiry-date="Fri, 23 Dec 2012 00:00:00 GMT"`, true, false},
+		{`Ongoing-Request="FALSE"`, true, false},
+	}
+
+	for i, testCase := range testCases {
+		restored, ongoing := parseRestoreStatus(testCase.status)
+		if restored != testCase.restored || ongoing != testCase.ongoing {
+			t.Errorf("Test %d: expected (%t, %t), got (%t, %t)", i+1, testCase.restored, testCase.ongoing, restored, ongoing)
+		}
+	}
+}
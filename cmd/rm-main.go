@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"syscall"
 
 	"github.com/fatih/color"
 	"github.com/minio/cli"
@@ -239,7 +240,7 @@ func removeSingle(url string, isIncomplete bool, isFake, isForce bool, olderThan
 	return nil
 }
 
-func removeRecursive(url string, isIncomplete bool, isFake bool, olderThan, newerThan string, encKeyDB map[string][]prefixSSEPair) error {
+func removeRecursive(url string, isIncomplete bool, isFake bool, olderThan, newerThan string, encKeyDB map[string][]prefixSSEPair, session *sessionV8) error {
 	targetAlias, targetURL, _ := mustExpandAlias(url)
 	clnt, pErr := newClientFromAlias(targetAlias, targetURL)
 	if pErr != nil {
@@ -288,6 +289,7 @@ func removeRecursive(url string, isIncomplete bool, isFake bool, olderThan, newe
 				select {
 				case contentCh <- content:
 					sent = true
+					session.setLastRemoved(targetAlias + urlString)
 				case pErr := <-errorCh:
 					errorIf(pErr.Trace(urlString), "Failed to remove `"+urlString+"`.")
 					switch pErr.ToGoError().(type) {
@@ -316,15 +318,9 @@ func removeRecursive(url string, isIncomplete bool, isFake bool, olderThan, newe
 	return nil
 }
 
-// main for rm command.
-func mainRm(ctx *cli.Context) error {
-	// Parse encryption keys per command.
-	encKeyDB, err := getEncKeys(ctx)
-	fatalIf(err, "Unable to parse encryption keys.")
-
-	// check 'rm' cli arguments.
-	checkRmSyntax(ctx, encKeyDB)
-
+// removeTargets removes all the targets passed on the command line and,
+// if requested, on STDIN.
+func removeTargets(ctx *cli.Context, encKeyDB map[string][]prefixSSEPair, session *sessionV8) error {
 	// rm specific flags.
 	isIncomplete := ctx.Bool("incomplete")
 	isRecursive := ctx.Bool("recursive")
@@ -334,15 +330,12 @@ func mainRm(ctx *cli.Context) error {
 	newerThan := ctx.String("newer-than")
 	isForce := ctx.Bool("force")
 
-	// Set color.
-	console.SetColor("Remove", color.New(color.FgGreen, color.Bold))
-
 	var rerr error
 	var e error
 	// Support multiple targets.
 	for _, url := range ctx.Args() {
 		if isRecursive {
-			e = removeRecursive(url, isIncomplete, isFake, olderThan, newerThan, encKeyDB)
+			e = removeRecursive(url, isIncomplete, isFake, olderThan, newerThan, encKeyDB, session)
 		} else {
 			e = removeSingle(url, isIncomplete, isFake, isForce, olderThan, newerThan, encKeyDB)
 		}
@@ -360,7 +353,7 @@ func mainRm(ctx *cli.Context) error {
 	for scanner.Scan() {
 		url := scanner.Text()
 		if isRecursive {
-			e = removeRecursive(url, isIncomplete, isFake, olderThan, newerThan, encKeyDB)
+			e = removeRecursive(url, isIncomplete, isFake, olderThan, newerThan, encKeyDB, session)
 		} else {
 			e = removeSingle(url, isIncomplete, isFake, isForce, olderThan, newerThan, encKeyDB)
 		}
@@ -372,3 +365,43 @@ func mainRm(ctx *cli.Context) error {
 
 	return rerr
 }
+
+// doRmSession removes the targets recorded in a session, the session is
+// saved for 'mc session resume' if interrupted.
+func doRmSession(session *sessionV8, ctx *cli.Context, encKeyDB map[string][]prefixSSEPair) error {
+	trapCh := signalTrap(os.Interrupt, syscall.SIGTERM, syscall.SIGKILL)
+	doneCh := make(chan struct{})
+	defer close(doneCh)
+	go func() {
+		select {
+		case <-trapCh:
+			session.CloseAndDie()
+		case <-doneCh:
+		}
+	}()
+	return removeTargets(ctx, encKeyDB, session)
+}
+
+// main for rm command.
+func mainRm(ctx *cli.Context) error {
+	// Parse encryption keys per command.
+	encKeyDB, err := getEncKeys(ctx)
+	fatalIf(err, "Unable to parse encryption keys.")
+
+	// check 'rm' cli arguments.
+	checkRmSyntax(ctx, encKeyDB)
+
+	// Set color.
+	console.SetColor("Remove", color.New(color.FgGreen, color.Bold))
+
+	// Only recursive removals of the targets on the command line take
+	// long enough to be worth resuming, resuming lists them again.
+	if !ctx.Bool("recursive") || ctx.Bool("stdin") || ctx.Bool("fake") {
+		return removeTargets(ctx, encKeyDB, nil)
+	}
+
+	session := newCommandSession(ctx, append(rmFlags, ioFlags...))
+	e := doRmSession(session, ctx, encKeyDB)
+	session.Delete()
+	return e
+}
@@ -0,0 +1,106 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/minio/mc/pkg/probe"
+)
+
+// Number of rotated files kept, as FILE.1 to FILE.5.
+const rotatingFileMaxBackups = 5
+
+// rotatingFile - appends to a file, renaming it to FILE.1 and starting
+// a new one once it grows beyond maxSize.
+type rotatingFile struct {
+	mutex   sync.Mutex
+	path    string
+	file    *os.File
+	size    int64
+	maxSize int64
+}
+
+// openRotatingFile - opens the file at path for appending.
+func openRotatingFile(path string, maxSize int64) (*rotatingFile, *probe.Error) {
+	r := &rotatingFile{path: path, maxSize: maxSize}
+	if err := r.open(); err != nil {
+		return nil, err.Trace(path)
+	}
+	return r, nil
+}
+
+func (r *rotatingFile) open() *probe.Error {
+	file, e := os.OpenFile(r.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if e != nil {
+		return probe.NewError(e)
+	}
+	fi, e := file.Stat()
+	if e != nil {
+		file.Close()
+		return probe.NewError(e)
+	}
+	r.file, r.size = file, fi.Size()
+	return nil
+}
+
+// rotate - renames the file to FILE.1, shifting older ones, and starts
+// a new one.
+func (r *rotatingFile) rotate() *probe.Error {
+	r.file.Close()
+	os.Remove(fmt.Sprintf("%s.%d", r.path, rotatingFileMaxBackups))
+	for i := rotatingFileMaxBackups - 1; i > 0; i-- {
+		os.Rename(fmt.Sprintf("%s.%d", r.path, i), fmt.Sprintf("%s.%d", r.path, i+1))
+	}
+	if e := os.Rename(r.path, r.path+".1"); e != nil {
+		return probe.NewError(e)
+	}
+	return r.open()
+}
+
+// Write - appends p, rotating the file first when p does not fit.
+// Writes after a failed rotation are dropped with the error.
+func (r *rotatingFile) Write(p []byte) (int, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if r.file == nil {
+		return 0, os.ErrClosed
+	}
+	if r.size+int64(len(p)) > r.maxSize && r.size > 0 {
+		if err := r.rotate(); err != nil {
+			r.file = nil
+			return 0, err.ToGoError()
+		}
+	}
+	n, e := r.file.Write(p)
+	r.size += int64(n)
+	return n, e
+}
+
+// Close - closes the file.
+func (r *rotatingFile) Close() error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if r.file == nil {
+		return nil
+	}
+	e := r.file.Close()
+	r.file = nil
+	return e
+}
@@ -0,0 +1,292 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"time"
+
+	humanize "github.com/dustin/go-humanize"
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/console"
+	"github.com/minio/mc/pkg/probe"
+)
+
+// sample specific flags.
+var (
+	sampleFlags = []cli.Flag{
+		cli.IntFlag{
+			Name:  "prefixes",
+			Value: 100,
+			Usage: "maximum number of top level prefixes to sample",
+		},
+		cli.IntFlag{
+			Name:  "objects",
+			Value: 1000,
+			Usage: "maximum number of objects to list in each sampled prefix",
+		},
+	}
+)
+
+// Estimate namespace statistics by sampling.
+var sampleCmd = cli.Command{
+	Name:   "sample",
+	Usage:  "estimate object count and size distribution without a full listing",
+	Action: mainSample,
+	Before: setGlobalsFromContext,
+	Flags:  append(sampleFlags, globalFlags...),
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [FLAGS] TARGET
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+DESCRIPTION:
+  Top level prefixes of TARGET are discovered first, then a random subset of them
+  is listed, up to --objects objects each. Totals are extrapolated from the sample,
+  they are lower bounds when a sampled prefix holds more objects than --objects.
+
+EXAMPLES:
+   1. Estimate the number of objects and their size distribution in 'photos' bucket.
+      $ {{.HelpName}} s3/photos
+
+   2. Sample 1000 prefixes of 'logs' bucket listing at most 5000 objects in each.
+      $ {{.HelpName}} --prefixes 1000 --objects 5000 play/logs
+`,
+}
+
+// Object size ranges reported in the size distribution.
+var sampleSizeBuckets = []struct {
+	name  string
+	limit int64
+}{
+	{"< 1 KiB", humanize.KiByte},
+	{"1 KiB - 64 KiB", 64 * humanize.KiByte},
+	{"64 KiB - 1 MiB", humanize.MiByte},
+	{"1 MiB - 16 MiB", 16 * humanize.MiByte},
+	{"16 MiB - 128 MiB", 128 * humanize.MiByte},
+	{"128 MiB - 1 GiB", humanize.GiByte},
+	{">= 1 GiB", -1},
+}
+
+// sampleSizeBucket returns the index of the size range of an object.
+func sampleSizeBucket(size int64) int {
+	for i, bucket := range sampleSizeBuckets {
+		if bucket.limit < 0 || size < bucket.limit {
+			return i
+		}
+	}
+	return len(sampleSizeBuckets) - 1
+}
+
+// sampleDistribution is the number of sampled objects in a size range.
+type sampleDistribution struct {
+	Range string `json:"range"`
+	Count int64  `json:"count"`
+}
+
+// sampleMessage container for sampling results.
+type sampleMessage struct {
+	Status             string               `json:"status"`
+	URL                string               `json:"url"`
+	Prefixes           int64                `json:"prefixes"`
+	SampledPrefixes    int64                `json:"sampledPrefixes"`
+	SampledObjects     int64                `json:"sampledObjects"`
+	SampledElapsedTime time.Duration        `json:"sampledElapsedTime"`
+	EstimatedObjects   int64                `json:"estimatedObjects"`
+	EstimatedSize      int64                `json:"estimatedSize"`
+	LowerBound         bool                 `json:"lowerBound"`
+	ListRate           float64              `json:"listRate"`
+	EstimatedListTime  time.Duration        `json:"estimatedListTime"`
+	SizeDistribution   []sampleDistribution `json:"sizeDistribution"`
+}
+
+// String colorized sample message.
+func (s sampleMessage) String() string {
+	approx := "~"
+	if s.LowerBound {
+		approx = ">="
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s %s\n", console.Colorize("Key", "Target:"), s.URL)
+	fmt.Fprintf(&b, "%s %d (%d sampled)\n", console.Colorize("Key", "Prefixes:"), s.Prefixes, s.SampledPrefixes)
+	fmt.Fprintf(&b, "%s %s %d (%d sampled)\n", console.Colorize("Key", "Objects:"), approx, s.EstimatedObjects, s.SampledObjects)
+	fmt.Fprintf(&b, "%s %s %s\n", console.Colorize("Key", "Size:"), approx, humanize.IBytes(uint64(s.EstimatedSize)))
+	fmt.Fprintf(&b, "%s %.0f objects/s, full listing %s %s\n", console.Colorize("Key", "Listing:"),
+		s.ListRate, approx, timeDurationToHumanizedDuration(s.EstimatedListTime).StringShort())
+	fmt.Fprintf(&b, "%s\n", console.Colorize("Key", "Size distribution:"))
+	for _, d := range s.SizeDistribution {
+		percent := 0.0
+		if s.SampledObjects > 0 {
+			percent = float64(d.Count) * 100 / float64(s.SampledObjects)
+		}
+		fmt.Fprintf(&b, "  %-18s %8d %6.2f%%\n", d.Range, d.Count, percent)
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// JSON jsonified sample message.
+func (s sampleMessage) JSON() string {
+	s.Status = "success"
+	sampleMessageBytes, e := json.MarshalIndent(s, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(sampleMessageBytes)
+}
+
+// estimateObjects extrapolates the number of objects from the objects
+// counted in a sample of prefixes.
+func estimateObjects(directObjects int64, sampledCounts []int64, totalPrefixes int64) int64 {
+	if len(sampledCounts) == 0 {
+		return directObjects
+	}
+	var sampled int64
+	for _, count := range sampledCounts {
+		sampled += count
+	}
+	return directObjects + sampled*totalPrefixes/int64(len(sampledCounts))
+}
+
+// sampleURL samples the namespace under urlStr.
+func sampleURL(urlStr string, maxPrefixes, maxObjects int) (sampleMessage, *probe.Error) {
+	msg := sampleMessage{URL: urlStr}
+
+	targetAlias, targetURL, _ := mustExpandAlias(urlStr)
+	if !strings.HasSuffix(targetURL, "/") {
+		targetURL += "/"
+	}
+	clnt, err := newClientFromAlias(targetAlias, targetURL)
+	if err != nil {
+		return msg, err.Trace(urlStr)
+	}
+
+	startTime := time.Now()
+	histogram := make([]int64, len(sampleSizeBuckets))
+	var sampledSize int64
+
+	// Discover top level prefixes, objects at the top level are
+	// counted exactly.
+	var prefixes []string
+	var directObjects int64
+	for content := range clnt.List(false, false, DirFirst) {
+		if content.Err != nil {
+			return msg, content.Err.Trace(urlStr)
+		}
+		if content.URL.String() == targetURL {
+			continue
+		}
+		if content.Type.IsDir() {
+			prefixes = append(prefixes, content.URL.Path)
+			continue
+		}
+		directObjects++
+		sampledSize += content.Size
+		histogram[sampleSizeBucket(content.Size)]++
+	}
+	msg.Prefixes = int64(len(prefixes))
+	listed := directObjects
+
+	// List a random subset of prefixes.
+	rand.Seed(UTCNow().UnixNano())
+	rand.Shuffle(len(prefixes), func(i, j int) { prefixes[i], prefixes[j] = prefixes[j], prefixes[i] })
+	if len(prefixes) > maxPrefixes {
+		prefixes = prefixes[:maxPrefixes]
+	}
+
+	var sampledCounts []int64
+	for _, prefix := range prefixes {
+		prefixURL := prefix
+		if targetAlias != "" {
+			prefixURL = targetAlias + "/" + prefix
+		}
+		prefixClnt, err := newClient(prefixURL)
+		if err != nil {
+			return msg, err.Trace(prefixURL)
+		}
+		var count int64
+		for content := range prefixClnt.List(true, false, DirNone) {
+			if content.Err != nil {
+				return msg, content.Err.Trace(prefixURL)
+			}
+			if content.Type.IsDir() {
+				continue
+			}
+			count++
+			sampledSize += content.Size
+			histogram[sampleSizeBucket(content.Size)]++
+			if count >= int64(maxObjects) {
+				// Stop listing, the count is only a lower bound.
+				msg.LowerBound = true
+				break
+			}
+		}
+		sampledCounts = append(sampledCounts, count)
+		listed += count
+	}
+
+	elapsed := time.Since(startTime)
+	msg.SampledPrefixes = int64(len(sampledCounts))
+	msg.SampledObjects = listed
+	msg.SampledElapsedTime = elapsed
+	msg.EstimatedObjects = estimateObjects(directObjects, sampledCounts, msg.Prefixes)
+	if listed > 0 {
+		msg.EstimatedSize = int64(float64(sampledSize) / float64(listed) * float64(msg.EstimatedObjects))
+	}
+	if elapsed > 0 {
+		msg.ListRate = float64(listed) / elapsed.Seconds()
+	}
+	if msg.ListRate > 0 {
+		msg.EstimatedListTime = time.Duration(float64(msg.EstimatedObjects) / msg.ListRate * float64(time.Second))
+	}
+	for i, bucket := range sampleSizeBuckets {
+		msg.SizeDistribution = append(msg.SizeDistribution, sampleDistribution{
+			Range: bucket.name,
+			Count: histogram[i],
+		})
+	}
+	return msg, nil
+}
+
+// checkSampleSyntax - validate all the passed arguments
+func checkSampleSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) != 1 {
+		cli.ShowCommandHelpAndExit(ctx, "sample", 1) // last argument is exit code
+	}
+	if ctx.Int("prefixes") < 1 || ctx.Int("objects") < 1 {
+		fatalIf(errInvalidArgument().Trace(ctx.Args()...), "--prefixes and --objects should be at least 1.")
+	}
+}
+
+// mainSample is the entry point for sample command.
+func mainSample(ctx *cli.Context) error {
+	checkSampleSyntax(ctx)
+
+	console.SetColor("Key", color.New(color.FgCyan, color.Bold))
+
+	urlStr := ctx.Args().First()
+	msg, err := sampleURL(urlStr, ctx.Int("prefixes"), ctx.Int("objects"))
+	fatalIf(err, "Unable to sample `"+urlStr+"`.")
+	printMsg(msg)
+	return nil
+}
@@ -0,0 +1,57 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import "testing"
+
+func TestEstimateObjects(t *testing.T) {
+	testCases := []struct {
+		directObjects int64
+		sampledCounts []int64
+		totalPrefixes int64
+		expected      int64
+	}{
+		{10, nil, 0, 10},
+		{0, []int64{100, 200}, 2, 300},
+		{5, []int64{100, 300}, 10, 2005},
+		{0, []int64{0, 0, 30}, 300, 3000},
+	}
+	for i, testCase := range testCases {
+		if estimated := estimateObjects(testCase.directObjects, testCase.sampledCounts, testCase.totalPrefixes); estimated != testCase.expected {
+			t.Errorf("Test %d: expected %d, got %d", i+1, testCase.expected, estimated)
+		}
+	}
+}
+
+func TestSampleSizeBucket(t *testing.T) {
+	testCases := []struct {
+		size   int64
+		bucket int
+	}{
+		{0, 0},
+		{1023, 0},
+		{1024, 1},
+		{1 << 20, 3},
+		{1 << 30, 6},
+		{1 << 40, 6},
+	}
+	for i, testCase := range testCases {
+		if bucket := sampleSizeBucket(testCase.size); bucket != testCase.bucket {
+			t.Errorf("Test %d: expected %d, got %d", i+1, testCase.bucket, bucket)
+		}
+	}
+}
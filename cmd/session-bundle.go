@@ -0,0 +1,230 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"archive/tar"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/ioutil"
+	"os"
+	"sort"
+
+	"github.com/minio/mc/pkg/probe"
+)
+
+// Names of the entries of a session bundle.
+const (
+	sessionBundleManifest = "manifest.json"
+	sessionBundleHeader   = "session.json"
+	sessionBundleData     = "session.data"
+)
+
+// sessionBundleInfo describes an exported session, the aliases
+// listed here need to be configured on the host importing the bundle.
+type sessionBundleInfo struct {
+	Version   string   `json:"version"`
+	SessionID string   `json:"sessionId"`
+	Aliases   []string `json:"aliases"`
+}
+
+// sessionAliases returns the aliases used by the arguments of a session.
+func sessionAliases(args []string) []string {
+	seen := make(map[string]bool)
+	var aliases []string
+	for _, arg := range args {
+		alias, _, hostCfg, err := expandAlias(arg)
+		if err != nil || hostCfg == nil || seen[alias] {
+			continue
+		}
+		seen[alias] = true
+		aliases = append(aliases, alias)
+	}
+	sort.Strings(aliases)
+	return aliases
+}
+
+// writeBundleEntry adds a single file to a session bundle.
+func writeBundleEntry(tw *tar.Writer, name string, data []byte) *probe.Error {
+	hdr := &tar.Header{
+		Name:    name,
+		Mode:    0600,
+		Size:    int64(len(data)),
+		ModTime: UTCNow(),
+	}
+	if e := tw.WriteHeader(hdr); e != nil {
+		return probe.NewError(e).Trace(name)
+	}
+	if _, e := tw.Write(data); e != nil {
+		return probe.NewError(e).Trace(name)
+	}
+	return nil
+}
+
+// exportSession writes the session header and data files of sid into a
+// tar bundle.
+func exportSession(sid string, w io.Writer) *probe.Error {
+	sessionFile, err := getSessionFile(sid)
+	if err != nil {
+		return err.Trace(sid)
+	}
+	headerBytes, e := ioutil.ReadFile(sessionFile)
+	if e != nil {
+		return probe.NewError(e).Trace(sid)
+	}
+	sessionDataFile, err := getSessionDataFile(sid)
+	if err != nil {
+		return err.Trace(sid)
+	}
+	dataBytes, e := ioutil.ReadFile(sessionDataFile)
+	if e != nil {
+		return probe.NewError(e).Trace(sid)
+	}
+
+	header := &sessionV8Header{}
+	if e = json.Unmarshal(headerBytes, header); e != nil {
+		return probe.NewError(e).Trace(sid)
+	}
+	manifestBytes, e := json.MarshalIndent(sessionBundleInfo{
+		Version:   header.Version,
+		SessionID: sid,
+		Aliases:   sessionAliases(header.CommandArgs),
+	}, "", " ")
+	if e != nil {
+		return probe.NewError(e)
+	}
+
+	tw := tar.NewWriter(w)
+	if err = writeBundleEntry(tw, sessionBundleManifest, manifestBytes); err != nil {
+		return err.Trace(sid)
+	}
+	if err = writeBundleEntry(tw, sessionBundleHeader, headerBytes); err != nil {
+		return err.Trace(sid)
+	}
+	if err = writeBundleEntry(tw, sessionBundleData, dataBytes); err != nil {
+		return err.Trace(sid)
+	}
+	if e = tw.Close(); e != nil {
+		return probe.NewError(e).Trace(sid)
+	}
+	return nil
+}
+
+// sessionBundle is the content of an exported session.
+type sessionBundle struct {
+	info   sessionBundleInfo
+	header sessionV8Header
+	data   []byte
+}
+
+// readSessionBundle reads and validates a session bundle.
+func readSessionBundle(r io.Reader) (*sessionBundle, *probe.Error) {
+	bundle := &sessionBundle{}
+	var hasInfo, hasHeader, hasData bool
+
+	tr := tar.NewReader(r)
+	for {
+		hdr, e := tr.Next()
+		if e == io.EOF {
+			break
+		}
+		if e != nil {
+			return nil, probe.NewError(e)
+		}
+		data, e := ioutil.ReadAll(tr)
+		if e != nil {
+			return nil, probe.NewError(e).Trace(hdr.Name)
+		}
+		switch hdr.Name {
+		case sessionBundleManifest:
+			if e = json.Unmarshal(data, &bundle.info); e != nil {
+				return nil, probe.NewError(e).Trace(hdr.Name)
+			}
+			hasInfo = true
+		case sessionBundleHeader:
+			if e = json.Unmarshal(data, &bundle.header); e != nil {
+				return nil, probe.NewError(e).Trace(hdr.Name)
+			}
+			hasHeader = true
+		case sessionBundleData:
+			bundle.data = data
+			hasData = true
+		}
+	}
+
+	if !hasInfo || !hasHeader || !hasData {
+		return nil, probe.NewError(errors.New("not a session bundle, expected entries " +
+			sessionBundleManifest + ", " + sessionBundleHeader + " and " + sessionBundleData))
+	}
+	if bundle.header.Version != globalSessionConfigVersion {
+		return nil, probe.NewError(errors.New("session version `" + bundle.header.Version +
+			"` does not match mc session version `" + globalSessionConfigVersion + "`"))
+	}
+	if bundle.info.SessionID == "" {
+		return nil, probe.NewError(errors.New("session bundle has no session ID"))
+	}
+	return bundle, nil
+}
+
+// missingSessionAliases returns the aliases of a bundle which are not
+// configured on this host.
+func missingSessionAliases(aliases []string) []string {
+	var missing []string
+	for _, alias := range aliases {
+		if _, err := getHostConfig(alias); err != nil {
+			missing = append(missing, alias)
+		}
+	}
+	return missing
+}
+
+// importSession saves a session bundle as a local session, rootPath
+// replaces the working folder of the session if not empty.
+func importSession(bundle *sessionBundle, rootPath string) *probe.Error {
+	sid := bundle.info.SessionID
+	if isSessionExists(sid) {
+		return probe.NewError(errors.New("session `" + sid + "` already exists"))
+	}
+
+	header := bundle.header
+	if rootPath != "" {
+		header.RootPath = rootPath
+	}
+	headerBytes, e := json.MarshalIndent(header, "", "\t")
+	if e != nil {
+		return probe.NewError(e).Trace(sid)
+	}
+
+	sessionDataFile, err := getSessionDataFile(sid)
+	if err != nil {
+		return err.Trace(sid)
+	}
+	if e = ioutil.WriteFile(sessionDataFile, bundle.data, 0600); e != nil {
+		return probe.NewError(e).Trace(sid)
+	}
+	sessionFile, err := getSessionFile(sid)
+	if err != nil {
+		os.Remove(sessionDataFile)
+		return err.Trace(sid)
+	}
+	if e = ioutil.WriteFile(sessionFile, headerBytes, 0600); e != nil {
+		os.Remove(sessionDataFile)
+		return probe.NewError(e).Trace(sid)
+	}
+	return nil
+}
@@ -17,10 +17,13 @@
 package cmd
 
 import (
+	"time"
+
 	"github.com/fatih/color"
 	"github.com/minio/cli"
 	json "github.com/minio/mc/pkg/colorjson"
 	"github.com/minio/mc/pkg/console"
+	"github.com/minio/mc/pkg/ioutils"
 	"github.com/minio/mc/pkg/probe"
 )
 
@@ -29,6 +32,10 @@ var sessionClearFlags = []cli.Flag{
 		Name:  "force",
 		Usage: "force a dangerous clear operation.",
 	},
+	cli.StringFlag{
+		Name:  "older-than",
+		Usage: "only clear sessions older than L days, M hours and N minutes",
+	},
 }
 
 var sessionClear = cli.Command{
@@ -59,6 +66,9 @@ EXAMPLES:
 
   3. Forcefully clear an obsolete session.
      $ {{.HelpName}} ygVIpSJs --force
+
+  4. Clear all sessions older than 7 days.
+     $ {{.HelpName}} --older-than 7d all
 `,
 }
 
@@ -105,8 +115,8 @@ func forceClear(sid string, session *sessionV8) {
 	printMsg(clearSessionMessage{Status: "forced", SessionID: sid})
 }
 
-// clearSession clear sessions.
-func clearSession(sid string, isForce bool) {
+// clearSession clear sessions, sessions younger than olderThan are kept.
+func clearSession(sid string, isForce bool, olderThan time.Duration) {
 	var toRemove []string
 	if sid == "all" {
 		toRemove = getSessionIDs()
@@ -115,6 +125,10 @@ func clearSession(sid string, isForce bool) {
 	}
 	for _, sid := range toRemove {
 		session, err := loadSessionV8(sid)
+		if err == nil && olderThan > 0 && time.Since(session.Header.When) < olderThan {
+			session.Close()
+			continue
+		}
 		if !isForce {
 			fatalIf(err.Trace(sid), "Unable to load session `"+sid+"`. Use --force flag to remove obsolete session files.")
 
@@ -155,7 +169,14 @@ func mainSessionClear(ctx *cli.Context) error {
 	// Retrieve requested session id.
 	sessionID := ctx.Args().Get(0)
 
+	var olderThan time.Duration
+	if ref := ctx.String("older-than"); ref != "" {
+		var e error
+		olderThan, e = ioutils.ParseDurationTime(ref)
+		fatalIf(probe.NewError(e).Trace(ref), "Unable to parse --older-than.")
+	}
+
 	// Purge requested session id or all pending sessions.
-	clearSession(sessionID, isForce)
+	clearSession(sessionID, isForce, olderThan)
 	return nil
 }
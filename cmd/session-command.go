@@ -0,0 +1,120 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"flag"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/probe"
+)
+
+// sessionFlagName returns the long name of a command flag.
+func sessionFlagName(f cli.Flag) string {
+	return strings.TrimSpace(strings.Split(f.GetName(), ",")[0])
+}
+
+// newCommandSession records a command, its arguments and the values of
+// its flags into a new session, such that the command can be replayed
+// by 'mc session resume'.
+func newCommandSession(ctx *cli.Context, flags []cli.Flag) *sessionV8 {
+	session := newSessionV8()
+	session.Header.CommandType = ctx.Command.Name
+	session.Header.CommandArgs = ctx.Args()
+	for _, f := range flags {
+		name := sessionFlagName(f)
+		switch f.(type) {
+		case cli.BoolFlag:
+			session.Header.CommandBoolFlags[name] = ctx.Bool(name)
+		case cli.IntFlag:
+			session.Header.CommandIntFlags[name] = ctx.Int(name)
+		case cli.StringFlag:
+			session.Header.CommandStringFlags[name] = ctx.String(name)
+		case cli.StringSliceFlag:
+			session.Header.CommandStringFlags[name] = strings.Join(ctx.StringSlice(name), "\n")
+		}
+	}
+
+	var e error
+	if session.Header.RootPath, e = os.Getwd(); e != nil {
+		session.Delete()
+		fatalIf(probe.NewError(e), "Unable to get current working folder.")
+	}
+	fatalIf(session.Save().Trace(session.SessionID), "Unable to save session.")
+	return session
+}
+
+// newSessionContext rebuilds the command line context recorded by
+// newCommandSession.
+func newSessionContext(session *sessionV8, flags []cli.Flag) (*cli.Context, *probe.Error) {
+	set := flag.NewFlagSet(session.Header.CommandType, flag.ContinueOnError)
+	set.SetOutput(ioutil.Discard)
+	for _, f := range flags {
+		f.Apply(set)
+	}
+
+	var values [][2]string
+	for _, f := range flags {
+		name := sessionFlagName(f)
+		switch f.(type) {
+		case cli.BoolFlag:
+			if v, ok := session.Header.CommandBoolFlags[name]; ok {
+				values = append(values, [2]string{name, strconv.FormatBool(v)})
+			}
+		case cli.IntFlag:
+			if v, ok := session.Header.CommandIntFlags[name]; ok {
+				values = append(values, [2]string{name, strconv.Itoa(v)})
+			}
+		case cli.StringFlag:
+			if v, ok := session.Header.CommandStringFlags[name]; ok {
+				values = append(values, [2]string{name, v})
+			}
+		case cli.StringSliceFlag:
+			if v, ok := session.Header.CommandStringFlags[name]; ok && v != "" {
+				for _, s := range strings.Split(v, "\n") {
+					values = append(values, [2]string{name, s})
+				}
+			}
+		}
+	}
+	for _, value := range values {
+		if e := set.Set(value[0], value[1]); e != nil {
+			return nil, probe.NewError(e).Trace(session.SessionID, value[0])
+		}
+	}
+
+	// Arguments are recorded after flags were parsed, terminate flag
+	// parsing such that arguments starting with '-' are kept as is.
+	if e := set.Parse(append([]string{"--"}, session.Header.CommandArgs...)); e != nil {
+		return nil, probe.NewError(e).Trace(session.SessionID)
+	}
+	return cli.NewContext(nil, set, nil), nil
+}
+
+// isSessionInterrupted returns true if a signal was received on trapCh.
+func isSessionInterrupted(trapCh <-chan bool) bool {
+	select {
+	case <-trapCh:
+		return true
+	default:
+		return false
+	}
+}
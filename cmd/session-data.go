@@ -0,0 +1,161 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bufio"
+	"compress/gzip"
+	"errors"
+	"hash"
+	"hash/crc32"
+	"io"
+	"io/ioutil"
+	"os"
+
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/probe"
+)
+
+// errSessionDataCorrupted is returned when the session data file does
+// not match the checksum recorded in the session header.
+var errSessionDataCorrupted = errors.New("session data is corrupted")
+
+// sessionDataWriter compresses session data and computes the checksum
+// of the uncompressed data.
+type sessionDataWriter struct {
+	session *sessionV8
+	gzw     *gzip.Writer
+	crc     hash.Hash32
+}
+
+func newSessionDataWriter(s *sessionV8) *sessionDataWriter {
+	return &sessionDataWriter{
+		session: s,
+		gzw:     gzip.NewWriter(s.DataFP),
+		crc:     crc32.NewIEEE(),
+	}
+}
+
+func (w *sessionDataWriter) Write(p []byte) (int, error) {
+	n, e := w.gzw.Write(p)
+	w.crc.Write(p[:n])
+	return n, e
+}
+
+// Close flushes the compressed data and records its checksum in the
+// session header, the session data file itself is left open.
+func (w *sessionDataWriter) Close() error {
+	if e := w.gzw.Close(); e != nil {
+		return e
+	}
+	w.session.Header.DataCompressed = true
+	w.session.Header.DataCRC = w.crc.Sum32()
+	return nil
+}
+
+// sessionDataReader verifies the checksum of session data at EOF.
+type sessionDataReader struct {
+	r        io.Reader
+	crc      hash.Hash32
+	expected uint32
+}
+
+func newSessionDataReader(r io.Reader, expected uint32) *sessionDataReader {
+	return &sessionDataReader{r: r, crc: crc32.NewIEEE(), expected: expected}
+}
+
+func (r *sessionDataReader) Read(p []byte) (int, error) {
+	n, e := r.r.Read(p)
+	r.crc.Write(p[:n])
+	if e == io.EOF && r.crc.Sum32() != r.expected {
+		return n, errSessionDataCorrupted
+	}
+	if e != nil && e != io.EOF {
+		// Truncated or damaged gzip streams.
+		return n, errSessionDataCorrupted
+	}
+	return n, e
+}
+
+// verifyData reads the whole session data and verifies its checksum.
+func (s *sessionV8) verifyData() *probe.Error {
+	reader, err := s.NewDataReader()
+	if err != nil {
+		return err
+	}
+	if _, e := io.Copy(ioutil.Discard, reader); e != nil {
+		return probe.NewError(e).Trace(s.SessionID)
+	}
+	return nil
+}
+
+// repairData rewrites the session data keeping every entry which can
+// still be read, it returns the number of entries kept. Totals are
+// recomputed and if the last copied entry was lost the session starts
+// over from the first entry.
+func (s *sessionV8) repairData() (int64, *probe.Error) {
+	sessionDir, err := getSessionDir()
+	if err != nil {
+		return 0, err.Trace(s.SessionID)
+	}
+	// Salvaged entries are kept in a temporary file, session data
+	// can be too large to be kept in memory.
+	tmpFile, e := ioutil.TempFile(sessionDir, s.SessionID+".repair.")
+	if e != nil {
+		return 0, probe.NewError(e).Trace(s.SessionID)
+	}
+	defer os.Remove(tmpFile.Name())
+	defer tmpFile.Close()
+
+	var totalObjects, totalBytes int64
+	foundLast := false
+	if reader, err := s.NewDataReader(); err == nil {
+		scanner := bufio.NewScanner(reader)
+		for scanner.Scan() {
+			var urls URLs
+			if e := json.Unmarshal(scanner.Bytes(), &urls); e != nil || urls.SourceContent == nil {
+				// Entries are only lost at the end of a damaged stream.
+				break
+			}
+			if _, e := tmpFile.Write(append(scanner.Bytes(), '\n')); e != nil {
+				return 0, probe.NewError(e).Trace(s.SessionID)
+			}
+			totalObjects++
+			totalBytes += urls.SourceContent.Size
+			if urls.SourceContent.URL.String() == s.Header.LastCopied {
+				foundLast = true
+			}
+		}
+	}
+
+	if _, e = tmpFile.Seek(0, io.SeekStart); e != nil {
+		return 0, probe.NewError(e).Trace(s.SessionID)
+	}
+	w := s.NewDataWriter()
+	if _, e = io.Copy(w, tmpFile); e != nil {
+		return 0, probe.NewError(e).Trace(s.SessionID)
+	}
+	if e = w.Close(); e != nil {
+		return 0, probe.NewError(e).Trace(s.SessionID)
+	}
+	if !foundLast {
+		s.Header.LastCopied = ""
+	}
+	s.Header.TotalObjects = totalObjects
+	s.Header.TotalBytes = totalBytes
+	return totalObjects, s.Save().Trace(s.SessionID)
+}
@@ -0,0 +1,115 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"os"
+
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/console"
+	"github.com/minio/mc/pkg/probe"
+)
+
+var sessionExport = cli.Command{
+	Name:   "export",
+	Usage:  "export interrupted session to a bundle",
+	Action: mainSessionExport,
+	Flags:  globalFlags,
+	Before: setGlobalsFromContext,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} SESSION-ID BUNDLE
+
+SESSION-ID:
+  SESSION - Session is your previously saved SESSION-ID
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+DESCRIPTION:
+  The bundle is a tar archive which can be imported with 'mc session import' on
+  another host, to resume the session there. Credentials are not exported, the
+  aliases used by the session need to be configured on the importing host.
+
+EXAMPLES:
+  1. Export session to a bundle.
+     $ {{.HelpName}} ygVIpSJs ygVIpSJs.tar
+`,
+}
+
+// exportSessionMessage container for exported session messages.
+type exportSessionMessage struct {
+	Status    string `json:"status"`
+	SessionID string `json:"sessionId"`
+	Bundle    string `json:"bundle"`
+}
+
+// String colorized export session message.
+func (e exportSessionMessage) String() string {
+	return console.Colorize("ExportSession", "Session `"+e.SessionID+"` exported to `"+e.Bundle+"`.")
+}
+
+// JSON jsonified export session message.
+func (e exportSessionMessage) JSON() string {
+	e.Status = "success"
+	exportSessionJSONBytes, err := json.MarshalIndent(e, "", " ")
+	fatalIf(probe.NewError(err), "Unable to marshal into JSON.")
+
+	return string(exportSessionJSONBytes)
+}
+
+// checkSessionExportSyntax - Validate session export command.
+func checkSessionExportSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) != 2 {
+		cli.ShowCommandHelpAndExit(ctx, "export", 1) // last argument is exit code
+	}
+}
+
+// mainSessionExport - Main session export function.
+func mainSessionExport(ctx *cli.Context) error {
+	// Validate session export syntax.
+	checkSessionExportSyntax(ctx)
+
+	// Additional command specific theme customization.
+	console.SetColor("ExportSession", color.New(color.FgGreen, color.Bold))
+
+	if !isSessionDirExists() {
+		fatalIf(createSessionDir().Trace(), "Unable to create session folder.")
+	}
+
+	sessionID, bundlePath := ctx.Args().Get(0), ctx.Args().Get(1)
+	if !isSessionExists(sessionID) {
+		fatalIf(errDummy().Trace(sessionID), "Session `"+sessionID+"` not found.")
+	}
+
+	bundleFile, e := os.OpenFile(bundlePath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+	fatalIf(probe.NewError(e).Trace(bundlePath), "Unable to create bundle `"+bundlePath+"`.")
+
+	if err := exportSession(sessionID, bundleFile); err != nil {
+		bundleFile.Close()
+		os.Remove(bundlePath)
+		fatalIf(err.Trace(sessionID), "Unable to export session `"+sessionID+"`.")
+	}
+	fatalIf(probe.NewError(bundleFile.Close()).Trace(bundlePath), "Unable to write bundle `"+bundlePath+"`.")
+
+	printMsg(exportSessionMessage{SessionID: sessionID, Bundle: bundlePath})
+	return nil
+}
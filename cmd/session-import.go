@@ -0,0 +1,130 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"os"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/console"
+	"github.com/minio/mc/pkg/probe"
+)
+
+var sessionImportFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:  "working-folder",
+		Usage: "resume the session from this folder instead of the exported one",
+	},
+}
+
+var sessionImport = cli.Command{
+	Name:   "import",
+	Usage:  "import interrupted session from a bundle",
+	Action: mainSessionImport,
+	Flags:  append(sessionImportFlags, globalFlags...),
+	Before: setGlobalsFromContext,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [FLAGS] BUNDLE
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+DESCRIPTION:
+  Import a bundle created by 'mc session export', the session can then be resumed
+  with 'mc session resume'. All aliases used by the session need to be configured
+  on this host. Sessions copying local files need the files at the same paths, or
+  relative to --working-folder.
+
+EXAMPLES:
+  1. Import session from a bundle.
+     $ {{.HelpName}} ygVIpSJs.tar
+
+  2. Import session from a bundle and resume it from another folder.
+     $ {{.HelpName}} --working-folder /mnt/backup ygVIpSJs.tar
+`,
+}
+
+// importSessionMessage container for imported session messages.
+type importSessionMessage struct {
+	Status    string `json:"status"`
+	SessionID string `json:"sessionId"`
+	Bundle    string `json:"bundle"`
+}
+
+// String colorized import session message.
+func (i importSessionMessage) String() string {
+	return console.Colorize("ImportSession", "Session `"+i.SessionID+"` imported from `"+i.Bundle+
+		"`, resume it with `mc session resume "+i.SessionID+"`.")
+}
+
+// JSON jsonified import session message.
+func (i importSessionMessage) JSON() string {
+	i.Status = "success"
+	importSessionJSONBytes, e := json.MarshalIndent(i, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(importSessionJSONBytes)
+}
+
+// checkSessionImportSyntax - Validate session import command.
+func checkSessionImportSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) != 1 {
+		cli.ShowCommandHelpAndExit(ctx, "import", 1) // last argument is exit code
+	}
+}
+
+// mainSessionImport - Main session import function.
+func mainSessionImport(ctx *cli.Context) error {
+	// Validate session import syntax.
+	checkSessionImportSyntax(ctx)
+
+	// Additional command specific theme customization.
+	console.SetColor("ImportSession", color.New(color.FgGreen, color.Bold))
+
+	if !isSessionDirExists() {
+		fatalIf(createSessionDir().Trace(), "Unable to create session folder.")
+	}
+
+	bundlePath := ctx.Args().Get(0)
+	bundleFile, e := os.Open(bundlePath)
+	fatalIf(probe.NewError(e).Trace(bundlePath), "Unable to open bundle `"+bundlePath+"`.")
+	defer bundleFile.Close()
+
+	bundle, err := readSessionBundle(bundleFile)
+	fatalIf(err.Trace(bundlePath), "Unable to read bundle `"+bundlePath+"`.")
+
+	if missing := missingSessionAliases(bundle.info.Aliases); len(missing) > 0 {
+		fatalIf(errDummy().Trace(missing...), "Session `"+bundle.info.SessionID+"` requires aliases `"+
+			strings.Join(missing, "`, `")+"`, add them with `mc config host add` before importing.")
+	}
+
+	rootPath := ctx.String("working-folder")
+	if rootPath != "" {
+		_, e = os.Stat(rootPath)
+		fatalIf(probe.NewError(e).Trace(rootPath), "Unable to access working folder `"+rootPath+"`.")
+	}
+	fatalIf(importSession(bundle, rootPath).Trace(bundlePath), "Unable to import session from `"+bundlePath+"`.")
+
+	printMsg(importSessionMessage{SessionID: bundle.info.SessionID, Bundle: bundlePath})
+	return nil
+}
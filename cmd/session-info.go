@@ -0,0 +1,259 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	humanize "github.com/dustin/go-humanize"
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/console"
+	"github.com/minio/mc/pkg/probe"
+)
+
+var sessionInfoFlags = []cli.Flag{
+	cli.BoolFlag{
+		Name:  "data",
+		Usage: "also show every object recorded in the session",
+	},
+}
+
+var sessionInfo = cli.Command{
+	Name:   "info",
+	Usage:  "show details of an interrupted session",
+	Action: mainSessionInfo,
+	Flags:  append(sessionInfoFlags, globalFlags...),
+	Before: setGlobalsFromContext,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [FLAGS] SESSION-ID
+
+SESSION-ID:
+  SESSION - Session is your previously saved SESSION-ID
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+EXAMPLES:
+  1. Show the command, flags and progress of a session.
+     $ {{.HelpName}} ygVIpSJs
+
+  2. Show the session header and all the objects it records, in JSON.
+     $ {{.HelpName}} --json --data ygVIpSJs
+`,
+}
+
+// sessionProgress is the amount of work already done by a session.
+type sessionProgress struct {
+	Objects int64 `json:"objects"`
+	Bytes   int64 `json:"bytes"`
+}
+
+// percent returns the completion of a session in percent.
+func (p sessionProgress) percent(header *sessionV8Header) float64 {
+	switch {
+	case header.TotalBytes > 0:
+		return float64(p.Bytes) * 100 / float64(header.TotalBytes)
+	case header.TotalObjects > 0:
+		return float64(p.Objects) * 100 / float64(header.TotalObjects)
+	}
+	return 0
+}
+
+// sessionDataMessage describes a single object recorded in a session.
+type sessionDataMessage struct {
+	Status string `json:"status"`
+	Source string `json:"source"`
+	Target string `json:"target"`
+	Size   int64  `json:"size"`
+	Done   bool   `json:"done"`
+}
+
+// String colorized session data message.
+func (d sessionDataMessage) String() string {
+	state := "pending"
+	if d.Done {
+		state = "done"
+	}
+	return fmt.Sprintf("%-7s %8s  %s -> %s", state, humanize.IBytes(uint64(d.Size)), d.Source, d.Target)
+}
+
+// JSON jsonified session data message.
+func (d sessionDataMessage) JSON() string {
+	d.Status = "success"
+	dataBytes, e := json.MarshalIndent(d, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(dataBytes)
+}
+
+// walkSessionData calls fn for every object recorded in the session
+// data file, done is true for objects already processed.
+func walkSessionData(s *sessionV8, fn func(urls URLs, done bool)) *probe.Error {
+	isDone := isLastFactory(s.Header.LastCopied)
+	reader, err := s.NewDataReader()
+	if err != nil {
+		return err
+	}
+	scanner := bufio.NewScanner(reader)
+	for scanner.Scan() {
+		var urls URLs
+		if e := json.Unmarshal(scanner.Bytes(), &urls); e != nil {
+			return probe.NewError(e).Trace(s.SessionID)
+		}
+		if urls.SourceContent == nil || urls.SourceContent.URL.String() == "" {
+			continue
+		}
+		fn(urls, isDone(urls.SourceContent.URL.String()))
+	}
+	if e := scanner.Err(); e != nil {
+		return probe.NewError(e).Trace(s.SessionID)
+	}
+	return nil
+}
+
+// getSessionProgress counts the objects and bytes already processed
+// by a session.
+func getSessionProgress(s *sessionV8) (sessionProgress, *probe.Error) {
+	var progress sessionProgress
+	err := walkSessionData(s, func(urls URLs, done bool) {
+		if done {
+			progress.Objects++
+			progress.Bytes += urls.SourceContent.Size
+		}
+	})
+	return progress, err
+}
+
+// sessionInfoMessage container for session details.
+type sessionInfoMessage struct {
+	Status    string           `json:"status"`
+	SessionID string           `json:"sessionId"`
+	Header    *sessionV8Header `json:"header"`
+	Progress  sessionProgress  `json:"progress"`
+	Percent   float64          `json:"percent"`
+}
+
+// String colorized session info message.
+func (i sessionInfoMessage) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s %s\n", console.Colorize("Key", "Session:"), console.Colorize("SessionID", i.SessionID))
+	fmt.Fprintf(&b, "%s %s %s\n", console.Colorize("Key", "Command:"), i.Header.CommandType, strings.Join(i.Header.CommandArgs, " "))
+	fmt.Fprintf(&b, "%s %s (%s ago)\n", console.Colorize("Key", "Started:"),
+		i.Header.When.Local().Format(printDate), timeDurationToHumanizedDuration(time.Since(i.Header.When)).StringShort())
+	fmt.Fprintf(&b, "%s %s\n", console.Colorize("Key", "Folder:"), i.Header.RootPath)
+	fmt.Fprintf(&b, "%s %d/%d objects, %s/%s (%.1f%%)\n", console.Colorize("Key", "Progress:"),
+		i.Progress.Objects, i.Header.TotalObjects,
+		humanize.IBytes(uint64(i.Progress.Bytes)), humanize.IBytes(uint64(i.Header.TotalBytes)), i.Percent)
+	if i.Header.LastCopied != "" {
+		fmt.Fprintf(&b, "%s %s\n", console.Colorize("Key", "Last:"), i.Header.LastCopied)
+	}
+	var flags []string
+	for k, v := range i.Header.CommandBoolFlags {
+		if v {
+			flags = append(flags, "--"+k)
+		}
+	}
+	for k, v := range i.Header.CommandStringFlags {
+		// Never print encryption keys.
+		if v != "" && k != "encrypt-key" {
+			flags = append(flags, "--"+k+"="+v)
+		}
+	}
+	if len(flags) > 0 {
+		sort.Strings(flags)
+		fmt.Fprintf(&b, "%s %s\n", console.Colorize("Key", "Flags:"), strings.Join(flags, " "))
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// JSON jsonified session info message.
+func (i sessionInfoMessage) JSON() string {
+	i.Status = "success"
+	header := *i.Header
+	header.CommandStringFlags = make(map[string]string)
+	for k, v := range i.Header.CommandStringFlags {
+		// Never print encryption keys.
+		if k == "encrypt-key" && v != "" {
+			v = "<redacted>"
+		}
+		header.CommandStringFlags[k] = v
+	}
+	i.Header = &header
+	infoBytes, e := json.MarshalIndent(i, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(infoBytes)
+}
+
+// checkSessionInfoSyntax - Validate session info command.
+func checkSessionInfoSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) != 1 {
+		cli.ShowCommandHelpAndExit(ctx, "info", 1) // last argument is exit code
+	}
+}
+
+// mainSessionInfo - Main session info function.
+func mainSessionInfo(ctx *cli.Context) error {
+	checkSessionInfoSyntax(ctx)
+
+	// Additional command specific theme customization.
+	console.SetColor("Key", color.New(color.FgCyan, color.Bold))
+	console.SetColor("SessionID", color.New(color.FgYellow, color.Bold))
+
+	if !isSessionDirExists() {
+		fatalIf(createSessionDir().Trace(), "Unable to create session folder.")
+	}
+
+	sid := ctx.Args().Get(0)
+	s, err := loadSessionV8(sid)
+	fatalIf(err.Trace(sid), "Unable to load session `"+sid+"`.")
+	defer s.Close()
+
+	progress, err := getSessionProgress(s)
+	fatalIf(err.Trace(sid), "Unable to read session data.")
+	printMsg(sessionInfoMessage{
+		SessionID: sid,
+		Header:    s.Header,
+		Progress:  progress,
+		Percent:   progress.percent(s.Header),
+	})
+
+	if ctx.Bool("data") {
+		err = walkSessionData(s, func(urls URLs, done bool) {
+			msg := sessionDataMessage{
+				Source: urls.SourceContent.URL.String(),
+				Size:   urls.SourceContent.Size,
+				Done:   done,
+			}
+			if urls.TargetContent != nil {
+				msg.Target = urls.TargetContent.URL.String()
+			}
+			printMsg(msg)
+		})
+		fatalIf(err.Trace(sid), "Unable to read session data.")
+	}
+	return nil
+}
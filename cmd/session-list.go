@@ -17,10 +17,14 @@
 package cmd
 
 import (
+	"fmt"
 	"sort"
+	"strings"
+	"time"
 
 	"github.com/fatih/color"
 	"github.com/minio/cli"
+	json "github.com/minio/mc/pkg/colorjson"
 	"github.com/minio/mc/pkg/console"
 	"github.com/minio/mc/pkg/probe"
 )
@@ -41,26 +45,67 @@ FLAGS:
   {{range .VisibleFlags}}{{.}}
   {{end}}
 EXAMPLES:
-  1. List sessions.
+  1. List sessions with their age and progress.
      $ {{.HelpName}}
+
+  2. List sessions in JSON format.
+     $ {{.HelpName}} --json
 `,
 }
 
+// sessionListMessage container for listed sessions.
+type sessionListMessage struct {
+	Status      string    `json:"status"`
+	SessionID   string    `json:"sessionId"`
+	Time        time.Time `json:"time"`
+	CommandType string    `json:"commandType"`
+	CommandArgs []string  `json:"commandArgs"`
+	Percent     float64   `json:"percent"`
+}
+
+// String colorized session list message.
+func (s sessionListMessage) String() string {
+	age := timeDurationToHumanizedDuration(time.Since(s.Time)).StringShort()
+	message := console.Colorize("SessionID", fmt.Sprintf("%s -> ", s.SessionID))
+	message += console.Colorize("SessionTime", fmt.Sprintf("[%s, %s ago]", s.Time.Local().Format(printDate), age))
+	message += console.Colorize("Progress", fmt.Sprintf(" %5.1f%%", s.Percent))
+	message += console.Colorize("Command", fmt.Sprintf(" %s %s", s.CommandType, strings.Join(s.CommandArgs, " ")))
+	return message
+}
+
+// JSON jsonified session list message.
+func (s sessionListMessage) JSON() string {
+	s.Status = "success"
+	sessionBytes, e := json.MarshalIndent(s, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(sessionBytes)
+}
+
 // listSessions list all current sessions.
 func listSessions() *probe.Error {
 	var bySessions []*sessionV8
+	var progress = make(map[string]sessionProgress)
 	for _, sid := range getSessionIDs() {
 		session, err := loadSessionV8(sid)
 		if err != nil {
 			continue // Skip 'broken' session during listing
 		}
+		// Progress is best effort, a damaged data file shows up as 0%.
+		progress[sid], _ = getSessionProgress(session)
 		session.Close() // Session close right here.
 		bySessions = append(bySessions, session)
 	}
 	// sort sessions based on time.
 	sort.Sort(bySessionWhen(bySessions))
 	for _, session := range bySessions {
-		printMsg(session)
+		printMsg(sessionListMessage{
+			SessionID:   session.SessionID,
+			Time:        session.Header.When,
+			CommandType: session.Header.CommandType,
+			CommandArgs: session.Header.CommandArgs,
+			Percent:     progress[session.SessionID].percent(session.Header),
+		})
 	}
 	return nil
 }
@@ -79,6 +124,7 @@ func mainSessionList(ctx *cli.Context) error {
 	console.SetColor("Command", color.New(color.FgWhite, color.Bold))
 	console.SetColor("SessionID", color.New(color.FgYellow, color.Bold))
 	console.SetColor("SessionTime", color.New(color.FgGreen))
+	console.SetColor("Progress", color.New(color.FgCyan))
 
 	if !isSessionDirExists() {
 		fatalIf(createSessionDir().Trace(), "Unable to create session folder.")
@@ -36,6 +36,10 @@ var sessionCmd = cli.Command{
 		sessionList,
 		sessionClear,
 		sessionResume,
+		sessionInfo,
+		sessionRepair,
+		sessionExport,
+		sessionImport,
 	},
 }
 
@@ -43,5 +47,5 @@ var sessionCmd = cli.Command{
 func mainSession(ctx *cli.Context) error {
 	cli.ShowCommandHelp(ctx, ctx.Args().First())
 	return nil
-	// Sub-commands like "list", "clear", "resume" have their own main.
+	// Sub-commands like "list", "clear", "resume", "info", "repair", "export", "import" have their own main.
 }
@@ -0,0 +1,117 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	json "github.com/minio/mc/pkg/colorjson"
+	"github.com/minio/mc/pkg/console"
+	"github.com/minio/mc/pkg/probe"
+)
+
+var sessionRepair = cli.Command{
+	Name:   "repair",
+	Usage:  "recover a session with damaged session data",
+	Action: mainSessionRepair,
+	Flags:  globalFlags,
+	Before: setGlobalsFromContext,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} SESSION-ID
+
+SESSION-ID:
+  SESSION - Session is your previously saved SESSION-ID
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+DESCRIPTION:
+  Session data is stored compressed along with a checksum, a session whose data does
+  not match its checksum cannot be resumed. Repairing keeps every entry which can still
+  be read. If the last copied entry was lost, resuming starts over from the first entry.
+
+EXAMPLES:
+  1. Repair session.
+     $ {{.HelpName}} ygVIpSJs
+`,
+}
+
+// repairSessionMessage container for repaired session messages.
+type repairSessionMessage struct {
+	Status    string `json:"status"`
+	SessionID string `json:"sessionId"`
+	Entries   int64  `json:"entries"`
+	Restarted bool   `json:"restarted"`
+}
+
+// String colorized repair session message.
+func (r repairSessionMessage) String() string {
+	msg := fmt.Sprintf("Session `%s` repaired, %d entries recovered.", r.SessionID, r.Entries)
+	if r.Restarted {
+		msg += " Resuming starts over from the first entry."
+	}
+	return console.Colorize("RepairSession", msg)
+}
+
+// JSON jsonified repair session message.
+func (r repairSessionMessage) JSON() string {
+	r.Status = "success"
+	repairSessionJSONBytes, e := json.MarshalIndent(r, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(repairSessionJSONBytes)
+}
+
+// checkSessionRepairSyntax - Validate session repair command.
+func checkSessionRepairSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) != 1 {
+		cli.ShowCommandHelpAndExit(ctx, "repair", 1) // last argument is exit code
+	}
+}
+
+// mainSessionRepair - Main session repair function.
+func mainSessionRepair(ctx *cli.Context) error {
+	checkSessionRepairSyntax(ctx)
+
+	// Additional command specific theme customization.
+	console.SetColor("RepairSession", color.New(color.FgGreen, color.Bold))
+
+	if !isSessionDirExists() {
+		fatalIf(createSessionDir().Trace(), "Unable to create session folder.")
+	}
+
+	sid := ctx.Args().Get(0)
+	s, err := loadSessionV8(sid)
+	fatalIf(err.Trace(sid), "Unable to load session `"+sid+"`.")
+
+	lastCopied := s.Header.LastCopied
+	entries, err := s.repairData()
+	fatalIf(err.Trace(sid), "Unable to repair session `"+sid+"`.")
+	fatalIf(s.Close().Trace(sid), "Unable to close session `"+sid+"`.")
+
+	printMsg(repairSessionMessage{
+		SessionID: sid,
+		Entries:   entries,
+		Restarted: lastCopied != "" && s.Header.LastCopied == "",
+	})
+	return nil
+}
@@ -46,6 +46,11 @@ SESSION-ID:
 FLAGS:
   {{range .VisibleFlags}}{{.}}
   {{end}}
+DESCRIPTION:
+  Interrupted 'cp', 'mirror' and 'rm --recursive' commands are recorded as sessions.
+  Resuming a 'mirror' or 'rm' session runs the command again with the same arguments
+  and flags, objects already processed no longer need mirroring or removal.
+
 EXAMPLES:
   1. Resume session.
      $ {{.HelpName}} ygVIpSJs
@@ -67,6 +72,22 @@ func sessionExecute(s *sessionV8) {
 		sseServer := s.Header.CommandStringFlags["encrypt"]
 		encKeyDB, _ := parseAndValidateEncryptionKeys(sseKeys, sseServer)
 		doCopySession(s, encKeyDB)
+	case "mirror":
+		ctx, err := newSessionContext(s, append(mirrorFlags, ioFlags...))
+		fatalIf(err.Trace(s.SessionID), "Unable to restore mirror flags.")
+		encKeyDB, err := getEncKeys(ctx)
+		fatalIf(err, "Unable to parse encryption keys.")
+		console.SetColor("Mirror", color.New(color.FgGreen, color.Bold))
+		doMirrorSession(s, ctx, encKeyDB)
+	case "rm":
+		ctx, err := newSessionContext(s, append(rmFlags, ioFlags...))
+		fatalIf(err.Trace(s.SessionID), "Unable to restore rm flags.")
+		encKeyDB, err := getEncKeys(ctx)
+		fatalIf(err, "Unable to parse encryption keys.")
+		console.SetColor("Remove", color.New(color.FgGreen, color.Bold))
+		doRmSession(s, ctx, encKeyDB)
+	default:
+		fatalIf(errDummy().Trace(s.SessionID), "Session command `"+s.Header.CommandType+"` cannot be resumed.")
 	}
 }
 
@@ -19,6 +19,7 @@
 package cmd
 
 import (
+	"compress/gzip"
 	"errors"
 	"fmt"
 	"io"
@@ -51,6 +52,8 @@ type sessionV8Header struct {
 	TotalBytes         int64             `json:"totalBytes"`
 	TotalObjects       int64             `json:"totalObjects"`
 	UserMetaData       map[string]string `json:"metaData"`
+	DataCompressed     bool              `json:"dataCompressed,omitempty"`
+	DataCRC            uint32            `json:"dataCRC,omitempty"`
 }
 
 // sessionMessage container for session messages
@@ -152,7 +155,9 @@ func loadSessionV8(sid string) (*sessionV8, *probe.Error) {
 		return nil, err.Trace(sid, s.Header.Version)
 	}
 
-	dataFile, e := os.Open(sessionDataFile)
+	// Data is rewritten when a session is resumed before all its
+	// URLs were prepared, or when it is repaired.
+	dataFile, e := os.OpenFile(sessionDataFile, os.O_RDWR, 0600)
 	if e != nil {
 		return nil, probe.NewError(e)
 	}
@@ -198,21 +203,33 @@ func (s sessionV8) HasData() bool {
 	return s.Header.LastCopied != "" || s.Header.LastRemoved != ""
 }
 
-// NewDataReader provides reader interface to session data file.
-func (s *sessionV8) NewDataReader() io.Reader {
+// NewDataReader provides reader interface to session data file,
+// compressed data is verified against its checksum once fully read.
+func (s *sessionV8) NewDataReader() (io.Reader, *probe.Error) {
 	// DataFP is always intitialized, either via new or load functions.
-	s.DataFP.Seek(0, io.SeekStart)
-	return io.Reader(s.DataFP)
+	if _, e := s.DataFP.Seek(0, io.SeekStart); e != nil {
+		return nil, probe.NewError(e).Trace(s.SessionID)
+	}
+	if !s.Header.DataCompressed {
+		return io.Reader(s.DataFP), nil
+	}
+	gzr, e := gzip.NewReader(s.DataFP)
+	if e != nil {
+		return nil, probe.NewError(errSessionDataCorrupted).Trace(s.SessionID)
+	}
+	return newSessionDataReader(gzr, s.Header.DataCRC), nil
 }
 
-// NewDataReader provides writer interface to session data file.
-func (s *sessionV8) NewDataWriter() io.Writer {
+// NewDataWriter provides writer interface to session data file, the
+// data is compressed and its checksum recorded in the session header
+// when the writer is closed.
+func (s *sessionV8) NewDataWriter() io.WriteCloser {
 	// DataFP is always intitialized, either via new or load functions.
 	s.DataFP.Seek(0, io.SeekStart)
 	// when moving to file position 0 we want to truncate the file as well,
 	// otherwise we'll partly overwrite existing data
 	s.DataFP.Truncate(0)
-	return io.Writer(s.DataFP)
+	return newSessionDataWriter(s)
 }
 
 // Save this session.
@@ -251,6 +268,7 @@ func (s *sessionV8) setGlobals() {
 	s.Header.GlobalBoolFlags["json"] = globalJSON
 	s.Header.GlobalBoolFlags["noColor"] = globalNoColor
 	s.Header.GlobalBoolFlags["insecure"] = globalInsecure
+	s.Header.GlobalStringFlags["profile"] = globalProfile
 }
 
 // RestoreGlobals restores the state of global variables.
@@ -261,7 +279,8 @@ func (s sessionV8) restoreGlobals() {
 	json := s.Header.GlobalBoolFlags["json"]
 	noColor := s.Header.GlobalBoolFlags["noColor"]
 	insecure := s.Header.GlobalBoolFlags["insecure"]
-	setGlobals(quiet, debug, json, noColor, insecure)
+	profile := s.Header.GlobalStringFlags["profile"]
+	setGlobals(quiet, debug, json, noColor, insecure, profile)
 }
 
 // IsModified - returns if in memory session header has changed from
@@ -369,6 +388,16 @@ func (s *sessionV8) Delete() *probe.Error {
 	return nil
 }
 
+// setLastRemoved records the last object removed by this session.
+func (s *sessionV8) setLastRemoved(url string) {
+	if s == nil {
+		return
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.Header.LastRemoved = url
+}
+
 // Close a session and exit.
 func (s sessionV8) CloseAndDie() {
 	s.Close()
@@ -17,9 +17,12 @@
 package cmd
 
 import (
+	"bytes"
+	"encoding/json"
 	"os"
 	"regexp"
 
+	"github.com/minio/cli"
 	. "gopkg.in/check.v1"
 )
 
@@ -58,3 +61,144 @@ func (s *TestSuite) TestSession(c *C) {
 	_, e = os.Stat(session.DataFP.Name())
 	c.Assert(e, NotNil)
 }
+
+func (s *TestSuite) TestSessionBundle(c *C) {
+	err := createSessionDir()
+	c.Assert(err, IsNil)
+
+	session := newSessionV8()
+	session.Header.CommandType = "cp"
+	session.Header.CommandArgs = []string{"/tmp/source", "/tmp/target"}
+	// Bundles carry session data as is.
+	_, e := session.DataFP.Write([]byte("/tmp/source/object\n"))
+	c.Assert(e, IsNil)
+	err = session.Close()
+	c.Assert(err, IsNil)
+
+	var bundleBytes bytes.Buffer
+	err = exportSession(session.SessionID, &bundleBytes)
+	c.Assert(err, IsNil)
+
+	bundle, err := readSessionBundle(bytes.NewReader(bundleBytes.Bytes()))
+	c.Assert(err, IsNil)
+	c.Assert(bundle.info.SessionID, Equals, session.SessionID)
+	c.Assert(bundle.info.Aliases, IsNil)
+	c.Assert(bundle.header.CommandArgs, DeepEquals, session.Header.CommandArgs)
+	c.Assert(string(bundle.data), Equals, "/tmp/source/object\n")
+
+	// Importing over an existing session is not allowed.
+	err = importSession(bundle, "")
+	c.Assert(err, NotNil)
+
+	err = session.Delete()
+	c.Assert(err, IsNil)
+	err = importSession(bundle, "/tmp")
+	c.Assert(err, IsNil)
+
+	imported, err := loadSessionV8(session.SessionID)
+	c.Assert(err, IsNil)
+	c.Assert(imported.Header.RootPath, Equals, "/tmp")
+	err = imported.Close()
+	c.Assert(err, IsNil)
+	err = imported.Delete()
+	c.Assert(err, IsNil)
+
+	_, err = readSessionBundle(bytes.NewReader([]byte("not a bundle")))
+	c.Assert(err, NotNil)
+}
+
+func (s *TestSuite) TestSessionProgress(c *C) {
+	err := createSessionDir()
+	c.Assert(err, IsNil)
+
+	session := newSessionV8()
+	session.Header.CommandType = "cp"
+	session.Header.TotalObjects = 2
+	session.Header.TotalBytes = 40
+	w := session.NewDataWriter()
+	for _, content := range []*clientContent{
+		{URL: *newClientURL("/tmp/source/a"), Size: 10},
+		{URL: *newClientURL("/tmp/source/b"), Size: 30},
+	} {
+		urlsBytes, e := json.Marshal(URLs{SourceContent: content})
+		c.Assert(e, IsNil)
+		_, e = w.Write(append(urlsBytes, '\n'))
+		c.Assert(e, IsNil)
+	}
+	c.Assert(w.Close(), IsNil)
+	session.Header.LastCopied = "/tmp/source/a"
+
+	progress, err := getSessionProgress(session)
+	c.Assert(err, IsNil)
+	c.Assert(progress, Equals, sessionProgress{Objects: 1, Bytes: 10})
+	c.Assert(progress.percent(session.Header), Equals, 25.0)
+
+	c.Assert(session.Close(), IsNil)
+	c.Assert(session.Delete(), IsNil)
+}
+
+func (s *TestSuite) TestSessionContext(c *C) {
+	err := createSessionDir()
+	c.Assert(err, IsNil)
+
+	flags := []cli.Flag{
+		cli.BoolFlag{Name: "watch, w"},
+		cli.BoolFlag{Name: "remove"},
+		cli.StringFlag{Name: "older-than"},
+		cli.StringSliceFlag{Name: "exclude"},
+		cli.IntFlag{Name: "restore-days", Value: 1},
+	}
+	session := newSessionV8()
+	session.Header.CommandType = "mirror"
+	session.Header.CommandArgs = []string{"-source", "play/target"}
+	session.Header.CommandBoolFlags["remove"] = true
+	session.Header.CommandStringFlags["older-than"] = "7d"
+	session.Header.CommandStringFlags["exclude"] = "*.tmp\n.*"
+	session.Header.CommandIntFlags["restore-days"] = 3
+
+	ctx, err := newSessionContext(session, flags)
+	c.Assert(err, IsNil)
+	c.Assert(ctx.Bool("remove"), Equals, true)
+	c.Assert(ctx.Bool("watch"), Equals, false)
+	c.Assert(ctx.String("older-than"), Equals, "7d")
+	c.Assert(ctx.StringSlice("exclude"), DeepEquals, []string{"*.tmp", ".*"})
+	c.Assert(ctx.Int("restore-days"), Equals, 3)
+	c.Assert([]string(ctx.Args()), DeepEquals, []string{"-source", "play/target"})
+
+	c.Assert(session.Close(), IsNil)
+	c.Assert(session.Delete(), IsNil)
+}
+
+func (s *TestSuite) TestSessionDataRepair(c *C) {
+	err := createSessionDir()
+	c.Assert(err, IsNil)
+
+	session := newSessionV8()
+	session.Header.CommandType = "cp"
+	w := session.NewDataWriter()
+	for _, name := range []string{"a", "b", "c"} {
+		urlsBytes, e := json.Marshal(URLs{SourceContent: &clientContent{URL: *newClientURL("/tmp/source/" + name), Size: 10}})
+		c.Assert(e, IsNil)
+		_, e = w.Write(append(urlsBytes, '\n'))
+		c.Assert(e, IsNil)
+	}
+	c.Assert(w.Close(), IsNil)
+	session.Header.LastCopied = "/tmp/source/c"
+	c.Assert(session.verifyData(), IsNil)
+
+	// Drop the end of the compressed stream.
+	fi, e := session.DataFP.Stat()
+	c.Assert(e, IsNil)
+	c.Assert(session.DataFP.Truncate(fi.Size()-12), IsNil)
+	c.Assert(session.verifyData(), NotNil)
+
+	entries, err := session.repairData()
+	c.Assert(err, IsNil)
+	c.Assert(entries < 3, Equals, true)
+	c.Assert(session.Header.LastCopied, Equals, "")
+	c.Assert(session.Header.TotalObjects, Equals, entries)
+	c.Assert(session.verifyData(), IsNil)
+
+	c.Assert(session.Close(), IsNil)
+	c.Assert(session.Delete(), IsNil)
+}
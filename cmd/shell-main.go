@@ -0,0 +1,373 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/console"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/peterh/liner"
+)
+
+// History of the shell is kept in this file of the config folder.
+const shellHistoryFile = "shell-history"
+
+// Commands handled by the shell itself.
+var shellBuiltins = []string{"cd", "exit", "help", "history", "pwd", "quit"}
+
+// Interactive mc shell.
+var shellCmd = cli.Command{
+	Name:   "shell",
+	Usage:  "start an interactive shell with a working prefix",
+	Action: mainShell,
+	Before: setGlobalsFromContext,
+	Flags:  globalFlags,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [FLAGS] [TARGET]
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+BUILTINS:
+  cd [TARGET]      change the working prefix, "cd" alone goes back to the aliases
+  pwd              print the working prefix
+  history          print the command history
+  help [COMMAND]   show help of the shell or of an mc command
+  exit, quit       leave the shell, as does Ctrl-D
+
+  Any other line runs an mc command without the "mc" prefix. Arguments of
+  commands working on objects are relative to the working prefix unless they
+  start with an alias. Local paths have to start with "./", "/" or "~".
+  Tab completes command names, aliases, buckets and objects.
+
+EXAMPLES:
+  1. Start a shell at the alias list.
+     $ {{.HelpName}}
+
+  2. Start a shell inside "mybucket" and list a folder of it.
+     $ {{.HelpName}} myminio/mybucket
+     mc myminio/mybucket/> ls photos/
+
+  3. Copy a local file to the working prefix.
+     mc myminio/mybucket/> cp ./report.pdf reports/
+`,
+}
+
+// shellExit is raised in place of exiting the process while a
+// command runs inside the shell.
+type shellExit int
+
+// shell is the state of an interactive session.
+type shell struct {
+	app  *cli.App
+	line *liner.State
+	// cwd is the working prefix, empty at the alias list.
+	cwd string
+}
+
+// aliases returns the set of configured aliases.
+func (s *shell) aliases() map[string]bool {
+	aliases := make(map[string]bool)
+	conf, err := loadMcConfig()
+	if err != nil {
+		return aliases
+	}
+	for alias := range conf.Hosts {
+		aliases[alias] = true
+	}
+	return aliases
+}
+
+// valueFlags returns the names of the flags of the command in words
+// which take a value.
+func (s *shell) valueFlags(words []string) map[string]bool {
+	command := s.app.Command(words[0])
+	if command == nil {
+		return nil
+	}
+	flags := command.Flags
+	if len(words) > 1 {
+		for _, sub := range command.Subcommands {
+			if sub.HasName(words[1]) {
+				flags = sub.Flags
+			}
+		}
+	}
+	valueFlags := make(map[string]bool)
+	for _, flag := range flags {
+		switch flag.(type) {
+		case cli.BoolFlag, cli.BoolTFlag:
+			continue
+		}
+		for _, name := range strings.Split(flag.GetName(), ",") {
+			valueFlags[strings.TrimSpace(name)] = true
+		}
+	}
+	return valueFlags
+}
+
+// prompt shows the working prefix.
+func (s *shell) prompt() string {
+	if s.cwd == "" {
+		return "mc> "
+	}
+	return "mc " + s.cwd + "> "
+}
+
+// cd changes the working prefix to target, which has to be a folder.
+func (s *shell) cd(target string) *probe.Error {
+	if target == "" || target == "/" {
+		s.cwd = ""
+		return nil
+	}
+	aliases := s.aliases()
+	if shellIsRelative(target, aliases) {
+		target = shellResolve(s.cwd, target)
+	}
+	if target == "" {
+		s.cwd = ""
+		return nil
+	}
+	if !strings.HasSuffix(target, "/") {
+		target += "/"
+	}
+	if alias := splitStr(target, "/", 2)[0]; !aliases[alias] {
+		return errNoMatchingHost(target).Trace(target)
+	}
+	// Aliases are always folders, check anything below them.
+	if strings.Count(target, "/") > 1 {
+		clnt, err := newClient(target)
+		if err != nil {
+			return err.Trace(target)
+		}
+		st, err := clnt.Stat(false, false, nil)
+		if err != nil {
+			return err.Trace(target)
+		}
+		if !st.Type.IsDir() {
+			return probe.NewError(fmt.Errorf("`%s` is not a folder", target))
+		}
+	}
+	s.cwd = target
+	return nil
+}
+
+// run runs an mc command in this process and returns its exit status.
+// Exits of the command are turned into a shellExit panic and
+// recovered here, so that the shell keeps running.
+func (s *shell) run(args []string) (status int) {
+	osExiter, consoleExit := cli.OsExiter, console.Exit
+	exit := func(code int) {
+		panic(shellExit(code))
+	}
+	cli.OsExiter, console.Exit = exit, exit
+	globalSummary = &commandSummary{Categories: make(map[string]int)}
+	defer func() {
+		cli.OsExiter, console.Exit = osExiter, consoleExit
+		if r := recover(); r != nil {
+			code, ok := r.(shellExit)
+			if !ok {
+				panic(r)
+			}
+			status = globalSummary.exitStatus(int(code))
+		}
+	}()
+	if e := s.app.Run(append([]string{s.app.Name}, args...)); e != nil {
+		return globalSummary.exitStatus(1)
+	}
+	return 0
+}
+
+// exec runs one command line, it returns true to leave the shell.
+func (s *shell) exec(words []string) bool {
+	switch words[0] {
+	case "exit", "quit":
+		return true
+	case "pwd":
+		if s.cwd == "" {
+			console.Println("/")
+		} else {
+			console.Println(s.cwd)
+		}
+	case "cd":
+		target := ""
+		if len(words) > 1 {
+			target = words[1]
+		}
+		errorIf(s.cd(target), "Unable to change the working prefix to `"+target+"`.")
+	case "history":
+		var buf bytes.Buffer
+		if _, e := s.line.WriteHistory(&buf); e != nil {
+			errorIf(probe.NewError(e), "Unable to read the shell history.")
+			break
+		}
+		for i, entry := range strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n") {
+			console.Printf("%5d  %s\n", i+1, entry)
+		}
+	case "help":
+		if len(words) == 1 {
+			console.Println("Shell builtins: " + strings.Join(shellBuiltins, ", ") + ". Other lines run mc commands.\n")
+		}
+		s.run(words)
+	case "shell":
+		errorIf(errInvalidArgument().Trace(), "Already running a shell.")
+	default:
+		s.run(shellResolveArgs(s.cwd, words, s.aliases(), s.valueFlags(words)))
+	}
+	return false
+}
+
+// complete completes the word under the cursor, command names for the
+// first word and paths for the arguments of commands working on objects.
+func (s *shell) complete(line string, pos int) (head string, completions []string, tail string) {
+	before := line[:pos]
+	start := strings.LastIndexAny(before, " \t") + 1
+	head, word, tail := line[:start], before[start:], line[pos:]
+
+	words, e := shellSplit(head)
+	if e != nil {
+		return head, nil, tail
+	}
+	if len(words) == 0 {
+		names := append([]string{}, shellBuiltins...)
+		for _, command := range s.app.Commands {
+			if !command.Hidden {
+				names = append(names, command.Name)
+			}
+		}
+		for _, name := range names {
+			if strings.HasPrefix(name, word) {
+				completions = append(completions, name+" ")
+			}
+		}
+		sort.Strings(completions)
+		return head, completions, tail
+	}
+	if words[0] != "cd" && !shellPathCmds[words[0]] {
+		return head, nil, tail
+	}
+	for _, entry := range s.completePath(word) {
+		if words[0] == "cd" && !strings.HasSuffix(entry, "/") {
+			continue
+		}
+		completions = append(completions, entry)
+	}
+	return head, completions, tail
+}
+
+// completePath lists the aliases or the entries starting with word,
+// relative to the working prefix when word is relative.
+func (s *shell) completePath(word string) (entries []string) {
+	aliases := s.aliases()
+	full, prefix := word, ""
+	if s.cwd != "" && (word == "" || shellIsRelative(word, aliases)) {
+		prefix = s.cwd
+		full = prefix + word
+	}
+	if !strings.Contains(full, "/") {
+		for alias := range aliases {
+			if strings.HasPrefix(alias, full) {
+				entries = append(entries, alias+"/")
+			}
+		}
+		sort.Strings(entries)
+		return entries
+	}
+	dir := full[:strings.LastIndex(full, "/")+1]
+	alias := splitStr(full, "/", 2)[0]
+	for _, entry := range listPrefixEntries(alias, dir) {
+		if strings.HasPrefix(entry, full) {
+			entries = append(entries, strings.TrimPrefix(entry, prefix))
+		}
+	}
+	return entries
+}
+
+// checkShellSyntax - validate all the passed arguments
+func checkShellSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) > 1 {
+		cli.ShowCommandHelpAndExit(ctx, "shell", 1) // last argument is exit code
+	}
+}
+
+// mainShell is the entry point for shell command.
+func mainShell(ctx *cli.Context) error {
+	checkShellSyntax(ctx)
+
+	s := &shell{app: ctx.App}
+	if target := ctx.Args().First(); target != "" {
+		fatalIf(s.cd(target), "Unable to change the working prefix to `"+target+"`.")
+	}
+
+	s.line = liner.NewLiner()
+	defer s.line.Close()
+	s.line.SetCtrlCAborts(true)
+	s.line.SetTabCompletionStyle(liner.TabPrints)
+	s.line.SetWordCompleter(s.complete)
+
+	historyFile := filepath.Join(mustGetMcConfigDir(), shellHistoryFile)
+	if f, e := os.Open(historyFile); e == nil {
+		s.line.ReadHistory(f)
+		f.Close()
+	}
+
+	for {
+		input, e := s.line.Prompt(s.prompt())
+		if e == liner.ErrPromptAborted {
+			continue
+		}
+		if e == io.EOF {
+			break
+		}
+		fatalIf(probe.NewError(e), "Unable to read the command line.")
+
+		words, e := shellSplit(input)
+		if e != nil {
+			errorIf(probe.NewError(e), "Unable to parse the command line.")
+			continue
+		}
+		if len(words) == 0 {
+			continue
+		}
+		s.line.AppendHistory(input)
+		if s.exec(words) {
+			break
+		}
+	}
+
+	f, e := os.OpenFile(historyFile, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if e != nil {
+		errorIf(probe.NewError(e), "Unable to save the shell history.")
+		return nil
+	}
+	defer f.Close()
+	if _, e = s.line.WriteHistory(f); e != nil {
+		errorIf(probe.NewError(e), "Unable to save the shell history.")
+	}
+	return nil
+}
@@ -0,0 +1,152 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"errors"
+	"path"
+	"strings"
+)
+
+// shellPathCmds are the commands whose arguments are resolved against
+// the working prefix of the shell.
+var shellPathCmds = map[string]bool{
+	"browse":  true,
+	"cat":     true,
+	"cp":      true,
+	"diff":    true,
+	"du":      true,
+	"extract": true,
+	"find":    true,
+	"head":    true,
+	"ls":      true,
+	"mb":      true,
+	"mirror":  true,
+	"pipe":    true,
+	"rb":      true,
+	"rm":      true,
+	"sample":  true,
+	"share":   true,
+	"sql":     true,
+	"stat":    true,
+	"tree":    true,
+	"watch":   true,
+}
+
+// shellSplit splits a command line into words, honoring single and
+// double quotes and backslash escapes.
+func shellSplit(line string) ([]string, error) {
+	var words []string
+	var word strings.Builder
+	var quote rune
+	inWord, escaped := false, false
+	for _, r := range line {
+		switch {
+		case escaped:
+			word.WriteRune(r)
+			escaped = false
+		case r == '\\' && quote != '\'':
+			escaped, inWord = true, true
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else {
+				word.WriteRune(r)
+			}
+		case r == '\'' || r == '"':
+			quote, inWord = r, true
+		case r == ' ' || r == '\t':
+			if inWord {
+				words = append(words, word.String())
+				word.Reset()
+				inWord = false
+			}
+		default:
+			word.WriteRune(r)
+			inWord = true
+		}
+	}
+	if quote != 0 {
+		return nil, errors.New("unterminated quote")
+	}
+	if escaped {
+		return nil, errors.New("trailing backslash")
+	}
+	if inWord {
+		words = append(words, word.String())
+	}
+	return words, nil
+}
+
+// shellIsRelative tells if arg is relative to the working prefix. Local
+// paths start with "./", "/" or "~", and arguments starting with a
+// known alias are absolute.
+func shellIsRelative(arg string, aliases map[string]bool) bool {
+	if arg == "" {
+		return false
+	}
+	for _, prefix := range []string{"./", "/", "~", "-"} {
+		if strings.HasPrefix(arg, prefix) {
+			return false
+		}
+	}
+	return !aliases[splitStr(arg, "/", 2)[0]]
+}
+
+// shellResolve joins a relative arg to the working prefix cwd, ".."
+// moves up one level and the result keeps a trailing "/" of arg.
+func shellResolve(cwd, arg string) string {
+	resolved := path.Clean(cwd + arg)
+	if resolved == "." || strings.HasPrefix(resolved, "..") {
+		return ""
+	}
+	if strings.HasSuffix(arg, "/") || arg == "." || arg == ".." || strings.HasSuffix(arg, "/..") {
+		resolved += "/"
+	}
+	return resolved
+}
+
+// shellResolveArgs resolves the path arguments of args, the first
+// word being the command, against the working prefix cwd. Values of
+// flags are left alone, valueFlags tells which flags take a value.
+func shellResolveArgs(cwd string, args []string, aliases map[string]bool, valueFlags map[string]bool) []string {
+	if cwd == "" || len(args) == 0 || !shellPathCmds[args[0]] {
+		return args
+	}
+	resolved := make([]string, 0, len(args))
+	resolved = append(resolved, args[0])
+	rest := args[1:]
+	// Subcommands like "share download" take paths after their name.
+	if args[0] == "share" && len(rest) > 0 {
+		resolved = append(resolved, rest[0])
+		rest = rest[1:]
+	}
+	isFlagValue := false
+	for _, arg := range rest {
+		switch {
+		case isFlagValue:
+			isFlagValue = false
+		case strings.HasPrefix(arg, "-"):
+			name := strings.TrimLeft(arg, "-")
+			isFlagValue = !strings.Contains(name, "=") && valueFlags[name]
+		case shellIsRelative(arg, aliases):
+			arg = shellResolve(cwd, arg)
+		}
+		resolved = append(resolved, arg)
+	}
+	return resolved
+}
@@ -0,0 +1,76 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestShellSplit(t *testing.T) {
+	testCases := []struct {
+		line    string
+		words   []string
+		wantErr bool
+	}{
+		{"ls", []string{"ls"}, false},
+		{"  cp  a   b ", []string{"cp", "a", "b"}, false},
+		{`cp "my file.txt" 'other file'`, []string{"cp", "my file.txt", "other file"}, false},
+		{`cat my\ file.txt`, []string{"cat", "my file.txt"}, false},
+		{`ls ""`, []string{"ls", ""}, false},
+		{`ls "unterminated`, nil, true},
+		{`ls trailing\`, nil, true},
+	}
+	for i, testCase := range testCases {
+		words, err := shellSplit(testCase.line)
+		if (err != nil) != testCase.wantErr {
+			t.Fatalf("Test %d: unexpected error %v", i+1, err)
+		}
+		if !testCase.wantErr && !reflect.DeepEqual(words, testCase.words) {
+			t.Errorf("Test %d: expected %q, got %q", i+1, testCase.words, words)
+		}
+	}
+}
+
+func TestShellResolveArgs(t *testing.T) {
+	aliases := map[string]bool{"myminio": true, "s3": true}
+	valueFlags := map[string]bool{"older-than": true, "expire": true, "E": true}
+	testCases := []struct {
+		cwd      string
+		args     []string
+		expected []string
+	}{
+		// Nothing is resolved at the alias list.
+		{"", []string{"ls", "photos/"}, []string{"ls", "photos/"}},
+		{"myminio/mybucket/", []string{"ls"}, []string{"ls"}},
+		{"myminio/mybucket/", []string{"ls", "photos/"}, []string{"ls", "myminio/mybucket/photos/"}},
+		{"myminio/mybucket/", []string{"ls", "."}, []string{"ls", "myminio/mybucket/"}},
+		{"myminio/mybucket/photos/", []string{"ls", ".."}, []string{"ls", "myminio/mybucket/"}},
+		{"myminio/mybucket/", []string{"cp", "./local.txt", "s3/backup/", "docs/a.txt"}, []string{"cp", "./local.txt", "s3/backup/", "myminio/mybucket/docs/a.txt"}},
+		{"myminio/mybucket/", []string{"rm", "--older-than", "7d", "--recursive", "logs/"}, []string{"rm", "--older-than", "7d", "--recursive", "myminio/mybucket/logs/"}},
+		{"myminio/mybucket/", []string{"rm", "--older-than=7d", "logs/"}, []string{"rm", "--older-than=7d", "myminio/mybucket/logs/"}},
+		{"myminio/mybucket/", []string{"share", "download", "-E", "2h", "a.txt"}, []string{"share", "download", "-E", "2h", "myminio/mybucket/a.txt"}},
+		// Commands not working on objects are left alone.
+		{"myminio/mybucket/", []string{"admin", "info", "server"}, []string{"admin", "info", "server"}},
+	}
+	for i, testCase := range testCases {
+		args := shellResolveArgs(testCase.cwd, testCase.args, aliases, valueFlags)
+		if !reflect.DeepEqual(args, testCase.expected) {
+			t.Errorf("Test %d: expected %q, got %q", i+1, testCase.expected, args)
+		}
+	}
+}
@@ -19,6 +19,7 @@ package cmd
 import (
 	"os"
 	"os/signal"
+	"sync/atomic"
 )
 
 // signalTrap traps the registered signals and notifies the caller.
@@ -37,6 +38,7 @@ func signalTrap(sig ...os.Signal) <-chan bool {
 
 		// Wait for the signal.
 		<-sigCh
+		atomic.StoreInt32(&globalInterrupted, 1)
 
 		// Once signal has been received stop signal Notify handler.
 		signal.Stop(sigCh)
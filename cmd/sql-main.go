@@ -31,6 +31,7 @@ import (
 
 	"github.com/minio/cli"
 	"github.com/minio/mc/pkg/probe"
+	minio "github.com/minio/minio-go/v6"
 	"github.com/minio/minio/pkg/mimedb"
 )
 
@@ -52,9 +53,13 @@ var (
 			Name:  "json-input",
 			Usage: "json input serialization option",
 		},
+		cli.BoolFlag{
+			Name:  "parquet-input",
+			Usage: "parquet input serialization",
+		},
 		cli.StringFlag{
 			Name:  "compression",
-			Usage: "input compression type",
+			Usage: "input compression type, one of [NONE, GZIP, BZIP2]",
 		},
 		cli.StringFlag{
 			Name:  "csv-output",
@@ -119,6 +124,13 @@ EXAMPLES:
      $ {{.HelpName}} --compression GZIP --csv-input "rd=\n,fh=USE,fd=;" \
                      --csv-output "rd=\n" --csv-output-header "device_id,uptime,lat,lon" \
                      --query "select * from S3Object" myminio/iot-devices/data.csv
+
+  7. Run a query on an object on MinIO compressed with bzip2 but stored without file extension.
+     $ {{.HelpName}} --compression BZIP2 --csv-input "fh=USE" --query "select * from S3Object" myminio/iot-devices/data
+
+  8. Run a query recursively on all parquet objects under a prefix, results are streamed one object after another.
+     $ {{.HelpName}} --recursive --parquet-input --json-output "rd=\n" \
+                     --query "select s.device_id from S3Object s" myminio/iot-devices/parquet/
 `,
 }
 
@@ -256,8 +268,9 @@ func getInputSerializationOpts(ctx *cli.Context) map[string]map[string]string {
 
 	csvType := ctx.IsSet("csv-input")
 	jsonType := ctx.IsSet("json-input")
-	if csvType && jsonType {
-		fatalIf(errInvalidArgument(), "Only one of --csv-input or --json-input can be specified as input serialization option")
+	parquetType := ctx.Bool("parquet-input")
+	if (csvType && jsonType) || (parquetType && (csvType || jsonType)) {
+		fatalIf(errInvalidArgument(), "Only one of --csv-input, --json-input or --parquet-input can be specified as input serialization option")
 	}
 
 	if icsv != "" {
@@ -272,10 +285,25 @@ func getInputSerializationOpts(ctx *cli.Context) map[string]map[string]string {
 		fatalIf(err, "Invalid serialization option(s) specified for --json-input flag")
 		m["json"] = kv
 	}
+	if parquetType {
+		m["parquet"] = make(map[string]string)
+	}
 
 	return m
 }
 
+// gets the input compression type from cli context, an empty value lets
+// the compression type be guessed from the object name.
+func getSQLCompressionType(ctx *cli.Context) minio.SelectCompressionType {
+	compression := strings.ToUpper(ctx.String("compression"))
+	switch minio.SelectCompressionType(compression) {
+	case "", minio.SelectCompressionNONE, minio.SelectCompressionGZIP, minio.SelectCompressionBZIP:
+		return minio.SelectCompressionType(compression)
+	}
+	fatalIf(errInvalidArgument().Trace(compression), "Invalid value specified for --compression flag, valid values are NONE, GZIP, BZIP2")
+	return ""
+}
+
 // gets the output serialization opts from cli context and constructs a map of csv or json options
 func getOutputSerializationOpts(ctx *cli.Context, csvHdrs []string) (opts map[string]map[string]string) {
 	m := make(map[string]map[string]string)
@@ -373,8 +401,9 @@ func getSQLOpts(ctx *cli.Context, csvHdrs []string) (s SelectObjectOpts) {
 	os := getOutputSerializationOpts(ctx, csvHdrs)
 
 	return SelectObjectOpts{
-		InputSerOpts:  is,
-		OutputSerOpts: os,
+		InputSerOpts:    is,
+		OutputSerOpts:   os,
+		CompressionType: getSQLCompressionType(ctx),
 	}
 }
 
@@ -474,20 +503,43 @@ func mainSQL(ctx *cli.Context) error {
 				errorIf(content.Err.Trace(url), "Unable to list on target `"+url+"`.")
 				continue
 			}
+			if !isSQLSupportedObject(ctx, content.URL.Path) {
+				continue
+			}
 			if writeHdr {
 				query, csvHdrs, selOpts = getAndValidateArgs(ctx, encKeyDB, targetAlias+content.URL.Path)
 			}
-			contentType := mimedb.TypeByExtension(filepath.Ext(content.URL.Path))
-			for _, cTypeSuffix := range supportedContentTypes {
-				if strings.Contains(contentType, cTypeSuffix) {
-					errorIf(sqlSelect(targetAlias+content.URL.Path, query,
-						encKeyDB, selOpts, csvHdrs, writeHdr).Trace(content.URL.String()), "Unable to run sql")
-				}
-				writeHdr = false
-			}
+			errorIf(sqlSelect(targetAlias+content.URL.Path, query,
+				encKeyDB, selOpts, csvHdrs, writeHdr).Trace(content.URL.String()), "Unable to run sql")
+			writeHdr = false
 		}
 	}
 
 	// Done.
 	return nil
 }
+
+// isSQLSupportedObject returns true if an object found while listing
+// a prefix can be queried, explicit input serialization flags accept
+// all the objects.
+func isSQLSupportedObject(ctx *cli.Context, object string) bool {
+	if ctx.IsSet("csv-input") || ctx.IsSet("json-input") || ctx.Bool("parquet-input") {
+		return true
+	}
+	return isSQLSupportedName(object)
+}
+
+// isSQLSupportedName returns true if the object name has an extension
+// which can be queried with S3 Select.
+func isSQLSupportedName(object string) bool {
+	if strings.HasSuffix(object, ".parquet") {
+		return true
+	}
+	contentType := mimedb.TypeByExtension(filepath.Ext(object))
+	for _, cTypeSuffix := range supportedContentTypes {
+		if strings.Contains(contentType, cTypeSuffix) {
+			return true
+		}
+	}
+	return false
+}
@@ -138,3 +138,23 @@ func TestParseSerializationOpts(t *testing.T) {
 		}
 	}
 }
+
+func TestIsSQLSupportedName(t *testing.T) {
+	testCases := []struct {
+		object    string
+		supported bool
+	}{
+		{"data.csv", true},
+		{"data.json", true},
+		{"data.csv.gz", true},
+		{"data.csv.bz2", true},
+		{"year=2019/data.parquet", true},
+		{"image.png", false},
+		{"README", false},
+	}
+	for i, testCase := range testCases {
+		if supported := isSQLSupportedName(testCase.object); supported != testCase.supported {
+			t.Errorf("Test %d: expected %t for %s, got %t", i+1, testCase.supported, testCase.object, supported)
+		}
+	}
+}
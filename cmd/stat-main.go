@@ -17,6 +17,7 @@
 package cmd
 
 import (
+	"path/filepath"
 	"strings"
 
 	"github.com/fatih/color"
@@ -31,6 +32,10 @@ var (
 			Name:  "recursive, r",
 			Usage: "stat all objects recursively",
 		},
+		cli.BoolFlag{
+			Name:  "versions",
+			Usage: "show all versions of objects",
+		},
 	}
 )
 
@@ -69,6 +74,9 @@ EXAMPLES:
   5. Stat encrypted files on Amazon S3 cloud storage. In case the encryption key contains non-printable character like tab, pass the
      base64 encoded string as key.
      $ {{.HelpName}} --encrypt-key "s3/personal-document/=MzJieXRlc2xvbmdzZWNyZWFiY2RlZmcJZ2l2ZW5uMjE=" s3/personal-document/2019-account_report.docx
+
+  6. Stat an object on a versioned bucket showing all its versions along with its tags, retention and replication status.
+     $ {{.HelpName}} --versions s3/backups/db.dump
 `,
 }
 
@@ -129,8 +137,17 @@ func mainStat(ctx *cli.Context) error {
 		if err != nil {
 			fatalIf(err, "Unable to stat `"+targetURL+"`.")
 		}
+		clnt, err := newClient(targetURL)
+		fatalIf(err.Trace(targetURL), "Unable to initialize `"+targetURL+"`.")
+		targetAlias, _, _ := mustExpandAlias(targetURL)
+		prefixPath := filepath.ToSlash(statPrefixPath(clnt))
 		for _, stat := range stats {
 			st := parseStat(stat)
+			if !stat.Type.IsDir() {
+				objectURL := targetAlias + prefixPath + filepath.ToSlash(stat.URL.Path)
+				err = statObjectDetails(objectURL, &st, ctx.Bool("versions"))
+				errorIf(err, "Unable to fetch tags and versions of `"+objectURL+"`.")
+			}
 			if !globalJSON {
 				printStat(st)
 			} else {
@@ -19,6 +19,7 @@ package cmd
 import (
 	"fmt"
 	"path/filepath"
+	"sort"
 	"strings"
 	"time"
 
@@ -26,6 +27,7 @@ import (
 	json "github.com/minio/mc/pkg/colorjson"
 	"github.com/minio/mc/pkg/console"
 	"github.com/minio/mc/pkg/probe"
+	minio "github.com/minio/minio-go/v6"
 )
 
 // contentMessage container for content message structure.
@@ -39,6 +41,22 @@ type statMessage struct {
 	Expires           time.Time         `json:"expires"`
 	EncryptionHeaders map[string]string `json:"encryption,omitempty"`
 	Metadata          map[string]string `json:"metadata"`
+	VersionID         string            `json:"versionID,omitempty"`
+	ReplicationStatus string            `json:"replicationStatus,omitempty"`
+	LockMode          string            `json:"lockMode,omitempty"`
+	LockRetainUntil   string            `json:"lockRetainUntil,omitempty"`
+	LegalHold         string            `json:"legalHold,omitempty"`
+	Tags              map[string]string `json:"tags,omitempty"`
+	Versions          []statVersion     `json:"versions,omitempty"`
+}
+
+// statVersion container for a single version of an object.
+type statVersion struct {
+	VersionID string    `json:"versionID"`
+	IsLatest  bool      `json:"isLatest"`
+	Date      time.Time `json:"lastModified"`
+	Size      int64     `json:"size"`
+	ETag      string    `json:"etag"`
 }
 
 // String colorized string message.
@@ -55,28 +73,59 @@ func printStat(stat statMessage) {
 	if !stat.Expires.IsZero() {
 		console.Println(fmt.Sprintf("%-10s: %s ", "Expires", stat.Expires.Format(printDate)))
 	}
+	if stat.VersionID != "" {
+		console.Println(fmt.Sprintf("%-10s: %s ", "VersionID", stat.VersionID))
+	}
+	if stat.ReplicationStatus != "" {
+		console.Println(fmt.Sprintf("%-10s: %s ", "Replication", stat.ReplicationStatus))
+	}
+	if stat.LockMode != "" {
+		console.Println(fmt.Sprintf("%-10s: %s until %s ", "Retention", stat.LockMode, stat.LockRetainUntil))
+	}
+	if stat.LegalHold != "" {
+		console.Println(fmt.Sprintf("%-10s: %s ", "LegalHold", stat.LegalHold))
+	}
+	if len(stat.Tags) > 0 {
+		var tags []string
+		for k, v := range stat.Tags {
+			tags = append(tags, k+"="+v)
+		}
+		sort.Strings(tags)
+		console.Println(fmt.Sprintf("%-10s: %s ", "Tags", strings.Join(tags, "&")))
+	}
+	if len(stat.Versions) > 0 {
+		console.Println(fmt.Sprintf("%-10s:", "Versions"))
+		for _, v := range stat.Versions {
+			latest := ""
+			if v.IsLatest {
+				latest = " (latest)"
+			}
+			console.Println(fmt.Sprintf("  %s %7s %s%s ", v.Date.Format(printDate),
+				strings.Join(strings.Fields(humanize.IBytes(uint64(v.Size))), ""), v.VersionID, latest))
+		}
+	}
 	var maxKey = 0
 	for k := range stat.Metadata {
-		if len(k) > maxKey {
-			maxKey = len(k)
+		if w := console.StringWidth(k); w > maxKey {
+			maxKey = w
 		}
 	}
 	if len(stat.Metadata) > 0 {
 		console.Println(fmt.Sprintf("%-10s:", "Metadata"))
 		for k, v := range stat.Metadata {
-			console.Println(fmt.Sprintf("  %-*.*s: %s ", maxKey, maxKey, k, v))
+			console.Println(fmt.Sprintf("  %s: %s ", console.PadRight(k, maxKey), v))
 		}
 	}
 	maxKey = 0
 	for k := range stat.EncryptionHeaders {
-		if len(k) > maxKey {
-			maxKey = len(k)
+		if w := console.StringWidth(k); w > maxKey {
+			maxKey = w
 		}
 	}
 	if len(stat.EncryptionHeaders) > 0 {
 		console.Println(fmt.Sprintf("%-10s:", "Encrypted"))
 		for k, v := range stat.EncryptionHeaders {
-			console.Println(fmt.Sprintf("  %-*.*s: %s ", maxKey, maxKey, k, v))
+			console.Println(fmt.Sprintf("  %s: %s ", console.PadRight(k, maxKey), v))
 		}
 	}
 	console.Println()
@@ -109,9 +158,77 @@ func parseStat(c *clientContent) statMessage {
 	content.ETag = strings.TrimSuffix(content.ETag, "\"")
 	content.Expires = c.Expires
 	content.EncryptionHeaders = c.EncryptionHeaders
+	for k, v := range c.Metadata {
+		switch strings.ToLower(k) {
+		case "x-amz-version-id":
+			content.VersionID = v
+		case "x-amz-replication-status":
+			content.ReplicationStatus = v
+		case "x-amz-object-lock-mode":
+			content.LockMode = v
+		case "x-amz-object-lock-retain-until-date":
+			content.LockRetainUntil = v
+		case "x-amz-object-lock-legal-hold":
+			content.LegalHold = v
+		}
+	}
 	return content
 }
 
+// statObjectDetails adds the tags of an object and optionally all its
+// versions, only S3 objects have tags and versions.
+func statObjectDetails(objectURL string, stat *statMessage, isVersions bool) *probe.Error {
+	clnt, err := newClient(objectURL)
+	if err != nil {
+		return err.Trace(objectURL)
+	}
+	s3Clnt, ok := clnt.(*s3Client)
+	if !ok {
+		return nil
+	}
+
+	tags, err := s3Clnt.GetTags()
+	switch {
+	case err == nil:
+		stat.Tags = tags
+	case minio.ToErrorResponse(err.ToGoError()).Code != "NotImplemented":
+		// Servers without object tagging have nothing to show.
+		return err.Trace(objectURL)
+	}
+
+	if !isVersions {
+		return nil
+	}
+	versions, err := s3Clnt.ListVersions(false)
+	if err != nil {
+		return err.Trace(objectURL)
+	}
+	_, object := s3Clnt.url2BucketAndObject()
+	for _, v := range versions {
+		if v.Key != object {
+			continue
+		}
+		stat.Versions = append(stat.Versions, statVersion{
+			VersionID: v.VersionID,
+			IsLatest:  v.IsLatest,
+			Date:      v.LastModified.Local(),
+			Size:      v.Size,
+			ETag:      strings.Trim(v.ETag, "\""),
+		})
+	}
+	return nil
+}
+
+// statPrefixPath returns the path statURL trims from listed contents.
+func statPrefixPath(clnt Client) string {
+	prefixPath := clnt.GetURL().Path
+	separator := string(clnt.GetURL().Separator)
+	if !strings.HasSuffix(prefixPath, separator) {
+		prefixPath = prefixPath[:strings.LastIndex(prefixPath, separator)+1]
+	}
+	return prefixPath
+}
+
 // Return standardized URL to be used to compare later.
 func getStandardizedURL(targetURL string) string {
 	return filepath.FromSlash(targetURL)
@@ -128,11 +245,7 @@ func statURL(targetURL string, isIncomplete, isRecursive bool, encKeyDB map[stri
 
 	targetAlias, _, _ := mustExpandAlias(targetURL)
 
-	prefixPath := clnt.GetURL().Path
-	separator := string(clnt.GetURL().Separator)
-	if !strings.HasSuffix(prefixPath, separator) {
-		prefixPath = prefixPath[:strings.LastIndex(prefixPath, separator)+1]
-	}
+	prefixPath := statPrefixPath(clnt)
 	var cErr error
 	for content := range clnt.List(isRecursive, isIncomplete, DirNone) {
 		if content.Err != nil {
@@ -56,3 +56,24 @@ func (s *TestSuite) TestParseStat(c *C) {
 		c.Assert(etag, Equals, statMsg.ETag)
 	}
 }
+
+func (s *TestSuite) TestParseStatObjectHeaders(c *C) {
+	content := clientContent{
+		URL:  *newClientURL("https://play.min.io/testbucket/object"),
+		Type: 0644,
+		Metadata: map[string]string{
+			"X-Amz-Version-Id":                    "3HL4kqtJlcpXroDTDmJ+rmSpXd3dIbrHY",
+			"X-Amz-Replication-Status":            "COMPLETED",
+			"X-Amz-Object-Lock-Mode":              "GOVERNANCE",
+			"X-Amz-Object-Lock-Retain-Until-Date": "2020-01-01T00:00:00Z",
+			"X-Amz-Object-Lock-Legal-Hold":        "ON",
+		},
+	}
+	statMsg := parseStat(&content)
+	c.Assert(statMsg.VersionID, Equals, "3HL4kqtJlcpXroDTDmJ+rmSpXd3dIbrHY")
+	c.Assert(statMsg.ReplicationStatus, Equals, "COMPLETED")
+	c.Assert(statMsg.LockMode, Equals, "GOVERNANCE")
+	c.Assert(statMsg.LockRetainUntil, Equals, "2020-01-01T00:00:00Z")
+	c.Assert(statMsg.LegalHold, Equals, "ON")
+	c.Assert(statMsg.Metadata, DeepEquals, content.Metadata)
+}
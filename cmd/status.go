@@ -269,3 +269,71 @@ func (ps *ProgressStatus) fatalIf(err *probe.Error, msg string) {
 
 	ps.progressBar.Update()
 }
+
+// NewLineStatus returns a status object rendering progress with the
+// given line oriented renderer.
+func NewLineStatus(hook io.Reader, mode string) Status {
+	return &LineStatus{
+		newLineProgress(mode, 0),
+		hook,
+	}
+}
+
+// LineStatus shows the progress without terminal control characters
+type LineStatus struct {
+	*lineProgress
+	hook io.Reader
+}
+
+// Read implements the io.Reader interface
+func (ls *LineStatus) Read(p []byte) (n int, err error) {
+	ls.hook.Read(p)
+	return ls.lineProgress.Read(p)
+}
+
+// SetTotal sets the total number of bytes to transfer
+func (ls *LineStatus) SetTotal(v int64) Status {
+	ls.lineProgress.Total = v
+	return ls
+}
+
+// SetCaption is ignored for linestatus
+func (ls *LineStatus) SetCaption(s string) {}
+
+// Total returns the total number of bytes
+func (ls *LineStatus) Total() int64 {
+	return ls.lineProgress.Total
+}
+
+// Add bytes to current number of bytes
+func (ls *LineStatus) Add(v int64) Status {
+	ls.lineProgress.Add(v)
+	return ls
+}
+
+// Println prints line
+func (ls *LineStatus) Println(data ...interface{}) {
+	console.Println(data...)
+}
+
+// PrintMsg is ignored for linestatus, only the overall progress is shown
+func (ls *LineStatus) PrintMsg(msg message) {}
+
+// Start is ignored for linestatus
+func (ls *LineStatus) Start() {}
+
+// Finish displays the accounting summary
+func (ls *LineStatus) Finish() {
+	ls.lineProgress.Finish()
+}
+
+// Update is ignored for linestatus
+func (ls *LineStatus) Update() {}
+
+func (ls *LineStatus) errorIf(err *probe.Error, msg string) {
+	errorIf(err, msg)
+}
+
+func (ls *LineStatus) fatalIf(err *probe.Error, msg string) {
+	fatalIf(err, msg)
+}
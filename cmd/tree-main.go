@@ -17,13 +17,15 @@
 package cmd
 
 import (
-	"errors"
 	"fmt"
+	"path"
 	"path/filepath"
 	"strings"
 
+	humanize "github.com/dustin/go-humanize"
 	"github.com/fatih/color"
 	"github.com/minio/cli"
+	json "github.com/minio/mc/pkg/colorjson"
 	"github.com/minio/mc/pkg/console"
 	"github.com/minio/mc/pkg/probe"
 )
@@ -37,9 +39,18 @@ const (
 
 // Structured message depending on the type of console.
 type treeMessage struct {
-	Entry        string
-	IsDir        bool
-	BranchString string
+	Status       string `json:"status"`
+	Type         string `json:"type"`
+	Entry        string `json:"name"`
+	Path         string `json:"path"`
+	Level        int    `json:"level"`
+	Objects      int64  `json:"objects,omitempty"`
+	Size         int64  `json:"size,omitempty"`
+	IsDir        bool   `json:"-"`
+	BranchString string `json:"-"`
+
+	// show object counts and sizes when printing.
+	showUsage bool
 }
 
 // Colorized message for console printing.
@@ -48,14 +59,87 @@ func (t treeMessage) String() string {
 	if t.IsDir {
 		entryType = "Dir"
 	}
-	return fmt.Sprintf("%s%s", t.BranchString, console.Colorize(entryType, t.Entry))
+	msg := fmt.Sprintf("%s%s", t.BranchString, console.Colorize(entryType, t.Entry))
+	if t.showUsage {
+		size := strings.Join(strings.Fields(humanize.IBytes(uint64(t.Size))), "")
+		if t.IsDir {
+			msg += console.Colorize("Usage", fmt.Sprintf(" (%d objects, %s)", t.Objects, size))
+		} else {
+			msg += console.Colorize("Usage", fmt.Sprintf(" (%s)", size))
+		}
+	}
+	return msg
 }
 
 // JSON'ified message for scripting.
-// Does No-op. JSON requests are redirected to `ls -r --json`
 func (t treeMessage) JSON() string {
-	fatalIf(probe.NewError(errors.New("JSON() should never be called here")), "Unable to list in tree format. Please report this issue at https://github.com/minio/mc/issues")
-	return ""
+	t.Status = "success"
+	t.Type = "file"
+	if t.IsDir {
+		t.Type = "folder"
+	}
+	msgBytes, e := json.MarshalIndent(t, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(msgBytes)
+}
+
+// treeUsage is the number of objects and their total size below a folder.
+type treeUsage struct {
+	objects int64
+	size    int64
+}
+
+// treePathKey normalizes a path to lookup folder usage.
+func treePathKey(p string) string {
+	return path.Clean(filepath.ToSlash(p))
+}
+
+// addTreeUsage accounts an object to all of its parent folders up to root.
+func addTreeUsage(usage map[string]*treeUsage, root, objectPath string, size int64) {
+	root = treePathKey(root)
+	dir := treePathKey(objectPath)
+	for {
+		parent := path.Dir(dir)
+		if parent == dir {
+			return
+		}
+		dir = parent
+		u, ok := usage[dir]
+		if !ok {
+			u = &treeUsage{}
+			usage[dir] = u
+		}
+		u.objects++
+		u.size += size
+		if dir == root {
+			return
+		}
+	}
+}
+
+// getTreeUsage lists url recursively once and returns the usage of all
+// folders below it.
+func getTreeUsage(url string) (map[string]*treeUsage, *probe.Error) {
+	targetAlias, targetURL, _ := mustExpandAlias(url)
+	if !strings.HasSuffix(targetURL, "/") {
+		targetURL += "/"
+	}
+	clnt, err := newClientFromAlias(targetAlias, targetURL)
+	if err != nil {
+		return nil, err.Trace(targetURL)
+	}
+	usage := make(map[string]*treeUsage)
+	root := clnt.GetURL().Path
+	for content := range clnt.List(true, false, DirNone) {
+		if content.Err != nil {
+			return nil, content.Err.Trace(url)
+		}
+		if content.Type.IsDir() {
+			continue
+		}
+		addTreeUsage(usage, root, content.URL.Path, content.Size)
+	}
+	return usage, nil
 }
 
 var treeFlags = []cli.Flag{
@@ -68,6 +152,10 @@ var treeFlags = []cli.Flag{
 		Usage: "sets the depth threshold",
 		Value: -1,
 	},
+	cli.BoolFlag{
+		Name:  "usage, u",
+		Usage: "show the number of objects and total size of each folder",
+	},
 }
 
 // trees files and folders.
@@ -101,6 +189,12 @@ EXAMPLES:
 
    5. List all directories upto depth level '2' in tree format.
       $ {{.HelpName}} --depth 2 myminio/mybucket/
+
+   6. List all directories in "mybucket" with the number of objects and total size of each directory.
+      $ {{.HelpName}} --usage myminio/mybucket/
+
+   7. List all directories and objects in "mybucket" as JSON records for scripting.
+      $ {{.HelpName}} --files --usage --json myminio/mybucket/
 `,
 }
 
@@ -126,7 +220,8 @@ func checkTreeSyntax(ctx *cli.Context) {
 }
 
 // doTree - list all entities inside a folder in a tree format.
-func doTree(url string, level int, leaf bool, branchString string, depth int, includeFiles bool) error {
+// Folder usage is shown when usage is not nil.
+func doTree(url string, level int, leaf bool, branchString string, depth int, includeFiles bool, usage map[string]*treeUsage) error {
 
 	targetAlias, targetURL, _ := mustExpandAlias(url)
 	if !strings.HasSuffix(targetURL, "/") {
@@ -148,11 +243,17 @@ func doTree(url string, level int, leaf bool, branchString string, depth int, in
 		currbranchString := branchString
 		if level == 1 && !bucketNameShowed {
 			bucketNameShowed = true
-			printMsg(treeMessage{
+			msg := treeMessage{
 				Entry:        url,
+				Path:         url,
 				IsDir:        true,
 				BranchString: branchString,
-			})
+				showUsage:    usage != nil,
+			}
+			if u, ok := usage[treePathKey(clnt.GetURL().Path)]; ok {
+				msg.Objects, msg.Size = u.objects, u.size
+			}
+			printMsg(msg)
 		}
 
 		isLevelClosed := strings.HasSuffix(currbranchString, treeLastEntry)
@@ -183,30 +284,39 @@ func doTree(url string, level int, leaf bool, branchString string, depth int, in
 		// Trim prefix of current working dir
 		prefixPath = strings.TrimPrefix(prefixPath, "."+separator)
 
+		url := contentURL
+		if targetAlias != "" {
+			url = targetAlias + "/" + contentURL
+		}
+
 		if prev.Type.IsDir() {
-			printMsg(treeMessage{
+			msg := treeMessage{
 				Entry:        strings.TrimSuffix(strings.TrimPrefix(contentURL, prefixPath), "/"),
+				Path:         url,
+				Level:        level,
 				IsDir:        true,
 				BranchString: currbranchString,
-			})
+				showUsage:    usage != nil,
+			}
+			if u, ok := usage[treePathKey(prev.URL.Path)]; ok {
+				msg.Objects, msg.Size = u.objects, u.size
+			}
+			printMsg(msg)
 		} else {
 			printMsg(treeMessage{
 				Entry:        strings.TrimPrefix(contentURL, prefixPath),
+				Path:         url,
+				Level:        level,
+				Size:         prev.Size,
 				IsDir:        false,
 				BranchString: currbranchString,
+				showUsage:    usage != nil,
 			})
 		}
 
 		if prev.Type.IsDir() {
-			url := ""
-			if targetAlias != "" {
-				url = targetAlias + "/" + contentURL
-			} else {
-				url = contentURL
-			}
-
 			if depth == -1 || level <= depth {
-				if err := doTree(url, level+1, end, currbranchString, depth, includeFiles); err != nil {
+				if err := doTree(url, level+1, end, currbranchString, depth, includeFiles, usage); err != nil {
 					return err
 				}
 			}
@@ -252,6 +362,7 @@ func mainTree(ctx *cli.Context) error {
 
 	console.SetColor("File", color.New(color.Bold))
 	console.SetColor("Dir", color.New(color.FgCyan, color.Bold))
+	console.SetColor("Usage", color.New(color.FgYellow))
 
 	args := ctx.Args()
 	// mimic operating system tool behavior.
@@ -264,20 +375,14 @@ func mainTree(ctx *cli.Context) error {
 
 	var cErr error
 	for _, targetURL := range args {
-		if !globalJSON {
-			if e := doTree(targetURL, 1, false, "", depth, includeFiles); e != nil {
-				cErr = e
-			}
-		} else {
-			targetAlias, targetURL, _ := mustExpandAlias(targetURL)
-			if !strings.HasSuffix(targetURL, "/") {
-				targetURL += "/"
-			}
-			clnt, err := newClientFromAlias(targetAlias, targetURL)
-			fatalIf(err.Trace(targetURL), "Unable to initialize target `"+targetURL+"`.")
-			if e := doList(clnt, true, false); e != nil {
-				cErr = e
-			}
+		var usage map[string]*treeUsage
+		if ctx.Bool("usage") {
+			var err *probe.Error
+			usage, err = getTreeUsage(targetURL)
+			fatalIf(err.Trace(targetURL), "Unable to compute usage of `"+targetURL+"`.")
+		}
+		if e := doTree(targetURL, 1, false, "", depth, includeFiles, usage); e != nil {
+			cErr = e
 		}
 	}
 	return cErr
@@ -0,0 +1,71 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import "testing"
+
+func TestAddTreeUsage(t *testing.T) {
+	testCases := []struct {
+		root    string
+		objects map[string]int64
+		usage   map[string]treeUsage
+	}{
+		{
+			root: "/bucket/",
+			objects: map[string]int64{
+				"/bucket/a/b/1": 10,
+				"/bucket/a/2":   20,
+				"/bucket/3":     5,
+			},
+			usage: map[string]treeUsage{
+				"/bucket":     {3, 35},
+				"/bucket/a":   {2, 30},
+				"/bucket/a/b": {1, 10},
+			},
+		},
+		{
+			root: "./",
+			objects: map[string]int64{
+				"dir/1":     1,
+				"dir/sub/2": 2,
+			},
+			usage: map[string]treeUsage{
+				".":       {2, 3},
+				"dir":     {2, 3},
+				"dir/sub": {1, 2},
+			},
+		},
+	}
+	for i, testCase := range testCases {
+		usage := make(map[string]*treeUsage)
+		for objectPath, size := range testCase.objects {
+			addTreeUsage(usage, testCase.root, objectPath, size)
+		}
+		if len(usage) != len(testCase.usage) {
+			t.Fatalf("Test %d: Expected %d folders, got %d", i+1, len(testCase.usage), len(usage))
+		}
+		for dir, expected := range testCase.usage {
+			u, ok := usage[dir]
+			if !ok {
+				t.Fatalf("Test %d: Expected usage for %s", i+1, dir)
+			}
+			if *u != expected {
+				t.Errorf("Test %d: Expected %+v for %s, got %+v", i+1, expected, dir, *u)
+			}
+		}
+	}
+}
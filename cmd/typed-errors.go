@@ -125,3 +125,10 @@ var errConflictSSE = func(sseServer, sseKeys string) *probe.Error {
 	err := fmt.Errorf("SSE alias '%s' overlaps with SSE-C aliases '%s'", sseServer, sseKeys)
 	return probe.NewError(conflictSSEErr(err)).Untrace()
 }
+
+type noSuchProfileErr error
+
+var errNoSuchProfile = func(profile string) *probe.Error {
+	msg := "Profile `" + profile + "` not found. Use `mc --profile " + profile + " config host add ...` to add credentials to it."
+	return probe.NewError(noSuchProfileErr(errors.New(msg))).Untrace()
+}
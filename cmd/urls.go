@@ -29,6 +29,10 @@ type URLs struct {
 	TotalCount    int64
 	TotalSize     int64
 	encKeyDB      map[string][]prefixSSEPair
+	compress      string
+	cseKey        []byte
+	restoreDays   int
+	serverSide    bool
 	Error         *probe.Error `json:"-"`
 }
 
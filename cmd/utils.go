@@ -143,7 +143,16 @@ func newS3Config(urlStr string, hostCfg *hostConfigV9) *Config {
 	if hostCfg != nil {
 		s3Config.AccessKey = hostCfg.AccessKey
 		s3Config.SecretKey = hostCfg.SecretKey
+		s3Config.CredentialProcess = hostCfg.CredentialProcess
+		s3Config.RoleARN = hostCfg.RoleARN
+		s3Config.WebIdentityToken = hostCfg.WebIdentityTokenFile
+		s3Config.STSEndpoint = hostCfg.STSEndpoint
+		s3Config.TLS = hostCfg.TLS
+		if s3Config.STSEndpoint == "" {
+			s3Config.STSEndpoint = hostCfg.URL
+		}
 		s3Config.Signature = hostCfg.API
+		s3Config.Region = hostCfg.Region
 	}
 	s3Config.Lookup = getLookupType(hostCfg.Lookup)
 	return s3Config
@@ -20,6 +20,7 @@ import (
 	"reflect"
 	"testing"
 
+	"github.com/minio/minio-go/v6"
 	"github.com/minio/minio-go/v6/pkg/encrypt"
 )
 
@@ -159,3 +160,24 @@ func TestParseEncryptionKeys(t *testing.T) {
 		}
 	}
 }
+
+func TestNewS3ConfigHostOptions(t *testing.T) {
+	hostCfg := &hostConfigV9{
+		URL:       "https://rgw.internal",
+		AccessKey: "accesskey",
+		SecretKey: "secretkey",
+		API:       "S3v2",
+		Lookup:    "path",
+		Region:    "default",
+	}
+	s3Config := newS3Config(hostCfg.URL, hostCfg)
+	if s3Config.Signature != "S3v2" {
+		t.Errorf("Expected signature S3v2, got %s", s3Config.Signature)
+	}
+	if s3Config.Lookup != minio.BucketLookupPath {
+		t.Errorf("Expected path lookup, got %v", s3Config.Lookup)
+	}
+	if s3Config.Region != "default" {
+		t.Errorf("Expected region default, got %s", s3Config.Region)
+	}
+}
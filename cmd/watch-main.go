@@ -36,15 +36,15 @@ var (
 		cli.StringFlag{
 			Name:  "events",
 			Value: "put,delete,get",
-			Usage: "filter specific types of events; defaults to all events by default",
+			Usage: "filter specific types of events, comma separated list of put, delete and get",
 		},
 		cli.StringFlag{
 			Name:  "prefix",
-			Usage: "filter events for a prefix",
+			Usage: "filter events for a prefix, relative to PATH",
 		},
 		cli.StringFlag{
 			Name:  "suffix",
-			Usage: "filter events for a suffix",
+			Usage: "filter events for a suffix, relative to PATH",
 		},
 		cli.BoolFlag{
 			Name:  "recursive",
@@ -83,6 +83,9 @@ EXAMPLES:
 
   5. Watch for events on local directory.
      $ {{.HelpName}} /usr/share
+
+  6. Watch only for removed ".log" files below a local directory, in JSON.
+     $ {{.HelpName}} --recursive --events delete --suffix ".log" --json /var/log/app
 `,
 }
 
@@ -91,6 +94,8 @@ func checkWatchSyntax(ctx *cli.Context) {
 	if len(ctx.Args()) != 1 {
 		cli.ShowCommandHelpAndExit(ctx, "watch", 1) // last argument is exit code
 	}
+	events := strings.Split(ctx.String("events"), ",")
+	fatalIf(checkWatchEvents(events), "Invalid event types, supported types are [put, delete, get].")
 }
 
 // watchMessage container to hold one event notification
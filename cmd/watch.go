@@ -17,6 +17,7 @@
 package cmd
 
 import (
+	"strings"
 	"sync"
 	"time"
 
@@ -58,6 +59,32 @@ type watchParams struct {
 	recursive bool
 }
 
+// watchEventTypes are the event types accepted by watchParams.
+var watchEventTypes = []string{"put", "delete", "get"}
+
+// checkWatchEvents validates the requested event types.
+func checkWatchEvents(events []string) *probe.Error {
+	for _, event := range events {
+		supported := false
+		for _, eventType := range watchEventTypes {
+			if event == eventType {
+				supported = true
+				break
+			}
+		}
+		if !supported {
+			return errInvalidArgument().Trace(event)
+		}
+	}
+	return nil
+}
+
+// matches returns true if an object name, relative to the watched path,
+// passes the prefix and suffix filters.
+func (p watchParams) matches(name string) bool {
+	return strings.HasPrefix(name, p.prefix) && strings.HasSuffix(name, p.suffix)
+}
+
 type watchObject struct {
 	// eventInfo will be put on this chan
 	eventInfoChan chan EventInfo
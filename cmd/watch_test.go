@@ -0,0 +1,59 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import "testing"
+
+func TestWatchParamsMatches(t *testing.T) {
+	testCases := []struct {
+		prefix, suffix string
+		name           string
+		expected       bool
+	}{
+		{"", "", "photos/a.jpg", true},
+		{"photos/", "", "photos/a.jpg", true},
+		{"photos/", "", "videos/a.mp4", false},
+		{"", ".jpg", "photos/a.jpg", true},
+		{"", ".jpg", "photos/a.png", false},
+		{"photos/", ".jpg", "photos/2019/a.jpg", true},
+		{"photos/", ".jpg", "videos/a.jpg", false},
+	}
+	for i, testCase := range testCases {
+		params := watchParams{prefix: testCase.prefix, suffix: testCase.suffix}
+		if got := params.matches(testCase.name); got != testCase.expected {
+			t.Errorf("Test %d: expected %v, got %v", i+1, testCase.expected, got)
+		}
+	}
+}
+
+func TestCheckWatchEvents(t *testing.T) {
+	testCases := []struct {
+		events  []string
+		success bool
+	}{
+		{[]string{"put", "delete", "get"}, true},
+		{[]string{"delete"}, true},
+		{[]string{"put", "create"}, false},
+		{[]string{""}, false},
+	}
+	for i, testCase := range testCases {
+		err := checkWatchEvents(testCase.events)
+		if (err == nil) != testCase.success {
+			t.Errorf("Test %d: expected success %v, got %v", i+1, testCase.success, err)
+		}
+	}
+}
@@ -41,6 +41,10 @@ var (
 
 	stderrColoredOutput = colorable.NewColorableStderr()
 
+	// Exit is called by the Fatal functions, it can be replaced
+	// to choose the exit status.
+	Exit = os.Exit
+
 	// Print prints a message.
 	Print = func(data ...interface{}) {
 		consolePrint("Print", Theme["Print"], data...)
@@ -64,19 +68,19 @@ var (
 	// Fatal print a error message and exit.
 	Fatal = func(data ...interface{}) {
 		consolePrint("Fatal", Theme["Fatal"], data...)
-		os.Exit(1)
+		Exit(1)
 	}
 
 	// Fatalf print a error message with a format specified and exit.
 	Fatalf = func(format string, data ...interface{}) {
 		consolePrintf("Fatal", Theme["Fatal"], format, data...)
-		os.Exit(1)
+		Exit(1)
 	}
 
 	// Fatalln print a error message with a new line and exit.
 	Fatalln = func(data ...interface{}) {
 		consolePrintln("Fatal", Theme["Fatal"], data...)
-		os.Exit(1)
+		Exit(1)
 	}
 
 	// Error prints a error message.
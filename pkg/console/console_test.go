@@ -40,3 +40,13 @@ func (s *MySuite) TestLock(c *C) {
 	Print("") // Test for deadlocks.
 	Unlock()
 }
+
+func (s *MySuite) TestStringWidth(c *C) {
+	c.Assert(StringWidth("photo.jpg"), Equals, 9)
+	c.Assert(StringWidth("写真.jpg"), Equals, 8)
+	c.Assert(StringWidth("café"), Equals, 4)
+	c.Assert(PadRight("写真", 6), Equals, "写真  ")
+	c.Assert(PadLeft("写真", 6), Equals, "  写真")
+	c.Assert(Truncate("写真写真写真", 7, "..."), Equals, "写真...")
+	c.Assert(Truncate("photo", 7, "..."), Equals, "photo")
+}
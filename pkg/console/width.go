@@ -0,0 +1,84 @@
+/*
+ * MinIO Client (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package console
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/width"
+)
+
+// RuneWidth returns the number of terminal columns taken by r: two for
+// East Asian wide and fullwidth characters, none for combining marks
+// and control characters.
+func RuneWidth(r rune) int {
+	switch {
+	case r == 0 || unicode.Is(unicode.Mn, r) || unicode.Is(unicode.Me, r) || unicode.IsControl(r):
+		return 0
+	}
+	switch width.LookupRune(r).Kind() {
+	case width.EastAsianWide, width.EastAsianFullwidth:
+		return 2
+	}
+	return 1
+}
+
+// StringWidth returns the number of terminal columns taken by s, use it
+// instead of len() to align columns.
+func StringWidth(s string) int {
+	w := 0
+	for _, r := range s {
+		w += RuneWidth(r)
+	}
+	return w
+}
+
+// PadRight pads s with spaces up to w columns.
+func PadRight(s string, w int) string {
+	if pad := w - StringWidth(s); pad > 0 {
+		return s + strings.Repeat(" ", pad)
+	}
+	return s
+}
+
+// PadLeft right aligns s in w columns.
+func PadLeft(s string, w int) string {
+	if pad := w - StringWidth(s); pad > 0 {
+		return strings.Repeat(" ", pad) + s
+	}
+	return s
+}
+
+// Truncate cuts s to at most w columns, ending it with tail if it was
+// cut. Characters are never split.
+func Truncate(s string, w int, tail string) string {
+	if StringWidth(s) <= w {
+		return s
+	}
+	w -= StringWidth(tail)
+	var b strings.Builder
+	for _, r := range s {
+		rw := RuneWidth(r)
+		if w-rw < 0 {
+			break
+		}
+		w -= rw
+		b.WriteRune(r)
+	}
+	return b.String() + tail
+}